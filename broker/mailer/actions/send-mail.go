@@ -0,0 +1,393 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/i18n"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+const (
+	sendMailActionName = "actions.notify.send-mail"
+)
+
+// SendMailAction sends a templated email through the mailer service to
+// a set of recipients computed from the action parameters and input.
+type SendMailAction struct {
+	mailerClient mailer.MailerServiceClient
+	userClient   idm.UserServiceClient
+
+	toEventUser  bool
+	toInputUsers bool
+	toLogins     []string
+	toRoles      []string
+	toAddresses  []string
+
+	templateId   string
+	subject      string
+	content      string
+	templateData map[string]string
+	inQueue      bool
+}
+
+func (s *SendMailAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                sendMailActionName,
+		Label:             "Send Email",
+		Icon:              "email-outline",
+		Category:          actions.ActionCategoryNotify,
+		Description:       "Send a templated email to the event user, selected users, roles or fixed addresses",
+		InputDescription:  "Nodes and/or users, used to compute recipients and template variables",
+		OutputDescription: "Returns unchanged input, with the list of recipients in output",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (s *SendMailAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Label: "Recipients",
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "toEventUser",
+					Type:        forms.ParamBool,
+					Label:       "Event user",
+					Description: "Send to the user who triggered the event on the input node(s)",
+					Default:     false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "toInputUsers",
+					Type:        forms.ParamBool,
+					Label:       "Input users",
+					Description: "Send to the users passed in the action input",
+					Default:     false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "toUsers",
+					Type:        forms.ParamString,
+					Label:       "Users",
+					Description: "Comma-separated list of users logins",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "toRoles",
+					Type:        forms.ParamString,
+					Label:       "Roles",
+					Description: "Comma-separated list of roles IDs, all users having one of these roles will be notified",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "toAddresses",
+					Type:        forms.ParamString,
+					Label:       "Email addresses",
+					Description: "Comma-separated list of fixed email addresses",
+					Editable:    true,
+				},
+			},
+		},
+		{
+			Label: "Message",
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "templateId",
+					Type:        forms.ParamString,
+					Label:       "Template",
+					Description: "Predefined mailer template ID (optional, Subject is used if empty)",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "subject",
+					Type:        forms.ParamString,
+					Label:       "Subject",
+					Description: "Email subject, can use variables like {{.NodeName}}",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "content",
+					Type:        forms.ParamTextarea,
+					Label:       "Content",
+					Description: "Markdown body, can use variables like {{.NodePath}}, {{.NodeUuid}}, {{.UserLogin}}, {{.EventUser}}",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "templateData",
+					Type:        forms.ParamTextarea,
+					Label:       "Template Data",
+					Description: "JSON-encoded key/values passed to the template, values can use variables",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "inQueue",
+					Type:        forms.ParamBool,
+					Label:       "Use Queue",
+					Description: "Push emails to the mailer queue instead of sending them directly",
+					Default:     true,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns the Unique Identifier of the SendMailAction.
+func (s *SendMailAction) GetName() string {
+	return sendMailActionName
+}
+
+// Init passes parameters to a newly created instance.
+func (s *SendMailAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	p := action.Parameters
+	s.toEventUser = p["toEventUser"] == "true"
+	s.toInputUsers = p["toInputUsers"] == "true"
+	s.toLogins = splitList(p["toUsers"])
+	s.toRoles = splitList(p["toRoles"])
+	s.toAddresses = splitList(p["toAddresses"])
+	if !s.toEventUser && !s.toInputUsers && len(s.toLogins) == 0 && len(s.toRoles) == 0 && len(s.toAddresses) == 0 {
+		return errors.BadRequest(sendMailActionName, "please provide at least one type of recipient")
+	}
+	s.templateId = p["templateId"]
+	s.subject = p["subject"]
+	s.content = p["content"]
+	if s.templateId == "" && s.subject == "" {
+		return errors.BadRequest(sendMailActionName, "please provide either a templateId or a subject")
+	}
+	if s.templateId == "" && s.content == "" {
+		return errors.BadRequest(sendMailActionName, "please provide a content when no templateId is set")
+	}
+	if td, ok := p["templateData"]; ok && td != "" {
+		if e := json.Unmarshal([]byte(td), &s.templateData); e != nil {
+			return errors.BadRequest(sendMailActionName, "cannot parse templateData: %s", e.Error())
+		}
+	}
+	s.inQueue = p["inQueue"] != "false"
+	if s.mailerClient == nil {
+		s.mailerClient = mailer.NewMailerServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_MAILER, cl)
+	}
+	if s.userClient == nil {
+		s.userClient = idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, cl)
+	}
+	return nil
+}
+
+// Run computes recipients and variables, then sends the email.
+func (s *SendMailAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	vars := s.templateVars(ctx, input)
+	recipients, e := s.resolveRecipients(ctx, input)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if len(recipients) == 0 {
+		log.TasksLogger(ctx).Info("No recipients with a valid email address found, ignoring")
+		return input.WithIgnore(), nil
+	}
+
+	mail := &mailer.Mail{
+		To:           recipients,
+		TemplateId:   s.templateId,
+		TemplateData: map[string]string{},
+	}
+	for k, v := range vars {
+		mail.TemplateData[k] = v
+	}
+	for k, v := range s.templateData {
+		mail.TemplateData[k] = renderVars(jobs.EvaluateFieldStr(ctx, input, v), vars)
+	}
+	if s.subject != "" {
+		mail.Subject = renderVars(jobs.EvaluateFieldStr(ctx, input, s.subject), vars)
+	}
+	if s.content != "" {
+		mail.ContentMarkdown = renderVars(jobs.EvaluateFieldStr(ctx, input, s.content), vars)
+	}
+
+	if _, e := s.mailerClient.SendMail(ctx, &mailer.SendMailRequest{Mail: mail, InQueue: s.inQueue}); e != nil {
+		return input.WithError(e), e
+	}
+
+	var addresses []string
+	for _, r := range recipients {
+		addresses = append(addresses, r.Address)
+	}
+	log.TasksLogger(ctx).Info(fmt.Sprintf("Email sent to %d recipient(s): %s", len(addresses), strings.Join(addresses, ", ")))
+	jsonBody, _ := json.Marshal(map[string]interface{}{"Recipients": addresses})
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:  true,
+		JsonBody: jsonBody,
+	})
+	return input, nil
+}
+
+// templateVars builds a set of variables from the action input, they are passed
+// as TemplateData and can be used in subject and content.
+func (s *SendMailAction) templateVars(ctx context.Context, input jobs.ActionMessage) map[string]string {
+	vars := map[string]string{}
+	if len(input.Nodes) > 0 {
+		n := input.Nodes[0]
+		vars["NodePath"] = n.GetPath()
+		vars["NodeName"] = path.Base(n.GetPath())
+		vars["NodeUuid"] = n.GetUuid()
+		vars["NodeSize"] = fmt.Sprintf("%d", n.GetSize())
+		var pathes []string
+		for _, n := range input.Nodes {
+			pathes = append(pathes, n.GetPath())
+		}
+		vars["NodesPathes"] = strings.Join(pathes, ", ")
+	}
+	vars["NodesCount"] = fmt.Sprintf("%d", len(input.Nodes))
+	if len(input.Users) > 0 {
+		vars["UserLogin"] = input.Users[0].Login
+		if dn, ok := input.Users[0].Attributes["displayName"]; ok {
+			vars["UserDisplayName"] = dn
+		}
+	}
+	if userName, _ := permissions.FindUserNameInContext(ctx); userName != "" {
+		vars["EventUser"] = userName
+	}
+	return vars
+}
+
+// resolveRecipients computes a deduplicated list of mailer.User from the parameters and the input.
+func (s *SendMailAction) resolveRecipients(ctx context.Context, input jobs.ActionMessage) ([]*mailer.User, error) {
+
+	var users []*idm.User
+	var queries []*any.Any
+	if s.toEventUser {
+		if userName, _ := permissions.FindUserNameInContext(ctx); userName != "" && userName != common.PYDIO_SYSTEM_USERNAME {
+			q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: userName})
+			queries = append(queries, q)
+		}
+	}
+	for _, login := range s.toLogins {
+		q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: jobs.EvaluateFieldStr(ctx, input, login)})
+		queries = append(queries, q)
+	}
+	for _, role := range s.toRoles {
+		q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{HasRole: jobs.EvaluateFieldStr(ctx, input, role)})
+		queries = append(queries, q)
+	}
+	if len(queries) > 0 {
+		stream, e := s.userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: queries, Operation: service.OperationType_OR}})
+		if e != nil {
+			return nil, e
+		}
+		defer stream.Close()
+		for {
+			resp, e := stream.Recv()
+			if e == io.EOF || (e == nil && resp == nil) {
+				break
+			} else if e != nil {
+				return nil, e
+			}
+			if resp.User.IsGroup {
+				continue
+			}
+			users = append(users, resp.User)
+		}
+	}
+	if s.toInputUsers {
+		users = append(users, input.Users...)
+	}
+
+	var recipients []*mailer.User
+	seen := make(map[string]bool)
+	for _, u := range users {
+		email, has := u.Attributes["email"]
+		if !has || email == "" || seen[email] {
+			log.Logger(ctx).Debug("Skipping recipient without email or already added", u.ZapLogin())
+			continue
+		}
+		seen[email] = true
+		displayName, has := u.Attributes["displayName"]
+		if !has {
+			displayName = u.Login
+		}
+		recipients = append(recipients, &mailer.User{
+			Uuid:     u.Uuid,
+			Address:  email,
+			Name:     displayName,
+			Language: i18n.UserLanguage(ctx, u, config.Default()),
+		})
+	}
+	for _, a := range s.toAddresses {
+		a = jobs.EvaluateFieldStr(ctx, input, a)
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		recipients = append(recipients, &mailer.User{Address: a})
+	}
+	return recipients, nil
+}
+
+// renderVars applies a text/template using vars as data. If the template cannot
+// be parsed or executed, the original value is returned.
+func renderVars(value string, vars map[string]string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+	tpl, e := template.New("mail").Option("missingkey=zero").Parse(value)
+	if e != nil {
+		log.Logger(context.Background()).Debug("Cannot parse template", zap.Error(e))
+		return value
+	}
+	buf := bytes.NewBuffer(nil)
+	if e := tpl.Execute(buf, vars); e != nil {
+		log.Logger(context.Background()).Debug("Cannot execute template", zap.Error(e))
+		return value
+	}
+	return buf.String()
+}
+
+func splitList(value string) (list []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+type mockMailerClient struct {
+	sent []*mailer.SendMailRequest
+}
+
+func (m *mockMailerClient) SendMail(ctx context.Context, in *mailer.SendMailRequest, opts ...client.CallOption) (*mailer.SendMailResponse, error) {
+	m.sent = append(m.sent, in)
+	return &mailer.SendMailResponse{}, nil
+}
+
+func (m *mockMailerClient) ConsumeQueue(ctx context.Context, in *mailer.ConsumeQueueRequest, opts ...client.CallOption) (*mailer.ConsumeQueueResponse, error) {
+	return &mailer.ConsumeQueueResponse{}, nil
+}
+
+func TestSendMailAction_GetName(t *testing.T) {
+	Convey("Test GetName", t, func() {
+		action := &SendMailAction{}
+		So(action.GetName(), ShouldEqual, sendMailActionName)
+	})
+}
+
+func TestSendMailAction_Init(t *testing.T) {
+
+	Convey("Test Init", t, func() {
+
+		action := &SendMailAction{mailerClient: &mockMailerClient{}}
+		job := &jobs.Job{}
+		// No recipients
+		e := action.Init(job, nil, &jobs.Action{Parameters: map[string]string{"subject": "Subject"}})
+		So(e, ShouldNotBeNil)
+
+		// No subject nor template
+		e = action.Init(job, nil, &jobs.Action{Parameters: map[string]string{"toAddresses": "legal@example.com"}})
+		So(e, ShouldNotBeNil)
+
+		// Invalid template data
+		e = action.Init(job, nil, &jobs.Action{Parameters: map[string]string{
+			"toAddresses":  "legal@example.com",
+			"templateId":   "AdminTestMail",
+			"templateData": "{not json",
+		}})
+		So(e, ShouldNotBeNil)
+
+		e = action.Init(job, nil, &jobs.Action{Parameters: map[string]string{
+			"toAddresses": "legal@example.com, ops@example.com,",
+			"subject":     "New contract",
+			"content":     "A file was uploaded",
+		}})
+		So(e, ShouldBeNil)
+		So(action.toAddresses, ShouldResemble, []string{"legal@example.com", "ops@example.com"})
+		So(action.inQueue, ShouldBeTrue)
+
+		// The user who triggered the event is a valid recipient on its own
+		e = action.Init(job, nil, &jobs.Action{Parameters: map[string]string{
+			"toEventUser": "true",
+			"subject":     "New contract",
+			"content":     "A file was uploaded",
+		}})
+		So(e, ShouldBeNil)
+		So(action.toEventUser, ShouldBeTrue)
+
+	})
+}
+
+func TestSendMailAction_Run(t *testing.T) {
+
+	Convey("Test Run", t, func() {
+
+		mockClient := &mockMailerClient{}
+		action := &SendMailAction{mailerClient: mockClient}
+		e := action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{
+			"toAddresses":  "legal@example.com,legal@example.com",
+			"subject":      "New file {{.NodeName}}",
+			"content":      "File {{.NodePath}} ({{.NodeUuid}}) was uploaded",
+			"templateData": `{"Folder":"/contracts"}`,
+			"inQueue":      "false",
+		}})
+		So(e, ShouldBeNil)
+
+		output, e := action.Run(context.Background(), &actions.RunnableChannels{}, jobs.ActionMessage{
+			Nodes: []*tree.Node{{Path: "contracts/contract.pdf", Uuid: "node-uuid"}},
+		})
+		So(e, ShouldBeNil)
+		So(output.GetLastOutput().Success, ShouldBeTrue)
+		So(mockClient.sent, ShouldHaveLength, 1)
+
+		req := mockClient.sent[0]
+		So(req.InQueue, ShouldBeFalse)
+		So(req.Mail.To, ShouldHaveLength, 1)
+		So(req.Mail.To[0].Address, ShouldEqual, "legal@example.com")
+		So(req.Mail.Subject, ShouldEqual, "New file contract.pdf")
+		So(req.Mail.ContentMarkdown, ShouldEqual, "File contracts/contract.pdf (node-uuid) was uploaded")
+		So(req.Mail.TemplateData["Folder"], ShouldEqual, "/contracts")
+		So(req.Mail.TemplateData["NodesCount"], ShouldEqual, "1")
+
+	})
+}
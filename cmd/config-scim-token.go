@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/pborman/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+var scimTokenRevoke bool
+
+// scimTokenCmd generates the bearer token used by identity providers to call the SCIM endpoint.
+var scimTokenCmd = &cobra.Command{
+	Use:   "scim-token",
+	Short: "Generate the bearer token of the SCIM endpoint",
+	Long: `Generate a new bearer token for the SCIM 2.0 provisioning endpoint (/scim/v2), replacing any existing one.
+The token is stored in the vault and only displayed once: copy it in the configuration of your identity provider.
+
+Use --revoke to remove the token and disable the endpoint.
+
+### Examples
+
+$ ` + os.Args[0] + ` config scim-token
+$ ` + os.Args[0] + ` config scim-token --revoke
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if key := config.Get("services", common.SERVICE_GATEWAY_SCIM, "token").String(""); key != "" {
+			config.DelSecret(key)
+		}
+		if scimTokenRevoke {
+			config.Del("services", common.SERVICE_GATEWAY_SCIM, "token")
+			if err := config.Save("cli", "Revoke SCIM token"); err != nil {
+				log.Fatal(err)
+			}
+			cmd.Println("SCIM token revoked")
+			return
+		}
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			log.Fatal(err)
+		}
+		token := base64.RawURLEncoding.EncodeToString(b)
+		key := uuid.New()
+		config.SetSecret(key, token)
+		config.Set(key, "services", common.SERVICE_GATEWAY_SCIM, "token")
+		if err := config.Save("cli", "Generate SCIM token"); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("New SCIM bearer token (it will not be displayed again):")
+		fmt.Println(token)
+	},
+}
+
+func init() {
+	scimTokenCmd.Flags().BoolVar(&scimTokenRevoke, "revoke", false, "Remove the token and disable the SCIM endpoint")
+	configCmd.AddCommand(scimTokenCmd)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	p "github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsDeleteForce bool
+
+var jobsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a job",
+	Long: `Delete a job definition along with its tasks and logs.
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` jobs delete -i my-custom-flow
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsJobID == "" {
+			return fmt.Errorf("missing argument: please provide a job id")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		if !jobsDeleteForce {
+			q := fmt.Sprintf("You are about to definitively remove job [%s], are you sure you want to proceed?", jobsJobID)
+			confirm := p.Prompt{Label: q, IsConfirm: true}
+			if _, e := confirm.Run(); e != nil {
+				return nil
+			}
+		}
+		if _, e := jobsServiceClient().DeleteJob(context.Background(), &jobs.DeleteJobRequest{JobID: jobsJobID}); e != nil {
+			return e
+		}
+		cmd.Printf("Job %s deleted\n", jobsJobID)
+
+		return nil
+	},
+}
+
+func init() {
+	jobsDeleteCmd.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job")
+	jobsDeleteCmd.Flags().BoolVar(&jobsDeleteForce, "force", false, "Do not ask for confirmation")
+
+	jobsCmd.AddCommand(jobsDeleteCmd)
+}
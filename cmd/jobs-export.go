@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsExportIDs []string
+
+var jobsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export jobs definitions to a file",
+	Long: `Export one or more jobs definitions as a list, in YAML or JSON format.
+
+If no job id is passed, all jobs are exported. The format is guessed from the file
+extension, unless the --format flag is set. If no file is passed, definitions are
+written to the standard output.
+
+EXAMPLES
+========
+$ ` + os.Args[0] + ` jobs export -i clean-orphan-files -i my-custom-flow -f flows.yaml
+$ ` + os.Args[0] + ` jobs export --format json > all-jobs.json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		format, e := jobsDetectFormat(jobsFormat, jobsFile)
+		if e != nil {
+			return e
+		}
+
+		var jj []*jobs.Job
+		stream, e := jobsServiceClient().ListJobs(context.Background(), &jobs.ListJobsRequest{JobIDs: jobsExportIDs})
+		if e != nil {
+			return e
+		}
+		defer stream.Close()
+		for {
+			resp, er := stream.Recv()
+			if er != nil {
+				break
+			}
+			if resp != nil && resp.Job != nil {
+				jj = append(jj, resp.Job)
+			}
+		}
+		if len(jobsExportIDs) > 0 && len(jj) < len(jobsExportIDs) {
+			return fmt.Errorf("some jobs could not be found (found %d on %d)", len(jj), len(jobsExportIDs))
+		}
+
+		data, e := marshalJobs(jj, format, true)
+		if e != nil {
+			return e
+		}
+		if jobsFile == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+		if e := ioutil.WriteFile(jobsFile, data, 0644); e != nil {
+			return e
+		}
+		cmd.Printf("Exported %d job(s) to %s\n", len(jj), jobsFile)
+
+		return nil
+	},
+}
+
+func init() {
+	jobsExportCmd.Flags().StringArrayVarP(&jobsExportIDs, "id", "i", []string{}, "Id(s) of the job(s) to export (all jobs if empty)")
+	jobsExportCmd.Flags().StringVarP(&jobsFile, "file", "f", "", "Path to the output file")
+	jobsExportCmd.Flags().StringVar(&jobsFormat, "format", "", "Output format (yaml or json), guessed from the file extension if empty")
+
+	jobsCmd.AddCommand(jobsExportCmd)
+}
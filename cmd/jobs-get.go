@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Display a job definition",
+	Long: `Display the full definition of a job, in YAML (default) or JSON format.
+
+EXAMPLES
+========
+$ ` + os.Args[0] + ` jobs get -i clean-orphan-files
+$ ` + os.Args[0] + ` jobs get -i clean-orphan-files --format json
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsJobID == "" {
+			return fmt.Errorf("missing argument: please provide a job id")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		format, e := jobsDetectFormat(jobsFormat, "")
+		if e != nil {
+			return e
+		}
+		resp, e := jobsServiceClient().GetJob(context.Background(), &jobs.GetJobRequest{JobID: jobsJobID})
+		if e != nil {
+			return e
+		}
+		data, e := marshalJobs([]*jobs.Job{resp.Job}, format, false)
+		if e != nil {
+			return e
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+
+		return nil
+	},
+}
+
+func init() {
+	jobsGetCmd.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job")
+	jobsGetCmd.Flags().StringVar(&jobsFormat, "format", "", "Output format (yaml or json), defaults to yaml")
+
+	jobsCmd.AddCommand(jobsGetCmd)
+}
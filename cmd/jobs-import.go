@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsImportDryRun bool
+
+var jobsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import jobs definitions from a file",
+	Long: `Import jobs definitions from a YAML or JSON file.
+
+The file may contain a single job definition or a list of definitions, as produced
+by the export command. Existing jobs with the same id are replaced.
+
+EXAMPLES
+========
+$ ` + os.Args[0] + ` jobs import -f flows.yaml
+
+# Only check the file content
+$ ` + os.Args[0] + ` jobs import -f flows.yaml --dry-run
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsFile == "" {
+			return fmt.Errorf("missing argument: please provide a file to import")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		data, e := ioutil.ReadFile(jobsFile)
+		if e != nil {
+			return e
+		}
+		jj, e := unmarshalJobs(data)
+		if e != nil {
+			return e
+		}
+		for _, job := range jj {
+			if e := job.CheckDefinition(); e != nil {
+				return fmt.Errorf("invalid definition for job %s: %s", job.ID, e.Error())
+			}
+		}
+		if jobsImportDryRun {
+			cmd.Printf("File is valid, %d job(s) would be imported\n", len(jj))
+			return nil
+		}
+
+		cli := jobsServiceClient()
+		for _, job := range jj {
+			if _, e := cli.PutJob(context.Background(), &jobs.PutJobRequest{Job: job}); e != nil {
+				return fmt.Errorf("could not import job %s: %s", job.ID, e.Error())
+			}
+			cmd.Printf("Imported job %s\n", job.ID)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	jobsImportCmd.Flags().StringVarP(&jobsFile, "file", "f", "", "Path to a YAML or JSON file")
+	jobsImportCmd.Flags().BoolVar(&jobsImportDryRun, "dry-run", false, "Validate the definitions without importing them")
+
+	jobsCmd.AddCommand(jobsImportCmd)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var (
+	jobsListOwner      string
+	jobsListEventsOnly bool
+	jobsListTimersOnly bool
+)
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List jobs",
+	Long: `List jobs currently registered in the scheduler.
+
+EXAMPLES
+========
+$ ` + os.Args[0] + ` jobs list
+
+# List only jobs triggered by a schedule
+$ ` + os.Args[0] + ` jobs list --timers
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		stream, err := jobsServiceClient().ListJobs(context.Background(), &jobs.ListJobsRequest{
+			Owner:      jobsListOwner,
+			EventsOnly: jobsListEventsOnly,
+			TimersOnly: jobsListTimersOnly,
+		})
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Id", "Label", "Owner", "Trigger", "Status"})
+		for {
+			resp, e := stream.Recv()
+			if e != nil {
+				break
+			}
+			if resp == nil || resp.Job == nil {
+				continue
+			}
+			status := "Active"
+			if resp.Job.Inactive {
+				status = "Inactive"
+			}
+			table.Append([]string{resp.Job.ID, resp.Job.Label, resp.Job.Owner, jobTrigger(resp.Job), status})
+		}
+		table.Render()
+		fmt.Fprintln(cmd.OutOrStdout())
+
+		return nil
+	},
+}
+
+func init() {
+	jobsListCmd.Flags().StringVarP(&jobsListOwner, "owner", "o", "", "Only list jobs belonging to this owner")
+	jobsListCmd.Flags().BoolVar(&jobsListEventsOnly, "events", false, "Only list event-based jobs")
+	jobsListCmd.Flags().BoolVar(&jobsListTimersOnly, "timers", false, "Only list timer-based jobs")
+
+	jobsCmd.AddCommand(jobsListCmd)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/micro/go-micro/client"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run a job now",
+	Long: `Trigger a job immediately, whatever its schedule or events.
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` jobs run -i clean-orphan-files
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsJobID == "" {
+			return fmt.Errorf("missing argument: please provide a job id")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		ctx := context.Background()
+		// Make sure the job exists
+		if _, e := jobsServiceClient().GetJob(ctx, &jobs.GetJobRequest{JobID: jobsJobID}); e != nil {
+			return e
+		}
+		if e := client.Publish(ctx, client.NewPublication(common.TOPIC_TIMER_EVENT, &jobs.JobTriggerEvent{
+			JobID:  jobsJobID,
+			RunNow: true,
+		})); e != nil {
+			return e
+		}
+		cmd.Printf("Job %s has been triggered, use 'tasks list' to follow its execution\n", jobsJobID)
+
+		return nil
+	},
+}
+
+func init() {
+	jobsRunCmd.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job")
+
+	jobsCmd.AddCommand(jobsRunCmd)
+}
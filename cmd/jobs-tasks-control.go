@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+// newJobsTaskControlCmd creates a command sending a control command to a running task
+func newJobsTaskControlCmd(command jobs.Command, use string, short string) *cobra.Command {
+	c := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `.
+
+The task must support this command, see the CanStop and CanPause flags of the task.
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` jobs tasks ` + use + ` -i clean-orphan-files -t 1cbbdc3c-2a8c-4e6f-a6ef-0bb4f54dd3f1
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if jobsJobID == "" || jobsTaskID == "" {
+				return fmt.Errorf("missing arguments: please provide a job id and a task id")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, e := jobsTaskServiceClient().Control(context.Background(), &jobs.CtrlCommand{
+				Cmd:    command,
+				JobId:  jobsJobID,
+				TaskId: jobsTaskID,
+			})
+			if e != nil {
+				return e
+			}
+			cmd.Printf("%s command sent to task %s: %s\n", command.String(), jobsTaskID, resp.Msg)
+			return nil
+		},
+	}
+	c.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job")
+	c.Flags().StringVarP(&jobsTaskID, "task", "t", "", "Id of the task")
+	return c
+}
+
+func init() {
+	jobsTasksCmd.AddCommand(
+		newJobsTaskControlCmd(jobs.Command_Stop, "stop", "Stop a running task"),
+		newJobsTaskControlCmd(jobs.Command_Pause, "pause", "Pause a running task"),
+		newJobsTaskControlCmd(jobs.Command_Resume, "resume", "Resume a paused task"),
+	)
+}
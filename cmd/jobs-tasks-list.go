@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var jobsTasksListStatus string
+
+var jobsTasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks",
+	Long: `List tasks of a given job, or of all jobs, optionally filtered by status.
+
+Status can be one of Idle, Running, Finished, Interrupted, Paused, Error, Queued or Any (default).
+
+EXAMPLES
+========
+$ ` + os.Args[0] + ` jobs tasks list -i clean-orphan-files
+$ ` + os.Args[0] + ` jobs tasks list --status Running
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		status := jobs.TaskStatus_Any
+		if jobsTasksListStatus != "" {
+			s, ok := jobs.TaskStatus_value[strings.Title(strings.ToLower(jobsTasksListStatus))]
+			if !ok {
+				return fmt.Errorf("unknown status %s", jobsTasksListStatus)
+			}
+			status = jobs.TaskStatus(s)
+		}
+
+		stream, e := jobsServiceClient().ListTasks(context.Background(), &jobs.ListTasksRequest{
+			JobID:  jobsJobID,
+			Status: status,
+		})
+		if e != nil {
+			return e
+		}
+		defer stream.Close()
+
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"Job", "Task", "Status", "Message", "Start", "End", "Skipped"})
+		for {
+			resp, er := stream.Recv()
+			if er != nil {
+				break
+			}
+			if resp == nil || resp.Task == nil {
+				continue
+			}
+			t := resp.Task
+			table.Append([]string{t.JobID, t.ID, t.Status.String(), t.StatusMessage, jobsFormatTime(t.StartTime), jobsFormatTime(t.EndTime), fmt.Sprintf("%d", t.SkippedTriggers)})
+		}
+		table.Render()
+		fmt.Fprintln(cmd.OutOrStdout())
+
+		return nil
+	},
+}
+
+func jobsFormatTime(ts int32) string {
+	if ts == 0 {
+		return "-"
+	}
+	return time.Unix(int64(ts), 0).Format(time.RFC3339)
+}
+
+func init() {
+	jobsTasksListCmd.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job (all jobs if empty)")
+	jobsTasksListCmd.Flags().StringVarP(&jobsTasksListStatus, "status", "s", "", "Filter tasks by status")
+
+	jobsTasksCmd.AddCommand(jobsTasksListCmd)
+}
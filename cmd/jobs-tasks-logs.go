@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/log"
+)
+
+var jobsTasksLogsSize int32
+
+var jobsTasksLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Display the logs of a task",
+	Long: `Display the logs recorded during the execution of a task, oldest first.
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` jobs tasks logs -i clean-orphan-files -t 1cbbdc3c-2a8c-4e6f-a6ef-0bb4f54dd3f1
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if jobsJobID == "" || len(jobsTaskID) < 8 {
+			return fmt.Errorf("missing arguments: please provide a job id and a task id")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		cli := log.NewLogRecorderClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+		// Tasks logs are tagged with an operation ID built from the job ID and the task ID prefix
+		operationId := jobsJobID + "-" + jobsTaskID[0:8]
+		stream, e := cli.ListLogs(context.Background(), &log.ListLogRequest{
+			Query: "+OperationUuid:\"" + operationId + "\"",
+			Size:  jobsTasksLogsSize,
+		})
+		if e != nil {
+			return e
+		}
+		defer stream.Close()
+
+		var messages []*log.LogMessage
+		for {
+			resp, er := stream.Recv()
+			if er != nil {
+				break
+			}
+			if resp != nil && resp.LogMessage != nil {
+				messages = append(messages, resp.LogMessage)
+			}
+		}
+		// Logs are reverse sorted on time
+		for i := len(messages) - 1; i >= 0; i-- {
+			m := messages[i]
+			ts := time.Unix(int64(m.Ts), 0).Format(time.RFC3339)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", ts, m.Level, m.Msg)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	jobsTasksLogsCmd.Flags().StringVarP(&jobsJobID, "id", "i", "", "Id of the job")
+	jobsTasksLogsCmd.Flags().StringVarP(&jobsTaskID, "task", "t", "", "Id of the task")
+	jobsTasksLogsCmd.Flags().Int32VarP(&jobsTasksLogsSize, "size", "n", 200, "Maximum number of log lines")
+
+	jobsTasksCmd.AddCommand(jobsTasksLogsCmd)
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var (
+	jobsJobID  string
+	jobsFile   string
+	jobsFormat string
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Manage scheduler jobs and tasks",
+	Long: `Manage the jobs definitions stored in the scheduler and the tasks they spawn.
+
+Jobs definitions can be exported to and imported from YAML or JSON files, which makes it
+easy to version custom flows and to deploy them across environments.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(jobsCmd)
+}
+
+/* Package protected utility methods that are used by the various jobs subcommands */
+
+func jobsServiceClient() jobs.JobServiceClient {
+	return jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+}
+
+func jobsTaskServiceClient() jobs.TaskServiceClient {
+	return jobs.NewTaskServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_TASKS, defaults.NewClient())
+}
+
+// jobsDetectFormat returns the format passed by flag, or guesses it from the file extension.
+// It defaults to yaml.
+func jobsDetectFormat(format string, file string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".json":
+			format = "json"
+		default:
+			format = "yaml"
+		}
+	}
+	switch format {
+	case "json", "yaml":
+		return format, nil
+	case "yml":
+		return "yaml", nil
+	}
+	return "", fmt.Errorf("unsupported format %s, please use json or yaml", format)
+}
+
+// marshalJobs serializes job definitions to JSON or YAML. If asList is false, only the first job is serialized.
+// Tasks are never exported.
+func marshalJobs(jj []*jobs.Job, format string, asList bool) ([]byte, error) {
+	marshaler := &jsonpb.Marshaler{Indent: "  "}
+	var parts []string
+	for _, j := range jj {
+		clean := *j
+		clean.Tasks = nil
+		s, e := marshaler.MarshalToString(&clean)
+		if e != nil {
+			return nil, e
+		}
+		parts = append(parts, s)
+		if !asList {
+			break
+		}
+	}
+	data := []byte(strings.Join(parts, ""))
+	if asList {
+		data = []byte("[\n" + strings.Join(parts, ",\n") + "\n]")
+	}
+	if format == "yaml" {
+		return yaml.JSONToYAML(data)
+	}
+	return data, nil
+}
+
+// unmarshalJobs parses a YAML or JSON document containing either a single job definition or a list of jobs.
+func unmarshalJobs(data []byte) ([]*jobs.Job, error) {
+	jsonData, e := yaml.YAMLToJSON(data)
+	if e != nil {
+		return nil, e
+	}
+	jsonData = bytes.TrimSpace(jsonData)
+	var raws []json.RawMessage
+	if bytes.HasPrefix(jsonData, []byte("[")) {
+		if e := json.Unmarshal(jsonData, &raws); e != nil {
+			return nil, e
+		}
+	} else {
+		raws = append(raws, json.RawMessage(jsonData))
+	}
+	var jj []*jobs.Job
+	for i, raw := range raws {
+		job := &jobs.Job{}
+		if e := jsonpb.Unmarshal(bytes.NewReader(raw), job); e != nil {
+			return nil, fmt.Errorf("cannot parse job definition #%d: %s", i+1, e.Error())
+		}
+		if job.ID == "" {
+			return nil, fmt.Errorf("job definition #%d has no ID", i+1)
+		}
+		jj = append(jj, job)
+	}
+	return jj, nil
+}
+
+// jobTrigger returns a short human readable description of what triggers the job
+func jobTrigger(job *jobs.Job) string {
+	if job.Schedule != nil {
+		if job.Schedule.Cron != "" {
+			if job.Schedule.TimeZone != "" {
+				return fmt.Sprintf("Cron %s (%s)", job.Schedule.Cron, job.Schedule.TimeZone)
+			}
+			return "Cron " + job.Schedule.Cron
+		}
+		return "Schedule " + job.Schedule.Iso8601Schedule
+	}
+	if len(job.EventNames) > 0 {
+		return "Events " + strings.Join(job.EventNames, ", ")
+	}
+	return "Manual"
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+func TestJobsDefinitions(t *testing.T) {
+
+	Convey("Test format detection", t, func() {
+		f, e := jobsDetectFormat("", "flows.json")
+		So(e, ShouldBeNil)
+		So(f, ShouldEqual, "json")
+		f, _ = jobsDetectFormat("", "flows.yml")
+		So(f, ShouldEqual, "yaml")
+		f, _ = jobsDetectFormat("", "")
+		So(f, ShouldEqual, "yaml")
+		f, _ = jobsDetectFormat("json", "flows.yaml")
+		So(f, ShouldEqual, "json")
+		_, e = jobsDetectFormat("xml", "")
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test export / import round trip", t, func() {
+		source := []*jobs.Job{
+			{
+				ID:       "custom-flow",
+				Label:    "Custom Flow",
+				Owner:    "pydio.system.user",
+				Schedule: &jobs.Schedule{Cron: "0 2 * * 1-5", TimeZone: "Europe/Paris"},
+				Actions: []*jobs.Action{
+					{ID: "actions.cmd.resync", Parameters: map[string]string{"service": "pydio.grpc.search"}, RetryPolicy: &jobs.RetryPolicy{MaxAttempts: 3}},
+				},
+				Tasks: []*jobs.Task{{ID: "task", Status: jobs.TaskStatus_Finished}},
+			},
+			{ID: "second-flow", EventNames: []string{"NODE_CHANGE:0"}},
+		}
+		for _, format := range []string{"yaml", "json"} {
+			data, e := marshalJobs(source, format, true)
+			So(e, ShouldBeNil)
+			jj, e := unmarshalJobs(data)
+			So(e, ShouldBeNil)
+			So(jj, ShouldHaveLength, 2)
+			So(jj[0].Schedule.Cron, ShouldEqual, "0 2 * * 1-5")
+			So(jj[0].Actions[0].Parameters["service"], ShouldEqual, "pydio.grpc.search")
+			So(jj[0].Actions[0].RetryPolicy.MaxAttempts, ShouldEqual, 3)
+			So(jj[0].Tasks, ShouldBeEmpty)
+			So(jj[1].EventNames, ShouldResemble, []string{"NODE_CHANGE:0"})
+		}
+		// Source is not modified
+		So(source[0].Tasks, ShouldHaveLength, 1)
+	})
+
+	Convey("Test single definition", t, func() {
+		data, e := marshalJobs([]*jobs.Job{{ID: "single", Label: "Single"}}, "yaml", false)
+		So(e, ShouldBeNil)
+		So(string(data), ShouldContainSubstring, "ID: single")
+		jj, e := unmarshalJobs(data)
+		So(e, ShouldBeNil)
+		So(jj, ShouldHaveLength, 1)
+		So(jj[0].Label, ShouldEqual, "Single")
+	})
+
+	Convey("Test invalid definitions", t, func() {
+		_, e := unmarshalJobs([]byte("Label: no id"))
+		So(e, ShouldNotBeNil)
+		_, e = unmarshalJobs([]byte("- ID: a\n- UnknownField: b"))
+		So(e, ShouldNotBeNil)
+		_, e = unmarshalJobs([]byte("{not yaml"))
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test trigger description", t, func() {
+		So(jobTrigger(&jobs.Job{}), ShouldEqual, "Manual")
+		So(jobTrigger(&jobs.Job{Schedule: &jobs.Schedule{Cron: "@daily", TimeZone: "UTC"}}), ShouldEqual, "Cron @daily (UTC)")
+		So(jobTrigger(&jobs.Job{EventNames: []string{"A", "B"}}), ShouldEqual, "Events A, B")
+	})
+}
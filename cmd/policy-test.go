@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/idm/policy"
+)
+
+var (
+	policyTestFile       string
+	policyTestRequests   string
+	policyTestWithStored bool
+)
+
+var policyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Evaluate requests against candidate policies",
+	Long: `Evaluate a batch of requests against candidate policy groups, without storing them.
+
+Candidate policy groups are read from a YAML or JSON file containing one group or a list
+of groups, in the format returned by the REST API. They replace stored groups with the
+same Uuid, other stored groups are evaluated as well unless --with-stored=false is used.
+
+Requests are read from a YAML or JSON file containing a list of requests, each with
+Subjects, Resource, Action and an optional Context map.
+
+For each request, the decision is displayed with the policy that took it.
+
+EXAMPLES
+========
+# Check stored policies
+$ ` + os.Args[0] + ` policy test -r requests.yaml
+
+# Check a modified group before saving it
+$ ` + os.Args[0] + ` policy test -f group.json -r requests.yaml
+
+With requests.yaml containing:
+- Subjects: ["profile:standard", "user:john"]
+  Resource: "rest:/workspace"
+  Action: "PUT"
+- Subjects: ["policy:my-policy-uuid"]
+  Resource: "acl"
+  Action: "read"
+  Context:
+    RemoteAddress: "10.0.0.1"
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if policyTestRequests == "" {
+			return fmt.Errorf("missing argument: please provide a requests file")
+		}
+		if policyTestFile == "" && !policyTestWithStored {
+			return fmt.Errorf("missing argument: please provide candidate policies or evaluate the stored ones")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		var candidates []*idm.PolicyGroup
+		if policyTestFile != "" {
+			data, e := ioutil.ReadFile(policyTestFile)
+			if e != nil {
+				return e
+			}
+			raws, e := splitDefinitions(data)
+			if e != nil {
+				return e
+			}
+			for i, raw := range raws {
+				group := &idm.PolicyGroup{}
+				if e := jsonpb.Unmarshal(bytes.NewReader(raw), group); e != nil {
+					return fmt.Errorf("cannot parse policy group #%d: %s", i+1, e.Error())
+				}
+				candidates = append(candidates, group)
+			}
+		}
+
+		data, e := ioutil.ReadFile(policyTestRequests)
+		if e != nil {
+			return e
+		}
+		raws, e := splitDefinitions(data)
+		if e != nil {
+			return e
+		}
+		var requests []*idm.PolicyEngineRequest
+		for i, raw := range raws {
+			request := &idm.PolicyEngineRequest{}
+			if e := jsonpb.Unmarshal(bytes.NewReader(raw), request); e != nil {
+				return fmt.Errorf("cannot parse request #%d: %s", i+1, e.Error())
+			}
+			requests = append(requests, request)
+		}
+
+		groups := candidates
+		if policyTestWithStored {
+			cli := idm.NewPolicyEngineServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_POLICY, defaults.NewClient())
+			stored, e := cli.ListPolicyGroups(context.Background(), &idm.ListPolicyGroupsRequest{})
+			if e != nil {
+				return e
+			}
+			groups = policy.MergePolicyGroups(candidates, stored.PolicyGroups)
+		}
+
+		simulator := policy.NewSimulator(groups)
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader([]string{"#", "Subjects", "Action", "Resource", "Decision", "Policy", "Group"})
+		for i, request := range requests {
+			row := []string{fmt.Sprintf("%d", i+1), strings.Join(request.Subjects, ", "), request.Action, request.Resource}
+			result, e := simulator.Evaluate(request)
+			if e != nil {
+				table.Append(append(row, "Error: "+e.Error(), "", ""))
+				continue
+			}
+			decision := "Allow"
+			if result.ExplicitDeny {
+				decision = "Deny"
+			} else if result.DefaultDeny {
+				decision = "Deny (no match)"
+			}
+			var policyLabel, groupLabel string
+			if result.Policy != nil {
+				policyLabel = result.Policy.Id
+				if result.Policy.Description != "" {
+					policyLabel += " (" + result.Policy.Description + ")"
+				}
+				groupLabel = result.Group.Name
+				if groupLabel == "" {
+					groupLabel = result.Group.Uuid
+				}
+			}
+			table.Append(append(row, decision, policyLabel, groupLabel))
+		}
+		table.Render()
+
+		return nil
+	},
+}
+
+func init() {
+	policyTestCmd.Flags().StringVarP(&policyTestFile, "file", "f", "", "Path to a YAML or JSON file containing candidate policy groups")
+	policyTestCmd.Flags().StringVarP(&policyTestRequests, "requests", "r", "", "Path to a YAML or JSON file containing the requests to evaluate")
+	policyTestCmd.Flags().BoolVar(&policyTestWithStored, "with-stored", true, "Evaluate stored policy groups along with the candidates")
+
+	policyCmd.AddCommand(policyTestCmd)
+}
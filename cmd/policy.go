@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+)
+
+// policyCmd represents the policy command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage security policies",
+	Long: `Security policies are groups of ladon policies stored in the policy micro-service.
+
+They are evaluated for REST access points, ACLs and OpenID Connect resources.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// splitDefinitions reads a YAML or JSON document containing a single object or a list of objects
+func splitDefinitions(data []byte) ([]json.RawMessage, error) {
+	jsonData, e := yaml.YAMLToJSON(data)
+	if e != nil {
+		return nil, e
+	}
+	jsonData = bytes.TrimSpace(jsonData)
+	var raws []json.RawMessage
+	if bytes.HasPrefix(jsonData, []byte("[")) {
+		if e := json.Unmarshal(jsonData, &raws); e != nil {
+			return nil, e
+		}
+	} else {
+		raws = append(raws, json.RawMessage(jsonData))
+	}
+	return raws, nil
+}
+
+func init() {
+	RootCmd.AddCommand(policyCmd)
+}
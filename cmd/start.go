@@ -36,6 +36,7 @@ import (
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/plugins"
 	"github.com/pydio/cells/common/registry"
+	servicecontext "github.com/pydio/cells/common/service/context"
 )
 
 var (
@@ -87,6 +88,11 @@ $ ` + os.Args[0] + ` start --exclude=pydio.grpc.idm.roles
 
 		plugins.Init()
 
+		// Reverse proxies allowed to set the client address with X-Forwarded-For
+		if err := servicecontext.SetTrustedProxies(config.Get("defaults", "trustedProxies").StringSlice([]string{})); err != nil {
+			return err
+		}
+
 		// Filtering out services by exclusion
 		registry.Default.Filter(func(s registry.Service) bool {
 			for _, exclude := range FilterStartExclude {
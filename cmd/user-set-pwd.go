@@ -45,6 +45,7 @@ var userSetPwdCmd = &cobra.Command{
 	Long: fmt.Sprintf(`Set the password of a given user. 
 
 Directly use --password (or -p) to provide a new password, or leave empty to be prompted.
+The new password must comply with the password policy configured by the administrator.
 
 EXAMPLE
 =======
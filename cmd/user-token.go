@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/auth"
+)
+
+var (
+	userTokenLogin  string
+	userTokenLabel  string
+	userTokenExpire time.Duration
+	userTokenScopes []string
+	userTokenUuid   string
+)
+
+var userTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage personal access tokens",
+	Long: `Manage personal access tokens, used instead of a password by scripts and tools calling the REST API.
+
+Tokens are sent as bearers in the Authorization header. They can be restricted with scopes: 
+"read-only" denies all modifications, "workspace:SLUG" limits the accessible workspaces.
+Expired tokens are regularly deleted by the tokens pruning job.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var userTokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Generate a personal access token",
+	Long: `Generate a personal access token for a user
+
+The value of the token is displayed only once.
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` user token create -u admin -l "Backup script" -e 720h -s read-only -s workspace:common-files
+
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userTokenLogin == "" || userTokenLabel == "" {
+			return fmt.Errorf("Missing arguments")
+		}
+		if userTokenExpire <= 0 {
+			return fmt.Errorf("Expiration must be a positive duration")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cli := auth.NewPersonalAccessTokenServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_OAUTH, defaults.NewClient())
+		resp, err := cli.Generate(context.Background(), &auth.PatGenerateRequest{
+			Label:     userTokenLabel,
+			UserLogin: userTokenLogin,
+			ExpiresAt: int32(time.Now().Add(userTokenExpire).Unix()),
+			Scopes:    userTokenScopes,
+		})
+		if err != nil {
+			return err
+		}
+		cmd.Println("Token generated, copy it now as it will not be displayed again:")
+		cmd.Println(resp.AccessToken)
+		cmd.Println("It expires on " + time.Unix(int64(resp.Token.ExpiresAt), 0).Format(time.RFC1123))
+		return nil
+	},
+}
+
+var userTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List personal access tokens",
+	Long: `List personal access tokens of a user, or of all users if no login is given
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` user token list -u admin
+
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cli := auth.NewPersonalAccessTokenServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_OAUTH, defaults.NewClient())
+		resp, err := cli.List(context.Background(), &auth.PatListRequest{ByUserLogin: userTokenLogin})
+		if err != nil {
+			return err
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Uuid", "Label", "User", "Scopes", "Expires", "Last used"})
+		for _, t := range resp.Tokens {
+			lastUsed := "never"
+			if t.LastUsedAt > 0 {
+				lastUsed = time.Unix(int64(t.LastUsedAt), 0).Format(time.RFC822)
+			}
+			table.Append([]string{t.Uuid, t.Label, t.UserLogin, strings.Join(t.Scopes, ", "), time.Unix(int64(t.ExpiresAt), 0).Format(time.RFC822), lastUsed})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var userTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke a personal access token",
+	Long: `Revoke a personal access token, find its uuid with the list command
+
+EXAMPLE
+=======
+$ ` + os.Args[0] + ` user token revoke -i TOKEN_UUID
+
+`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if userTokenUuid == "" {
+			return fmt.Errorf("Missing arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cli := auth.NewPersonalAccessTokenServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_OAUTH, defaults.NewClient())
+		if _, err := cli.Revoke(context.Background(), &auth.PatRevokeRequest{Uuid: userTokenUuid}); err != nil {
+			return err
+		}
+		cmd.Println("Token successfully revoked")
+		return nil
+	},
+}
+
+func init() {
+	userTokenCreateCmd.Flags().StringVarP(&userTokenLogin, "username", "u", "", "Login of the token owner")
+	userTokenCreateCmd.Flags().StringVarP(&userTokenLabel, "label", "l", "", "Label describing the usage of the token")
+	userTokenCreateCmd.Flags().DurationVarP(&userTokenExpire, "expire", "e", 30*24*time.Hour, "Validity of the token")
+	userTokenCreateCmd.Flags().StringArrayVarP(&userTokenScopes, "scope", "s", []string{}, "Restrict the token: read-only or workspace:SLUG, can be repeated")
+	userTokenListCmd.Flags().StringVarP(&userTokenLogin, "username", "u", "", "Login of the tokens owner, all tokens if empty")
+	userTokenRevokeCmd.Flags().StringVarP(&userTokenUuid, "uuid", "i", "", "Uuid of the token to revoke")
+
+	userTokenCmd.AddCommand(userTokenCreateCmd, userTokenListCmd, userTokenRevokeCmd)
+	userCmd.AddCommand(userTokenCmd)
+}
@@ -70,6 +70,9 @@ $ %s user unlock -u LOGIN
 			if _, ok := user.Attributes["failedConnections"]; ok {
 				delete(user.Attributes, "failedConnections")
 			}
+			if _, ok := user.Attributes["lockedUntil"]; ok {
+				delete(user.Attributes, "lockedUntil")
+			}
 			if _, err := client.CreateUser(context.Background(), &idm.CreateUserRequest{
 				User: user,
 			}); err != nil {
@@ -75,14 +75,19 @@ func (b *BasicAuthenticator) Wrap(handler http.Handler) http.HandlerFunc {
 				return
 			}
 
-			token, err := DefaultJWTVerifier().PasswordCredentialsToken(ctx, user, pass)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusNotFound)
-				return
+			// Personal access tokens can be used as password, e.g. by users that have a second factor
+			accessToken := pass
+			if !IsPersonalToken(pass) {
+				token, err := DefaultJWTVerifier().PasswordCredentialsToken(ctx, user, pass)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusNotFound)
+					return
+				}
+				accessToken = token.AccessToken
 			}
 
-			newCtx, claims, err := DefaultJWTVerifier().Verify(ctx, token.AccessToken)
-			if err == nil {
+			newCtx, claims, err := DefaultJWTVerifier().Verify(ctx, accessToken)
+			if err == nil && (accessToken != pass || claims.Name == user) {
 
 				r = r.WithContext(newCtx)
 				b.cache[user] = &validBasicUser{
@@ -32,6 +32,11 @@ import (
 
 const (
 	ContextKey = "pydio-claims"
+
+	// ScopeReadOnly restricts a token to read accesses
+	ScopeReadOnly = "read-only"
+	// ScopeWorkspacePrefix restricts a token to the workspace whose slug or uuid follows the prefix
+	ScopeWorkspacePrefix = "workspace:"
 )
 
 type Claims struct {
@@ -49,6 +54,7 @@ type Claims struct {
 	AuthSource  string      `json:"authSource" mapstructure:"authSource"`
 	DisplayName string      `json:"displayName" mapstructure:"displayName"`
 	GroupPath   string      `json:"groupPath" mapstructure:"groupPath"`
+	Scopes      string      `json:"scopes,omitempty" mapstructure:"scopes"`
 }
 
 // Decode Subject field of the claims
@@ -67,6 +73,38 @@ func (c *Claims) DecodeSubject() (*IDTokenSubject, error) {
 	}
 }
 
+// GetScopes splits the comma-separated list of scopes restricting the token, empty if the token is not restricted
+func (c *Claims) GetScopes() []string {
+	var scopes []string
+	for _, s := range strings.Split(c.Scopes, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// ReadOnly checks if the token is restricted to read accesses
+func (c *Claims) ReadOnly() bool {
+	for _, s := range c.GetScopes() {
+		if s == ScopeReadOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// Workspaces lists the slugs or uuids of the workspaces the token is restricted to, empty if not restricted
+func (c *Claims) Workspaces() []string {
+	var ws []string
+	for _, s := range c.GetScopes() {
+		if strings.HasPrefix(s, ScopeWorkspacePrefix) {
+			ws = append(ws, strings.TrimPrefix(s, ScopeWorkspacePrefix))
+		}
+	}
+	return ws
+}
+
 func (c *Claims) GetClientApp() string {
 	switch v := c.ClientApp.(type) {
 	case string:
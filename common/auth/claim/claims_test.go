@@ -42,3 +42,20 @@ func TestIDTokenSubjectEncoding(t *testing.T) {
 		So(decodedSub.UserId, ShouldEqual, "testuser")
 	})
 }
+
+func TestClaimsScopes(t *testing.T) {
+
+	Convey("Test unrestricted claims", t, func() {
+		c := &Claims{}
+		So(c.GetScopes(), ShouldBeEmpty)
+		So(c.ReadOnly(), ShouldBeFalse)
+		So(c.Workspaces(), ShouldBeEmpty)
+	})
+
+	Convey("Test restricted claims", t, func() {
+		c := &Claims{Scopes: "read-only, workspace:common-files,workspace:personal-files,"}
+		So(c.GetScopes(), ShouldHaveLength, 3)
+		So(c.ReadOnly(), ShouldBeTrue)
+		So(c.Workspaces(), ShouldResemble, []string{"common-files", "personal-files"})
+	})
+}
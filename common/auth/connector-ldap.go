@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	dlog "github.com/dexidp/dex/pkg/log"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+var (
+	_ PasswordConnector = (*ldapconnector)(nil)
+)
+
+func init() {
+	RegisterConnectorType("ldap", func(data proto.Message) (Opener, error) {
+		conf, ok := data.(*auth.LdapConnectorConfig)
+		if !ok || conf == nil {
+			return nil, fmt.Errorf("ldap connector requires an LdapConnectorConfig")
+		}
+		return &ldapconfig{conf: conf}, nil
+	})
+}
+
+// LoadLdapConnectorConfig finds an ldap connector by its id in the oauth service configuration.
+func LoadLdapConnectorConfig(id string) (*auth.LdapConnectorConfig, error) {
+	var connectors []ConnectorData
+	if e := config.Get("services", common.SERVICE_WEB_NAMESPACE_+common.SERVICE_OAUTH, "connectors").Scan(&connectors); e != nil {
+		return nil, e
+	}
+	for _, c := range connectors {
+		if c.ID != id || c.Type != "ldap" {
+			continue
+		}
+		conf := &auth.LdapConnectorConfig{}
+		if e := json.Unmarshal(c.Config, conf); e != nil {
+			return nil, e
+		}
+		return conf, nil
+	}
+	return nil, fmt.Errorf("cannot find ldap connector %s", id)
+}
+
+type ldapconfig struct {
+	conf *auth.LdapConnectorConfig
+}
+
+func (c *ldapconfig) Open(id string, _ dlog.Logger) (Connector, error) {
+	return &ldapconnector{
+		directory: NewLdapDirectory(id, c.conf),
+		provision: provisionExternalUser,
+	}, nil
+}
+
+type ldapconnector struct {
+	directory *LdapDirectory
+	// provision stores the authenticated user in the users service
+	provision func(ctx context.Context, user *idm.User) (*idm.User, error)
+}
+
+func (l *ldapconnector) Prompt() string {
+	return "ldap"
+}
+
+func (l *ldapconnector) Login(ctx context.Context, s Scopes, username, password string) (Identity, bool, error) {
+	user, valid, err := l.directory.Authenticate(username, password)
+	if err != nil || !valid {
+		return Identity{}, valid, err
+	}
+
+	stored, err := l.provision(ctx, user)
+	if err != nil {
+		return Identity{}, false, err
+	}
+
+	return Identity{
+		UserID:        stored.GetUuid(),
+		Username:      stored.GetLogin(),
+		Email:         stored.GetAttributes()[idm.UserAttrEmail],
+		EmailVerified: true,
+		Groups:        []string{},
+	}, true, nil
+}
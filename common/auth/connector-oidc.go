@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/coreos/go-oidc"
+	dlog "github.com/dexidp/dex/pkg/log"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/oauth2"
+
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+var (
+	_ CallbackConnector = (*oidcconnector)(nil)
+)
+
+func init() {
+	RegisterConnectorType("oidc", func(data proto.Message) (Opener, error) {
+		conf, ok := data.(*auth.OidcConnectorConfig)
+		if !ok || conf == nil {
+			return nil, fmt.Errorf("oidc connector requires an OidcConnectorConfig")
+		}
+		if conf.Issuer == "" || conf.ClientID == "" {
+			return nil, fmt.Errorf("oidc connector requires an Issuer and a ClientID")
+		}
+		return &oidcconfig{conf: conf}, nil
+	})
+}
+
+type oidcconfig struct {
+	conf *auth.OidcConnectorConfig
+}
+
+func (c *oidcconfig) Open(id string, _ dlog.Logger) (Connector, error) {
+	return &oidcconnector{
+		id:        id,
+		conf:      c.conf,
+		provision: provisionExternalUser,
+	}, nil
+}
+
+type oidcconnector struct {
+	id   string
+	conf *auth.OidcConnectorConfig
+	// provision stores the authenticated user in the users service
+	provision func(ctx context.Context, user *idm.User) (*idm.User, error)
+
+	// provider is discovered on first use, so that an unavailable issuer does not prevent the connector to load
+	sync.Mutex
+	provider *oidc.Provider
+	verifier *oidc.IDTokenVerifier
+}
+
+// LoginURL returns the authorization endpoint of the provider the user must be redirected to.
+func (o *oidcconnector) LoginURL(s Scopes, callbackURL, state string) (string, error) {
+	oauthConfig, err := o.oauthConfig(callbackURL)
+	if err != nil {
+		return "", err
+	}
+	return oauthConfig.AuthCodeURL(state), nil
+}
+
+// HandleCallback exchanges the code received by the callback, verifies the ID token, and provisions
+// the user from its claims.
+func (o *oidcconnector) HandleCallback(s Scopes, r *http.Request) (Identity, error) {
+	ctx := r.Context()
+	if e := r.FormValue("error"); e != "" {
+		return Identity{}, fmt.Errorf("oidc: %s %s", e, r.FormValue("error_description"))
+	}
+	code := r.FormValue("code")
+	if code == "" {
+		return Identity{}, fmt.Errorf("oidc: missing code in callback")
+	}
+
+	oauthConfig, err := o.oauthConfig("")
+	if err != nil {
+		return Identity{}, err
+	}
+	o.Lock()
+	provider, verifier := o.provider, o.verifier
+	o.Unlock()
+	token, err := oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return Identity{}, fmt.Errorf("oidc: cannot exchange code: %v", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, fmt.Errorf("oidc: no id_token in token response")
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("oidc: cannot verify id_token: %v", err)
+	}
+	claims := make(map[string]interface{})
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+	// Claims such as groups may only be returned by the userinfo endpoint
+	if info, e := provider.UserInfo(ctx, oauth2.StaticTokenSource(token)); e == nil {
+		extra := make(map[string]interface{})
+		if info.Claims(&extra) == nil {
+			for k, v := range extra {
+				if _, exists := claims[k]; !exists {
+					claims[k] = v
+				}
+			}
+		}
+	}
+
+	user, err := o.MapClaims(claims)
+	if err != nil {
+		return Identity{}, err
+	}
+	stored, err := o.provision(ctx, user)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	verified, _ := claims["email_verified"].(bool)
+	return Identity{
+		UserID:        stored.GetUuid(),
+		Username:      stored.GetLogin(),
+		Email:         stored.GetAttributes()[idm.UserAttrEmail],
+		EmailVerified: verified,
+		Claims:        claims,
+		Groups:        claimValues(claims, "groups"),
+	}, nil
+}
+
+// MapClaims builds a user from the ID token claims, applying the mapping rules to fill its
+// attributes, its roles and its GroupPath.
+func (o *oidcconnector) MapClaims(claims map[string]interface{}) (*idm.User, error) {
+	loginClaim := o.conf.LoginClaim
+	if loginClaim == "" {
+		loginClaim = "preferred_username"
+	}
+	login := claimValues(claims, loginClaim)
+	if len(login) == 0 || login[0] == "" {
+		return nil, fmt.Errorf("oidc: missing claim %s", loginClaim)
+	}
+	u := &idm.User{
+		Login:     login[0],
+		GroupPath: "/",
+		Attributes: map[string]string{
+			idm.UserAttrAuthSource: o.id,
+			idm.UserAttrOrigin:     o.id,
+		},
+	}
+	if name, ok := claims["name"].(string); ok && name != "" {
+		u.Attributes[idm.UserAttrDisplayName] = name
+	}
+	if email, ok := claims["email"].(string); ok && email != "" {
+		u.Attributes[idm.UserAttrEmail] = email
+	}
+	applyMappingRules(u, o.conf.MappingRules, func(attribute string) []string {
+		return claimValues(claims, attribute)
+	})
+	return u, nil
+}
+
+// oauthConfig discovers the provider if required and builds the oauth2 configuration.
+// The provider keys are fetched with a background context, as they are cached across requests.
+func (o *oidcconnector) oauthConfig(callbackURL string) (*oauth2.Config, error) {
+	o.Lock()
+	defer o.Unlock()
+	if o.provider == nil {
+		provider, err := oidc.NewProvider(context.Background(), o.conf.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: cannot discover issuer %s: %v", o.conf.Issuer, err)
+		}
+		o.provider = provider
+		o.verifier = provider.Verifier(&oidc.Config{ClientID: o.conf.ClientID})
+	}
+	if callbackURL == "" {
+		callbackURL = o.conf.RedirectURI
+	}
+	scopes := o.conf.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"profile", "email"}
+	}
+	return &oauth2.Config{
+		ClientID:     o.conf.ClientID,
+		ClientSecret: o.conf.ClientSecret,
+		Endpoint:     o.provider.Endpoint(),
+		RedirectURL:  callbackURL,
+		Scopes:       append([]string{oidc.ScopeOpenID}, scopes...),
+	}, nil
+}
+
+// claimValues reads a claim as a list of strings.
+func claimValues(claims map[string]interface{}, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, i := range v {
+			values = append(values, fmt.Sprintf("%v", i))
+		}
+		return values
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// testOidcServer is a minimal OpenID Connect provider issuing a signed ID token for a known code.
+type testOidcServer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	code   string
+	claims map[string]interface{}
+}
+
+func newTestOidcServer() (*testOidcServer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	s := &testOidcServer{key: key, code: "valid-code"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/auth",
+			"token_endpoint":         s.URL + "/token",
+			"jwks_uri":               s.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &s.key.PublicKey, KeyID: "test-key", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != s.code {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := map[string]interface{}{
+			"iss": s.URL,
+			"aud": "cells",
+			"sub": "upstream-id",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"iat": time.Now().Unix(),
+		}
+		for k, v := range s.claims {
+			claims[k] = v
+		}
+		payload, _ := json.Marshal(claims)
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: s.key, KeyID: "test-key"}}, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		signed, _ := signer.Sign(payload)
+		idToken, _ := signed.CompactSerialize()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "upstream-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s, nil
+}
+
+func TestOidcConnector(t *testing.T) {
+
+	server, err := newTestOidcServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	server.claims = map[string]interface{}{
+		"preferred_username": "jdoe",
+		"name":               "John Doe",
+		"email":              "jdoe@example.org",
+		"email_verified":     true,
+		"groups":             []string{"admins", "devs"},
+		"department":         "engineering",
+	}
+
+	open := func(clientID string) *oidcconnector {
+		opener, e := connectorTypes["oidc"](&auth.OidcConnectorConfig{
+			Issuer:       server.URL,
+			ClientID:     clientID,
+			ClientSecret: "secret",
+			RedirectURI:  "https://cells.example.org/auth/callback",
+			MappingRules: []*auth.LdapMappingRule{
+				{RuleName: "roles", LeftAttribute: "groups", RightAttribute: MappingRightRoles},
+				{RuleName: "group", LeftAttribute: "department", RightAttribute: MappingRightGroupPath},
+			},
+		})
+		So(e, ShouldBeNil)
+		c, e := opener.Open("oidc1", nil)
+		So(e, ShouldBeNil)
+		return c.(*oidcconnector)
+	}
+
+	callback := func(values url.Values) *http.Request {
+		r, _ := http.NewRequest(http.MethodGet, "https://cells.example.org/auth/callback?"+values.Encode(), nil)
+		return r
+	}
+
+	Convey("Test invalid configuration", t, func() {
+		_, e := connectorTypes["oidc"](&auth.OidcConnectorConfig{Issuer: server.URL})
+		So(e, ShouldNotBeNil)
+		_, e = connectorTypes["oidc"](nil)
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test login URL", t, func() {
+		c := open("cells")
+		u, e := c.LoginURL(Scopes{}, "", "some-state")
+		So(e, ShouldBeNil)
+		parsed, e := url.Parse(u)
+		So(e, ShouldBeNil)
+		So(parsed.Path, ShouldEqual, "/auth")
+		So(parsed.Query().Get("client_id"), ShouldEqual, "cells")
+		So(parsed.Query().Get("state"), ShouldEqual, "some-state")
+		So(parsed.Query().Get("redirect_uri"), ShouldEqual, "https://cells.example.org/auth/callback")
+		So(parsed.Query().Get("scope"), ShouldEqual, "openid profile email")
+	})
+
+	Convey("Test callback provisions the user", t, func() {
+		c := open("cells")
+		var provisioned *idm.User
+		c.provision = func(ctx context.Context, user *idm.User) (*idm.User, error) {
+			provisioned = user
+			user.Uuid = "user-uuid"
+			return user, nil
+		}
+
+		identity, e := c.HandleCallback(Scopes{}, callback(url.Values{"code": {"valid-code"}, "state": {"s"}}))
+		So(e, ShouldBeNil)
+		So(identity.UserID, ShouldEqual, "user-uuid")
+		So(identity.Username, ShouldEqual, "jdoe")
+		So(identity.Email, ShouldEqual, "jdoe@example.org")
+		So(identity.EmailVerified, ShouldBeTrue)
+		So(identity.Groups, ShouldResemble, []string{"admins", "devs"})
+
+		So(provisioned, ShouldNotBeNil)
+		So(provisioned.GroupPath, ShouldEqual, "/engineering")
+		So(provisioned.Attributes[idm.UserAttrDisplayName], ShouldEqual, "John Doe")
+		So(provisioned.Attributes[idm.UserAttrAuthSource], ShouldEqual, "oidc1")
+		So(provisioned.Roles, ShouldHaveLength, 2)
+		So(provisioned.Roles[0].Uuid, ShouldEqual, "oidc1_admins")
+		So(provisioned.Roles[1].Label, ShouldEqual, "devs")
+	})
+
+	Convey("Test callback failures", t, func() {
+		c := open("cells")
+		c.provision = func(ctx context.Context, user *idm.User) (*idm.User, error) {
+			return user, nil
+		}
+
+		_, e := c.HandleCallback(Scopes{}, callback(url.Values{"code": {"wrong-code"}}))
+		So(e, ShouldNotBeNil)
+
+		_, e = c.HandleCallback(Scopes{}, callback(url.Values{"error": {"access_denied"}}))
+		So(e, ShouldNotBeNil)
+
+		_, e = c.HandleCallback(Scopes{}, callback(url.Values{}))
+		So(e, ShouldNotBeNil)
+
+		// Token issued for another audience
+		other := open("other-client")
+		other.provision = c.provision
+		_, e = other.HandleCallback(Scopes{}, callback(url.Values{"code": {"valid-code"}}))
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test missing login claim", t, func() {
+		c := open("cells")
+		_, e := c.MapClaims(map[string]interface{}{"sub": "upstream-id"})
+		So(e, ShouldNotBeNil)
+	})
+}
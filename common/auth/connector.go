@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	dlog "github.com/dexidp/dex/pkg/log"
@@ -20,6 +21,14 @@ type ConnectorConfig interface {
 
 type Connector interface{}
 
+// ConnectorData is the generic structure of a connector in the configuration.
+type ConnectorData struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
 type OpenerFunc func(proto.Message) (Opener, error)
 
 type Opener interface {
@@ -56,12 +65,20 @@ func RegisterConnector(id, name, connectorType string, data proto.Message) {
 		return
 	}
 
-	connectors = append(connectors, &conn{
+	cc := &conn{
 		id:            id,
 		name:          name,
 		connectorType: connectorType,
 		conn:          c,
-	})
+	}
+	// Replace a connector registered with the same id, as configs may be reloaded
+	for i, existing := range connectors {
+		if existing.ID() == id {
+			connectors[i] = cc
+			return
+		}
+	}
+	connectors = append(connectors, cc)
 }
 
 // GetConnectors list all the connectors correctly configured
@@ -34,6 +34,7 @@ import (
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/auth/totp"
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/utils/permissions"
@@ -142,6 +143,11 @@ func (j *JWTVerifier) loadClaims(ctx context.Context, token IDToken, claims *cla
 		return err
 	}
 
+	// Restrictions of personal access tokens must be understood, or the token would grant too much
+	if e := ValidatePersonalTokenScopes(claims.GetScopes()); e != nil {
+		return errors2.Unauthorized("token.scopes", "%s", e.Error())
+	}
+
 	// Search by name or by email
 	var user *idm.User
 	var uE error
@@ -308,8 +314,14 @@ func (j *JWTVerifier) PasswordCredentialsToken(ctx context.Context, userName str
 			break
 		}
 	}
+	if err == nil {
+		err = checkSecondFactor(ctx, userName)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return token, err
+	return token, nil
 }
 
 // LoginChallengeCode will perform an implicit flow
@@ -352,8 +364,33 @@ func (j *JWTVerifier) PasswordCredentialsCode(ctx context.Context, username, pas
 			break
 		}
 	}
+	if err == nil {
+		err = checkSecondFactor(ctx, username)
+	}
+	if err != nil {
+		return "", err
+	}
 
-	return code, err
+	return code, nil
+}
+
+// checkSecondFactor refuses password grants for users whose second factor is enforced, unless
+// the code was verified by the login form. It is called once the password is validated, not to
+// reveal anything about the account otherwise.
+func checkSecondFactor(ctx context.Context, userName string) error {
+	if totp.IsVerified(ctx, userName) {
+		return nil
+	}
+	user, err := permissions.SearchUniqueUser(ctx, userName, "")
+	if err != nil || user == nil {
+		return err
+	}
+	if enforced, err := totp.Enforced(ctx, user); err != nil {
+		return err
+	} else if enforced {
+		return errors2.Forbidden(common.SERVICE_USER, "A second factor is enabled for this account: use a personal access token instead of the password")
+	}
+	return nil
 }
 
 // Logout
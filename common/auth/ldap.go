@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+
+	"gopkg.in/ldap.v2"
+
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// LdapDirectory searches and authenticates users against an LDAP or Active Directory server.
+// Users are built by applying the MappingRules of the configuration to LDAP entries.
+type LdapDirectory struct {
+	// AuthSource identifies the directory, it is stored in the users attributes
+	AuthSource string
+	Config     *auth.LdapConnectorConfig
+}
+
+// NewLdapDirectory creates an LdapDirectory for a connector configuration.
+func NewLdapDirectory(authSource string, conf *auth.LdapConnectorConfig) *LdapDirectory {
+	return &LdapDirectory{
+		AuthSource: authSource,
+		Config:     conf,
+	}
+}
+
+// Authenticate finds a user by login and binds with its DN and password.
+// It returns an error if the user cannot be found, and false if the password does not match.
+func (d *LdapDirectory) Authenticate(login, password string) (*idm.User, bool, error) {
+	if d.Config.User == nil || d.Config.User.IDAttribute == "" {
+		return nil, false, fmt.Errorf("missing user search configuration")
+	}
+	conn, err := d.connect()
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf("(&%s(%s=%s))", d.filter(d.Config.User), d.Config.User.IDAttribute, ldap.EscapeFilter(login))
+	entries, err := d.search(conn, d.Config.User, filter, d.userAttributes())
+	if err != nil {
+		return nil, false, err
+	}
+	if len(entries) == 0 {
+		return nil, false, fmt.Errorf("cannot find user %s", login)
+	} else if len(entries) > 1 {
+		return nil, false, fmt.Errorf("found multiple entries for user %s", login)
+	}
+	// Binding with an empty password would be an anonymous bind
+	if password == "" {
+		return nil, false, nil
+	}
+	if err := conn.Bind(entries[0].DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return d.MapUser(entries[0]), true, nil
+}
+
+// SearchUsers lists all users matching the user filter.
+func (d *LdapDirectory) SearchUsers() ([]*idm.User, error) {
+	if d.Config.User == nil || d.Config.User.IDAttribute == "" {
+		return nil, fmt.Errorf("missing user search configuration")
+	}
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := d.search(conn, d.Config.User, d.filter(d.Config.User), d.userAttributes())
+	if err != nil {
+		return nil, err
+	}
+	var users []*idm.User
+	for _, e := range entries {
+		if u := d.MapUser(e); u.Login != "" {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+// SearchGroups lists all groups matching the group filter, if it is configured.
+func (d *LdapDirectory) SearchGroups() ([]*idm.User, error) {
+	if d.Config.Group == nil || d.Config.Group.IDAttribute == "" {
+		return nil, nil
+	}
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	attributes := []string{d.Config.Group.IDAttribute}
+	if d.Config.Group.DisplayAttribute != "" {
+		attributes = append(attributes, d.Config.Group.DisplayAttribute)
+	}
+	entries, err := d.search(conn, d.Config.Group, d.filter(d.Config.Group), attributes)
+	if err != nil {
+		return nil, err
+	}
+	var groups []*idm.User
+	for _, e := range entries {
+		if g := d.MapGroup(e); g.GroupLabel != "" {
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
+
+// MapUser builds a user from an LDAP entry, applying the mapping rules to fill its
+// attributes, its roles and its GroupPath.
+func (d *LdapDirectory) MapUser(entry *ldap.Entry) *idm.User {
+	u := &idm.User{
+		Login:     entry.GetAttributeValue(d.Config.User.IDAttribute),
+		GroupPath: "/",
+		Attributes: map[string]string{
+			idm.UserAttrAuthSource: d.AuthSource,
+			idm.UserAttrOrigin:     d.AuthSource,
+		},
+	}
+	if a := d.Config.User.DisplayAttribute; a != "" {
+		if v := entry.GetAttributeValue(a); v != "" {
+			u.Attributes[idm.UserAttrDisplayName] = v
+		}
+	}
+	applyMappingRules(u, d.Config.MappingRules, entry.GetAttributeValues)
+	return u
+}
+
+// MapGroup builds a group from an LDAP entry.
+func (d *LdapDirectory) MapGroup(entry *ldap.Entry) *idm.User {
+	g := &idm.User{
+		IsGroup:    true,
+		GroupLabel: entry.GetAttributeValue(d.Config.Group.IDAttribute),
+		GroupPath:  "/",
+		Attributes: map[string]string{
+			idm.UserAttrAuthSource: d.AuthSource,
+			idm.UserAttrOrigin:     d.AuthSource,
+		},
+	}
+	if a := d.Config.Group.DisplayAttribute; a != "" {
+		if v := entry.GetAttributeValue(a); v != "" {
+			g.Attributes[idm.UserAttrDisplayName] = v
+		}
+	}
+	return g
+}
+
+// RoleID builds the identifier of a role mapped from the directory, as AuthSource_Prefix_Value.
+func (d *LdapDirectory) RoleID(prefix, value string) string {
+	return mappedRoleID(d.AuthSource, prefix, value)
+}
+
+// connect dials the server, upgrades the connection to TLS if required, and binds with the BindDN.
+func (d *LdapDirectory) connect() (*ldap.Conn, error) {
+	tlsConfig, err := d.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	var conn *ldap.Conn
+	switch strings.ToLower(d.Config.Connection) {
+	case "ssl":
+		conn, err = ldap.DialTLS("tcp", d.Config.Host, tlsConfig)
+	case "normal":
+		conn, err = ldap.Dial("tcp", d.Config.Host)
+	case "", "starttls":
+		if conn, err = ldap.Dial("tcp", d.Config.Host); err == nil {
+			if err = conn.StartTLS(tlsConfig); err != nil {
+				conn.Close()
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported connection type %s", d.Config.Connection)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if d.Config.BindDN != "" {
+		if err := conn.Bind(d.Config.BindDN, d.Config.BindPassword); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (d *LdapDirectory) tlsConfig() (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(d.Config.Host)
+	if err != nil {
+		host = d.Config.Host
+	}
+	conf := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: d.Config.SkipVerifyCertificate,
+	}
+	if d.Config.RootCA != "" {
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM([]byte(d.Config.RootCA)) {
+			return nil, fmt.Errorf("cannot parse RootCA")
+		}
+	}
+	return conf, nil
+}
+
+func (d *LdapDirectory) search(conn *ldap.Conn, f *auth.LdapSearchFilter, filter string, attributes []string) ([]*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(f.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+	var res *ldap.SearchResult
+	var err error
+	if d.Config.PageSize > 0 {
+		res, err = conn.SearchWithPaging(req, uint32(d.Config.PageSize))
+	} else {
+		res, err = conn.Search(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Entries, nil
+}
+
+func (d *LdapDirectory) filter(f *auth.LdapSearchFilter) string {
+	if f.Filter == "" {
+		return "(objectClass=*)"
+	}
+	if !strings.HasPrefix(f.Filter, "(") {
+		return "(" + f.Filter + ")"
+	}
+	return f.Filter
+}
+
+func (d *LdapDirectory) userAttributes() []string {
+	attributes := []string{d.Config.User.IDAttribute}
+	if d.Config.User.DisplayAttribute != "" {
+		attributes = append(attributes, d.Config.User.DisplayAttribute)
+	}
+	for _, r := range d.Config.MappingRules {
+		attributes = append(attributes, r.LeftAttribute)
+	}
+	return attributes
+}
@@ -0,0 +1,429 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/asn1-ber.v1"
+	"gopkg.in/ldap.v2"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const testBaseDN = "dc=example,dc=org"
+
+func testLdapEntries() []*testLdapEntry {
+	return []*testLdapEntry{
+		{
+			DN:       "cn=admin," + testBaseDN,
+			Password: "adminpass",
+			Attributes: map[string][]string{
+				"objectClass": {"organizationalRole"},
+				"cn":          {"admin"},
+			},
+		},
+		{
+			DN:       "uid=jdoe,ou=people," + testBaseDN,
+			Password: "secret",
+			Attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"uid":         {"jdoe"},
+				"cn":          {"John Doe"},
+				"mail":        {"jdoe@example.org"},
+				"ou":          {"engineering"},
+				"memberOf":    {"cn=admins,ou=groups," + testBaseDN, "cn=devs,ou=groups," + testBaseDN},
+			},
+		},
+		{
+			DN:       "uid=asmith,ou=people," + testBaseDN,
+			Password: "other",
+			Attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"uid":         {"asmith"},
+				"cn":          {"Alice Smith"},
+				"mail":        {"asmith@example.org"},
+				"memberOf":    {"cn=devs,ou=groups," + testBaseDN},
+			},
+		},
+		{
+			DN: "cn=admins,ou=groups," + testBaseDN,
+			Attributes: map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"admins"},
+				"description": {"Administrators"},
+			},
+		},
+		{
+			DN: "cn=devs,ou=groups," + testBaseDN,
+			Attributes: map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"devs"},
+			},
+		},
+	}
+}
+
+func testLdapConfig(host, rootCA string) *auth.LdapConnectorConfig {
+	return &auth.LdapConnectorConfig{
+		Host:         host,
+		Connection:   "starttls",
+		RootCA:       rootCA,
+		BindDN:       "cn=admin," + testBaseDN,
+		BindPassword: "adminpass",
+		User: &auth.LdapSearchFilter{
+			BaseDN:           "ou=people," + testBaseDN,
+			Filter:           "objectClass=inetOrgPerson",
+			IDAttribute:      "uid",
+			DisplayAttribute: "cn",
+		},
+		Group: &auth.LdapSearchFilter{
+			BaseDN:           "ou=groups," + testBaseDN,
+			Filter:           "(objectClass=groupOfNames)",
+			IDAttribute:      "cn",
+			DisplayAttribute: "description",
+		},
+		MappingRules: []*auth.LdapMappingRule{
+			{RuleName: "email", LeftAttribute: "mail", RightAttribute: idm.UserAttrEmail},
+			{RuleName: "roles", LeftAttribute: "memberOf", RightAttribute: MappingRightRoles},
+			{RuleName: "admins", LeftAttribute: "memberOf", RightAttribute: MappingRightRoles, RuleString: "admins", RolePrefix: "adm"},
+			{RuleName: "group", LeftAttribute: "ou", RightAttribute: MappingRightGroupPath},
+		},
+	}
+}
+
+func TestLdapDirectory(t *testing.T) {
+
+	server, err := newTestLdapServer(testLdapEntries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	Convey("Test authentication against the directory", t, func() {
+		d := NewLdapDirectory("ldap1", testLdapConfig(server.Addr(), server.RootCA))
+
+		u, valid, e := d.Authenticate("jdoe", "secret")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeTrue)
+		So(u.Login, ShouldEqual, "jdoe")
+
+		_, valid, e = d.Authenticate("jdoe", "wrong")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeFalse)
+
+		_, valid, e = d.Authenticate("jdoe", "")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeFalse)
+
+		_, _, e = d.Authenticate("unknown", "secret")
+		So(e, ShouldNotBeNil)
+
+		_, _, e = d.Authenticate("*", "secret")
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test connection failures", t, func() {
+		conf := testLdapConfig(server.Addr(), "")
+		_, _, e := NewLdapDirectory("ldap1", conf).Authenticate("jdoe", "secret")
+		So(e, ShouldNotBeNil)
+
+		conf.SkipVerifyCertificate = true
+		_, valid, e := NewLdapDirectory("ldap1", conf).Authenticate("jdoe", "secret")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeTrue)
+
+		conf.BindPassword = "wrong"
+		_, _, e = NewLdapDirectory("ldap1", conf).Authenticate("jdoe", "secret")
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test users mapping", t, func() {
+		d := NewLdapDirectory("ldap1", testLdapConfig(server.Addr(), server.RootCA))
+
+		users, e := d.SearchUsers()
+		So(e, ShouldBeNil)
+		So(users, ShouldHaveLength, 2)
+
+		byLogin := make(map[string]*idm.User)
+		for _, u := range users {
+			byLogin[u.Login] = u
+		}
+		jdoe := byLogin["jdoe"]
+		So(jdoe, ShouldNotBeNil)
+		So(jdoe.GroupPath, ShouldEqual, "/engineering")
+		So(jdoe.Attributes[idm.UserAttrEmail], ShouldEqual, "jdoe@example.org")
+		So(jdoe.Attributes[idm.UserAttrDisplayName], ShouldEqual, "John Doe")
+		So(jdoe.Attributes[idm.UserAttrAuthSource], ShouldEqual, "ldap1")
+		var roles []string
+		for _, r := range jdoe.Roles {
+			roles = append(roles, r.Uuid)
+		}
+		So(roles, ShouldResemble, []string{"ldap1_admins", "ldap1_devs", "ldap1_adm_admins"})
+
+		asmith := byLogin["asmith"]
+		So(asmith, ShouldNotBeNil)
+		So(asmith.GroupPath, ShouldEqual, "/")
+		So(asmith.Roles, ShouldHaveLength, 1)
+		So(asmith.Roles[0].Label, ShouldEqual, "devs")
+	})
+
+	Convey("Test groups listing", t, func() {
+		d := NewLdapDirectory("ldap1", testLdapConfig(server.Addr(), server.RootCA))
+
+		groups, e := d.SearchGroups()
+		So(e, ShouldBeNil)
+		So(groups, ShouldHaveLength, 2)
+		So(groups[0].IsGroup, ShouldBeTrue)
+		So(groups[0].GroupLabel, ShouldEqual, "admins")
+		So(groups[0].Attributes[idm.UserAttrDisplayName], ShouldEqual, "Administrators")
+	})
+
+	Convey("Test ldap connector login", t, func() {
+		opener, e := connectorTypes["ldap"](testLdapConfig(server.Addr(), server.RootCA))
+		So(e, ShouldBeNil)
+		c, e := opener.Open("ldap1", nil)
+		So(e, ShouldBeNil)
+
+		var provisioned *idm.User
+		conn := c.(*ldapconnector)
+		conn.provision = func(ctx context.Context, user *idm.User) (*idm.User, error) {
+			provisioned = user
+			user.Uuid = "user-uuid"
+			return user, nil
+		}
+
+		identity, valid, e := conn.Login(context.Background(), Scopes{}, "jdoe", "secret")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeTrue)
+		So(identity.UserID, ShouldEqual, "user-uuid")
+		So(identity.Username, ShouldEqual, "jdoe")
+		So(identity.Email, ShouldEqual, "jdoe@example.org")
+		So(provisioned, ShouldNotBeNil)
+
+		provisioned = nil
+		_, valid, e = conn.Login(context.Background(), Scopes{}, "jdoe", "wrong")
+		So(e, ShouldBeNil)
+		So(valid, ShouldBeFalse)
+		So(provisioned, ShouldBeNil)
+	})
+}
+
+type testLdapEntry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// testLdapServer is a minimal in-process LDAP server supporting StartTLS, simple binds and searches.
+type testLdapServer struct {
+	listener net.Listener
+	entries  []*testLdapEntry
+	tls      *tls.Config
+	RootCA   string
+}
+
+func newTestLdapServer(entries []*testLdapEntry) (*testLdapServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	s := &testLdapServer{
+		listener: l,
+		entries:  entries,
+		tls: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		},
+		RootCA: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+	go func() {
+		for {
+			c, e := l.Accept()
+			if e != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s, nil
+}
+
+func (s *testLdapServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testLdapServer) Close() {
+	s.listener.Close()
+}
+
+func (s *testLdapServer) serve(conn net.Conn) {
+	defer func() {
+		conn.Close()
+	}()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := string(op.Children[2].Data.Bytes())
+			code := uint8(ldap.LDAPResultInvalidCredentials)
+			if dn == "" && password == "" {
+				code = ldap.LDAPResultSuccess
+			}
+			for _, e := range s.entries {
+				if strings.EqualFold(e.DN, dn) && e.Password != "" && e.Password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			s.respond(conn, id, ldap.ApplicationBindResponse, code)
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationSearchRequest:
+			base := strings.ToLower(op.Children[0].Value.(string))
+			for _, e := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(e.DN), base) || !matchTestFilter(op.Children[6], e) {
+					continue
+				}
+				res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Entry")
+				res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "DN"))
+				attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+				for name, values := range e.Attributes {
+					attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+					attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Name"))
+					vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+					for _, v := range values {
+						vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+					}
+					attr.AppendChild(vals)
+					attributes.AppendChild(attr)
+				}
+				res.AppendChild(attributes)
+				s.write(conn, id, res)
+			}
+			s.respond(conn, id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		case ldap.ApplicationExtendedRequest:
+			s.respond(conn, id, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess)
+			tlsConn := tls.Server(conn, s.tls)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn = tlsConn
+		default:
+			return
+		}
+	}
+}
+
+func (s *testLdapServer) respond(w io.Writer, id int64, tag ber.Tag, code uint8) {
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	s.write(w, id, res)
+}
+
+func (s *testLdapServer) write(w io.Writer, id int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	packet.AppendChild(op)
+	w.Write(packet.Bytes())
+}
+
+// matchTestFilter evaluates and, or, not, equality and presence filters against an entry.
+func matchTestFilter(filter *ber.Packet, e *testLdapEntry) bool {
+	values := func(name string) []string {
+		for k, v := range e.Attributes {
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+		return nil
+	}
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, c := range filter.Children {
+			if !matchTestFilter(c, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range filter.Children {
+			if matchTestFilter(c, e) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchTestFilter(filter.Children[0], e)
+	case ldap.FilterEqualityMatch:
+		expected := string(filter.Children[1].Data.Bytes())
+		for _, v := range values(string(filter.Children[0].Data.Bytes())) {
+			if strings.EqualFold(v, expected) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(values(string(filter.Data.Bytes()))) > 0
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"strings"
+
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	// MappingRightRoles is the reserved RightAttribute of a MappingRule filling the user roles
+	MappingRightRoles = "Roles"
+	// MappingRightGroupPath is the reserved RightAttribute of a MappingRule filling the user GroupPath
+	MappingRightGroupPath = "GroupPath"
+)
+
+// applyMappingRules fills the user attributes, roles and GroupPath from the values of external
+// attributes (LDAP attributes or OIDC claims). The user must carry its AuthSource attribute.
+func applyMappingRules(u *idm.User, rules []*auth.LdapMappingRule, values func(attribute string) []string) {
+	for _, r := range rules {
+		rule := MappingRule{
+			RuleName:       r.RuleName,
+			LeftAttribute:  r.LeftAttribute,
+			RightAttribute: r.RightAttribute,
+			RuleString:     r.RuleString,
+			RolePrefix:     r.RolePrefix,
+		}
+		vv := rule.ConvertDNtoName(rule.RemoveLdapEscape(rule.SanitizeValues(values(r.LeftAttribute))))
+		if strings.HasPrefix(rule.RuleString, "preg:") {
+			vv = rule.FilterPreg(rule.RuleString, vv)
+		} else if rule.RuleString != "" {
+			vv = rule.FilterList(rule.SanitizeValues(strings.Split(rule.RuleString, ",")), vv)
+		}
+		if len(vv) == 0 {
+			continue
+		}
+		switch rule.RightAttribute {
+		case MappingRightRoles:
+			for _, v := range vv {
+				u.Roles = append(u.Roles, &idm.Role{
+					Uuid:  mappedRoleID(u.Attributes[idm.UserAttrAuthSource], rule.RolePrefix, v),
+					Label: v,
+				})
+			}
+		case MappingRightGroupPath:
+			u.GroupPath = "/" + strings.Trim(vv[0], "/")
+		default:
+			u.Attributes[rule.RightAttribute] = strings.Join(vv, ",")
+		}
+	}
+}
+
+// mappedRoleID builds the identifier of a role mapped from an external source, as AuthSource_Prefix_Value.
+func mappedRoleID(authSource, prefix, value string) string {
+	parts := []string{authSource}
+	if prefix != "" {
+		parts = append(parts, prefix)
+	}
+	return strings.Join(append(parts, value), "_")
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+const (
+	// MaxPasswordHistory is the maximum number of previous password hashes kept for each user
+	MaxPasswordHistory = 24
+
+	defaultMaxFailedLogins = 10
+)
+
+// commonPasswords is a short list of the most frequently used passwords, always rejected when
+// the denylist is enabled. Additional entries can be provided in the policy.
+var commonPasswords = []string{
+	"123456", "1234567", "12345678", "123456789", "1234567890", "12345", "1234", "111111", "000000", "123123",
+	"654321", "666666", "121212", "112233", "987654321", "password", "password1", "password123", "passw0rd", "p@ssw0rd",
+	"qwerty", "qwerty123", "qwertyuiop", "azerty", "azertyuiop", "abc123", "abcdef", "letmein", "welcome", "welcome1",
+	"iloveyou", "admin", "admin123", "administrator", "root", "toor", "monkey", "dragon", "master", "sunshine",
+	"princess", "football", "baseball", "shadow", "superman", "trustno1", "changeme", "secret", "login", "pydio",
+}
+
+// PasswordPolicy defines the rules applied to passwords of the internal directory, as well as
+// the lockout applied after too many failed logins. It is read from the
+// services/pydio.grpc.user/passwordPolicy configuration.
+type PasswordPolicy struct {
+	// Complexity
+	MinLength      int  `json:"minLength"`
+	RequireUpper   bool `json:"requireUpper"`
+	RequireLower   bool `json:"requireLower"`
+	RequireDigit   bool `json:"requireDigit"`
+	RequireSpecial bool `json:"requireSpecial"`
+	// Denylist
+	UseDenylist bool     `json:"useDenylist"`
+	Denylist    []string `json:"denylist"`
+	// Number of previous passwords that cannot be reused
+	HistorySize int `json:"historySize"`
+	// Password expiry, a change is forced at next login (0 for no expiry)
+	MaxAgeDays int `json:"maxAgeDays"`
+	// Lockout after failed logins. LockoutMinutes set to 0 keeps the user locked until an admin unlocks it.
+	MaxFailedLogins int `json:"maxFailedLogins"`
+	LockoutMinutes  int `json:"lockoutMinutes"`
+}
+
+// DefaultPasswordPolicy returns the policy applied when nothing is configured:
+// no complexity rules, only the lockout after too many failed logins.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{MaxFailedLogins: defaultMaxFailedLogins}
+}
+
+// LoadPasswordPolicy reads the current policy from the configuration, or returns
+// the default policy if the configuration is not loaded yet.
+func LoadPasswordPolicy() *PasswordPolicy {
+	if !config.Loaded() {
+		return DefaultPasswordPolicy()
+	}
+	p := &PasswordPolicy{}
+	config.Get("services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, "passwordPolicy").Scan(p)
+	if p.MaxFailedLogins <= 0 {
+		p.MaxFailedLogins = defaultMaxFailedLogins
+	}
+	if p.HistorySize > MaxPasswordHistory {
+		p.HistorySize = MaxPasswordHistory
+	}
+	return p
+}
+
+// Validate checks a new password against complexity rules and the denylist. History is checked
+// separately by the user service, as it requires access to stored hashes.
+func (p *PasswordPolicy) Validate(login, password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			special = true
+		}
+	}
+	var missing []string
+	if p.RequireUpper && !upper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSpecial && !special {
+		missing = append(missing, "a special character")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("password must contain %s", strings.Join(missing, ", "))
+	}
+	if p.UseDenylist {
+		lc := strings.ToLower(password)
+		if login != "" && lc == strings.ToLower(login) {
+			return fmt.Errorf("password cannot be the same as the login")
+		}
+		for _, list := range [][]string{commonPasswords, p.Denylist} {
+			for _, d := range list {
+				if lc == strings.ToLower(d) {
+					return fmt.Errorf("this password is too common, please choose another one")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Expired tells whether a password last changed at the given time must be renewed.
+func (p *PasswordPolicy) Expired(changed time.Time, now time.Time) bool {
+	if p.MaxAgeDays <= 0 || changed.IsZero() {
+		return false
+	}
+	return now.Sub(changed) > time.Duration(p.MaxAgeDays)*24*time.Hour
+}
+
+// LockoutDuration returns how long a user stays locked after too many failed logins, 0 meaning forever.
+func (p *PasswordPolicy) LockoutDuration() time.Duration {
+	return time.Duration(p.LockoutMinutes) * time.Minute
+}
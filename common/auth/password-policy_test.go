@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+
+	Convey("Test complexity rules", t, func() {
+		p := &PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}
+		So(p.Validate("john", "Ab1!"), ShouldNotBeNil)
+		So(p.Validate("john", "abcdefg1!"), ShouldNotBeNil)
+		So(p.Validate("john", "ABCDEFG1!"), ShouldNotBeNil)
+		So(p.Validate("john", "Abcdefgh!"), ShouldNotBeNil)
+		So(p.Validate("john", "Abcdefgh1"), ShouldNotBeNil)
+		So(p.Validate("john", "Abcdefgh1!"), ShouldBeNil)
+		So(p.Validate("john", "Ébcdéfgh1 "), ShouldBeNil)
+
+		empty := &PasswordPolicy{}
+		So(empty.Validate("john", "a"), ShouldBeNil)
+	})
+
+	Convey("Test denylist", t, func() {
+		p := &PasswordPolicy{UseDenylist: true, Denylist: []string{"Cells2020"}}
+		So(p.Validate("john", "Password1"), ShouldNotBeNil)
+		So(p.Validate("john", "cells2020"), ShouldNotBeNil)
+		So(p.Validate("john", "JOHN"), ShouldNotBeNil)
+		So(p.Validate("john", "correct horse battery"), ShouldBeNil)
+
+		p.UseDenylist = false
+		So(p.Validate("john", "password1"), ShouldBeNil)
+	})
+
+	Convey("Test expiry and lockout", t, func() {
+		now := time.Now()
+		p := &PasswordPolicy{}
+		So(p.Expired(now.Add(-1000*24*time.Hour), now), ShouldBeFalse)
+		p.MaxAgeDays = 90
+		So(p.Expired(now.Add(-89*24*time.Hour), now), ShouldBeFalse)
+		So(p.Expired(now.Add(-91*24*time.Hour), now), ShouldBeTrue)
+		So(p.Expired(time.Time{}, now), ShouldBeFalse)
+
+		So(p.LockoutDuration(), ShouldEqual, 0)
+		p.LockoutMinutes = 15
+		So(p.LockoutDuration(), ShouldEqual, 15*time.Minute)
+	})
+}
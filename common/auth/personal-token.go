@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pydio/cells/common/auth/claim"
+)
+
+const (
+	// PersonalTokenPrefix starts the value of all personal access tokens, to distinguish them
+	// from the tokens issued by the OAuth server without a lookup.
+	PersonalTokenPrefix = "pat_"
+
+	personalTokenLength = 32
+)
+
+// NewPersonalToken generates a random personal access token. It returns the value to be sent
+// once to the user, and its hash, the only form under which it is stored.
+func NewPersonalToken() (value string, hash string, err error) {
+	b := make([]byte, personalTokenLength)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	value = PersonalTokenPrefix + hex.EncodeToString(b)
+	return value, HashPersonalToken(value), nil
+}
+
+// HashPersonalToken computes the hash used to store and look up a personal access token.
+// Tokens are random enough for a plain SHA-256 to be safe here.
+func HashPersonalToken(value string) string {
+	h := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(h[:])
+}
+
+// IsPersonalToken checks if a raw bearer looks like a personal access token.
+// OAuth tokens are made of two dot-separated parts and can never match.
+func IsPersonalToken(raw string) bool {
+	return strings.HasPrefix(raw, PersonalTokenPrefix) && !strings.Contains(raw, ".")
+}
+
+// ValidatePersonalTokenScopes checks that scopes are either claim.ScopeReadOnly or a
+// claim.ScopeWorkspacePrefix followed by a workspace slug or uuid.
+func ValidatePersonalTokenScopes(scopes []string) error {
+	for _, s := range scopes {
+		if s == claim.ScopeReadOnly {
+			continue
+		}
+		if strings.HasPrefix(s, claim.ScopeWorkspacePrefix) && strings.TrimPrefix(s, claim.ScopeWorkspacePrefix) != "" && !strings.ContainsAny(s, ", ") {
+			continue
+		}
+		return fmt.Errorf("invalid scope %q, use %s or %sSLUG", s, claim.ScopeReadOnly, claim.ScopeWorkspacePrefix)
+	}
+	return nil
+}
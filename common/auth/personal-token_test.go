@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPersonalToken(t *testing.T) {
+
+	Convey("Test token generation", t, func() {
+		value, hash, err := NewPersonalToken()
+		So(err, ShouldBeNil)
+		So(strings.HasPrefix(value, PersonalTokenPrefix), ShouldBeTrue)
+		So(hash, ShouldEqual, HashPersonalToken(value))
+		So(hash, ShouldNotContainSubstring, value)
+		So(IsPersonalToken(value), ShouldBeTrue)
+
+		other, _, _ := NewPersonalToken()
+		So(other, ShouldNotEqual, value)
+	})
+
+	Convey("Test OAuth tokens are not recognized", t, func() {
+		So(IsPersonalToken("pat_abc.def"), ShouldBeFalse)
+		So(IsPersonalToken("QeT5YrPgMj3b8ZV0.a9lGtmM8Lw2p0dQ"), ShouldBeFalse)
+	})
+
+	Convey("Test scopes validation", t, func() {
+		So(ValidatePersonalTokenScopes(nil), ShouldBeNil)
+		So(ValidatePersonalTokenScopes([]string{"read-only", "workspace:common-files"}), ShouldBeNil)
+		So(ValidatePersonalTokenScopes([]string{"workspace:"}), ShouldNotBeNil)
+		So(ValidatePersonalTokenScopes([]string{"workspace:a,b"}), ShouldNotBeNil)
+		So(ValidatePersonalTokenScopes([]string{"admin"}), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+// provisionExternalUser creates or updates a user coming from an external authentication source, so that
+// its attributes, roles and group are up-to-date at each login. Mapped roles are created if missing.
+func provisionExternalUser(ctx context.Context, user *idm.User) (*idm.User, error) {
+	authSource := user.Attributes[idm.UserAttrAuthSource]
+	userClient := idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, defaults.NewClient())
+	roleClient := idm.NewRoleServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_ROLE, defaults.NewClient())
+
+	var existing *idm.User
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: user.Login})
+	stream, err := userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		rsp, e := stream.Recv()
+		if e != nil {
+			break
+		}
+		existing = rsp.User
+		break
+	}
+	stream.Close()
+
+	if existing != nil {
+		if existing.Attributes[idm.UserAttrAuthSource] != authSource {
+			return nil, fmt.Errorf("user %s already exists in another authentication source", user.Login)
+		}
+		user.Uuid = existing.Uuid
+		user.Policies = existing.Policies
+		attributes := make(map[string]string, len(existing.Attributes))
+		for k, v := range existing.Attributes {
+			attributes[k] = v
+		}
+		for k, v := range user.Attributes {
+			attributes[k] = v
+		}
+		user.Attributes = attributes
+		// Keep roles that are not managed by the directory
+		var roles []*idm.Role
+		for _, r := range existing.Roles {
+			if !strings.HasPrefix(r.Uuid, authSource+"_") {
+				roles = append(roles, r)
+			}
+		}
+		user.Roles = append(roles, user.Roles...)
+	}
+	if _, ok := user.Attributes[idm.UserAttrProfile]; !ok {
+		user.Attributes[idm.UserAttrProfile] = common.PYDIO_PROFILE_STANDARD
+	}
+
+	for _, r := range user.Roles {
+		if !strings.HasPrefix(r.Uuid, authSource+"_") {
+			continue
+		}
+		rq, _ := ptypes.MarshalAny(&idm.RoleSingleQuery{Uuid: []string{r.Uuid}})
+		rsp, e := roleClient.SearchRole(ctx, &idm.SearchRoleRequest{Query: &service.Query{SubQueries: []*any.Any{rq}}})
+		if e != nil {
+			return nil, e
+		}
+		var found bool
+		for {
+			if _, e := rsp.Recv(); e != nil {
+				break
+			}
+			found = true
+		}
+		rsp.Close()
+		if !found {
+			if _, e := roleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{Uuid: r.Uuid, Label: r.Label}}); e != nil {
+				return nil, e
+			}
+		}
+	}
+
+	resp, err := userClient.CreateUser(ctx, &idm.CreateUserRequest{User: user})
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		// Create the role associated to the new user
+		builder := service.NewResourcePoliciesBuilder()
+		builder = builder.WithOwner(resp.User.Uuid)
+		builder = builder.WithProfileWrite(common.PYDIO_PROFILE_ADMIN)
+		builder = builder.WithUserRead(user.Login)
+		builder = builder.WithUserWrite(user.Login)
+		if _, e := roleClient.CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+			Uuid:     resp.User.Uuid,
+			Label:    "User " + resp.User.Uuid,
+			UserRole: true,
+			Policies: builder.Policies(),
+		}}); e != nil {
+			return nil, e
+		}
+	}
+	return resp.User, nil
+}
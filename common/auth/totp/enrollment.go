@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package totp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// RecoveryCodesCount is the number of recovery codes generated at enrollment
+const RecoveryCodesCount = 10
+
+var (
+	// userLocks serializes the verifications of a user's codes
+	userLocks sync.Map
+	// Vault accessors, replaced in tests
+	getSecret = func(key string) string { return config.GetSecret(key).String("") }
+	setSecret = config.SetSecret
+	delSecret = config.DelSecret
+)
+
+// Enrollment is the second factor of a user. It is pending until a first code is verified.
+type Enrollment struct {
+	Secret string `json:"secret"`
+	Active bool   `json:"active"`
+	// Hashes of the unused recovery codes
+	RecoveryCodes []string `json:"recoveryCodes"`
+	// Last counter accepted, to prevent replays
+	LastCounter uint64 `json:"lastCounter"`
+	Created     int64  `json:"created"`
+}
+
+// NewEnrollment generates a pending enrollment and returns the clear recovery codes.
+func NewEnrollment() (*Enrollment, []string, error) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, nil, err
+	}
+	e := &Enrollment{
+		Secret:  secret,
+		Created: time.Now().Unix(),
+	}
+	var codes []string
+	for i := 0; i < RecoveryCodesCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))
+		code = code[:4] + "-" + code[4:]
+		codes = append(codes, code)
+		e.RecoveryCodes = append(e.RecoveryCodes, hashRecoveryCode(code))
+	}
+	return e, codes, nil
+}
+
+// Check validates either a code or a recovery code. Recovery codes are consumed, and the enrollment
+// must be saved after a successful check: use Verify to do both atomically.
+func (e *Enrollment) Check(code string, t time.Time) bool {
+	if counter, ok := Validate(e.Secret, code, t, e.LastCounter); ok {
+		e.LastCounter = counter
+		return true
+	}
+	hash := hashRecoveryCode(code)
+	for i, h := range e.RecoveryCodes {
+		if h == hash {
+			e.RecoveryCodes = append(e.RecoveryCodes[:i], e.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Verify loads the enrollment of a user, checks the code and saves the consumed counter or recovery code
+// while holding a lock on the user, so that concurrent requests cannot use the same code twice.
+// If activate is true, a pending enrollment is activated by a valid code. It returns the enrollment,
+// or nil if the user has none.
+func Verify(userUuid string, code string, t time.Time, activate bool) (*Enrollment, bool, error) {
+	l, _ := userLocks.LoadOrStore(userUuid, &sync.Mutex{})
+	lock := l.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+
+	e, err := Load(userUuid)
+	if err != nil || e == nil {
+		return e, false, err
+	}
+	if !e.Check(code, t) {
+		return e, false, nil
+	}
+	if activate {
+		e.Active = true
+	}
+	if err := Save(userUuid, e); err != nil {
+		return e, false, err
+	}
+	return e, true, nil
+}
+
+// Load finds the enrollment of a user, or nil if there is none.
+func Load(userUuid string) (*Enrollment, error) {
+	data := getSecret(vaultKey(userUuid))
+	if data == "" {
+		return nil, nil
+	}
+	e := &Enrollment{}
+	if err := json.Unmarshal([]byte(data), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Save stores the enrollment of a user in the vault.
+func Save(userUuid string, e *Enrollment) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	setSecret(vaultKey(userUuid), string(data))
+	return nil
+}
+
+// Delete removes the enrollment of a user.
+func Delete(userUuid string) {
+	delSecret(vaultKey(userUuid))
+}
+
+// Required tells whether the user must use a second factor, because one of its roles is listed
+// in the services/pydio.grpc.user/totp/requiredRoles configuration.
+func Required(roles []*idm.Role) bool {
+	var required []string
+	config.Get("services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, "totp", "requiredRoles").Scan(&required)
+	for _, r := range roles {
+		for _, id := range required {
+			if r.Uuid == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Enforced tells whether a second factor is needed for this user to log in: it is active,
+// or one of the user roles makes it mandatory.
+func Enforced(ctx context.Context, user *idm.User) (bool, error) {
+	if !IsLocalUser(user) {
+		return false, nil
+	}
+	e, err := Load(user.Uuid)
+	if err != nil {
+		return false, err
+	}
+	if e != nil && e.Active {
+		return true, nil
+	}
+	return Required(permissions.GetRolesForUser(ctx, user, false)), nil
+}
+
+type verifiedKey struct{}
+
+// WithVerified flags the context of a login whose second factor was just verified.
+func WithVerified(ctx context.Context, login string) context.Context {
+	return context.WithValue(ctx, verifiedKey{}, login)
+}
+
+// IsVerified checks if the second factor of this login was verified in the current context.
+func IsVerified(ctx context.Context, login string) bool {
+	l, ok := ctx.Value(verifiedKey{}).(string)
+	return ok && l != "" && l == login
+}
+
+// IsLocalUser tells whether the user authenticates against the internal users directory,
+// users from external sources being left to their own authentication policies.
+func IsLocalUser(user *idm.User) bool {
+	source := user.GetAttributes()[idm.UserAttrAuthSource]
+	return source == "" || source == "pydio"
+}
+
+// Issuer is the name displayed by authenticator applications, taken from the application title.
+func Issuer() string {
+	return config.Get("frontend", "plugin", "core.pydio", "APPLICATION_TITLE").String("Pydio Cells")
+}
+
+func vaultKey(userUuid string) string {
+	return "totp-" + userUuid
+}
+
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.Replace(strings.TrimSpace(code), " ", "", -1))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package totp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Secret from RFC 6238 test vectors ("12345678901234567890")
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	Convey("Test RFC 6238 vectors", t, func() {
+		vectors := map[int64]string{
+			59:         "287082",
+			1111111109: "081804",
+			1111111111: "050471",
+			1234567890: "005924",
+			2000000000: "279037",
+		}
+		for ts, expected := range vectors {
+			code, e := GenerateCode(rfcSecret, Counter(time.Unix(ts, 0)))
+			So(e, ShouldBeNil)
+			So(code, ShouldEqual, expected)
+		}
+		_, e := GenerateCode("not base32!", 1)
+		So(e, ShouldNotBeNil)
+	})
+
+	Convey("Test validation window and replays", t, func() {
+		now := time.Unix(1234567890, 0)
+		counter, ok := Validate(rfcSecret, "005924", now, 0)
+		So(ok, ShouldBeTrue)
+		So(counter, ShouldEqual, Counter(now))
+
+		// Previous and next periods are accepted
+		_, ok = Validate(rfcSecret, "005924", now.Add(Period*time.Second), 0)
+		So(ok, ShouldBeTrue)
+		_, ok = Validate(rfcSecret, "005924", now.Add(-Period*time.Second), 0)
+		So(ok, ShouldBeTrue)
+		_, ok = Validate(rfcSecret, "005924", now.Add(2*Period*time.Second), 0)
+		So(ok, ShouldBeFalse)
+
+		// Already used counter
+		_, ok = Validate(rfcSecret, "005924", now, counter)
+		So(ok, ShouldBeFalse)
+
+		_, ok = Validate(rfcSecret, "12345", now, 0)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Test key URI", t, func() {
+		secret, e := GenerateSecret()
+		So(e, ShouldBeNil)
+		So(secret, ShouldHaveLength, 32)
+		uri := KeyURI("Pydio Cells", "jdoe", secret)
+		So(uri, ShouldStartWith, "otpauth://totp/Pydio%20Cells:jdoe?")
+		So(uri, ShouldContainSubstring, "secret="+secret)
+	})
+}
+
+func TestEnrollment(t *testing.T) {
+
+	vault := make(map[string]string)
+	getSecret = func(key string) string { return vault[key] }
+	setSecret = func(key, value string) { vault[key] = value }
+	delSecret = func(key string) { delete(vault, key) }
+
+	Convey("Test enrollment codes", t, func() {
+		e, codes, err := NewEnrollment()
+		So(err, ShouldBeNil)
+		So(e.Active, ShouldBeFalse)
+		So(codes, ShouldHaveLength, RecoveryCodesCount)
+		So(e.RecoveryCodes, ShouldHaveLength, RecoveryCodesCount)
+
+		now := time.Now()
+		code, _ := GenerateCode(e.Secret, Counter(now))
+		So(e.Check(code, now), ShouldBeTrue)
+		So(e.Check(code, now), ShouldBeFalse)
+
+		So(e.Check(strings.ToUpper(codes[3]), now), ShouldBeTrue)
+		So(e.RecoveryCodes, ShouldHaveLength, RecoveryCodesCount-1)
+		So(e.Check(codes[3], now), ShouldBeFalse)
+		So(e.Check("wrong", now), ShouldBeFalse)
+	})
+
+	Convey("Test enrollment storage", t, func() {
+		e, err := Load("user-uuid")
+		So(err, ShouldBeNil)
+		So(e, ShouldBeNil)
+
+		e, _, _ = NewEnrollment()
+		e.Active = true
+		So(Save("user-uuid", e), ShouldBeNil)
+		So(vault["totp-user-uuid"], ShouldNotBeEmpty)
+
+		loaded, err := Load("user-uuid")
+		So(err, ShouldBeNil)
+		So(loaded, ShouldResemble, e)
+
+		Delete("user-uuid")
+		loaded, err = Load("user-uuid")
+		So(err, ShouldBeNil)
+		So(loaded, ShouldBeNil)
+	})
+
+	Convey("Test concurrent verifications", t, func() {
+		e, codes, _ := NewEnrollment()
+		So(Save("user-uuid", e), ShouldBeNil)
+
+		now := time.Now()
+		code, _ := GenerateCode(e.Secret, Counter(now))
+		for _, c := range []string{code, codes[0]} {
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			success := 0
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, ok, err := Verify("user-uuid", c, now, true); err == nil && ok {
+						mu.Lock()
+						success++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			So(success, ShouldEqual, 1)
+		}
+
+		loaded, _ := Load("user-uuid")
+		So(loaded.Active, ShouldBeTrue)
+		So(loaded.RecoveryCodes, ShouldHaveLength, RecoveryCodesCount-1)
+
+		_, ok, err := Verify("unknown-uuid", code, now, false)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+		Delete("user-uuid")
+	})
+}
@@ -73,6 +73,16 @@ func AsTestEnv() {
 	close(configLoaded)
 }
 
+// Loaded tells whether the configuration is initialized, without waiting for it.
+func Loaded() bool {
+	select {
+	case <-configLoaded:
+		return true
+	default:
+		return false
+	}
+}
+
 // Config wrapper around micro Config
 type Config struct {
 	config.Config
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package ldap provides an ETL store reading users from an ldap connector, to synchronize them into Cells.
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micro/go-config/source"
+
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/etl/models"
+	"github.com/pydio/cells/common/etl/stores"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func init() {
+	stores.RegisterStore("ldap", func(options *stores.Options) (interface{}, error) {
+
+		connector, ok := options.Params["connector"]
+		if !ok {
+			return nil, fmt.Errorf("missing connector parameter")
+		}
+
+		conf, err := auth.LoadLdapConnectorConfig(connector)
+		if err != nil {
+			return nil, err
+		}
+
+		options.MergeOptions.AuthSource = connector
+		options.MergeOptions.Origin = connector
+		options.MergeOptions.RolePrefix = connector + "_"
+		return NewLdapStore(auth.NewLdapDirectory(connector, conf)), nil
+	})
+}
+
+// LdapStore lists users, groups and mapped roles from an ldap directory
+type LdapStore struct {
+	Directory *auth.LdapDirectory
+}
+
+func NewLdapStore(directory *auth.LdapDirectory) *LdapStore {
+	return &LdapStore{
+		Directory: directory,
+	}
+}
+
+// ListConfig is not supported by an ldap directory
+func (s *LdapStore) ListConfig(ctx context.Context, params map[string]interface{}) (*source.ChangeSet, error) {
+	return &source.ChangeSet{}, nil
+}
+
+// ListUsers from the directory, indexed by login
+func (s *LdapStore) ListUsers(ctx context.Context, params map[string]interface{}, progress chan float32) (map[string]*idm.User, error) {
+	users, err := s.Directory.SearchUsers()
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*idm.User, len(users))
+	for i, u := range users {
+		ret[u.Login] = u
+		if progress != nil {
+			progress <- float32(i+1) / float32(len(users))
+		}
+	}
+	return ret, nil
+}
+
+// ListGroups from the directory
+func (s *LdapStore) ListGroups(ctx context.Context, params map[string]interface{}) ([]*idm.User, error) {
+	return s.Directory.SearchGroups()
+}
+
+// ListRoles returns the roles mapped on the directory users
+func (s *LdapStore) ListRoles(ctx context.Context, userStore models.ReadableStore, params map[string]interface{}) ([]*idm.Role, error) {
+	users, err := s.Directory.SearchUsers()
+	if err != nil {
+		return nil, err
+	}
+	var roles []*idm.Role
+	seen := make(map[string]bool)
+	for _, u := range users {
+		for _, r := range u.Roles {
+			if !seen[r.Uuid] {
+				seen[r.Uuid] = true
+				roles = append(roles, r)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// ListACLs is not supported by an ldap directory
+func (s *LdapStore) ListACLs(ctx context.Context, params map[string]interface{}) ([]*idm.ACL, error) {
+	return nil, nil
+}
+
+// ListShares is not supported by an ldap directory
+func (s *LdapStore) ListShares(ctx context.Context, params map[string]interface{}) ([]*models.SyncShare, error) {
+	return nil, nil
+}
+
+func (s *LdapStore) CrossLoadShare(ctx context.Context, syncShare *models.SyncShare, target models.ReadableStore, params map[string]interface{}) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (s *LdapStore) GetUserInfo(c context.Context, userName string, params map[string]interface{}) (u *idm.User, aclCtxt context.Context, e error) {
+	return nil, nil, fmt.Errorf("not implemented")
+}
+
+func (s *LdapStore) GetGroupInfo(ctx context.Context, groupPath string, params map[string]interface{}) (u *idm.User, e error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *LdapStore) ReadNode(c context.Context, wsUuid string, wsPath string) (*tree.Node, error) {
+	return nil, fmt.Errorf("not implemented")
+}
@@ -106,6 +106,7 @@ const (
 	SERVICE_GATEWAY_GRPC  = SERVICE_GATEWAY_NAMESPACE_ + "grpc"
 	SERVICE_GATEWAY_DAV   = SERVICE_GATEWAY_NAMESPACE_ + "dav"
 	SERVICE_GATEWAY_WOPI  = SERVICE_GATEWAY_NAMESPACE_ + "wopi"
+	SERVICE_GATEWAY_SCIM  = SERVICE_GATEWAY_NAMESPACE_ + "scim"
 	SERVICE_MICRO_API     = SERVICE_GATEWAY_NAMESPACE_ + "rest"
 )
 
@@ -136,6 +137,8 @@ const (
 	META_NAMESPACE_NODE_TEST_LOCAL_FOLDER = "pydio:test:local-folder-storage"
 	META_NAMESPACE_RECYCLE_RESTORE        = "pydio:recycle_restore"
 	META_NAMESPACE_NODENAME               = "name"
+	META_NAMESPACE_SENSITIVE_DATA         = "sensitive_data"
+	META_NAMESPACE_TIERING                = "tiering_location"
 	RECYCLE_BIN_NAME                      = "recycle_bin"
 
 	PYDIO_THUMBSTORE_NAMESPACE        = "pydio-thumbstore"
@@ -183,6 +186,7 @@ const (
 	META_FLAG_WORKSPACE_UUID        = "ws_uuid"
 	META_FLAG_VIRTUAL_ROOT          = "virtual_root"
 	META_FLAG_BUCKET                = "ds_bucket"
+	META_FLAG_SENSITIVE             = "sensitive_flag"
 	NODE_FLAG_ETAG_TEMPORARY        = "temporary"
 )
 
@@ -38,6 +38,17 @@ It has these top-level messages:
 	ExchangeResponse
 	RefreshTokenRequest
 	RefreshTokenResponse
+	LdapConnectorConfig
+	LdapSearchFilter
+	LdapMappingRule
+	OidcConnectorConfig
+	PersonalAccessToken
+	PatGenerateRequest
+	PatGenerateResponse
+	PatRevokeRequest
+	PatRevokeResponse
+	PatListRequest
+	PatListResponse
 */
 package auth
 
@@ -800,6 +811,497 @@ func (m *RefreshTokenResponse) GetExpiry() int64 {
 	return 0
 }
 
+// LdapConnectorConfig configures an "ldap" authentication connector, binding
+// users against an LDAP or Active Directory server.
+type LdapConnectorConfig struct {
+	// Server address as host:port
+	Host string `protobuf:"bytes,1,opt,name=Host" json:"Host,omitempty"`
+	// One of "starttls" (default), "ssl" or "normal" (no encryption)
+	Connection string `protobuf:"bytes,2,opt,name=Connection" json:"Connection,omitempty"`
+	// Do not verify the server certificate
+	SkipVerifyCertificate bool `protobuf:"varint,3,opt,name=SkipVerifyCertificate" json:"SkipVerifyCertificate,omitempty"`
+	// PEM-encoded certificate authority used to verify the server certificate
+	RootCA string `protobuf:"bytes,4,opt,name=RootCA" json:"RootCA,omitempty"`
+	// DN used to search the directory, anonymous bind if empty
+	BindDN string `protobuf:"bytes,5,opt,name=BindDN" json:"BindDN,omitempty"`
+	// Password of the BindDN
+	BindPassword string `protobuf:"bytes,6,opt,name=BindPassword" json:"BindPassword,omitempty"`
+	// How to find users
+	User *LdapSearchFilter `protobuf:"bytes,7,opt,name=User" json:"User,omitempty"`
+	// How to find groups
+	Group *LdapSearchFilter `protobuf:"bytes,8,opt,name=Group" json:"Group,omitempty"`
+	// Rules mapping LDAP attributes to users attributes, roles and GroupPath
+	MappingRules []*LdapMappingRule `protobuf:"bytes,9,rep,name=MappingRules" json:"MappingRules,omitempty"`
+	// ISO8601 repeating interval used to synchronize users into the
+	// directory (e.g. R/2020-01-01T02:00:00Z/PT6H), no sync if empty
+	SyncSchedule string `protobuf:"bytes,10,opt,name=SyncSchedule" json:"SyncSchedule,omitempty"`
+	// Page size for searches, no paging if 0
+	PageSize int32 `protobuf:"varint,11,opt,name=PageSize" json:"PageSize,omitempty"`
+}
+
+func (m *LdapConnectorConfig) Reset()                    { *m = LdapConnectorConfig{} }
+func (m *LdapConnectorConfig) String() string            { return proto.CompactTextString(m) }
+func (*LdapConnectorConfig) ProtoMessage()               {}
+func (*LdapConnectorConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *LdapConnectorConfig) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetConnection() string {
+	if m != nil {
+		return m.Connection
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetSkipVerifyCertificate() bool {
+	if m != nil {
+		return m.SkipVerifyCertificate
+	}
+	return false
+}
+
+func (m *LdapConnectorConfig) GetRootCA() string {
+	if m != nil {
+		return m.RootCA
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetBindDN() string {
+	if m != nil {
+		return m.BindDN
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetBindPassword() string {
+	if m != nil {
+		return m.BindPassword
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetUser() *LdapSearchFilter {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *LdapConnectorConfig) GetGroup() *LdapSearchFilter {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *LdapConnectorConfig) GetMappingRules() []*LdapMappingRule {
+	if m != nil {
+		return m.MappingRules
+	}
+	return nil
+}
+
+func (m *LdapConnectorConfig) GetSyncSchedule() string {
+	if m != nil {
+		return m.SyncSchedule
+	}
+	return ""
+}
+
+func (m *LdapConnectorConfig) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+// LdapSearchFilter describes where and how to search entries.
+type LdapSearchFilter struct {
+	// Base DN of the search
+	BaseDN string `protobuf:"bytes,1,opt,name=BaseDN" json:"BaseDN,omitempty"`
+	// LDAP filter (e.g. "(objectClass=person)")
+	Filter string `protobuf:"bytes,2,opt,name=Filter" json:"Filter,omitempty"`
+	// Attribute used as login for users, or as name for groups
+	IDAttribute string `protobuf:"bytes,3,opt,name=IDAttribute" json:"IDAttribute,omitempty"`
+	// Attribute used as display name
+	DisplayAttribute string `protobuf:"bytes,4,opt,name=DisplayAttribute" json:"DisplayAttribute,omitempty"`
+}
+
+func (m *LdapSearchFilter) Reset()                    { *m = LdapSearchFilter{} }
+func (m *LdapSearchFilter) String() string            { return proto.CompactTextString(m) }
+func (*LdapSearchFilter) ProtoMessage()               {}
+func (*LdapSearchFilter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *LdapSearchFilter) GetBaseDN() string {
+	if m != nil {
+		return m.BaseDN
+	}
+	return ""
+}
+
+func (m *LdapSearchFilter) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *LdapSearchFilter) GetIDAttribute() string {
+	if m != nil {
+		return m.IDAttribute
+	}
+	return ""
+}
+
+func (m *LdapSearchFilter) GetDisplayAttribute() string {
+	if m != nil {
+		return m.DisplayAttribute
+	}
+	return ""
+}
+
+// LdapMappingRule maps an LDAP attribute to a user attribute, see auth.MappingRule.
+type LdapMappingRule struct {
+	RuleName       string `protobuf:"bytes,1,opt,name=RuleName" json:"RuleName,omitempty"`
+	LeftAttribute  string `protobuf:"bytes,2,opt,name=LeftAttribute" json:"LeftAttribute,omitempty"`
+	RightAttribute string `protobuf:"bytes,3,opt,name=RightAttribute" json:"RightAttribute,omitempty"`
+	RuleString     string `protobuf:"bytes,4,opt,name=RuleString" json:"RuleString,omitempty"`
+	RolePrefix     string `protobuf:"bytes,5,opt,name=RolePrefix" json:"RolePrefix,omitempty"`
+}
+
+func (m *LdapMappingRule) Reset()                    { *m = LdapMappingRule{} }
+func (m *LdapMappingRule) String() string            { return proto.CompactTextString(m) }
+func (*LdapMappingRule) ProtoMessage()               {}
+func (*LdapMappingRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *LdapMappingRule) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+func (m *LdapMappingRule) GetLeftAttribute() string {
+	if m != nil {
+		return m.LeftAttribute
+	}
+	return ""
+}
+
+func (m *LdapMappingRule) GetRightAttribute() string {
+	if m != nil {
+		return m.RightAttribute
+	}
+	return ""
+}
+
+func (m *LdapMappingRule) GetRuleString() string {
+	if m != nil {
+		return m.RuleString
+	}
+	return ""
+}
+
+func (m *LdapMappingRule) GetRolePrefix() string {
+	if m != nil {
+		return m.RolePrefix
+	}
+	return ""
+}
+
+// OidcConnectorConfig configures an "oidc" authentication connector, delegating
+// login to an upstream OpenID Connect provider with the authorization code flow.
+type OidcConnectorConfig struct {
+	// Issuer URL, used to discover the provider endpoints
+	Issuer string `protobuf:"bytes,1,opt,name=Issuer" json:"Issuer,omitempty"`
+	// Client registered on the provider
+	ClientID string `protobuf:"bytes,2,opt,name=ClientID" json:"ClientID,omitempty"`
+	// Secret of the client
+	ClientSecret string `protobuf:"bytes,3,opt,name=ClientSecret" json:"ClientSecret,omitempty"`
+	// URL the provider redirects to after login
+	RedirectURI string `protobuf:"bytes,4,opt,name=RedirectURI" json:"RedirectURI,omitempty"`
+	// Additional scopes, "profile" and "email" if empty
+	Scopes []string `protobuf:"bytes,5,rep,name=Scopes" json:"Scopes,omitempty"`
+	// Claim used as login, "preferred_username" if empty
+	LoginClaim string `protobuf:"bytes,6,opt,name=LoginClaim" json:"LoginClaim,omitempty"`
+	// Rules mapping claims (e.g. "groups") to users attributes, roles and GroupPath
+	MappingRules []*LdapMappingRule `protobuf:"bytes,7,rep,name=MappingRules" json:"MappingRules,omitempty"`
+}
+
+func (m *OidcConnectorConfig) Reset()                    { *m = OidcConnectorConfig{} }
+func (m *OidcConnectorConfig) String() string            { return proto.CompactTextString(m) }
+func (*OidcConnectorConfig) ProtoMessage()               {}
+func (*OidcConnectorConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *OidcConnectorConfig) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *OidcConnectorConfig) GetClientID() string {
+	if m != nil {
+		return m.ClientID
+	}
+	return ""
+}
+
+func (m *OidcConnectorConfig) GetClientSecret() string {
+	if m != nil {
+		return m.ClientSecret
+	}
+	return ""
+}
+
+func (m *OidcConnectorConfig) GetRedirectURI() string {
+	if m != nil {
+		return m.RedirectURI
+	}
+	return ""
+}
+
+func (m *OidcConnectorConfig) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *OidcConnectorConfig) GetLoginClaim() string {
+	if m != nil {
+		return m.LoginClaim
+	}
+	return ""
+}
+
+func (m *OidcConnectorConfig) GetMappingRules() []*LdapMappingRule {
+	if m != nil {
+		return m.MappingRules
+	}
+	return nil
+}
+
+// PersonalAccessToken describes a token, only its hash is stored
+type PersonalAccessToken struct {
+	Uuid      string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+	Label     string `protobuf:"bytes,2,opt,name=Label" json:"Label,omitempty"`
+	UserUuid  string `protobuf:"bytes,3,opt,name=UserUuid" json:"UserUuid,omitempty"`
+	UserLogin string `protobuf:"bytes,4,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	// Restrictions applied to the token, "read-only" and/or "workspace:SLUG" entries
+	Scopes []string `protobuf:"bytes,5,rep,name=Scopes" json:"Scopes,omitempty"`
+	// Expiration timestamp, mandatory
+	ExpiresAt int32 `protobuf:"varint,6,opt,name=ExpiresAt" json:"ExpiresAt,omitempty"`
+	CreatedAt int32 `protobuf:"varint,7,opt,name=CreatedAt" json:"CreatedAt,omitempty"`
+	// Last time the token was used, 0 if never
+	LastUsedAt int32 `protobuf:"varint,8,opt,name=LastUsedAt" json:"LastUsedAt,omitempty"`
+}
+
+func (m *PersonalAccessToken) Reset()                    { *m = PersonalAccessToken{} }
+func (m *PersonalAccessToken) String() string            { return proto.CompactTextString(m) }
+func (*PersonalAccessToken) ProtoMessage()               {}
+func (*PersonalAccessToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *PersonalAccessToken) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *PersonalAccessToken) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *PersonalAccessToken) GetUserUuid() string {
+	if m != nil {
+		return m.UserUuid
+	}
+	return ""
+}
+
+func (m *PersonalAccessToken) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *PersonalAccessToken) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *PersonalAccessToken) GetExpiresAt() int32 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *PersonalAccessToken) GetCreatedAt() int32 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *PersonalAccessToken) GetLastUsedAt() int32 {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return 0
+}
+
+type PatGenerateRequest struct {
+	Label     string   `protobuf:"bytes,1,opt,name=Label" json:"Label,omitempty"`
+	UserUuid  string   `protobuf:"bytes,2,opt,name=UserUuid" json:"UserUuid,omitempty"`
+	UserLogin string   `protobuf:"bytes,3,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	ExpiresAt int32    `protobuf:"varint,4,opt,name=ExpiresAt" json:"ExpiresAt,omitempty"`
+	Scopes    []string `protobuf:"bytes,5,rep,name=Scopes" json:"Scopes,omitempty"`
+}
+
+func (m *PatGenerateRequest) Reset()                    { *m = PatGenerateRequest{} }
+func (m *PatGenerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*PatGenerateRequest) ProtoMessage()               {}
+func (*PatGenerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *PatGenerateRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *PatGenerateRequest) GetUserUuid() string {
+	if m != nil {
+		return m.UserUuid
+	}
+	return ""
+}
+
+func (m *PatGenerateRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *PatGenerateRequest) GetExpiresAt() int32 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *PatGenerateRequest) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+type PatGenerateResponse struct {
+	// Value of the token, sent only once
+	AccessToken string               `protobuf:"bytes,1,opt,name=AccessToken" json:"AccessToken,omitempty"`
+	Token       *PersonalAccessToken `protobuf:"bytes,2,opt,name=Token" json:"Token,omitempty"`
+}
+
+func (m *PatGenerateResponse) Reset()                    { *m = PatGenerateResponse{} }
+func (m *PatGenerateResponse) String() string            { return proto.CompactTextString(m) }
+func (*PatGenerateResponse) ProtoMessage()               {}
+func (*PatGenerateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *PatGenerateResponse) GetAccessToken() string {
+	if m != nil {
+		return m.AccessToken
+	}
+	return ""
+}
+
+func (m *PatGenerateResponse) GetToken() *PersonalAccessToken {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+type PatRevokeRequest struct {
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+}
+
+func (m *PatRevokeRequest) Reset()                    { *m = PatRevokeRequest{} }
+func (m *PatRevokeRequest) String() string            { return proto.CompactTextString(m) }
+func (*PatRevokeRequest) ProtoMessage()               {}
+func (*PatRevokeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *PatRevokeRequest) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+type PatRevokeResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *PatRevokeResponse) Reset()                    { *m = PatRevokeResponse{} }
+func (m *PatRevokeResponse) String() string            { return proto.CompactTextString(m) }
+func (*PatRevokeResponse) ProtoMessage()               {}
+func (*PatRevokeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *PatRevokeResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type PatListRequest struct {
+	// Restrict to the tokens of this user, all tokens if empty
+	ByUserLogin string `protobuf:"bytes,1,opt,name=ByUserLogin" json:"ByUserLogin,omitempty"`
+}
+
+func (m *PatListRequest) Reset()                    { *m = PatListRequest{} }
+func (m *PatListRequest) String() string            { return proto.CompactTextString(m) }
+func (*PatListRequest) ProtoMessage()               {}
+func (*PatListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *PatListRequest) GetByUserLogin() string {
+	if m != nil {
+		return m.ByUserLogin
+	}
+	return ""
+}
+
+type PatListResponse struct {
+	Tokens []*PersonalAccessToken `protobuf:"bytes,1,rep,name=Tokens" json:"Tokens,omitempty"`
+}
+
+func (m *PatListResponse) Reset()                    { *m = PatListResponse{} }
+func (m *PatListResponse) String() string            { return proto.CompactTextString(m) }
+func (*PatListResponse) ProtoMessage()               {}
+func (*PatListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *PatListResponse) GetTokens() []*PersonalAccessToken {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Token)(nil), "auth.Token")
 	proto.RegisterType((*RevokeTokenRequest)(nil), "auth.RevokeTokenRequest")
@@ -831,84 +1333,135 @@ func init() {
 	proto.RegisterType((*ExchangeResponse)(nil), "auth.ExchangeResponse")
 	proto.RegisterType((*RefreshTokenRequest)(nil), "auth.RefreshTokenRequest")
 	proto.RegisterType((*RefreshTokenResponse)(nil), "auth.RefreshTokenResponse")
+	proto.RegisterType((*LdapConnectorConfig)(nil), "auth.LdapConnectorConfig")
+	proto.RegisterType((*LdapSearchFilter)(nil), "auth.LdapSearchFilter")
+	proto.RegisterType((*LdapMappingRule)(nil), "auth.LdapMappingRule")
+	proto.RegisterType((*OidcConnectorConfig)(nil), "auth.OidcConnectorConfig")
+	proto.RegisterType((*PersonalAccessToken)(nil), "auth.PersonalAccessToken")
+	proto.RegisterType((*PatGenerateRequest)(nil), "auth.PatGenerateRequest")
+	proto.RegisterType((*PatGenerateResponse)(nil), "auth.PatGenerateResponse")
+	proto.RegisterType((*PatRevokeRequest)(nil), "auth.PatRevokeRequest")
+	proto.RegisterType((*PatRevokeResponse)(nil), "auth.PatRevokeResponse")
+	proto.RegisterType((*PatListRequest)(nil), "auth.PatListRequest")
+	proto.RegisterType((*PatListResponse)(nil), "auth.PatListResponse")
 }
 
 func init() { proto.RegisterFile("auth.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1178 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xf6, 0xda, 0xb1, 0xe3, 0x1c, 0x3b, 0x89, 0x33, 0x76, 0xdc, 0xed, 0x52, 0x55, 0xee, 0x54,
-	0x2a, 0x11, 0x94, 0x54, 0x18, 0x21, 0x4a, 0x2b, 0xb5, 0x32, 0x76, 0x88, 0x5c, 0xb5, 0x52, 0xb4,
-	0x69, 0x11, 0x77, 0x95, 0xeb, 0x9d, 0xc4, 0x4b, 0xc2, 0xae, 0xd9, 0x9f, 0xa8, 0x7e, 0x00, 0x2e,
-	0xb9, 0xe0, 0x92, 0x5b, 0x1e, 0x84, 0xe7, 0xe0, 0x21, 0x78, 0x00, 0xc4, 0x15, 0x9a, 0xbf, 0xdd,
-	0x99, 0xd9, 0x95, 0x49, 0x7a, 0xc3, 0xdd, 0xce, 0x39, 0x33, 0x67, 0xbe, 0xf3, 0xf7, 0xcd, 0x59,
-	0x80, 0x59, 0x9a, 0x2c, 0x0e, 0x97, 0x51, 0x98, 0x84, 0x68, 0x83, 0x7e, 0xe3, 0x9f, 0x2d, 0xa8,
-	0xbf, 0x0e, 0x2f, 0x48, 0x80, 0x06, 0xd0, 0x1a, 0xcd, 0xe7, 0x24, 0x8e, 0xd9, 0xd2, 0xb6, 0x06,
-	0xd6, 0xc1, 0x96, 0xab, 0x8a, 0x90, 0x0d, 0x9b, 0xd3, 0x09, 0xd7, 0x56, 0x99, 0x56, 0x2e, 0x11,
-	0x86, 0xb6, 0x4b, 0xce, 0x22, 0x12, 0x2f, 0xb8, 0xba, 0xc6, 0xd4, 0x9a, 0x0c, 0xdd, 0x81, 0xad,
-	0xa3, 0xf7, 0x4b, 0x3f, 0x22, 0xf1, 0x28, 0xb1, 0x37, 0xd8, 0x86, 0x5c, 0x80, 0xbf, 0x02, 0xe4,
-	0x92, 0xab, 0xf0, 0x82, 0xb0, 0xcd, 0x2e, 0xf9, 0x29, 0x25, 0x71, 0x82, 0xee, 0x09, 0x70, 0x0c,
-	0x4d, 0x6b, 0xd8, 0x3a, 0x64, 0xf8, 0xf9, 0x16, 0xae, 0xc1, 0x8f, 0xa0, 0xab, 0x1d, 0x8c, 0x97,
-	0x61, 0x10, 0x13, 0x8a, 0xf5, 0x34, 0x65, 0xd8, 0xd9, 0xd9, 0xa6, 0x2b, 0x97, 0xb8, 0x07, 0xe8,
-	0x24, 0x4a, 0x03, 0xbe, 0x3f, 0x16, 0x37, 0xe1, 0xcf, 0xa0, 0xab, 0x49, 0x85, 0x99, 0x3e, 0x34,
-	0x12, 0x26, 0xb1, 0xad, 0x41, 0xed, 0x60, 0xcb, 0x15, 0x2b, 0xec, 0x42, 0x75, 0x3a, 0xa1, 0x2e,
-	0x8d, 0x17, 0xb3, 0xcb, 0x4b, 0x12, 0x9c, 0x13, 0x11, 0xb0, 0x5c, 0x80, 0x1c, 0x68, 0x7e, 0x47,
-	0x22, 0xff, 0xcc, 0x27, 0x91, 0x88, 0x57, 0xb6, 0x46, 0x08, 0x36, 0xc6, 0xa7, 0xee, 0xb7, 0x22,
-	0x50, 0xec, 0x1b, 0x3f, 0x82, 0xdd, 0x63, 0x92, 0xbc, 0x0c, 0xcf, 0xfd, 0xcc, 0xff, 0xb5, 0x17,
-	0xe0, 0x7f, 0x2c, 0xe8, 0xe4, 0x27, 0x04, 0xe2, 0xf5, 0x98, 0x58, 0x58, 0xde, 0xfd, 0x40, 0xe6,
-	0x89, 0x4c, 0xa1, 0x58, 0xd2, 0x73, 0xa7, 0x24, 0x8e, 0xfd, 0x30, 0x98, 0x4e, 0x04, 0xac, 0x5c,
-	0x80, 0xee, 0x02, 0x08, 0x4c, 0x6f, 0xdc, 0x97, 0x22, 0x7b, 0x8a, 0x04, 0x3d, 0x80, 0x1d, 0xb1,
-	0x22, 0xde, 0xe9, 0x3c, 0x5c, 0x12, 0xbb, 0xce, 0xe2, 0x65, 0x48, 0xd1, 0x43, 0xd8, 0xcb, 0x24,
-	0xa3, 0xd4, 0xf3, 0x49, 0x30, 0x27, 0x76, 0x83, 0x6d, 0x2d, 0x2a, 0x68, 0x04, 0xc7, 0x97, 0x3e,
-	0x09, 0x92, 0xe9, 0xc4, 0xde, 0xe4, 0x11, 0x94, 0x6b, 0x7c, 0x06, 0x68, 0x1c, 0x91, 0x59, 0x42,
-	0xb4, 0x80, 0xa9, 0x27, 0x2c, 0xfd, 0x04, 0xcd, 0x25, 0x03, 0x11, 0xdb, 0x55, 0x9e, 0x4b, 0xbe,
-	0xa2, 0x9e, 0xcb, 0x1b, 0x63, 0xbb, 0xc6, 0x54, 0xb9, 0x00, 0x7f, 0x09, 0x5d, 0xed, 0x1e, 0x11,
-	0xe6, 0xbb, 0x50, 0x67, 0x02, 0x51, 0x99, 0x4d, 0x5e, 0x99, 0xd3, 0x89, 0xcb, 0xc5, 0x78, 0x01,
-	0x88, 0xb6, 0xce, 0xf2, 0x06, 0xf9, 0x5c, 0x5b, 0x30, 0x4a, 0xe2, 0x6a, 0x5a, 0xe2, 0xf0, 0x3e,
-	0x74, 0xb5, 0x9b, 0x38, 0x40, 0xfc, 0x39, 0xec, 0x1d, 0x93, 0x64, 0x4c, 0xbf, 0x83, 0xe4, 0x7a,
-	0xf5, 0xf4, 0x87, 0x05, 0x48, 0x3d, 0x73, 0xad, 0x8a, 0x7a, 0x00, 0x3b, 0xec, 0xe2, 0xbc, 0x78,
-	0x38, 0x74, 0x43, 0x4a, 0x33, 0x2f, 0x10, 0x4f, 0x3d, 0x12, 0x24, 0xdc, 0x4b, 0xee, 0x4a, 0x51,
-	0xa1, 0xba, 0xbb, 0xa1, 0xd7, 0xa9, 0x9a, 0xe1, 0xba, 0x51, 0x13, 0xcf, 0xa0, 0xc7, 0x73, 0x65,
-	0xb8, 0x2d, 0x31, 0x9a, 0x6e, 0x18, 0x52, 0xfc, 0x14, 0xf6, 0x8d, 0xf3, 0x22, 0x04, 0x18, 0x36,
-	0x85, 0xa8, 0x90, 0x6f, 0xa9, 0xc0, 0x7f, 0x57, 0xa1, 0xc7, 0x13, 0x71, 0x93, 0xa0, 0x7f, 0x58,
-	0x55, 0xa2, 0x57, 0x3a, 0x59, 0x6f, 0x0c, 0x6a, 0x07, 0xad, 0xe1, 0xa7, 0x1c, 0x54, 0x19, 0x88,
-	0x43, 0x65, 0xf7, 0x51, 0x90, 0x44, 0x2b, 0x9d, 0xd9, 0x47, 0x39, 0xb3, 0xd7, 0x99, 0xa9, 0x8f,
-	0xd7, 0x98, 0x12, 0x3b, 0xb9, 0x19, 0x79, 0xce, 0x79, 0x06, 0x1d, 0xf3, 0x0e, 0xd4, 0x81, 0xda,
-	0x05, 0x59, 0x09, 0x9f, 0xe9, 0x27, 0xea, 0x41, 0xfd, 0x6a, 0x76, 0x99, 0x12, 0x51, 0x24, 0x7c,
-	0xf1, 0xa4, 0xfa, 0xd8, 0x72, 0x9e, 0x40, 0x5b, 0x35, 0x7c, 0x93, 0xb3, 0xf8, 0x16, 0xec, 0x1b,
-	0x48, 0x45, 0x13, 0xfc, 0xa8, 0x34, 0x6f, 0x98, 0x66, 0x19, 0xd1, 0xd9, 0xcc, 0x2a, 0xb0, 0xd9,
-	0x07, 0xb2, 0x24, 0x7e, 0x2c, 0xeb, 0x4f, 0x5e, 0x27, 0xca, 0x67, 0x00, 0x0d, 0x2e, 0x29, 0x54,
-	0x8f, 0x90, 0xe3, 0x95, 0xd2, 0xc4, 0x0a, 0xd0, 0xf5, 0xa5, 0x63, 0xbc, 0xd8, 0xd5, 0xe2, 0x8b,
-	0x7d, 0x8d, 0x77, 0x19, 0xf7, 0x65, 0xd9, 0xea, 0xa0, 0xf1, 0x4a, 0x36, 0xc3, 0x28, 0x4d, 0x16,
-	0xe3, 0xd0, 0x23, 0x12, 0xd4, 0x35, 0x9a, 0x41, 0xeb, 0xd2, 0xaa, 0xc1, 0xc3, 0x03, 0x68, 0xb9,
-	0xc4, 0xf3, 0x23, 0x32, 0x4f, 0xde, 0xb8, 0x53, 0x81, 0x49, 0x15, 0xe1, 0x87, 0xd0, 0x37, 0xaf,
-	0x16, 0x91, 0xa4, 0xef, 0x66, 0xe8, 0xc9, 0x58, 0xb0, 0x6f, 0xfc, 0x09, 0x20, 0x46, 0x93, 0x2b,
-	0x6d, 0x74, 0xe8, 0xa9, 0xa3, 0xc3, 0x96, 0x9c, 0x16, 0xc6, 0xd0, 0xd5, 0xf6, 0xfe, 0xd7, 0xb4,
-	0x40, 0x2f, 0x9c, 0xcc, 0x92, 0x19, 0x73, 0xa2, 0xed, 0xb2, 0x6f, 0xfc, 0x02, 0x76, 0x8f, 0xde,
-	0xcf, 0x17, 0xb3, 0xe0, 0x3c, 0x8b, 0x89, 0x81, 0x6b, 0x1e, 0x7a, 0x04, 0xdd, 0x87, 0x36, 0x95,
-	0x19, 0x94, 0xbe, 0x4d, 0x75, 0x6f, 0xaf, 0x84, 0x10, 0xff, 0x62, 0x41, 0x27, 0x37, 0x26, 0xe0,
-	0xdc, 0x2b, 0x1b, 0xc5, 0xda, 0x33, 0x26, 0x7a, 0xcb, 0x26, 0x10, 0x74, 0xdb, 0x9c, 0xc5, 0x9a,
-	0xbe, 0x27, 0x54, 0xf7, 0x4b, 0x93, 0xbe, 0x1d, 0x71, 0x99, 0xd8, 0xd4, 0x87, 0x06, 0x1b, 0xbe,
-	0x56, 0x8c, 0x5f, 0x6b, 0x6e, 0x83, 0xb0, 0x15, 0xfe, 0x9a, 0x8e, 0x53, 0xf9, 0xe1, 0x3c, 0xe7,
-	0xba, 0x4d, 0xab, 0xa4, 0x90, 0x7e, 0xb5, 0xa0, 0xa7, 0x9f, 0xfd, 0xdf, 0xdd, 0x19, 0xfe, 0x66,
-	0x41, 0x87, 0x16, 0x91, 0x00, 0x44, 0xe7, 0xc4, 0x08, 0x3d, 0x87, 0x06, 0xff, 0x44, 0x36, 0xaf,
-	0xdc, 0xe2, 0xe4, 0xe9, 0xdc, 0x2e, 0xd1, 0x88, 0xc6, 0xa8, 0xa0, 0x09, 0xb4, 0x94, 0x61, 0x51,
-	0x5a, 0x29, 0x4e, 0x95, 0xd2, 0x4a, 0xc9, 0x64, 0x89, 0x2b, 0xc3, 0x3f, 0x2d, 0xd8, 0x66, 0x0f,
-	0xd0, 0x49, 0x14, 0x5e, 0xf9, 0x1e, 0x89, 0xd0, 0x53, 0x68, 0xca, 0x79, 0x0e, 0xed, 0xf3, 0xa3,
-	0xc6, 0x44, 0xe8, 0xf4, 0x4d, 0xb1, 0x0a, 0x4a, 0x19, 0x54, 0x24, 0xa8, 0xe2, 0x8c, 0x24, 0x41,
-	0x95, 0x4c, 0x35, 0xdc, 0x8a, 0x32, 0x4d, 0x48, 0x2b, 0xc5, 0x51, 0x46, 0x5a, 0x29, 0x1b, 0x3d,
-	0x2a, 0xc3, 0xbf, 0x2c, 0xd8, 0x15, 0x54, 0x90, 0x39, 0x37, 0x02, 0xc8, 0x87, 0x0b, 0x74, 0x2b,
-	0xf3, 0x43, 0x7f, 0x5d, 0x1c, 0xbb, 0xa8, 0xc8, 0xc0, 0xbd, 0x80, 0x6d, 0xed, 0x7d, 0x46, 0x8e,
-	0xea, 0x8a, 0x61, 0xe8, 0xa3, 0x52, 0x9d, 0x6a, 0x4b, 0x7b, 0x33, 0xa4, 0xad, 0xb2, 0x27, 0x4f,
-	0xda, 0x2a, 0x7f, 0x64, 0x2a, 0xc3, 0xdf, 0x2d, 0x36, 0x60, 0x84, 0x69, 0xee, 0xed, 0x31, 0xb4,
-	0xd5, 0xa7, 0x00, 0x99, 0x41, 0xcf, 0x49, 0xde, 0x71, 0xca, 0x54, 0x19, 0xce, 0x63, 0x68, 0xab,
-	0xf4, 0x8c, 0xcc, 0xb8, 0x17, 0x0d, 0x95, 0xb2, 0x79, 0x65, 0x38, 0xe3, 0x9d, 0x40, 0x29, 0x29,
-	0x43, 0xf9, 0x0a, 0x76, 0x74, 0xa2, 0x45, 0x5a, 0xd4, 0x0c, 0xe6, 0x77, 0xee, 0x94, 0x2b, 0xb3,
-	0x2b, 0x5e, 0xc3, 0x5e, 0xd6, 0x6c, 0xd9, 0xe4, 0xfa, 0x1c, 0x1a, 0x9c, 0x72, 0x65, 0x31, 0x15,
-	0xc9, 0x5a, 0x16, 0x53, 0x09, 0x35, 0xe3, 0xca, 0xf0, 0x84, 0x5b, 0xa5, 0x77, 0x49, 0xa6, 0x64,
-	0xad, 0x22, 0x17, 0xb2, 0x55, 0x0c, 0x4e, 0x96, 0xad, 0x62, 0xb2, 0x2b, 0xae, 0x0c, 0xbf, 0x07,
-	0xa4, 0x90, 0x02, 0xe3, 0x11, 0x12, 0xa1, 0x6f, 0x60, 0x53, 0x2c, 0x50, 0xd6, 0xfd, 0x05, 0x26,
-	0x94, 0x41, 0x2e, 0x23, 0x3a, 0x5c, 0x79, 0xd7, 0x60, 0xff, 0xd6, 0x5f, 0xfc, 0x1b, 0x00, 0x00,
-	0xff, 0xff, 0x13, 0x1f, 0xd0, 0x7b, 0x69, 0x0f, 0x00, 0x00,
+	// 1819 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xcf, 0xf2, 0x4b, 0xd4, 0x23, 0x65, 0x49, 0x43, 0x8a, 0xa6, 0x36, 0x81, 0xa1, 0x4c, 0x0a,
+	0xd7, 0x70, 0x1d, 0x1b, 0x51, 0x1b, 0x34, 0x89, 0x81, 0x04, 0x34, 0xe9, 0x28, 0x0c, 0x64, 0x97,
+	0x58, 0x5a, 0x45, 0x6f, 0xc1, 0x9a, 0x3b, 0x12, 0xb7, 0xa2, 0x77, 0xd9, 0xfd, 0x50, 0xcd, 0x1e,
+	0x0b, 0xf4, 0x58, 0xa0, 0x3d, 0xb6, 0xc7, 0x9e, 0xfa, 0x3f, 0x14, 0xe8, 0x3f, 0xd1, 0x4b, 0xff,
+	0x88, 0xf6, 0x5e, 0xf4, 0x54, 0xcc, 0xe7, 0xce, 0xcc, 0xae, 0x65, 0xc5, 0x97, 0x9e, 0x76, 0xde,
+	0xef, 0xcd, 0xc7, 0xfb, 0x9a, 0x37, 0xef, 0x2d, 0x80, 0x9f, 0x67, 0xcb, 0x87, 0xeb, 0x24, 0xce,
+	0x62, 0xd4, 0xa0, 0x63, 0xfc, 0x3b, 0x07, 0x9a, 0x2f, 0xe2, 0x4b, 0x12, 0xa1, 0x23, 0xe8, 0x8c,
+	0x16, 0x0b, 0x92, 0xa6, 0x8c, 0x1c, 0x3a, 0x47, 0xce, 0xbd, 0x6d, 0x4f, 0x87, 0xd0, 0x10, 0xb6,
+	0xa6, 0x13, 0xce, 0xad, 0x31, 0xae, 0x24, 0x11, 0x86, 0xae, 0x47, 0xce, 0x13, 0x92, 0x2e, 0x39,
+	0xbb, 0xce, 0xd8, 0x06, 0x86, 0x3e, 0x80, 0xed, 0xa7, 0xaf, 0xd7, 0x61, 0x42, 0xd2, 0x51, 0x36,
+	0x6c, 0xb0, 0x09, 0x05, 0x80, 0x7f, 0x0a, 0xc8, 0x23, 0x57, 0xf1, 0x25, 0x61, 0x93, 0x3d, 0xf2,
+	0xab, 0x9c, 0xa4, 0x19, 0xfa, 0x10, 0x9a, 0x85, 0x34, 0x9d, 0xe3, 0xce, 0x43, 0x26, 0x3f, 0x9f,
+	0xc2, 0x39, 0xf8, 0x11, 0xf4, 0x8c, 0x85, 0xe9, 0x3a, 0x8e, 0x52, 0x42, 0x65, 0x9d, 0xe7, 0x4c,
+	0x76, 0xb6, 0xb6, 0xed, 0x49, 0x12, 0xf7, 0x01, 0xcd, 0x92, 0x3c, 0xe2, 0xf3, 0x53, 0x71, 0x12,
+	0xfe, 0x18, 0x7a, 0x06, 0x2a, 0xb6, 0x19, 0x40, 0x2b, 0x63, 0xc8, 0xd0, 0x39, 0xaa, 0xdf, 0xdb,
+	0xf6, 0x04, 0x85, 0x3d, 0xa8, 0x4d, 0x27, 0x54, 0xa5, 0xf1, 0xd2, 0x5f, 0xad, 0x48, 0x74, 0x41,
+	0x84, 0xc1, 0x0a, 0x00, 0xb9, 0xd0, 0xfe, 0x39, 0x49, 0xc2, 0xf3, 0x90, 0x24, 0xc2, 0x5e, 0x8a,
+	0x46, 0x08, 0x1a, 0xe3, 0xb9, 0xf7, 0xb5, 0x30, 0x14, 0x1b, 0xe3, 0x47, 0xb0, 0x7b, 0x42, 0xb2,
+	0xd3, 0xf8, 0x22, 0x54, 0xfa, 0x5f, 0x7b, 0x00, 0xfe, 0xaf, 0x03, 0x7b, 0xc5, 0x0a, 0x21, 0xf1,
+	0xf5, 0x32, 0x31, 0xb3, 0xbc, 0xfc, 0x25, 0x59, 0x64, 0xd2, 0x85, 0x82, 0xa4, 0xeb, 0xe6, 0x24,
+	0x4d, 0xc3, 0x38, 0x9a, 0x4e, 0x84, 0x58, 0x05, 0x80, 0xee, 0x00, 0x08, 0x99, 0xce, 0xbc, 0x53,
+	0xe1, 0x3d, 0x0d, 0x41, 0x77, 0xe1, 0x96, 0xa0, 0x48, 0x30, 0x5f, 0xc4, 0x6b, 0x32, 0x6c, 0x32,
+	0x7b, 0x59, 0x28, 0x7a, 0x00, 0xfb, 0x0a, 0x19, 0xe5, 0x41, 0x48, 0xa2, 0x05, 0x19, 0xb6, 0xd8,
+	0xd4, 0x32, 0x83, 0x5a, 0x70, 0xbc, 0x0a, 0x49, 0x94, 0x4d, 0x27, 0xc3, 0x2d, 0x6e, 0x41, 0x49,
+	0xe3, 0x73, 0x40, 0xe3, 0x84, 0xf8, 0x19, 0x31, 0x0c, 0xa6, 0xaf, 0x70, 0xcc, 0x15, 0xd4, 0x97,
+	0x4c, 0x88, 0x74, 0x58, 0xe3, 0xbe, 0xe4, 0x14, 0xd5, 0x5c, 0x9e, 0x98, 0x0e, 0xeb, 0x8c, 0x55,
+	0x00, 0xf8, 0x53, 0xe8, 0x19, 0xe7, 0x08, 0x33, 0xdf, 0x81, 0x26, 0x03, 0x44, 0x64, 0xb6, 0x79,
+	0x64, 0x4e, 0x27, 0x1e, 0x87, 0xf1, 0x12, 0x10, 0xbd, 0x3a, 0xeb, 0xef, 0xe1, 0xcf, 0x6b, 0x03,
+	0x46, 0x73, 0x5c, 0xdd, 0x70, 0x1c, 0x3e, 0x80, 0x9e, 0x71, 0x12, 0x17, 0x10, 0x7f, 0x02, 0xfb,
+	0x27, 0x24, 0x1b, 0xd3, 0x71, 0x94, 0xdd, 0x2c, 0x9e, 0xfe, 0xee, 0x00, 0xd2, 0xd7, 0xdc, 0x28,
+	0xa2, 0xee, 0xc2, 0x2d, 0x76, 0x70, 0x11, 0x3c, 0x5c, 0x74, 0x0b, 0xa5, 0x9e, 0x17, 0x12, 0x4f,
+	0x03, 0x12, 0x65, 0x5c, 0x4b, 0xae, 0x4a, 0x99, 0xa1, 0xab, 0xdb, 0x30, 0xe3, 0x54, 0xf7, 0x70,
+	0xd3, 0x8a, 0x89, 0x2f, 0xa1, 0xcf, 0x7d, 0x65, 0xa9, 0x2d, 0x65, 0xb4, 0xd5, 0xb0, 0x50, 0xfc,
+	0x18, 0x0e, 0xac, 0xf5, 0xc2, 0x04, 0x18, 0xb6, 0x04, 0x54, 0xf2, 0xb7, 0x64, 0xe0, 0xff, 0xd4,
+	0xa0, 0xcf, 0x1d, 0xf1, 0x7d, 0x8c, 0xfe, 0x6e, 0x51, 0x89, 0x9e, 0x99, 0xc9, 0xba, 0x71, 0x54,
+	0xbf, 0xd7, 0x39, 0xfe, 0x11, 0x17, 0xaa, 0x4a, 0x88, 0x87, 0xda, 0xec, 0xa7, 0x51, 0x96, 0x6c,
+	0xcc, 0xcc, 0x3e, 0x2a, 0x32, 0x7b, 0x93, 0x6d, 0xf5, 0xc3, 0x6b, 0xb6, 0x9a, 0x4e, 0xb4, 0x6d,
+	0xe4, 0x3a, 0xf7, 0x4b, 0xd8, 0xb3, 0xcf, 0x40, 0x7b, 0x50, 0xbf, 0x24, 0x1b, 0xa1, 0x33, 0x1d,
+	0xa2, 0x3e, 0x34, 0xaf, 0xfc, 0x55, 0x4e, 0x44, 0x90, 0x70, 0xe2, 0x8b, 0xda, 0x67, 0x8e, 0xfb,
+	0x05, 0x74, 0xa7, 0x93, 0x77, 0x5b, 0x8b, 0x6f, 0xc3, 0x81, 0x25, 0xa9, 0xb8, 0x04, 0xaf, 0xb4,
+	0xcb, 0x1b, 0xe7, 0xca, 0x23, 0x66, 0x36, 0x73, 0x4a, 0xd9, 0xec, 0x1d, 0xb3, 0x24, 0xfe, 0x0c,
+	0xfa, 0xe6, 0x71, 0x22, 0x7c, 0x8e, 0xa0, 0xc5, 0x91, 0x52, 0xf4, 0x08, 0x1c, 0x6f, 0xb4, 0x4b,
+	0x1c, 0xe7, 0x37, 0x0c, 0x1d, 0xeb, 0xc5, 0xae, 0x95, 0x5f, 0xec, 0x1b, 0xbc, 0xcb, 0x78, 0x00,
+	0x7d, 0xf3, 0x68, 0x61, 0xbb, 0x8d, 0xbc, 0x0c, 0xa3, 0x3c, 0x5b, 0x8e, 0xe3, 0x80, 0x48, 0xa1,
+	0x6e, 0x70, 0x19, 0x8c, 0x5b, 0x5a, 0xb3, 0xf2, 0xf0, 0x11, 0x74, 0x3c, 0x12, 0x84, 0x09, 0x59,
+	0x64, 0x67, 0xde, 0x54, 0xc8, 0xa4, 0x43, 0xf8, 0x01, 0x0c, 0xec, 0xa3, 0x85, 0x25, 0xe9, 0xbb,
+	0x19, 0x07, 0xd2, 0x16, 0x6c, 0x8c, 0xef, 0x03, 0x62, 0x69, 0x72, 0x63, 0x94, 0x0e, 0x7d, 0xbd,
+	0x74, 0xd8, 0x96, 0xd5, 0xc2, 0x18, 0x7a, 0xc6, 0xdc, 0xb7, 0x55, 0x0b, 0xf4, 0xc0, 0x89, 0x9f,
+	0xf9, 0x4c, 0x89, 0xae, 0xc7, 0xc6, 0xf8, 0x5b, 0xd8, 0x7d, 0xfa, 0x7a, 0xb1, 0xf4, 0xa3, 0x0b,
+	0x65, 0x13, 0x4b, 0xae, 0x45, 0x1c, 0x10, 0xf4, 0x11, 0x74, 0x29, 0x66, 0xa5, 0xf4, 0x1d, 0xca,
+	0xfb, 0xee, 0x4a, 0x80, 0xf8, 0xf7, 0x0e, 0xec, 0x15, 0x9b, 0x09, 0x71, 0x3e, 0xac, 0x2a, 0xc5,
+	0xba, 0x3e, 0x83, 0xbe, 0x63, 0x15, 0x08, 0x3a, 0xb4, 0x6b, 0xb1, 0x76, 0x18, 0x08, 0xd6, 0x47,
+	0x95, 0x4e, 0xdf, 0x49, 0x38, 0x26, 0x26, 0x0d, 0xa0, 0xc5, 0x8a, 0xaf, 0x0d, 0xcb, 0xaf, 0x75,
+	0xaf, 0x45, 0x18, 0x85, 0x3f, 0xa7, 0xe5, 0x54, 0xb1, 0xb8, 0xf0, 0xb9, 0xb9, 0xa7, 0x53, 0x11,
+	0x48, 0x7f, 0x74, 0xa0, 0x6f, 0xae, 0xfd, 0xff, 0xab, 0xf3, 0xd7, 0x3a, 0xf4, 0x4e, 0x03, 0x7f,
+	0x3d, 0x8e, 0xa3, 0x88, 0x2c, 0xb2, 0x38, 0x19, 0xc7, 0xd1, 0x79, 0x78, 0x41, 0xfd, 0xf5, 0x4d,
+	0x9c, 0x66, 0xd2, 0x5f, 0xdf, 0xc4, 0x3c, 0x2b, 0x88, 0x69, 0x61, 0x2c, 0xc5, 0xd0, 0x10, 0xf4,
+	0x13, 0x38, 0x98, 0x5f, 0x86, 0x6b, 0x1e, 0x3f, 0x63, 0x92, 0xd0, 0xa7, 0x6a, 0xe1, 0x67, 0x84,
+	0x49, 0xd4, 0xf6, 0xaa, 0x99, 0x54, 0x32, 0x2f, 0x8e, 0xb3, 0xf1, 0x48, 0x3c, 0x64, 0x82, 0xa2,
+	0xf8, 0x93, 0x30, 0x0a, 0x26, 0xcf, 0xc5, 0x2b, 0x26, 0x28, 0x6a, 0x69, 0x3a, 0x9a, 0xf9, 0x69,
+	0xfa, 0xeb, 0x38, 0x09, 0x86, 0x2d, 0x6e, 0x2d, 0x1d, 0x43, 0xf7, 0xa1, 0x71, 0x96, 0x92, 0x84,
+	0xd5, 0x44, 0x9d, 0xe3, 0x01, 0xbf, 0x7e, 0x54, 0xcd, 0x39, 0xf1, 0x93, 0xc5, 0xf2, 0xeb, 0x70,
+	0x95, 0x91, 0xc4, 0x63, 0x73, 0xd0, 0x03, 0x68, 0x9e, 0x24, 0x71, 0xbe, 0x1e, 0xb6, 0xaf, 0x9d,
+	0xcc, 0x27, 0xa1, 0xcf, 0xa1, 0xfb, 0xcc, 0x5f, 0xaf, 0xc3, 0xe8, 0xc2, 0xcb, 0x57, 0x24, 0x1d,
+	0x6e, 0xb3, 0xd7, 0xe0, 0xa0, 0x58, 0xa4, 0x71, 0x3d, 0x63, 0x2a, 0x15, 0x7c, 0xbe, 0x89, 0x16,
+	0xf3, 0xc5, 0x92, 0x04, 0xf9, 0x8a, 0x0c, 0x81, 0x0b, 0xae, 0x63, 0x34, 0x2d, 0xcc, 0xfc, 0x0b,
+	0x32, 0x0f, 0x7f, 0x43, 0x86, 0x9d, 0x23, 0xe7, 0x5e, 0xd3, 0x53, 0x34, 0xfe, 0x83, 0x03, 0x7b,
+	0xb6, 0x58, 0xcc, 0x4a, 0x7e, 0x4a, 0x26, 0xcf, 0x85, 0xa7, 0x04, 0x45, 0x71, 0x3e, 0x43, 0xf8,
+	0x49, 0x50, 0x34, 0xb7, 0x4c, 0x27, 0xa3, 0x2c, 0x4b, 0xc2, 0x97, 0xb9, 0xf0, 0xcc, 0xb6, 0xa7,
+	0x43, 0xe8, 0x3e, 0xec, 0x4d, 0xc2, 0x74, 0xbd, 0xf2, 0x37, 0xc5, 0x34, 0xee, 0x99, 0x12, 0x8e,
+	0xff, 0xe6, 0xc0, 0xae, 0xa5, 0x34, 0x55, 0x81, 0x7e, 0x9f, 0xfb, 0xaf, 0xe4, 0x6d, 0x57, 0x34,
+	0xfa, 0x01, 0xec, 0x9c, 0x92, 0xf3, 0xac, 0xd8, 0x58, 0x5c, 0x79, 0x03, 0x64, 0xb5, 0x72, 0x78,
+	0xb1, 0xcc, 0x6c, 0x31, 0x2d, 0x94, 0xbd, 0x52, 0xf9, 0x8a, 0xcc, 0xb3, 0x24, 0x8c, 0x2e, 0x54,
+	0xcd, 0xad, 0x10, 0xc6, 0x8f, 0x57, 0x64, 0x96, 0x90, 0xf3, 0xf0, 0xb5, 0x88, 0x22, 0x0d, 0xc1,
+	0xbf, 0xad, 0x41, 0xef, 0x67, 0x61, 0xb0, 0xb0, 0x63, 0x7f, 0x00, 0xad, 0x69, 0x9a, 0xe6, 0x24,
+	0x91, 0x36, 0xe5, 0xd4, 0xb5, 0x39, 0x1b, 0x43, 0x97, 0x8f, 0xe7, 0x64, 0x91, 0x10, 0x59, 0x83,
+	0x1a, 0x98, 0x9d, 0xd7, 0x1b, 0xa5, 0xbc, 0xae, 0xd5, 0x3a, 0x4d, 0xa3, 0xd6, 0xb9, 0x03, 0xc0,
+	0x2b, 0xb1, 0x95, 0x1f, 0xbe, 0x12, 0x11, 0xaf, 0x21, 0xa5, 0xa8, 0xdc, 0xba, 0x71, 0x54, 0xe2,
+	0x7f, 0x3b, 0xd0, 0x9b, 0x91, 0x24, 0x8d, 0x23, 0x7f, 0xa5, 0xbf, 0x8c, 0x08, 0x1a, 0x67, 0x79,
+	0x18, 0xc8, 0x04, 0x40, 0xc7, 0xf4, 0xc9, 0x38, 0xf5, 0x5f, 0x92, 0x95, 0x2c, 0x30, 0x18, 0x41,
+	0xcd, 0x42, 0x2f, 0x12, 0x9b, 0xcd, 0xd5, 0x56, 0x34, 0x7d, 0x9f, 0xe9, 0x98, 0x77, 0x02, 0xa2,
+	0xa7, 0x55, 0xc0, 0x1b, 0xd5, 0x35, 0x3a, 0xe1, 0x16, 0xbb, 0x06, 0x05, 0x40, 0xb9, 0xfc, 0xf1,
+	0x0b, 0x46, 0x19, 0xbb, 0xe1, 0x4d, 0xaf, 0x00, 0x98, 0xa9, 0xfc, 0x34, 0x3b, 0x4b, 0x19, 0xbb,
+	0xcd, 0xd8, 0x1a, 0x82, 0xff, 0xec, 0x00, 0x9a, 0xf9, 0xd9, 0x09, 0x89, 0x48, 0xe2, 0x67, 0x44,
+	0x7b, 0x0d, 0xb9, 0x6a, 0xce, 0x9b, 0x54, 0xab, 0x5d, 0xa7, 0x5a, 0xdd, 0x56, 0xad, 0xd4, 0xcc,
+	0x1b, 0x2a, 0xbc, 0x41, 0x71, 0xbc, 0x84, 0x9e, 0x21, 0x9b, 0x2a, 0x8f, 0xde, 0xf6, 0xe7, 0xe1,
+	0x91, 0x7c, 0xcc, 0x6b, 0x2c, 0x89, 0x1d, 0x72, 0xcf, 0x57, 0xf8, 0x55, 0xbe, 0xf3, 0x77, 0x61,
+	0x6f, 0xe6, 0x67, 0xfc, 0xc7, 0x80, 0xf6, 0x46, 0xdb, 0x2e, 0xc7, 0x1f, 0xc3, 0xbe, 0x36, 0xef,
+	0xad, 0xff, 0x0e, 0x8e, 0xe1, 0xd6, 0xcc, 0xcf, 0x4e, 0xc3, 0x54, 0x55, 0x68, 0x47, 0xd0, 0x79,
+	0xb2, 0x29, 0x0c, 0x25, 0x64, 0xd7, 0x20, 0x3c, 0x81, 0x5d, 0xb5, 0x46, 0x1c, 0xf0, 0x09, 0xb4,
+	0x5e, 0x14, 0x7f, 0x15, 0xae, 0xd5, 0x47, 0x4c, 0x3c, 0xfe, 0x93, 0x03, 0x7b, 0xb4, 0x1a, 0xe2,
+	0x28, 0x93, 0x37, 0x41, 0x5f, 0x41, 0x8b, 0x0f, 0xd1, 0x90, 0xef, 0x50, 0xfe, 0x85, 0xe2, 0x1e,
+	0x56, 0x70, 0x44, 0x85, 0xf7, 0x1e, 0x9a, 0x40, 0x47, 0xfb, 0xeb, 0x21, 0x77, 0x29, 0xff, 0x1e,
+	0x71, 0x0f, 0x2b, 0x38, 0x72, 0x97, 0xe3, 0x7f, 0x3a, 0xb0, 0xc3, 0x74, 0x9d, 0x25, 0xf1, 0x55,
+	0x18, 0x90, 0x04, 0x3d, 0x86, 0xb6, 0xfc, 0x31, 0x81, 0xc4, 0x35, 0xb5, 0x7e, 0x6d, 0xb8, 0x03,
+	0x1b, 0xd6, 0x85, 0xd2, 0x3a, 0x6e, 0x29, 0x54, 0xb9, 0xd9, 0x77, 0x0f, 0x2b, 0x38, 0xfa, 0x2e,
+	0x5a, 0x5b, 0x2c, 0x77, 0x29, 0xf7, 0xe4, 0xee, 0x61, 0x05, 0x47, 0xa9, 0xf6, 0x2f, 0x07, 0x76,
+	0x45, 0x4d, 0xab, 0x94, 0x1b, 0x01, 0x14, 0x5d, 0x32, 0xba, 0xad, 0xf4, 0x30, 0xdb, 0x24, 0x77,
+	0x58, 0x66, 0x28, 0xe1, 0xbe, 0x85, 0x1d, 0xa3, 0xd1, 0x44, 0xae, 0xae, 0x8a, 0xb5, 0xd1, 0xfb,
+	0x95, 0x3c, 0x7d, 0x2f, 0xa3, 0xf9, 0x91, 0x7b, 0x55, 0xf5, 0x6e, 0xee, 0xfb, 0x95, 0x3c, 0xa5,
+	0xee, 0x5f, 0x1c, 0xd6, 0x29, 0xc7, 0x79, 0xa1, 0xed, 0x09, 0x74, 0xf5, 0x9e, 0x06, 0xd9, 0x46,
+	0x2f, 0xba, 0x15, 0xd7, 0xad, 0x62, 0x29, 0x39, 0x4f, 0xa0, 0xab, 0xf7, 0x19, 0xc8, 0xb6, 0x7b,
+	0x79, 0xa3, 0xca, 0xb6, 0xe4, 0xbd, 0x63, 0x9f, 0xdf, 0x04, 0x5a, 0x5b, 0x2b, 0x29, 0x9f, 0xc1,
+	0x2d, 0xb3, 0x63, 0x40, 0x86, 0xd5, 0xac, 0x16, 0xc6, 0xfd, 0xa0, 0x9a, 0xa9, 0x8e, 0x78, 0x01,
+	0xfb, 0xea, 0xb2, 0xa9, 0x5f, 0x30, 0x5f, 0x41, 0x8b, 0x8d, 0x37, 0x32, 0x98, 0xca, 0x5d, 0x87,
+	0x7b, 0x58, 0xc1, 0x51, 0xbb, 0xce, 0x60, 0x5f, 0x9e, 0x25, 0x4b, 0x7e, 0x76, 0x55, 0x24, 0x21,
+	0xaf, 0x8a, 0xd5, 0x5c, 0xb8, 0x03, 0x1b, 0x56, 0x3b, 0xfe, 0x02, 0x90, 0x96, 0x14, 0x58, 0x41,
+	0x4c, 0x12, 0xf4, 0x04, 0xb6, 0x04, 0x81, 0xd4, 0xed, 0x2f, 0x95, 0xf4, 0xae, 0x5b, 0xc5, 0x52,
+	0x3b, 0xff, 0xc3, 0x01, 0xb7, 0x22, 0x1f, 0xcd, 0x49, 0x72, 0x15, 0x2e, 0x08, 0x1a, 0x41, 0x5b,
+	0xa6, 0x71, 0x95, 0x35, 0x4a, 0xaf, 0x8e, 0x7b, 0x58, 0xc1, 0x51, 0xf1, 0xf0, 0x58, 0x25, 0xaf,
+	0x81, 0x9a, 0x66, 0x24, 0x6c, 0xf7, 0x76, 0x09, 0x57, 0x8b, 0x3f, 0x85, 0x06, 0xcd, 0xa8, 0xa8,
+	0xaf, 0xa6, 0x68, 0x49, 0xd9, 0x3d, 0xb0, 0x50, 0xb9, 0xec, 0x65, 0x8b, 0xfd, 0xfa, 0xfe, 0xf1,
+	0xff, 0x06, 0x00, 0xb8, 0xf5, 0x96, 0x96, 0x08, 0x17, 0x00, 0x00,
 }
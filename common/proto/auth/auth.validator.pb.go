@@ -130,3 +130,72 @@ func (this *RefreshTokenRequest) Validate() error {
 func (this *RefreshTokenResponse) Validate() error {
 	return nil
 }
+func (this *LdapConnectorConfig) Validate() error {
+	if this.User != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.User); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("User", err)
+		}
+	}
+	if this.Group != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Group); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Group", err)
+		}
+	}
+	for _, item := range this.MappingRules {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("MappingRules", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *LdapSearchFilter) Validate() error {
+	return nil
+}
+func (this *LdapMappingRule) Validate() error {
+	return nil
+}
+func (this *OidcConnectorConfig) Validate() error {
+	for _, item := range this.MappingRules {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("MappingRules", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *PersonalAccessToken) Validate() error {
+	return nil
+}
+func (this *PatGenerateRequest) Validate() error {
+	return nil
+}
+func (this *PatGenerateResponse) Validate() error {
+	if this.Token != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Token); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Token", err)
+		}
+	}
+	return nil
+}
+func (this *PatRevokeRequest) Validate() error {
+	return nil
+}
+func (this *PatRevokeResponse) Validate() error {
+	return nil
+}
+func (this *PatListRequest) Validate() error {
+	return nil
+}
+func (this *PatListResponse) Validate() error {
+	for _, item := range this.Tokens {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Tokens", err)
+			}
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ const (
 	UserAttrEmail       = "email"
 	UserAttrHasEmail    = "hasEmail"
 	UserAttrAuthSource  = "AuthSource"
+	UserAttrDynamicRule = "dynamicRule"
 )
 
 func (u *User) WithPublicData(ctx context.Context, policiesContextEditable bool) *User {
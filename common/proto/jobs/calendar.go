@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+// calendarHorizon is how far NextOpening looks for an opening, in days
+const calendarHorizon = 370
+
+// calendars caches the calendars loaded by LoadCalendar and their next openings.
+// It is reset whenever the jobs service configuration changes.
+var calendars = &calendarsCache{}
+
+type calendarsCache struct {
+	sync.Mutex
+	watch      sync.Once
+	enabled    bool
+	generation int
+	byName     map[string]*Calendar
+	openings   map[*Calendar]*calendarOpening
+}
+
+// calendarOpening is the result of a NextOpening scan: the calendar is closed from "from" until "next".
+type calendarOpening struct {
+	from time.Time
+	next time.Time
+}
+
+// start watches the jobs service configuration to invalidate the cache, which stays
+// disabled if the configuration cannot be watched.
+func (cc *calendarsCache) start() {
+	cc.watch.Do(func() {
+		w, e := config.Watch("services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, "calendars")
+		if e != nil {
+			return
+		}
+		cc.reset(true)
+		go func() {
+			defer w.Stop()
+			for {
+				if _, e := w.Next(); e != nil {
+					cc.reset(false)
+					return
+				}
+				cc.reset(true)
+			}
+		}()
+	})
+}
+
+func (cc *calendarsCache) reset(enabled bool) {
+	cc.Lock()
+	defer cc.Unlock()
+	cc.enabled = enabled
+	cc.generation++
+	cc.byName = make(map[string]*Calendar)
+	cc.openings = make(map[*Calendar]*calendarOpening)
+}
+
+func (cc *calendarsCache) get(name string) (*Calendar, int, bool) {
+	cc.Lock()
+	defer cc.Unlock()
+	if !cc.enabled {
+		return nil, cc.generation, false
+	}
+	c, ok := cc.byName[name]
+	return c, cc.generation, ok
+}
+
+// put stores a calendar unless the configuration changed since it was read.
+func (cc *calendarsCache) put(c *Calendar, generation int) {
+	cc.Lock()
+	defer cc.Unlock()
+	if cc.enabled && cc.generation == generation {
+		cc.byName[c.Name] = c
+	}
+}
+
+func (cc *calendarsCache) nextOpening(c *Calendar, t time.Time) (time.Time, bool) {
+	cc.Lock()
+	defer cc.Unlock()
+	if !cc.enabled {
+		return time.Time{}, false
+	}
+	if o, ok := cc.openings[c]; ok && !t.Before(o.from) && t.Before(o.next) {
+		return o.next, true
+	}
+	return time.Time{}, false
+}
+
+// setNextOpening only keeps openings of the calendars currently in cache.
+func (cc *calendarsCache) setNextOpening(c *Calendar, from, next time.Time) {
+	cc.Lock()
+	defer cc.Unlock()
+	if cc.enabled && cc.byName[c.Name] == c {
+		cc.openings[c] = &calendarOpening{from: from, next: next}
+	}
+}
+
+// LoadCalendar finds a calendar by its name in the jobs service configuration,
+// where calendars are stored as a list under the "calendars" key.
+// Loaded calendars are cached until the configuration changes.
+func LoadCalendar(name string) (*Calendar, error) {
+	calendars.start()
+	cached, generation, ok := calendars.get(name)
+	if ok {
+		return cached, nil
+	}
+	var list []*Calendar
+	if e := config.Get("services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, "calendars").Scan(&list); e != nil {
+		return nil, e
+	}
+	for _, c := range list {
+		if c != nil && c.Name == name {
+			if e := c.Check(); e != nil {
+				return nil, fmt.Errorf("invalid calendar %s: %s", name, e.Error())
+			}
+			calendars.put(c, generation)
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find calendar %s", name)
+}
+
+// Check verifies the time zone and the windows of the calendar.
+func (c *Calendar) Check() error {
+	if c.Name == "" {
+		return fmt.Errorf("calendar must have a name")
+	}
+	if _, e := time.LoadLocation(c.TimeZone); e != nil {
+		return e
+	}
+	for _, w := range append(append([]*CalendarWindow{}, c.Allowed...), c.Forbidden...) {
+		if e := w.Check(); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// IsOpen tells whether tasks can be started at time t: t must be in one of the allowed
+// windows if there are any, and in none of the forbidden windows.
+func (c *Calendar) IsOpen(t time.Time) bool {
+	t = t.In(c.location())
+	if len(c.Allowed) > 0 {
+		var allowed bool
+		for _, w := range c.Allowed {
+			if w.contains(t) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	for _, w := range c.Forbidden {
+		if w.contains(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// NextOpening finds the first time after t at which the calendar is open, t itself if it is
+// already open. It returns false if the calendar does not open within the next year.
+func (c *Calendar) NextOpening(t time.Time) (time.Time, bool) {
+	if c.IsOpen(t) {
+		return t, true
+	}
+	if next, ok := calendars.nextOpening(c, t); ok {
+		return next, true
+	}
+	next, ok := c.scanOpening(t)
+	if ok {
+		calendars.setNextOpening(c, t, next)
+	}
+	return next, ok
+}
+
+// scanOpening looks for the first window boundary after t at which the calendar is open.
+func (c *Calendar) scanOpening(t time.Time) (time.Time, bool) {
+	// The calendar state can only change on windows boundaries
+	loc := c.location()
+	lt := t.In(loc)
+	var candidates []time.Time
+	for i := -1; i <= calendarHorizon; i++ {
+		day := time.Date(lt.Year(), lt.Month(), lt.Day()+i, 0, 0, 0, 0, loc)
+		for _, w := range append(append([]*CalendarWindow{}, c.Allowed...), c.Forbidden...) {
+			if start, end, ok := w.interval(day); ok {
+				if start.After(t) {
+					candidates = append(candidates, start)
+				}
+				if end.After(t) {
+					candidates = append(candidates, end)
+				}
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	for _, b := range candidates {
+		if c.IsOpen(b) {
+			return b, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c *Calendar) location() *time.Location {
+	if loc, e := time.LoadLocation(c.TimeZone); e == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Check verifies the days, dates and times of the window.
+func (w *CalendarWindow) Check() error {
+	for _, d := range w.Weekdays {
+		if d < 0 || d > 6 {
+			return fmt.Errorf("invalid weekday %d, must be between 0 (Sunday) and 6 (Saturday)", d)
+		}
+	}
+	for _, d := range w.Dates {
+		if _, e := time.Parse("2006-01-02", d); e != nil {
+			return fmt.Errorf("invalid date %s, must be formatted as YYYY-MM-DD", d)
+		}
+	}
+	if _, _, e := parseClock(w.Start, 0); e != nil {
+		return e
+	}
+	if _, _, e := parseClock(w.End, 24); e != nil {
+		return e
+	}
+	return nil
+}
+
+// appliesTo tells whether the window starts on the given day.
+func (w *CalendarWindow) appliesTo(day time.Time) bool {
+	if len(w.Weekdays) == 0 && len(w.Dates) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if time.Weekday(d) == day.Weekday() {
+			return true
+		}
+	}
+	date := day.Format("2006-01-02")
+	for _, d := range w.Dates {
+		if d == date {
+			return true
+		}
+	}
+	return false
+}
+
+// interval computes the start and end of the window starting on the given day.
+func (w *CalendarWindow) interval(day time.Time) (start time.Time, end time.Time, ok bool) {
+	if !w.appliesTo(day) {
+		return
+	}
+	sh, sm, e := parseClock(w.Start, 0)
+	if e != nil {
+		return
+	}
+	eh, em, e := parseClock(w.End, 24)
+	if e != nil {
+		return
+	}
+	y, m, d := day.Date()
+	start = time.Date(y, m, d, sh, sm, 0, 0, day.Location())
+	if eh*60+em <= sh*60+sm {
+		d++
+	}
+	end = time.Date(y, m, d, eh, em, 0, 0, day.Location())
+	return start, end, true
+}
+
+// contains tells whether t is in the window started on the same day or on the day before.
+func (w *CalendarWindow) contains(t time.Time) bool {
+	for _, offset := range []int{0, -1} {
+		day := time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, t.Location())
+		if start, end, ok := w.interval(day); ok && !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses a HH:MM time of the day, returning defHour:00 if it is empty.
+func parseClock(s string, defHour int) (int, int, error) {
+	if s == "" {
+		return defHour, 0, nil
+	}
+	var h, m int
+	if _, e := fmt.Sscanf(s, "%d:%d", &h, &m); e != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m > 0) {
+		return 0, 0, fmt.Errorf("invalid time %s, must be formatted as HH:MM", s)
+	}
+	return h, m, nil
+}
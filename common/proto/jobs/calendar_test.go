@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCalendar_Check(t *testing.T) {
+
+	Convey("Test Calendar Check", t, func() {
+
+		So((&Calendar{Name: "night", TimeZone: "Europe/Paris", Allowed: []*CalendarWindow{{Start: "22:00", End: "06:00"}}}).Check(), ShouldBeNil)
+		So((&Calendar{}).Check(), ShouldNotBeNil)
+		So((&Calendar{Name: "c", TimeZone: "Mars/Olympus"}).Check(), ShouldNotBeNil)
+		So((&Calendar{Name: "c", Allowed: []*CalendarWindow{{Weekdays: []int32{7}}}}).Check(), ShouldNotBeNil)
+		So((&Calendar{Name: "c", Forbidden: []*CalendarWindow{{Dates: []string{"25/12/2020"}}}}).Check(), ShouldNotBeNil)
+		So((&Calendar{Name: "c", Forbidden: []*CalendarWindow{{Start: "8h"}}}).Check(), ShouldNotBeNil)
+		So((&Calendar{Name: "c", Forbidden: []*CalendarWindow{{End: "24:30"}}}).Check(), ShouldNotBeNil)
+
+	})
+}
+
+func TestCalendar_IsOpen(t *testing.T) {
+
+	Convey("Test Calendar IsOpen", t, func() {
+
+		paris, _ := time.LoadLocation("Europe/Paris")
+		// Business hours are forbidden, as well as Christmas day
+		c := &Calendar{
+			Name:     "off-hours",
+			TimeZone: "Europe/Paris",
+			Forbidden: []*CalendarWindow{
+				{Weekdays: []int32{1, 2, 3, 4, 5}, Start: "08:00", End: "19:00"},
+				{Dates: []string{"2020-12-25"}},
+			},
+		}
+		So(c.IsOpen(time.Date(2020, 12, 21, 10, 0, 0, 0, paris)), ShouldBeFalse)
+		So(c.IsOpen(time.Date(2020, 12, 21, 7, 59, 0, 0, paris)), ShouldBeTrue)
+		So(c.IsOpen(time.Date(2020, 12, 21, 19, 0, 0, 0, paris)), ShouldBeTrue)
+		So(c.IsOpen(time.Date(2020, 12, 19, 10, 0, 0, 0, paris)), ShouldBeTrue)
+		So(c.IsOpen(time.Date(2020, 12, 25, 22, 0, 0, 0, paris)), ShouldBeFalse)
+		// Time zone is applied to times given in UTC
+		So(c.IsOpen(time.Date(2020, 12, 21, 7, 30, 0, 0, time.UTC)), ShouldBeFalse)
+
+		// Overnight allowed window
+		n := &Calendar{Name: "night", Allowed: []*CalendarWindow{{Start: "22:00", End: "06:00"}}}
+		So(n.IsOpen(time.Date(2020, 12, 21, 23, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		So(n.IsOpen(time.Date(2020, 12, 22, 5, 59, 0, 0, time.UTC)), ShouldBeTrue)
+		So(n.IsOpen(time.Date(2020, 12, 22, 6, 0, 0, 0, time.UTC)), ShouldBeFalse)
+		So(n.IsOpen(time.Date(2020, 12, 22, 12, 0, 0, 0, time.UTC)), ShouldBeFalse)
+
+		// Empty calendar is always open
+		So((&Calendar{Name: "empty"}).IsOpen(time.Now()), ShouldBeTrue)
+
+	})
+}
+
+func TestCalendar_NextOpening(t *testing.T) {
+
+	Convey("Test Calendar NextOpening", t, func() {
+
+		c := &Calendar{
+			Name: "off-hours",
+			Forbidden: []*CalendarWindow{
+				{Weekdays: []int32{1, 2, 3, 4, 5}, Start: "08:00", End: "19:00"},
+				{Dates: []string{"2020-12-25"}},
+			},
+		}
+		now := time.Date(2020, 12, 21, 10, 0, 0, 0, time.UTC)
+		next, ok := c.NextOpening(now)
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, time.Date(2020, 12, 21, 19, 0, 0, 0, time.UTC))
+
+		// Already open
+		open := time.Date(2020, 12, 21, 20, 0, 0, 0, time.UTC)
+		next, ok = c.NextOpening(open)
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, open)
+
+		// Friday 25th is forbidden all day
+		next, ok = c.NextOpening(time.Date(2020, 12, 25, 9, 0, 0, 0, time.UTC))
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, time.Date(2020, 12, 26, 0, 0, 0, 0, time.UTC))
+
+		// Allowed window on week-ends only, minus a forbidden night
+		w := &Calendar{
+			Name:      "week-end",
+			Allowed:   []*CalendarWindow{{Weekdays: []int32{0, 6}}},
+			Forbidden: []*CalendarWindow{{Dates: []string{"2020-12-26"}, Start: "00:00", End: "06:00"}},
+		}
+		next, ok = w.NextOpening(now)
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, time.Date(2020, 12, 26, 6, 0, 0, 0, time.UTC))
+
+		// Never opens
+		never := &Calendar{Name: "never", Forbidden: []*CalendarWindow{{}}}
+		_, ok = never.NextOpening(now)
+		So(ok, ShouldBeFalse)
+
+	})
+}
+
+func TestCalendar_Cache(t *testing.T) {
+
+	Convey("Test cached calendar openings", t, func() {
+
+		calendars.reset(true)
+		defer calendars.reset(false)
+
+		c := &Calendar{
+			Name:    "nights",
+			Allowed: []*CalendarWindow{{Start: "19:00", End: "23:00"}},
+		}
+		_, generation, _ := calendars.get("nights")
+		calendars.put(c, generation)
+		cached, _, ok := calendars.get("nights")
+		So(ok, ShouldBeTrue)
+		So(cached, ShouldEqual, c)
+
+		now := time.Date(2020, 12, 21, 10, 0, 0, 0, time.UTC)
+		next, ok := c.NextOpening(now)
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, time.Date(2020, 12, 21, 19, 0, 0, 0, time.UTC))
+		So(calendars.openings, ShouldContainKey, c)
+
+		// Later times before the opening reuse the scan
+		next, ok = c.NextOpening(now.Add(2 * time.Hour))
+		So(ok, ShouldBeTrue)
+		So(next, ShouldEqual, time.Date(2020, 12, 21, 19, 0, 0, 0, time.UTC))
+
+		// A configuration change drops calendars and openings
+		calendars.reset(true)
+		_, _, ok = calendars.get("nights")
+		So(ok, ShouldBeFalse)
+		So(calendars.openings, ShouldBeEmpty)
+
+		// A calendar read before the change is not cached
+		calendars.put(c, generation)
+		_, _, ok = calendars.get("nights")
+		So(ok, ShouldBeFalse)
+
+	})
+}
@@ -21,6 +21,7 @@
 package jobs
 
 import (
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
@@ -31,6 +32,60 @@ import (
 
 /* job.go file enriches default genrated proto structs with some custom pydio methods to ease development */
 
+// WalkActions recursively applies fn to all actions of the job, including
+// FailedFilterActions branches and actions chained to Joins. It stops at the first error.
+func (job *Job) WalkActions(fn func(a *Action) error) error {
+	var walk func(actions []*Action) error
+	walk = func(actions []*Action) error {
+		for _, a := range actions {
+			if e := fn(a); e != nil {
+				return e
+			}
+			if e := walk(a.ChainedActions); e != nil {
+				return e
+			}
+			if e := walk(a.FailedFilterActions); e != nil {
+				return e
+			}
+		}
+		return nil
+	}
+	if e := walk(job.Actions); e != nil {
+		return e
+	}
+	for _, j := range job.Joins {
+		if e := walk(j.ChainedActions); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// CheckDefinition performs consistency checks on the job definition before it is stored.
+func (job *Job) CheckDefinition() error {
+	if job.Schedule != nil {
+		if e := job.Schedule.Check(); e != nil {
+			return fmt.Errorf("invalid schedule: %s", e.Error())
+		}
+	}
+	if job.Throttle != nil {
+		if e := job.Throttle.Check(); e != nil {
+			return fmt.Errorf("invalid throttle: %s", e.Error())
+		}
+	}
+	if e := job.ValidateJoins(); e != nil {
+		return e
+	}
+	return job.WalkActions(func(a *Action) error {
+		if a.RetryPolicy != nil {
+			if e := a.RetryPolicy.Check(); e != nil {
+				return fmt.Errorf("invalid retry policy for action %s: %s", a.ID, e.Error())
+			}
+		}
+		return nil
+	})
+}
+
 /* LOGGING SUPPORT */
 
 func (job *Job) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
@@ -44,6 +44,34 @@ It has these top-level messages:
 	ActionOutput
 	ActionOutputSingleQuery
 	ActionMessage
+	Join
+	RetryPolicy
+	ActionAttempt
+	QueuedTask
+	EnqueueTaskRequest
+	EnqueueTaskResponse
+	ClaimTaskRequest
+	ClaimTaskResponse
+	RenewTaskLeaseRequest
+	RenewTaskLeaseResponse
+	ReleaseTaskRequest
+	ReleaseTaskResponse
+	TriggerThrottle
+	SimulateJobRequest
+	SimulationFilterResult
+	SimulationStep
+	SimulateJobResponse
+	TaskArtifact
+	AppendTaskLogRequest
+	AppendTaskLogResponse
+	ReadTaskLogRequest
+	ReadTaskLogResponse
+	PutTaskArtifactRequest
+	PutTaskArtifactResponse
+	ReadTaskArtifactRequest
+	ReadTaskArtifactResponse
+	ListTaskArtifactsRequest
+	ListTaskArtifactsResponse
 */
 package jobs
 
@@ -90,6 +118,16 @@ type JobServiceClient interface {
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...client.CallOption) (JobService_ListTasksClient, error)
 	DeleteTasks(ctx context.Context, in *DeleteTasksRequest, opts ...client.CallOption) (*DeleteTasksResponse, error)
 	DetectStuckTasks(ctx context.Context, in *DetectStuckTasksRequest, opts ...client.CallOption) (*DetectStuckTasksResponse, error)
+	EnqueueTask(ctx context.Context, in *EnqueueTaskRequest, opts ...client.CallOption) (*EnqueueTaskResponse, error)
+	ClaimTask(ctx context.Context, in *ClaimTaskRequest, opts ...client.CallOption) (*ClaimTaskResponse, error)
+	RenewTaskLease(ctx context.Context, in *RenewTaskLeaseRequest, opts ...client.CallOption) (*RenewTaskLeaseResponse, error)
+	ReleaseTask(ctx context.Context, in *ReleaseTaskRequest, opts ...client.CallOption) (*ReleaseTaskResponse, error)
+	SimulateJob(ctx context.Context, in *SimulateJobRequest, opts ...client.CallOption) (*SimulateJobResponse, error)
+	AppendTaskLog(ctx context.Context, in *AppendTaskLogRequest, opts ...client.CallOption) (*AppendTaskLogResponse, error)
+	ReadTaskLog(ctx context.Context, in *ReadTaskLogRequest, opts ...client.CallOption) (*ReadTaskLogResponse, error)
+	PutTaskArtifact(ctx context.Context, in *PutTaskArtifactRequest, opts ...client.CallOption) (*PutTaskArtifactResponse, error)
+	ReadTaskArtifact(ctx context.Context, in *ReadTaskArtifactRequest, opts ...client.CallOption) (*ReadTaskArtifactResponse, error)
+	ListTaskArtifacts(ctx context.Context, in *ListTaskArtifactsRequest, opts ...client.CallOption) (*ListTaskArtifactsResponse, error)
 }
 
 type jobServiceClient struct {
@@ -304,6 +342,106 @@ func (c *jobServiceClient) DetectStuckTasks(ctx context.Context, in *DetectStuck
 	return out, nil
 }
 
+func (c *jobServiceClient) EnqueueTask(ctx context.Context, in *EnqueueTaskRequest, opts ...client.CallOption) (*EnqueueTaskResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.EnqueueTask", in)
+	out := new(EnqueueTaskResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ClaimTask(ctx context.Context, in *ClaimTaskRequest, opts ...client.CallOption) (*ClaimTaskResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.ClaimTask", in)
+	out := new(ClaimTaskResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RenewTaskLease(ctx context.Context, in *RenewTaskLeaseRequest, opts ...client.CallOption) (*RenewTaskLeaseResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.RenewTaskLease", in)
+	out := new(RenewTaskLeaseResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ReleaseTask(ctx context.Context, in *ReleaseTaskRequest, opts ...client.CallOption) (*ReleaseTaskResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.ReleaseTask", in)
+	out := new(ReleaseTaskResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) SimulateJob(ctx context.Context, in *SimulateJobRequest, opts ...client.CallOption) (*SimulateJobResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.SimulateJob", in)
+	out := new(SimulateJobResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) AppendTaskLog(ctx context.Context, in *AppendTaskLogRequest, opts ...client.CallOption) (*AppendTaskLogResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.AppendTaskLog", in)
+	out := new(AppendTaskLogResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ReadTaskLog(ctx context.Context, in *ReadTaskLogRequest, opts ...client.CallOption) (*ReadTaskLogResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.ReadTaskLog", in)
+	out := new(ReadTaskLogResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) PutTaskArtifact(ctx context.Context, in *PutTaskArtifactRequest, opts ...client.CallOption) (*PutTaskArtifactResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.PutTaskArtifact", in)
+	out := new(PutTaskArtifactResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ReadTaskArtifact(ctx context.Context, in *ReadTaskArtifactRequest, opts ...client.CallOption) (*ReadTaskArtifactResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.ReadTaskArtifact", in)
+	out := new(ReadTaskArtifactResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListTaskArtifacts(ctx context.Context, in *ListTaskArtifactsRequest, opts ...client.CallOption) (*ListTaskArtifactsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "JobService.ListTaskArtifacts", in)
+	out := new(ListTaskArtifactsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for JobService service
 
 type JobServiceHandler interface {
@@ -316,6 +454,16 @@ type JobServiceHandler interface {
 	ListTasks(context.Context, *ListTasksRequest, JobService_ListTasksStream) error
 	DeleteTasks(context.Context, *DeleteTasksRequest, *DeleteTasksResponse) error
 	DetectStuckTasks(context.Context, *DetectStuckTasksRequest, *DetectStuckTasksResponse) error
+	EnqueueTask(context.Context, *EnqueueTaskRequest, *EnqueueTaskResponse) error
+	ClaimTask(context.Context, *ClaimTaskRequest, *ClaimTaskResponse) error
+	RenewTaskLease(context.Context, *RenewTaskLeaseRequest, *RenewTaskLeaseResponse) error
+	ReleaseTask(context.Context, *ReleaseTaskRequest, *ReleaseTaskResponse) error
+	SimulateJob(context.Context, *SimulateJobRequest, *SimulateJobResponse) error
+	AppendTaskLog(context.Context, *AppendTaskLogRequest, *AppendTaskLogResponse) error
+	ReadTaskLog(context.Context, *ReadTaskLogRequest, *ReadTaskLogResponse) error
+	PutTaskArtifact(context.Context, *PutTaskArtifactRequest, *PutTaskArtifactResponse) error
+	ReadTaskArtifact(context.Context, *ReadTaskArtifactRequest, *ReadTaskArtifactResponse) error
+	ListTaskArtifacts(context.Context, *ListTaskArtifactsRequest, *ListTaskArtifactsResponse) error
 }
 
 func RegisterJobServiceHandler(s server.Server, hdlr JobServiceHandler, opts ...server.HandlerOption) {
@@ -460,6 +608,46 @@ func (h *JobService) DetectStuckTasks(ctx context.Context, in *DetectStuckTasksR
 	return h.JobServiceHandler.DetectStuckTasks(ctx, in, out)
 }
 
+func (h *JobService) EnqueueTask(ctx context.Context, in *EnqueueTaskRequest, out *EnqueueTaskResponse) error {
+	return h.JobServiceHandler.EnqueueTask(ctx, in, out)
+}
+
+func (h *JobService) ClaimTask(ctx context.Context, in *ClaimTaskRequest, out *ClaimTaskResponse) error {
+	return h.JobServiceHandler.ClaimTask(ctx, in, out)
+}
+
+func (h *JobService) RenewTaskLease(ctx context.Context, in *RenewTaskLeaseRequest, out *RenewTaskLeaseResponse) error {
+	return h.JobServiceHandler.RenewTaskLease(ctx, in, out)
+}
+
+func (h *JobService) ReleaseTask(ctx context.Context, in *ReleaseTaskRequest, out *ReleaseTaskResponse) error {
+	return h.JobServiceHandler.ReleaseTask(ctx, in, out)
+}
+
+func (h *JobService) SimulateJob(ctx context.Context, in *SimulateJobRequest, out *SimulateJobResponse) error {
+	return h.JobServiceHandler.SimulateJob(ctx, in, out)
+}
+
+func (h *JobService) AppendTaskLog(ctx context.Context, in *AppendTaskLogRequest, out *AppendTaskLogResponse) error {
+	return h.JobServiceHandler.AppendTaskLog(ctx, in, out)
+}
+
+func (h *JobService) ReadTaskLog(ctx context.Context, in *ReadTaskLogRequest, out *ReadTaskLogResponse) error {
+	return h.JobServiceHandler.ReadTaskLog(ctx, in, out)
+}
+
+func (h *JobService) PutTaskArtifact(ctx context.Context, in *PutTaskArtifactRequest, out *PutTaskArtifactResponse) error {
+	return h.JobServiceHandler.PutTaskArtifact(ctx, in, out)
+}
+
+func (h *JobService) ReadTaskArtifact(ctx context.Context, in *ReadTaskArtifactRequest, out *ReadTaskArtifactResponse) error {
+	return h.JobServiceHandler.ReadTaskArtifact(ctx, in, out)
+}
+
+func (h *JobService) ListTaskArtifacts(ctx context.Context, in *ListTaskArtifactsRequest, out *ListTaskArtifactsResponse) error {
+	return h.JobServiceHandler.ListTaskArtifacts(ctx, in, out)
+}
+
 // Client API for TaskService service
 
 type TaskServiceClient interface {
@@ -44,6 +44,36 @@ It has these top-level messages:
 	ActionOutput
 	ActionOutputSingleQuery
 	ActionMessage
+	Join
+	RetryPolicy
+	ActionAttempt
+	QueuedTask
+	EnqueueTaskRequest
+	EnqueueTaskResponse
+	ClaimTaskRequest
+	ClaimTaskResponse
+	RenewTaskLeaseRequest
+	RenewTaskLeaseResponse
+	ReleaseTaskRequest
+	ReleaseTaskResponse
+	TriggerThrottle
+	SimulateJobRequest
+	SimulationFilterResult
+	SimulationStep
+	SimulateJobResponse
+	TaskArtifact
+	AppendTaskLogRequest
+	AppendTaskLogResponse
+	ReadTaskLogRequest
+	ReadTaskLogResponse
+	PutTaskArtifactRequest
+	PutTaskArtifactResponse
+	ReadTaskArtifactRequest
+	ReadTaskArtifactResponse
+	ListTaskArtifactsRequest
+	ListTaskArtifactsResponse
+	Calendar
+	CalendarWindow
 */
 package jobs
 
@@ -200,6 +230,62 @@ func (x Command) String() string {
 }
 func (Command) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
+// Possible values for Join.Mode
+type JoinMode int32
+
+const (
+	// Wait for all upstream branches to finish
+	JoinMode_WaitAll JoinMode = 0
+	// Continue as soon as one upstream branch has finished
+	JoinMode_WaitAny JoinMode = 1
+)
+
+var JoinMode_name = map[int32]string{
+	0: "WaitAll",
+	1: "WaitAny",
+}
+var JoinMode_value = map[string]int32{
+	"WaitAll": 0,
+	"WaitAny": 1,
+}
+
+func (x JoinMode) String() string {
+	return proto.EnumName(JoinMode_name, int32(x))
+}
+func (JoinMode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+// Possible values for SimulationStep.Status
+type SimulationStepStatus int32
+
+const (
+	// Action was run in simulation mode
+	SimulationStepStatus_Simulated SimulationStepStatus = 0
+	// Action does not support simulation, its input is passed as output
+	SimulationStepStatus_NotSimulated SimulationStepStatus = 1
+	// Action filters did not pass
+	SimulationStepStatus_FilteredOut SimulationStepStatus = 2
+	// Action is unknown or its simulation returned an error
+	SimulationStepStatus_Failed SimulationStepStatus = 3
+)
+
+var SimulationStepStatus_name = map[int32]string{
+	0: "Simulated",
+	1: "NotSimulated",
+	2: "FilteredOut",
+	3: "Failed",
+}
+var SimulationStepStatus_value = map[string]int32{
+	"Simulated":    0,
+	"NotSimulated": 1,
+	"FilteredOut":  2,
+	"Failed":       3,
+}
+
+func (x SimulationStepStatus) String() string {
+	return proto.EnumName(SimulationStepStatus_name, int32(x))
+}
+func (SimulationStepStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
 // /////////////////
 // JOB  SERVICE  //
 // /////////////////
@@ -418,6 +504,13 @@ type Schedule struct {
 	Iso8601Schedule string `protobuf:"bytes,1,opt,name=Iso8601Schedule" json:"Iso8601Schedule,omitempty"`
 	// Minimum time between two runs
 	Iso8601MinDelta string `protobuf:"bytes,3,opt,name=Iso8601MinDelta" json:"Iso8601MinDelta,omitempty"`
+	// Standard 5-fields cron expression, for instance "0 2 * * 1-5" (every weekday at 2am).
+	// Descriptors @yearly, @monthly, @weekly, @daily and @hourly are also supported.
+	// Cron and Iso8601Schedule are mutually exclusive.
+	Cron string `protobuf:"bytes,4,opt,name=Cron" json:"Cron,omitempty"`
+	// IANA time zone name used to evaluate the Cron expression, for instance "Europe/Paris".
+	// Defaults to the server local time zone.
+	TimeZone string `protobuf:"bytes,5,opt,name=TimeZone" json:"TimeZone,omitempty"`
 }
 
 func (m *Schedule) Reset()                    { *m = Schedule{} }
@@ -439,6 +532,20 @@ func (m *Schedule) GetIso8601MinDelta() string {
 	return ""
 }
 
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *Schedule) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
 type Action struct {
 	// String Identifier for specific action
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -472,6 +579,10 @@ type Action struct {
 	// If any Filter is used, next actions can be triggered on Failure
 	// This adds ability to create conditional Yes/No branches
 	FailedFilterActions []*Action `protobuf:"bytes,12,rep,name=FailedFilterActions" json:"FailedFilterActions,omitempty"`
+	// Send the output of this action to the Join with this ID
+	JoinID string `protobuf:"bytes,15,opt,name=JoinID" json:"JoinID,omitempty"`
+	// Optional policy to retry this action when it returns an error
+	RetryPolicy *RetryPolicy `protobuf:"bytes,16,opt,name=RetryPolicy" json:"RetryPolicy,omitempty"`
 }
 
 func (m *Action) Reset()                    { *m = Action{} }
@@ -577,6 +688,20 @@ func (m *Action) GetFailedFilterActions() []*Action {
 	return nil
 }
 
+func (m *Action) GetJoinID() string {
+	if m != nil {
+		return m.JoinID
+	}
+	return ""
+}
+
+func (m *Action) GetRetryPolicy() *RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
 type Job struct {
 	// Unique ID for this Job
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -616,6 +741,13 @@ type Job struct {
 	ContextMetaFilter *ContextMetaFilter `protobuf:"bytes,18,opt,name=ContextMetaFilter" json:"ContextMetaFilter,omitempty"`
 	// Job-level parameters that can be passed to underlying actions
 	Parameters []*JobParameter `protobuf:"bytes,19,rep,name=Parameters" json:"Parameters,omitempty"`
+	// Join nodes waiting for multiple branches before continuing
+	Joins []*Join `protobuf:"bytes,20,rep,name=Joins" json:"Joins,omitempty"`
+	// Limit the number of tasks started by events
+	Throttle *TriggerThrottle `protobuf:"bytes,21,opt,name=Throttle" json:"Throttle,omitempty"`
+	// Name of a Calendar restricting when tasks can be started by the timer or by events.
+	// Triggers received outside of the calendar windows are deferred until it opens.
+	CalendarName string `protobuf:"bytes,22,opt,name=CalendarName" json:"CalendarName,omitempty"`
 }
 
 func (m *Job) Reset()                    { *m = Job{} }
@@ -749,6 +881,27 @@ func (m *Job) GetParameters() []*JobParameter {
 	return nil
 }
 
+func (m *Job) GetJoins() []*Join {
+	if m != nil {
+		return m.Joins
+	}
+	return nil
+}
+
+func (m *Job) GetThrottle() *TriggerThrottle {
+	if m != nil {
+		return m.Throttle
+	}
+	return nil
+}
+
+func (m *Job) GetCalendarName() string {
+	if m != nil {
+		return m.CalendarName
+	}
+	return ""
+}
+
 type JobParameter struct {
 	// Parameter name
 	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
@@ -1251,6 +1404,8 @@ type Task struct {
 	Progress float32 `protobuf:"fixed32,11,opt,name=Progress" json:"Progress,omitempty"`
 	// Logs of all the actions performed
 	ActionsLogs []*ActionLog `protobuf:"bytes,12,rep,name=ActionsLogs" json:"ActionsLogs,omitempty"`
+	// Number of triggers skipped by the job throttle since the previous task
+	SkippedTriggers int32 `protobuf:"varint,13,opt,name=SkippedTriggers" json:"SkippedTriggers,omitempty"`
 }
 
 func (m *Task) Reset()                    { *m = Task{} }
@@ -1342,6 +1497,13 @@ func (m *Task) GetActionsLogs() []*ActionLog {
 	return nil
 }
 
+func (m *Task) GetSkippedTriggers() int32 {
+	if m != nil {
+		return m.SkippedTriggers
+	}
+	return 0
+}
+
 type CtrlCommand struct {
 	// Type of command to send (None, Pause, Resume, Stop, Delete, RunOnce, Inactive, Active)
 	Cmd Command `protobuf:"varint,1,opt,name=Cmd,enum=jobs.Command" json:"Cmd,omitempty"`
@@ -1406,6 +1568,8 @@ type ActionLog struct {
 	Action        *Action        `protobuf:"bytes,1,opt,name=Action" json:"Action,omitempty"`
 	InputMessage  *ActionMessage `protobuf:"bytes,2,opt,name=InputMessage" json:"InputMessage,omitempty"`
 	OutputMessage *ActionMessage `protobuf:"bytes,3,opt,name=OutputMessage" json:"OutputMessage,omitempty"`
+	// History of attempts if the action has a RetryPolicy
+	Attempts []*ActionAttempt `protobuf:"bytes,4,rep,name=Attempts" json:"Attempts,omitempty"`
 }
 
 func (m *ActionLog) Reset()                    { *m = ActionLog{} }
@@ -1434,6 +1598,13 @@ func (m *ActionLog) GetOutputMessage() *ActionMessage {
 	return nil
 }
 
+func (m *ActionLog) GetAttempts() []*ActionAttempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
 // Simple Event sent by the Timer Service
 // to trigger a JobID at a given time
 type JobTriggerEvent struct {
@@ -1741,6 +1912,1096 @@ func (m *ActionMessage) GetOutputChain() []*ActionOutput {
 	return nil
 }
 
+// Join waits for a set of upstream branches and merges their outputs
+// before triggering its own chain. Branches are connected to a Join
+// by setting their Action.JoinID.
+type Join struct {
+	// Unique identifier of this Join inside the Job
+	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	// User-defined label for this Join
+	Label string `protobuf:"bytes,2,opt,name=Label" json:"Label,omitempty"`
+	// Wait for all or any upstream branch
+	Mode JoinMode `protobuf:"varint,3,opt,name=Mode,enum=jobs.JoinMode" json:"Mode,omitempty"`
+	// Fail the Join as soon as one upstream branch fails
+	FailOnBranchError bool `protobuf:"varint,4,opt,name=FailOnBranchError" json:"FailOnBranchError,omitempty"`
+	// Actions to perform with the merged output
+	ChainedActions []*Action `protobuf:"bytes,5,rep,name=ChainedActions" json:"ChainedActions,omitempty"`
+}
+
+func (m *Join) Reset()                    { *m = Join{} }
+func (m *Join) String() string            { return proto.CompactTextString(m) }
+func (*Join) ProtoMessage()               {}
+func (*Join) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *Join) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Join) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Join) GetMode() JoinMode {
+	if m != nil {
+		return m.Mode
+	}
+	return JoinMode_WaitAll
+}
+
+func (m *Join) GetFailOnBranchError() bool {
+	if m != nil {
+		return m.FailOnBranchError
+	}
+	return false
+}
+
+func (m *Join) GetChainedActions() []*Action {
+	if m != nil {
+		return m.ChainedActions
+	}
+	return nil
+}
+
+// RetryPolicy defines how an action is retried when it returns an error
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=MaxAttempts" json:"MaxAttempts,omitempty"`
+	// Delay before the first retry, as a duration string (e.g. "10s"), 1s by default
+	InitialBackoff string `protobuf:"bytes,2,opt,name=InitialBackoff" json:"InitialBackoff,omitempty"`
+	// Maximum delay between two attempts, as a duration string
+	MaxBackoff string `protobuf:"bytes,3,opt,name=MaxBackoff" json:"MaxBackoff,omitempty"`
+	// Multiplier applied to the delay after each attempt, 2 by default
+	BackoffMultiplier float32 `protobuf:"fixed32,4,opt,name=BackoffMultiplier" json:"BackoffMultiplier,omitempty"`
+	// Retry only errors matching one of these regular expressions (all errors if empty)
+	RetryableErrors []string `protobuf:"bytes,5,rep,name=RetryableErrors" json:"RetryableErrors,omitempty"`
+	// Maximum duration of each attempt, as a duration string
+	AttemptTimeout string `protobuf:"bytes,6,opt,name=AttemptTimeout" json:"AttemptTimeout,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()                    { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()               {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetInitialBackoff() string {
+	if m != nil {
+		return m.InitialBackoff
+	}
+	return ""
+}
+
+func (m *RetryPolicy) GetMaxBackoff() string {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return ""
+}
+
+func (m *RetryPolicy) GetBackoffMultiplier() float32 {
+	if m != nil {
+		return m.BackoffMultiplier
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetRetryableErrors() []string {
+	if m != nil {
+		return m.RetryableErrors
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetAttemptTimeout() string {
+	if m != nil {
+		return m.AttemptTimeout
+	}
+	return ""
+}
+
+// ActionAttempt records one run of an action
+type ActionAttempt struct {
+	// Attempt number, starting at 1
+	Attempt   int32 `protobuf:"varint,1,opt,name=Attempt" json:"Attempt,omitempty"`
+	StartTime int32 `protobuf:"varint,2,opt,name=StartTime" json:"StartTime,omitempty"`
+	EndTime   int32 `protobuf:"varint,3,opt,name=EndTime" json:"EndTime,omitempty"`
+	// True if this attempt succeeded
+	Success bool `protobuf:"varint,4,opt,name=Success" json:"Success,omitempty"`
+	// Error returned by this attempt
+	ErrorString string `protobuf:"bytes,5,opt,name=ErrorString" json:"ErrorString,omitempty"`
+}
+
+func (m *ActionAttempt) Reset()                    { *m = ActionAttempt{} }
+func (m *ActionAttempt) String() string            { return proto.CompactTextString(m) }
+func (*ActionAttempt) ProtoMessage()               {}
+func (*ActionAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ActionAttempt) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *ActionAttempt) GetStartTime() int32 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ActionAttempt) GetEndTime() int32 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ActionAttempt) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *ActionAttempt) GetErrorString() string {
+	if m != nil {
+		return m.ErrorString
+	}
+	return ""
+}
+
+// QueuedTask is a job execution waiting in the distributed tasks queue,
+// or currently leased by one of the scheduler nodes
+type QueuedTask struct {
+	// Unique identifier of the queue entry
+	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	// Job to run
+	JobID string `protobuf:"bytes,2,opt,name=JobID" json:"JobID,omitempty"`
+	// Event that triggered the job
+	Event *google_protobuf.Any `protobuf:"bytes,3,opt,name=Event" json:"Event,omitempty"`
+	// Context metadata (user, etc.) forwarded to the node running the task
+	ContextMetadata map[string]string `protobuf:"bytes,4,rep,name=ContextMetadata" json:"ContextMetadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Time when the entry was queued
+	EnqueueTime int32 `protobuf:"varint,5,opt,name=EnqueueTime" json:"EnqueueTime,omitempty"`
+	// Scheduler node currently holding the lease, empty if not claimed
+	LeaseOwner string `protobuf:"bytes,6,opt,name=LeaseOwner" json:"LeaseOwner,omitempty"`
+	// The entry is re-queued if the lease is not renewed before this time
+	LeaseExpiry int32 `protobuf:"varint,7,opt,name=LeaseExpiry" json:"LeaseExpiry,omitempty"`
+	// Number of times this entry was claimed
+	Claims int32 `protobuf:"varint,8,opt,name=Claims" json:"Claims,omitempty"`
+	// Number of triggers skipped by the job throttle before this one
+	SkippedTriggers int32 `protobuf:"varint,9,opt,name=SkippedTriggers" json:"SkippedTriggers,omitempty"`
+}
+
+func (m *QueuedTask) Reset()                    { *m = QueuedTask{} }
+func (m *QueuedTask) String() string            { return proto.CompactTextString(m) }
+func (*QueuedTask) ProtoMessage()               {}
+func (*QueuedTask) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *QueuedTask) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *QueuedTask) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *QueuedTask) GetEvent() *google_protobuf.Any {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *QueuedTask) GetContextMetadata() map[string]string {
+	if m != nil {
+		return m.ContextMetadata
+	}
+	return nil
+}
+
+func (m *QueuedTask) GetEnqueueTime() int32 {
+	if m != nil {
+		return m.EnqueueTime
+	}
+	return 0
+}
+
+func (m *QueuedTask) GetLeaseOwner() string {
+	if m != nil {
+		return m.LeaseOwner
+	}
+	return ""
+}
+
+func (m *QueuedTask) GetLeaseExpiry() int32 {
+	if m != nil {
+		return m.LeaseExpiry
+	}
+	return 0
+}
+
+func (m *QueuedTask) GetClaims() int32 {
+	if m != nil {
+		return m.Claims
+	}
+	return 0
+}
+
+func (m *QueuedTask) GetSkippedTriggers() int32 {
+	if m != nil {
+		return m.SkippedTriggers
+	}
+	return 0
+}
+
+type EnqueueTaskRequest struct {
+	Task *QueuedTask `protobuf:"bytes,1,opt,name=Task" json:"Task,omitempty"`
+}
+
+func (m *EnqueueTaskRequest) Reset()                    { *m = EnqueueTaskRequest{} }
+func (m *EnqueueTaskRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueTaskRequest) ProtoMessage()               {}
+func (*EnqueueTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *EnqueueTaskRequest) GetTask() *QueuedTask {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type EnqueueTaskResponse struct {
+	Task *QueuedTask `protobuf:"bytes,1,opt,name=Task" json:"Task,omitempty"`
+}
+
+func (m *EnqueueTaskResponse) Reset()                    { *m = EnqueueTaskResponse{} }
+func (m *EnqueueTaskResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueTaskResponse) ProtoMessage()               {}
+func (*EnqueueTaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *EnqueueTaskResponse) GetTask() *QueuedTask {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type ClaimTaskRequest struct {
+	// Identifier of the claiming scheduler node
+	NodeID string `protobuf:"bytes,1,opt,name=NodeID" json:"NodeID,omitempty"`
+	// Duration of the lease
+	LeaseSeconds int32 `protobuf:"varint,2,opt,name=LeaseSeconds" json:"LeaseSeconds,omitempty"`
+}
+
+func (m *ClaimTaskRequest) Reset()                    { *m = ClaimTaskRequest{} }
+func (m *ClaimTaskRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClaimTaskRequest) ProtoMessage()               {}
+func (*ClaimTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ClaimTaskRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *ClaimTaskRequest) GetLeaseSeconds() int32 {
+	if m != nil {
+		return m.LeaseSeconds
+	}
+	return 0
+}
+
+type ClaimTaskResponse struct {
+	// Claimed entry, empty if nothing can be claimed
+	Task *QueuedTask `protobuf:"bytes,1,opt,name=Task" json:"Task,omitempty"`
+}
+
+func (m *ClaimTaskResponse) Reset()                    { *m = ClaimTaskResponse{} }
+func (m *ClaimTaskResponse) String() string            { return proto.CompactTextString(m) }
+func (*ClaimTaskResponse) ProtoMessage()               {}
+func (*ClaimTaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ClaimTaskResponse) GetTask() *QueuedTask {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type RenewTaskLeaseRequest struct {
+	ID           string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	NodeID       string `protobuf:"bytes,2,opt,name=NodeID" json:"NodeID,omitempty"`
+	LeaseSeconds int32  `protobuf:"varint,3,opt,name=LeaseSeconds" json:"LeaseSeconds,omitempty"`
+}
+
+func (m *RenewTaskLeaseRequest) Reset()                    { *m = RenewTaskLeaseRequest{} }
+func (m *RenewTaskLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenewTaskLeaseRequest) ProtoMessage()               {}
+func (*RenewTaskLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *RenewTaskLeaseRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *RenewTaskLeaseRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *RenewTaskLeaseRequest) GetLeaseSeconds() int32 {
+	if m != nil {
+		return m.LeaseSeconds
+	}
+	return 0
+}
+
+type RenewTaskLeaseResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *RenewTaskLeaseResponse) Reset()                    { *m = RenewTaskLeaseResponse{} }
+func (m *RenewTaskLeaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*RenewTaskLeaseResponse) ProtoMessage()               {}
+func (*RenewTaskLeaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *RenewTaskLeaseResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type ReleaseTaskRequest struct {
+	ID     string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	NodeID string `protobuf:"bytes,2,opt,name=NodeID" json:"NodeID,omitempty"`
+}
+
+func (m *ReleaseTaskRequest) Reset()                    { *m = ReleaseTaskRequest{} }
+func (m *ReleaseTaskRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseTaskRequest) ProtoMessage()               {}
+func (*ReleaseTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *ReleaseTaskRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ReleaseTaskRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+type ReleaseTaskResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *ReleaseTaskResponse) Reset()                    { *m = ReleaseTaskResponse{} }
+func (m *ReleaseTaskResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseTaskResponse) ProtoMessage()               {}
+func (*ReleaseTaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ReleaseTaskResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+// TriggerThrottle limits the number of tasks started by events for a given job.
+// Timer triggers and manual runs are never throttled.
+type TriggerThrottle struct {
+	// Wait for this duration without new events on the same node before starting a task,
+	// as a duration string (e.g. "10s"). Only the latest event is kept.
+	Debounce string `protobuf:"bytes,1,opt,name=Debounce" json:"Debounce,omitempty"`
+	// Maximum number of tasks started per minute, unlimited if 0
+	MaxPerMinute int32 `protobuf:"varint,2,opt,name=MaxPerMinute" json:"MaxPerMinute,omitempty"`
+	// When the rate limit is reached, keep the latest trigger and start it as soon
+	// as possible instead of dropping it
+	Coalesce bool `protobuf:"varint,3,opt,name=Coalesce" json:"Coalesce,omitempty"`
+}
+
+func (m *TriggerThrottle) Reset()                    { *m = TriggerThrottle{} }
+func (m *TriggerThrottle) String() string            { return proto.CompactTextString(m) }
+func (*TriggerThrottle) ProtoMessage()               {}
+func (*TriggerThrottle) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *TriggerThrottle) GetDebounce() string {
+	if m != nil {
+		return m.Debounce
+	}
+	return ""
+}
+
+func (m *TriggerThrottle) GetMaxPerMinute() int32 {
+	if m != nil {
+		return m.MaxPerMinute
+	}
+	return 0
+}
+
+func (m *TriggerThrottle) GetCoalesce() bool {
+	if m != nil {
+		return m.Coalesce
+	}
+	return false
+}
+
+// SimulateJobRequest evaluates a job definition against a sample event or input,
+// without any side effects.
+type SimulateJobRequest struct {
+	// Job definition, it does not have to be saved
+	Job *Job `protobuf:"bytes,1,opt,name=Job" json:"Job,omitempty"`
+	// Sample node event used as trigger
+	NodeEvent *tree.NodeChangeEvent `protobuf:"bytes,2,opt,name=NodeEvent" json:"NodeEvent,omitempty"`
+	// Sample idm event used as trigger
+	IdmEvent *idm.ChangeEvent `protobuf:"bytes,3,opt,name=IdmEvent" json:"IdmEvent,omitempty"`
+	// Input passed to the first actions, built from the event if empty
+	Input *ActionMessage `protobuf:"bytes,4,opt,name=Input" json:"Input,omitempty"`
+}
+
+func (m *SimulateJobRequest) Reset()                    { *m = SimulateJobRequest{} }
+func (m *SimulateJobRequest) String() string            { return proto.CompactTextString(m) }
+func (*SimulateJobRequest) ProtoMessage()               {}
+func (*SimulateJobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *SimulateJobRequest) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *SimulateJobRequest) GetNodeEvent() *tree.NodeChangeEvent {
+	if m != nil {
+		return m.NodeEvent
+	}
+	return nil
+}
+
+func (m *SimulateJobRequest) GetIdmEvent() *idm.ChangeEvent {
+	if m != nil {
+		return m.IdmEvent
+	}
+	return nil
+}
+
+func (m *SimulateJobRequest) GetInput() *ActionMessage {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+// Result of a single filter or selector evaluation
+type SimulationFilterResult struct {
+	// Filter type (NodesFilter, IdmFilter, ContextMetaFilter, etc.)
+	Type string `protobuf:"bytes,1,opt,name=Type" json:"Type,omitempty"`
+	// True if the input passed this filter
+	Passed bool `protobuf:"varint,2,opt,name=Passed" json:"Passed,omitempty"`
+}
+
+func (m *SimulationFilterResult) Reset()                    { *m = SimulationFilterResult{} }
+func (m *SimulationFilterResult) String() string            { return proto.CompactTextString(m) }
+func (*SimulationFilterResult) ProtoMessage()               {}
+func (*SimulationFilterResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *SimulationFilterResult) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SimulationFilterResult) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+// One action run during a simulation
+type SimulationStep struct {
+	// Path of the action in the chain, as used in tasks
+	ActionPath string `protobuf:"bytes,1,opt,name=ActionPath" json:"ActionPath,omitempty"`
+	// Action identifier
+	ActionID string               `protobuf:"bytes,2,opt,name=ActionID" json:"ActionID,omitempty"`
+	Status   SimulationStepStatus `protobuf:"varint,3,opt,name=Status,enum=jobs.SimulationStepStatus" json:"Status,omitempty"`
+	// Filters evaluated on the input
+	Filters []*SimulationFilterResult `protobuf:"bytes,4,rep,name=Filters" json:"Filters,omitempty"`
+	Input   *ActionMessage            `protobuf:"bytes,5,opt,name=Input" json:"Input,omitempty"`
+	Output  *ActionMessage            `protobuf:"bytes,6,opt,name=Output" json:"Output,omitempty"`
+	// Additional information (error, join, etc.)
+	Message string `protobuf:"bytes,7,opt,name=Message" json:"Message,omitempty"`
+}
+
+func (m *SimulationStep) Reset()                    { *m = SimulationStep{} }
+func (m *SimulationStep) String() string            { return proto.CompactTextString(m) }
+func (*SimulationStep) ProtoMessage()               {}
+func (*SimulationStep) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *SimulationStep) GetActionPath() string {
+	if m != nil {
+		return m.ActionPath
+	}
+	return ""
+}
+
+func (m *SimulationStep) GetActionID() string {
+	if m != nil {
+		return m.ActionID
+	}
+	return ""
+}
+
+func (m *SimulationStep) GetStatus() SimulationStepStatus {
+	if m != nil {
+		return m.Status
+	}
+	return SimulationStepStatus_Simulated
+}
+
+func (m *SimulationStep) GetFilters() []*SimulationFilterResult {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+func (m *SimulationStep) GetInput() *ActionMessage {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *SimulationStep) GetOutput() *ActionMessage {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *SimulationStep) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type SimulateJobResponse struct {
+	// False if the event does not pass the job-level filters
+	Triggered bool `protobuf:"varint,1,opt,name=Triggered" json:"Triggered,omitempty"`
+	// Job-level filters evaluated on the event
+	JobFilters []*SimulationFilterResult `protobuf:"bytes,2,rep,name=JobFilters" json:"JobFilters,omitempty"`
+	// Ordered list of the actions run
+	Trace []*SimulationStep `protobuf:"bytes,3,rep,name=Trace" json:"Trace,omitempty"`
+}
+
+func (m *SimulateJobResponse) Reset()                    { *m = SimulateJobResponse{} }
+func (m *SimulateJobResponse) String() string            { return proto.CompactTextString(m) }
+func (*SimulateJobResponse) ProtoMessage()               {}
+func (*SimulateJobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *SimulateJobResponse) GetTriggered() bool {
+	if m != nil {
+		return m.Triggered
+	}
+	return false
+}
+
+func (m *SimulateJobResponse) GetJobFilters() []*SimulationFilterResult {
+	if m != nil {
+		return m.JobFilters
+	}
+	return nil
+}
+
+func (m *SimulateJobResponse) GetTrace() []*SimulationStep {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
+// TaskArtifact describes a file produced by a task
+type TaskArtifact struct {
+	JobID  string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	TaskID string `protobuf:"bytes,2,opt,name=TaskID" json:"TaskID,omitempty"`
+	// File name, unique for a given task
+	Name        string `protobuf:"bytes,3,opt,name=Name" json:"Name,omitempty"`
+	Size        int64  `protobuf:"varint,4,opt,name=Size" json:"Size,omitempty"`
+	MTime       int32  `protobuf:"varint,5,opt,name=MTime" json:"MTime,omitempty"`
+	ContentType string `protobuf:"bytes,6,opt,name=ContentType" json:"ContentType,omitempty"`
+}
+
+func (m *TaskArtifact) Reset()                    { *m = TaskArtifact{} }
+func (m *TaskArtifact) String() string            { return proto.CompactTextString(m) }
+func (*TaskArtifact) ProtoMessage()               {}
+func (*TaskArtifact) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *TaskArtifact) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *TaskArtifact) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *TaskArtifact) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskArtifact) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TaskArtifact) GetMTime() int32 {
+	if m != nil {
+		return m.MTime
+	}
+	return 0
+}
+
+func (m *TaskArtifact) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+type AppendTaskLogRequest struct {
+	JobID  string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	TaskID string `protobuf:"bytes,2,opt,name=TaskID" json:"TaskID,omitempty"`
+	// Raw data appended to the task log stream
+	Data []byte `protobuf:"bytes,3,opt,name=Data" json:"Data,omitempty"`
+}
+
+func (m *AppendTaskLogRequest) Reset()                    { *m = AppendTaskLogRequest{} }
+func (m *AppendTaskLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*AppendTaskLogRequest) ProtoMessage()               {}
+func (*AppendTaskLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *AppendTaskLogRequest) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *AppendTaskLogRequest) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *AppendTaskLogRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type AppendTaskLogResponse struct {
+	// Size of the log stream after this call
+	Size int64 `protobuf:"varint,1,opt,name=Size" json:"Size,omitempty"`
+}
+
+func (m *AppendTaskLogResponse) Reset()                    { *m = AppendTaskLogResponse{} }
+func (m *AppendTaskLogResponse) String() string            { return proto.CompactTextString(m) }
+func (*AppendTaskLogResponse) ProtoMessage()               {}
+func (*AppendTaskLogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *AppendTaskLogResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type ReadTaskLogRequest struct {
+	JobID  string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	TaskID string `protobuf:"bytes,2,opt,name=TaskID" json:"TaskID,omitempty"`
+	// Start reading at this offset, or at this number of bytes before the end if negative
+	Offset int64 `protobuf:"varint,3,opt,name=Offset" json:"Offset,omitempty"`
+	// Maximum number of bytes to read, 64KB by default
+	Limit int32 `protobuf:"varint,4,opt,name=Limit" json:"Limit,omitempty"`
+}
+
+func (m *ReadTaskLogRequest) Reset()                    { *m = ReadTaskLogRequest{} }
+func (m *ReadTaskLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadTaskLogRequest) ProtoMessage()               {}
+func (*ReadTaskLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *ReadTaskLogRequest) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *ReadTaskLogRequest) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *ReadTaskLogRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadTaskLogRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ReadTaskLogResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=Data" json:"Data,omitempty"`
+	// Offset to use for the next call
+	NextOffset int64 `protobuf:"varint,2,opt,name=NextOffset" json:"NextOffset,omitempty"`
+	// Total size of the log stream
+	Size int64 `protobuf:"varint,3,opt,name=Size" json:"Size,omitempty"`
+}
+
+func (m *ReadTaskLogResponse) Reset()                    { *m = ReadTaskLogResponse{} }
+func (m *ReadTaskLogResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadTaskLogResponse) ProtoMessage()               {}
+func (*ReadTaskLogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ReadTaskLogResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadTaskLogResponse) GetNextOffset() int64 {
+	if m != nil {
+		return m.NextOffset
+	}
+	return 0
+}
+
+func (m *ReadTaskLogResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type PutTaskArtifactRequest struct {
+	// JobID, TaskID and Name of the artifact
+	Artifact *TaskArtifact `protobuf:"bytes,1,opt,name=Artifact" json:"Artifact,omitempty"`
+	Data     []byte        `protobuf:"bytes,2,opt,name=Data" json:"Data,omitempty"`
+	// Append Data to an existing artifact instead of replacing it
+	Append bool `protobuf:"varint,3,opt,name=Append" json:"Append,omitempty"`
+}
+
+func (m *PutTaskArtifactRequest) Reset()                    { *m = PutTaskArtifactRequest{} }
+func (m *PutTaskArtifactRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutTaskArtifactRequest) ProtoMessage()               {}
+func (*PutTaskArtifactRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *PutTaskArtifactRequest) GetArtifact() *TaskArtifact {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
+func (m *PutTaskArtifactRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PutTaskArtifactRequest) GetAppend() bool {
+	if m != nil {
+		return m.Append
+	}
+	return false
+}
+
+type PutTaskArtifactResponse struct {
+	Artifact *TaskArtifact `protobuf:"bytes,1,opt,name=Artifact" json:"Artifact,omitempty"`
+}
+
+func (m *PutTaskArtifactResponse) Reset()                    { *m = PutTaskArtifactResponse{} }
+func (m *PutTaskArtifactResponse) String() string            { return proto.CompactTextString(m) }
+func (*PutTaskArtifactResponse) ProtoMessage()               {}
+func (*PutTaskArtifactResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *PutTaskArtifactResponse) GetArtifact() *TaskArtifact {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
+type ReadTaskArtifactRequest struct {
+	JobID  string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	TaskID string `protobuf:"bytes,2,opt,name=TaskID" json:"TaskID,omitempty"`
+	Name   string `protobuf:"bytes,3,opt,name=Name" json:"Name,omitempty"`
+	// Start reading at this offset
+	Offset int64 `protobuf:"varint,4,opt,name=Offset" json:"Offset,omitempty"`
+	// Maximum number of bytes to read, 1MB by default
+	Limit int32 `protobuf:"varint,5,opt,name=Limit" json:"Limit,omitempty"`
+}
+
+func (m *ReadTaskArtifactRequest) Reset()                    { *m = ReadTaskArtifactRequest{} }
+func (m *ReadTaskArtifactRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadTaskArtifactRequest) ProtoMessage()               {}
+func (*ReadTaskArtifactRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ReadTaskArtifactRequest) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *ReadTaskArtifactRequest) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *ReadTaskArtifactRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReadTaskArtifactRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadTaskArtifactRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ReadTaskArtifactResponse struct {
+	Artifact *TaskArtifact `protobuf:"bytes,1,opt,name=Artifact" json:"Artifact,omitempty"`
+	Data     []byte        `protobuf:"bytes,2,opt,name=Data" json:"Data,omitempty"`
+}
+
+func (m *ReadTaskArtifactResponse) Reset()                    { *m = ReadTaskArtifactResponse{} }
+func (m *ReadTaskArtifactResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadTaskArtifactResponse) ProtoMessage()               {}
+func (*ReadTaskArtifactResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *ReadTaskArtifactResponse) GetArtifact() *TaskArtifact {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
+func (m *ReadTaskArtifactResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ListTaskArtifactsRequest struct {
+	JobID  string `protobuf:"bytes,1,opt,name=JobID" json:"JobID,omitempty"`
+	TaskID string `protobuf:"bytes,2,opt,name=TaskID" json:"TaskID,omitempty"`
+}
+
+func (m *ListTaskArtifactsRequest) Reset()                    { *m = ListTaskArtifactsRequest{} }
+func (m *ListTaskArtifactsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTaskArtifactsRequest) ProtoMessage()               {}
+func (*ListTaskArtifactsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *ListTaskArtifactsRequest) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *ListTaskArtifactsRequest) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+type ListTaskArtifactsResponse struct {
+	Artifacts []*TaskArtifact `protobuf:"bytes,1,rep,name=Artifacts" json:"Artifacts,omitempty"`
+}
+
+func (m *ListTaskArtifactsResponse) Reset()                    { *m = ListTaskArtifactsResponse{} }
+func (m *ListTaskArtifactsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTaskArtifactsResponse) ProtoMessage()               {}
+func (*ListTaskArtifactsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ListTaskArtifactsResponse) GetArtifacts() []*TaskArtifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// Calendar defines time windows during which jobs referencing it by name are allowed
+// to start tasks. Calendars are stored in the configuration of the jobs service.
+type Calendar struct {
+	// Unique name used by jobs to reference this calendar
+	Name string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	// Human-readable label
+	Label string `protobuf:"bytes,2,opt,name=Label" json:"Label,omitempty"`
+	// IANA time zone used to read the windows (e.g. "Europe/Paris"), UTC if empty
+	TimeZone string `protobuf:"bytes,3,opt,name=TimeZone" json:"TimeZone,omitempty"`
+	// Tasks can start only during these windows, or at any time if empty
+	Allowed []*CalendarWindow `protobuf:"bytes,4,rep,name=Allowed" json:"Allowed,omitempty"`
+	// Tasks cannot start during these windows, they take precedence over Allowed
+	Forbidden []*CalendarWindow `protobuf:"bytes,5,rep,name=Forbidden" json:"Forbidden,omitempty"`
+}
+
+func (m *Calendar) Reset()                    { *m = Calendar{} }
+func (m *Calendar) String() string            { return proto.CompactTextString(m) }
+func (*Calendar) ProtoMessage()               {}
+func (*Calendar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *Calendar) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Calendar) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Calendar) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *Calendar) GetAllowed() []*CalendarWindow {
+	if m != nil {
+		return m.Allowed
+	}
+	return nil
+}
+
+func (m *Calendar) GetForbidden() []*CalendarWindow {
+	if m != nil {
+		return m.Forbidden
+	}
+	return nil
+}
+
+// CalendarWindow is a daily time range, optionally restricted to some days.
+type CalendarWindow struct {
+	// Days of the week (0 for Sunday to 6 for Saturday)
+	Weekdays []int32 `protobuf:"varint,1,rep,packed,name=Weekdays" json:"Weekdays,omitempty"`
+	// Specific dates as YYYY-MM-DD, e.g. bank holidays
+	Dates []string `protobuf:"bytes,2,rep,name=Dates" json:"Dates,omitempty"`
+	// Start time as HH:MM, midnight if empty
+	Start string `protobuf:"bytes,3,opt,name=Start" json:"Start,omitempty"`
+	// End time as HH:MM, end of the day if empty. If it is before Start,
+	// the window ends on the next day.
+	End string `protobuf:"bytes,4,opt,name=End" json:"End,omitempty"`
+}
+
+func (m *CalendarWindow) Reset()                    { *m = CalendarWindow{} }
+func (m *CalendarWindow) String() string            { return proto.CompactTextString(m) }
+func (*CalendarWindow) ProtoMessage()               {}
+func (*CalendarWindow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *CalendarWindow) GetWeekdays() []int32 {
+	if m != nil {
+		return m.Weekdays
+	}
+	return nil
+}
+
+func (m *CalendarWindow) GetDates() []string {
+	if m != nil {
+		return m.Dates
+	}
+	return nil
+}
+
+func (m *CalendarWindow) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *CalendarWindow) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*NodesSelector)(nil), "jobs.NodesSelector")
 	proto.RegisterType((*IdmSelector)(nil), "jobs.IdmSelector")
@@ -1778,168 +3039,285 @@ func init() {
 	proto.RegisterType((*ActionOutput)(nil), "jobs.ActionOutput")
 	proto.RegisterType((*ActionOutputSingleQuery)(nil), "jobs.ActionOutputSingleQuery")
 	proto.RegisterType((*ActionMessage)(nil), "jobs.ActionMessage")
+	proto.RegisterType((*Join)(nil), "jobs.Join")
+	proto.RegisterType((*RetryPolicy)(nil), "jobs.RetryPolicy")
+	proto.RegisterType((*ActionAttempt)(nil), "jobs.ActionAttempt")
+	proto.RegisterType((*QueuedTask)(nil), "jobs.QueuedTask")
+	proto.RegisterType((*EnqueueTaskRequest)(nil), "jobs.EnqueueTaskRequest")
+	proto.RegisterType((*EnqueueTaskResponse)(nil), "jobs.EnqueueTaskResponse")
+	proto.RegisterType((*ClaimTaskRequest)(nil), "jobs.ClaimTaskRequest")
+	proto.RegisterType((*ClaimTaskResponse)(nil), "jobs.ClaimTaskResponse")
+	proto.RegisterType((*RenewTaskLeaseRequest)(nil), "jobs.RenewTaskLeaseRequest")
+	proto.RegisterType((*RenewTaskLeaseResponse)(nil), "jobs.RenewTaskLeaseResponse")
+	proto.RegisterType((*ReleaseTaskRequest)(nil), "jobs.ReleaseTaskRequest")
+	proto.RegisterType((*ReleaseTaskResponse)(nil), "jobs.ReleaseTaskResponse")
+	proto.RegisterType((*TriggerThrottle)(nil), "jobs.TriggerThrottle")
+	proto.RegisterType((*SimulateJobRequest)(nil), "jobs.SimulateJobRequest")
+	proto.RegisterType((*SimulationFilterResult)(nil), "jobs.SimulationFilterResult")
+	proto.RegisterType((*SimulationStep)(nil), "jobs.SimulationStep")
+	proto.RegisterType((*SimulateJobResponse)(nil), "jobs.SimulateJobResponse")
+	proto.RegisterType((*TaskArtifact)(nil), "jobs.TaskArtifact")
+	proto.RegisterType((*AppendTaskLogRequest)(nil), "jobs.AppendTaskLogRequest")
+	proto.RegisterType((*AppendTaskLogResponse)(nil), "jobs.AppendTaskLogResponse")
+	proto.RegisterType((*ReadTaskLogRequest)(nil), "jobs.ReadTaskLogRequest")
+	proto.RegisterType((*ReadTaskLogResponse)(nil), "jobs.ReadTaskLogResponse")
+	proto.RegisterType((*PutTaskArtifactRequest)(nil), "jobs.PutTaskArtifactRequest")
+	proto.RegisterType((*PutTaskArtifactResponse)(nil), "jobs.PutTaskArtifactResponse")
+	proto.RegisterType((*ReadTaskArtifactRequest)(nil), "jobs.ReadTaskArtifactRequest")
+	proto.RegisterType((*ReadTaskArtifactResponse)(nil), "jobs.ReadTaskArtifactResponse")
+	proto.RegisterType((*ListTaskArtifactsRequest)(nil), "jobs.ListTaskArtifactsRequest")
+	proto.RegisterType((*ListTaskArtifactsResponse)(nil), "jobs.ListTaskArtifactsResponse")
+	proto.RegisterType((*Calendar)(nil), "jobs.Calendar")
+	proto.RegisterType((*CalendarWindow)(nil), "jobs.CalendarWindow")
 	proto.RegisterEnum("jobs.IdmSelectorType", IdmSelectorType_name, IdmSelectorType_value)
 	proto.RegisterEnum("jobs.ContextMetaFilterType", ContextMetaFilterType_name, ContextMetaFilterType_value)
 	proto.RegisterEnum("jobs.TaskStatus", TaskStatus_name, TaskStatus_value)
 	proto.RegisterEnum("jobs.Command", Command_name, Command_value)
+	proto.RegisterEnum("jobs.JoinMode", JoinMode_name, JoinMode_value)
+	proto.RegisterEnum("jobs.SimulationStepStatus", SimulationStepStatus_name, SimulationStepStatus_value)
 }
 
 func init() { proto.RegisterFile("jobs.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2458 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4b, 0x73, 0xdb, 0xc8,
-	0xf1, 0x17, 0x1f, 0x10, 0xc9, 0xa6, 0x1e, 0xd0, 0x58, 0x6b, 0x73, 0xb5, 0xfe, 0xdb, 0x2a, 0x94,
-	0x6b, 0xff, 0x5a, 0x95, 0x43, 0xda, 0xf2, 0x6e, 0x62, 0x6f, 0xad, 0x5d, 0xd1, 0x52, 0x7e, 0x50,
-	0xd1, 0xcb, 0x43, 0x3b, 0x39, 0x24, 0x95, 0x2a, 0x90, 0x18, 0x53, 0xb0, 0xc0, 0x19, 0x06, 0x18,
-	0xc8, 0x66, 0x72, 0xcb, 0x31, 0x95, 0xcf, 0x90, 0x4b, 0x2a, 0xd7, 0x7c, 0x93, 0xe4, 0x90, 0xaa,
-	0x7c, 0x90, 0x7c, 0x82, 0xa4, 0xe6, 0x01, 0x60, 0x00, 0x92, 0xb2, 0x7c, 0x90, 0x0a, 0xf3, 0xeb,
-	0xee, 0x99, 0x9e, 0x9e, 0xee, 0x9e, 0xee, 0x21, 0xc0, 0x7b, 0x36, 0x88, 0xda, 0x93, 0x90, 0x71,
-	0x86, 0xaa, 0xe2, 0x7b, 0xeb, 0xcb, 0x11, 0x63, 0xa3, 0x80, 0x74, 0x24, 0x36, 0x88, 0xdf, 0x75,
-	0x5c, 0x3a, 0x55, 0x0c, 0x5b, 0x8f, 0x47, 0x3e, 0x3f, 0x8f, 0x07, 0xed, 0x21, 0x1b, 0x77, 0x26,
-	0x53, 0xcf, 0x67, 0x9d, 0x21, 0x09, 0x82, 0xa8, 0x33, 0x64, 0xe3, 0x31, 0xa3, 0x9d, 0x88, 0x84,
-	0x97, 0xfe, 0x50, 0x4b, 0x6a, 0x50, 0x4b, 0x3e, 0xba, 0x5a, 0x52, 0x49, 0xf0, 0x90, 0x10, 0xf9,
-	0x4f, 0x0b, 0x3d, 0xbc, 0x8e, 0x90, 0xef, 0x8d, 0xc5, 0x9f, 0x16, 0xd9, 0xbf, 0x8e, 0x88, 0x3b,
-	0xe4, 0xfe, 0xa5, 0xcf, 0xa7, 0xe9, 0x47, 0xc4, 0x43, 0xe2, 0xea, 0x29, 0x9c, 0x29, 0xac, 0x9e,
-	0x30, 0x8f, 0x44, 0x7d, 0x12, 0x90, 0x21, 0x67, 0x21, 0xb2, 0xa1, 0xb2, 0x1f, 0x04, 0xad, 0xd2,
-	0x76, 0x69, 0xa7, 0x8e, 0xc5, 0x27, 0xba, 0x09, 0xcb, 0x67, 0x2e, 0x3f, 0x27, 0x51, 0xab, 0xbc,
-	0x5d, 0xd9, 0x69, 0x60, 0x3d, 0x42, 0xf7, 0xc0, 0x7a, 0x1d, 0x93, 0x70, 0xda, 0xaa, 0x6e, 0x97,
-	0x76, 0x9a, 0x7b, 0x6b, 0x6d, 0x6d, 0x91, 0xb6, 0x44, 0xb1, 0x22, 0xa2, 0x16, 0xd4, 0xba, 0x2c,
-	0x10, 0x93, 0xb7, 0x2c, 0x39, 0x67, 0x32, 0x74, 0xfe, 0x54, 0x82, 0x66, 0xcf, 0x1b, 0xa7, 0x2b,
-	0x7f, 0x03, 0xd5, 0x37, 0xd3, 0x09, 0x91, 0x4b, 0xaf, 0xed, 0x7d, 0xd1, 0x96, 0x67, 0x65, 0x30,
-	0x08, 0x22, 0x96, 0x2c, 0x89, 0x92, 0xe5, 0x4c, 0xc9, 0x54, 0x99, 0xca, 0x35, 0x95, 0xa9, 0xe6,
-	0x95, 0xf9, 0x63, 0x09, 0x56, 0xdf, 0x46, 0x24, 0xbc, 0xca, 0x10, 0x77, 0xc1, 0x92, 0x2c, 0xd2,
-	0x0e, 0xcd, 0xbd, 0x46, 0x5b, 0x9c, 0x84, 0x40, 0xb0, 0xc2, 0x3f, 0x5f, 0x89, 0x82, 0x45, 0xbe,
-	0x07, 0xb4, 0x3f, 0xe4, 0x3e, 0xa3, 0xa7, 0x31, 0x9f, 0xc4, 0xfc, 0x85, 0x1f, 0x70, 0x12, 0x66,
-	0xb3, 0x96, 0xae, 0x98, 0xd5, 0x79, 0x0f, 0x1b, 0x5d, 0x46, 0x39, 0xf9, 0xc8, 0x8f, 0x09, 0x77,
-	0xb5, 0x68, 0x27, 0x67, 0xd2, 0xaf, 0x94, 0x49, 0x67, 0xd8, 0x0c, 0xc3, 0xa6, 0x6b, 0x95, 0xaf,
-	0x5e, 0xeb, 0xa6, 0x31, 0x49, 0xdf, 0xa7, 0xa3, 0x80, 0xa8, 0xbd, 0xdd, 0x86, 0xc6, 0x0b, 0x9f,
-	0x04, 0xde, 0x89, 0x3b, 0x56, 0xab, 0x36, 0x70, 0x06, 0xa0, 0x3d, 0x68, 0x74, 0x19, 0xf5, 0x7c,
-	0xb1, 0x45, 0xbd, 0xc2, 0xa6, 0x34, 0xe2, 0x19, 0x0b, 0xfc, 0xe1, 0x34, 0xa5, 0xe1, 0x8c, 0xcd,
-	0xf9, 0x2d, 0xd4, 0xfb, 0xc3, 0x73, 0xe2, 0xc5, 0x01, 0x41, 0x3b, 0xb0, 0xde, 0x8b, 0xd8, 0xe3,
-	0x9f, 0x3e, 0x78, 0x98, 0x40, 0x7a, 0x8d, 0x22, 0x6c, 0x70, 0x1e, 0xfb, 0xf4, 0x80, 0x04, 0xdc,
-	0x95, 0x67, 0x92, 0x71, 0x26, 0xb0, 0xf3, 0x97, 0x65, 0x58, 0x56, 0x46, 0x47, 0x6b, 0x50, 0xee,
-	0x1d, 0xe8, 0x19, 0xcb, 0xbd, 0x03, 0xb4, 0x09, 0xd6, 0x91, 0x3b, 0x20, 0x41, 0x6b, 0x55, 0x42,
-	0x6a, 0x80, 0xb6, 0xa1, 0x79, 0x40, 0xa2, 0x61, 0xe8, 0x4f, 0xe4, 0x36, 0xd6, 0x24, 0xcd, 0x84,
-	0xd0, 0x93, 0x42, 0x4c, 0xe9, 0xad, 0xde, 0x50, 0xe6, 0xcf, 0x91, 0x70, 0x21, 0xfa, 0x9e, 0x14,
-	0xbc, 0x50, 0x7b, 0x92, 0x16, 0xcd, 0x91, 0x70, 0xc1, 0x5f, 0xbf, 0x83, 0xa6, 0x9c, 0x4b, 0x9d,
-	0xa9, 0x0e, 0xca, 0xb9, 0x6b, 0x9a, 0x7c, 0x42, 0x4c, 0xce, 0xa3, 0xc5, 0xac, 0xc5, 0xeb, 0x99,
-	0x7c, 0xe8, 0x51, 0x2e, 0x76, 0x5b, 0x0d, 0x29, 0xb6, 0x31, 0x13, 0xb3, 0x38, 0x17, 0xe1, 0x1d,
-	0x68, 0xf4, 0xbc, 0xb1, 0x5e, 0x09, 0x16, 0x89, 0x64, 0x3c, 0xe8, 0xd5, 0xbc, 0x80, 0x68, 0x2d,
-	0x4b, 0xc9, 0x96, 0x92, 0x9c, 0xa5, 0xe3, 0x79, 0x41, 0xf4, 0x7c, 0x4e, 0x78, 0xb4, 0x9a, 0x72,
-	0xa2, 0x5b, 0x0b, 0xc2, 0x02, 0xcf, 0x09, 0xa8, 0x1f, 0x00, 0xce, 0xdc, 0xd0, 0x1d, 0x13, 0x2e,
-	0xf2, 0x40, 0x4d, 0xe6, 0x81, 0xdb, 0xa6, 0x22, 0xed, 0x8c, 0xfc, 0x9c, 0xf2, 0x70, 0x8a, 0x0d,
-	0x7e, 0xf4, 0x2d, 0xac, 0x75, 0xcf, 0x5d, 0x9f, 0x12, 0x4f, 0x31, 0x47, 0xad, 0xba, 0x9c, 0x61,
-	0xc5, 0x9c, 0x01, 0x17, 0x78, 0xd0, 0x33, 0xb8, 0xf1, 0xc2, 0xf5, 0x03, 0xe2, 0x29, 0x1d, 0x12,
-	0xd1, 0x95, 0x39, 0xa2, 0xf3, 0x18, 0xb7, 0x9e, 0xc2, 0x7a, 0x41, 0x29, 0x91, 0xdb, 0x2e, 0xc8,
-	0x54, 0xbb, 0xba, 0xf8, 0x14, 0xbe, 0x7e, 0xe9, 0x06, 0x31, 0x91, 0xbe, 0xda, 0xc0, 0x6a, 0xf0,
-	0x7d, 0xf9, 0x71, 0xc9, 0xf9, 0x87, 0x05, 0x95, 0x43, 0x36, 0x58, 0x1c, 0x1d, 0x65, 0x33, 0x3a,
-	0x36, 0xc1, 0x3a, 0xfd, 0x40, 0x49, 0xa8, 0xc3, 0x4d, 0x0d, 0xd0, 0x16, 0xd4, 0x7b, 0x54, 0xde,
-	0x3f, 0x44, 0x27, 0xde, 0x74, 0x2c, 0x52, 0xc6, 0x91, 0x4b, 0x47, 0xb1, 0x3b, 0x22, 0x51, 0x0b,
-	0xe4, 0x0d, 0x93, 0x01, 0xe8, 0x0e, 0xc0, 0xf3, 0x4b, 0x42, 0xb9, 0xc8, 0x1f, 0x51, 0xcb, 0x92,
-	0x64, 0x03, 0x41, 0xbb, 0x59, 0x7a, 0xd0, 0x7e, 0xb1, 0xa6, 0x2c, 0x92, 0xa0, 0x38, 0x4b, 0x1f,
-	0xb7, 0xa1, 0xb1, 0x1f, 0x73, 0xd6, 0xe7, 0x6e, 0xc8, 0x5b, 0x35, 0xa9, 0x46, 0x06, 0x24, 0xd4,
-	0x6e, 0x40, 0x5c, 0x2a, 0x3d, 0x43, 0x53, 0x25, 0x80, 0xbe, 0x86, 0xda, 0x55, 0x67, 0x96, 0x10,
-	0xd1, 0xd7, 0xb0, 0x76, 0xec, 0x7e, 0xec, 0x32, 0x3a, 0x8c, 0xc3, 0x90, 0xd0, 0xe1, 0x54, 0x86,
-	0x86, 0x85, 0x0b, 0x28, 0xba, 0x0f, 0x1b, 0x6f, 0xdc, 0xe8, 0x22, 0xea, 0xfb, 0x01, 0xa1, 0xfc,
-	0xed, 0xc4, 0x73, 0x39, 0x69, 0xad, 0xc8, 0x55, 0x67, 0x09, 0x68, 0x1b, 0x2c, 0x09, 0xb6, 0xd6,
-	0xe4, 0xda, 0xa0, 0xd6, 0x16, 0x10, 0x56, 0x04, 0xf4, 0x14, 0xd6, 0x45, 0x54, 0x4b, 0xcb, 0x68,
-	0xef, 0x5e, 0x5f, 0x9c, 0x01, 0x8a, 0xbc, 0x42, 0x5c, 0x44, 0xb7, 0x29, 0x6e, 0x2f, 0xce, 0x04,
-	0x45, 0xde, 0x7c, 0x60, 0x6f, 0x5c, 0x23, 0xb0, 0xe7, 0x86, 0x23, 0xfa, 0xec, 0x70, 0xdc, 0xcb,
-	0x85, 0xe3, 0x0d, 0x69, 0x1c, 0xa4, 0xe4, 0x0f, 0xd9, 0x20, 0x25, 0x99, 0x41, 0xe8, 0xfc, 0xbd,
-	0x04, 0x2b, 0x26, 0x11, 0x21, 0xa8, 0x1a, 0xd7, 0x95, 0xfc, 0x2e, 0x26, 0xf9, 0xf2, 0x6c, 0x92,
-	0xdf, 0x04, 0xeb, 0x97, 0x32, 0x60, 0xaa, 0xca, 0xd1, 0xe5, 0x40, 0x38, 0xd1, 0xb1, 0x4b, 0x3d,
-	0x97, 0x33, 0x5d, 0x05, 0xd4, 0x71, 0x06, 0x88, 0x95, 0xe4, 0x75, 0x6c, 0xa9, 0x95, 0xe4, 0x8d,
-	0xbb, 0x0d, 0xcd, 0xc3, 0x88, 0xd1, 0xee, 0x39, 0xf3, 0x87, 0x24, 0x92, 0x3e, 0xdc, 0xc0, 0x26,
-	0xe4, 0xfc, 0x1a, 0xd6, 0x0e, 0xd9, 0xa0, 0x7b, 0xee, 0xd2, 0x91, 0x3a, 0x33, 0xf4, 0x0d, 0xc0,
-	0x21, 0x1b, 0x28, 0xdf, 0xf0, 0x74, 0x59, 0xd0, 0x48, 0xb7, 0x8d, 0x0d, 0xa2, 0x88, 0x1f, 0x01,
-	0x91, 0x31, 0xbb, 0x24, 0x9e, 0xde, 0x87, 0x81, 0x38, 0xbf, 0x81, 0x75, 0xe1, 0x40, 0xe6, 0xec,
-	0xf7, 0xa1, 0x29, 0xa0, 0xfc, 0xf4, 0xa6, 0xcb, 0x99, 0x64, 0xf4, 0x95, 0xcc, 0x0e, 0xfa, 0x8a,
-	0x33, 0x94, 0x10, 0xa8, 0x73, 0x1f, 0x56, 0xcf, 0x62, 0x2e, 0x97, 0xfb, 0x5d, 0x4c, 0x22, 0x9e,
-	0x70, 0x97, 0xe6, 0x72, 0xff, 0x04, 0xd6, 0x12, 0xee, 0x68, 0xc2, 0x68, 0x44, 0xae, 0x66, 0x7f,
-	0x0b, 0xab, 0x2f, 0x89, 0x39, 0xf9, 0x26, 0x58, 0x87, 0x6c, 0x90, 0x26, 0x29, 0x35, 0x40, 0x6d,
-	0x68, 0x1c, 0x31, 0xd7, 0x53, 0xf1, 0x53, 0x96, 0x85, 0x90, 0x9d, 0x6d, 0xa6, 0xcf, 0x5d, 0x1e,
-	0x47, 0x38, 0x63, 0x11, 0x5a, 0x24, 0xd3, 0x5e, 0x47, 0x8b, 0x13, 0xb0, 0x0f, 0x48, 0x40, 0x38,
-	0xf9, 0xa4, 0x22, 0xf7, 0x60, 0x55, 0xe6, 0x12, 0x77, 0x10, 0x08, 0xe6, 0x48, 0x97, 0xaf, 0x79,
-	0xd0, 0x39, 0x85, 0x0d, 0x63, 0x3e, 0xad, 0x41, 0x0b, 0x6a, 0xfd, 0x78, 0x38, 0x24, 0x51, 0xa4,
-	0xeb, 0xd1, 0x64, 0xa8, 0x1c, 0x55, 0xb0, 0x77, 0x59, 0x4c, 0xb9, 0x9c, 0xd2, 0xc2, 0x26, 0xe4,
-	0xfc, 0xa7, 0x04, 0xeb, 0x47, 0x7e, 0x24, 0x76, 0x14, 0x19, 0x0a, 0xaa, 0x2c, 0x5d, 0x32, 0xb3,
-	0x74, 0x92, 0x6b, 0xa3, 0x53, 0x1a, 0x4c, 0xb5, 0x76, 0x06, 0x22, 0xe8, 0x6f, 0xfc, 0x31, 0x09,
-	0x15, 0x5d, 0x79, 0xb7, 0x81, 0xe4, 0x2d, 0x5d, 0xfd, 0xa4, 0xa5, 0x45, 0x63, 0x21, 0x2d, 0x93,
-	0xe4, 0x75, 0x3d, 0x12, 0x7b, 0x92, 0x0c, 0xa7, 0xef, 0xde, 0x45, 0x84, 0xcb, 0x90, 0xb0, 0xb0,
-	0x09, 0x49, 0x4d, 0xc4, 0xf0, 0xc8, 0x1f, 0xfb, 0x2a, 0x95, 0x5b, 0xd8, 0x40, 0x9c, 0x0e, 0xd8,
-	0xd9, 0x96, 0xaf, 0x73, 0x8a, 0x58, 0x09, 0xc8, 0x29, 0xae, 0x3e, 0xc5, 0x1d, 0x58, 0x56, 0x3b,
-	0x59, 0xe8, 0x4b, 0x9a, 0xee, 0x3c, 0x82, 0x0d, 0x63, 0x4e, 0xad, 0xc5, 0x1d, 0xa8, 0x0a, 0x60,
-	0x4e, 0x54, 0x49, 0xdc, 0x79, 0x20, 0x63, 0x40, 0x02, 0x5a, 0x8d, 0x4f, 0x49, 0x3c, 0x84, 0xf5,
-	0x54, 0xe2, 0x9a, 0x8b, 0xfc, 0xb9, 0x04, 0x48, 0xb9, 0xc8, 0xbc, 0x0d, 0x7b, 0xe6, 0x86, 0x3d,
-	0x71, 0x4a, 0x82, 0xab, 0x77, 0x90, 0xb4, 0x7f, 0x6a, 0x64, 0x18, 0xa2, 0xb2, 0x5d, 0xb9, 0xca,
-	0x10, 0xe2, 0xb4, 0xce, 0xc2, 0x98, 0x12, 0x75, 0x5a, 0x55, 0x75, 0x5a, 0x19, 0xe2, 0x74, 0xe0,
-	0x46, 0x4e, 0x9b, 0xcc, 0xe9, 0x15, 0x2c, 0x14, 0x12, 0x2b, 0x27, 0x43, 0xa7, 0x03, 0xb7, 0x0e,
-	0x08, 0x27, 0x43, 0xde, 0xe7, 0xf1, 0xf0, 0xa2, 0xb8, 0x87, 0xbe, 0x4f, 0x87, 0x2a, 0x9b, 0x5b,
-	0x58, 0x0d, 0x9c, 0x67, 0xd0, 0x9a, 0x15, 0xd0, 0xcb, 0x38, 0xb0, 0xf2, 0xc2, 0xff, 0x48, 0xa4,
-	0x4f, 0xf6, 0xbc, 0x48, 0xaf, 0x95, 0xc3, 0x9c, 0xff, 0x96, 0x95, 0x45, 0xe7, 0x15, 0x41, 0xca,
-	0x47, 0xca, 0xf3, 0x7d, 0xa4, 0x72, 0xb5, 0x8f, 0x88, 0x9c, 0xa0, 0xbe, 0x8e, 0x49, 0x14, 0xb9,
-	0xa3, 0xe4, 0x36, 0xc9, 0x83, 0x42, 0xc5, 0x37, 0xa1, 0x3f, 0x1a, 0x91, 0x50, 0x45, 0xad, 0xba,
-	0x3f, 0x72, 0x98, 0xb8, 0x79, 0x64, 0x1d, 0x23, 0xe2, 0x51, 0x87, 0x4c, 0x06, 0x08, 0x5b, 0x3e,
-	0xa7, 0x9e, 0xa4, 0xa9, 0x68, 0x49, 0x86, 0xb2, 0x1b, 0x75, 0x69, 0x9f, 0xb3, 0x49, 0xab, 0xae,
-	0xbb, 0x51, 0x35, 0x14, 0x45, 0x5b, 0xd7, 0xa5, 0x67, 0x6e, 0x1c, 0x11, 0x59, 0xc4, 0xd4, 0x71,
-	0x3a, 0x16, 0x21, 0xfa, 0xca, 0x8d, 0xce, 0x42, 0x36, 0x0a, 0x45, 0x52, 0x02, 0x49, 0x36, 0x21,
-	0x21, 0x9d, 0x92, 0x45, 0x35, 0x55, 0xc6, 0xe9, 0x18, 0x3d, 0x84, 0xa6, 0xae, 0x97, 0x8e, 0xd8,
-	0x28, 0xa9, 0x64, 0xd7, 0xcd, 0x82, 0xea, 0x88, 0x8d, 0xb0, 0xc9, 0xe3, 0x5c, 0x42, 0xb3, 0xcb,
-	0xc3, 0xa0, 0xcb, 0xc6, 0x63, 0x97, 0x7a, 0xe8, 0x2e, 0x54, 0xba, 0x63, 0x4f, 0xf7, 0xb5, 0xab,
-	0x49, 0xc5, 0x20, 0x69, 0x58, 0x50, 0x32, 0x5f, 0x2e, 0xcf, 0xf3, 0x65, 0x4f, 0x97, 0xa7, 0x7a,
-	0x24, 0x8c, 0x20, 0xad, 0xd8, 0xf3, 0xf4, 0x01, 0x24, 0x43, 0xe7, 0xff, 0xe1, 0x86, 0xb1, 0x6e,
-	0xea, 0x34, 0x36, 0x54, 0x8e, 0xa3, 0x51, 0x52, 0x40, 0x1f, 0x47, 0x23, 0xe7, 0x6f, 0x25, 0x68,
-	0xa4, 0xba, 0xa3, 0x7b, 0x49, 0x53, 0xa9, 0x63, 0x30, 0x5f, 0x2d, 0x26, 0x0d, 0xe7, 0xcf, 0x60,
-	0xa5, 0x47, 0x27, 0x31, 0x4f, 0x0e, 0x3f, 0xd7, 0x27, 0x2a, 0x1e, 0x4d, 0xc2, 0x39, 0x46, 0xd1,
-	0x26, 0xaa, 0xee, 0x26, 0x91, 0xac, 0x2c, 0x96, 0xcc, 0x73, 0x3a, 0x17, 0xb0, 0x7e, 0xc8, 0x06,
-	0xda, 0x75, 0xd4, 0x85, 0x3f, 0x3f, 0xd1, 0x99, 0x95, 0x75, 0xf9, 0x13, 0x95, 0xf5, 0x4d, 0x58,
-	0xc6, 0x31, 0x3d, 0x61, 0x1f, 0xf4, 0xad, 0xa0, 0x47, 0xce, 0x3f, 0x4b, 0xb0, 0x62, 0x36, 0x63,
-	0x57, 0x5c, 0x64, 0x2d, 0xa8, 0x61, 0xf7, 0xc3, 0x8f, 0xcc, 0x53, 0x37, 0xcf, 0x0a, 0x4e, 0x86,
-	0x22, 0x7d, 0xf4, 0x79, 0xe8, 0xd3, 0x91, 0x24, 0xaa, 0x83, 0x33, 0x10, 0xe1, 0x69, 0xa2, 0x5c,
-	0x92, 0xd4, 0xaa, 0x14, 0x4d, 0xc7, 0xc2, 0x4f, 0x9f, 0x87, 0x21, 0x0b, 0x15, 0xbb, 0x0e, 0x1c,
-	0x13, 0x12, 0xeb, 0xf6, 0x46, 0x94, 0x85, 0xc4, 0x93, 0x51, 0x53, 0xc7, 0xc9, 0x50, 0x56, 0x6b,
-	0x59, 0xc0, 0xc8, 0x6f, 0xe7, 0xaf, 0x55, 0xb8, 0x65, 0x6e, 0xa8, 0xf0, 0xf6, 0xd1, 0x8b, 0xf2,
-	0xbb, 0xcb, 0x00, 0xb4, 0x0b, 0x76, 0xa6, 0x33, 0x26, 0x23, 0xf2, 0x71, 0xa2, 0x7d, 0x73, 0x06,
-	0x47, 0x3f, 0xc0, 0x97, 0x19, 0xd6, 0xf7, 0x7f, 0x4f, 0x5e, 0x86, 0xc4, 0xe5, 0x24, 0x7c, 0x73,
-	0xee, 0x52, 0x69, 0x00, 0x0b, 0x2f, 0x66, 0x98, 0x95, 0xee, 0x8f, 0xdd, 0x20, 0xd0, 0xd2, 0xd5,
-	0x79, 0xd2, 0x06, 0x83, 0x68, 0x60, 0x12, 0xeb, 0x69, 0x2d, 0x95, 0xd1, 0x0a, 0xa8, 0xc9, 0xf7,
-	0xca, 0x8d, 0x7e, 0x41, 0xa6, 0xba, 0x74, 0x2d, 0xa0, 0xe8, 0x31, 0xdc, 0x4a, 0x90, 0xe2, 0x4e,
-	0x94, 0x61, 0x17, 0x91, 0x8b, 0x92, 0xe6, 0x2e, 0xea, 0xb3, 0x92, 0xe6, 0x1e, 0x74, 0x79, 0x20,
-	0x4e, 0xec, 0x25, 0xd7, 0x0d, 0x98, 0x81, 0x98, 0xf4, 0x23, 0x2e, 0x93, 0x97, 0x41, 0x3f, 0x12,
-	0x15, 0xf0, 0x86, 0xe1, 0x22, 0xda, 0x0c, 0x4d, 0xb9, 0xbd, 0x59, 0x82, 0xc8, 0x05, 0x27, 0x8c,
-	0xeb, 0xe6, 0x4d, 0x7c, 0x3a, 0xff, 0x2e, 0xc3, 0x6a, 0x2e, 0x08, 0xd1, 0x2e, 0x58, 0x32, 0xd6,
-	0x74, 0x3a, 0xd8, 0x6c, 0xab, 0x67, 0xe7, 0x76, 0xf2, 0xec, 0xdc, 0xde, 0xa7, 0x53, 0xac, 0x58,
-	0x44, 0xb3, 0x27, 0xbb, 0x35, 0xfd, 0xcc, 0x08, 0x6d, 0xf9, 0x48, 0x2c, 0x20, 0xac, 0x08, 0xd9,
-	0x43, 0x64, 0x65, 0xc1, 0x43, 0xe4, 0x5d, 0xb0, 0x30, 0x0b, 0x64, 0x3b, 0x91, 0x31, 0x08, 0x04,
-	0x2b, 0x1c, 0xb5, 0x01, 0x7e, 0xc5, 0xc2, 0x8b, 0x68, 0xe2, 0x8a, 0xa6, 0x43, 0xbd, 0x63, 0xac,
-	0x49, 0xae, 0x14, 0xc6, 0x06, 0x07, 0xba, 0x0d, 0xd5, 0xfd, 0x61, 0x90, 0xf4, 0xbe, 0x75, 0xc9,
-	0xb9, 0xdf, 0x3d, 0xc2, 0x12, 0x45, 0x0f, 0x00, 0xf6, 0xd5, 0xe3, 0xb2, 0x4f, 0x44, 0xe5, 0x27,
-	0x78, 0xec, 0x76, 0xf2, 0xde, 0xdc, 0x3e, 0x1d, 0xbc, 0x27, 0x43, 0x8e, 0x0d, 0x1e, 0xf4, 0x2d,
-	0x34, 0x55, 0x00, 0xc9, 0xb7, 0x0e, 0x59, 0xff, 0xa5, 0x9d, 0x9b, 0x19, 0x5f, 0xd8, 0x64, 0xdb,
-	0x7d, 0x0a, 0xeb, 0x85, 0xf7, 0x60, 0x54, 0x87, 0xaa, 0xd8, 0xb2, 0xbd, 0x24, 0xbe, 0xc4, 0xde,
-	0xec, 0x12, 0x5a, 0x85, 0x46, 0xaa, 0xba, 0x5d, 0x46, 0x35, 0xa8, 0xec, 0x0f, 0x03, 0xbb, 0xb2,
-	0xfb, 0x04, 0xbe, 0x98, 0xfb, 0xf6, 0x89, 0xd6, 0xa1, 0xa9, 0xeb, 0x07, 0x41, 0xb0, 0x97, 0x04,
-	0xa0, 0x39, 0xe5, 0xe4, 0xa5, 0xdd, 0x3f, 0x28, 0x8f, 0xd1, 0xb7, 0x76, 0x13, 0x6a, 0x6f, 0xe9,
-	0x05, 0x65, 0x1f, 0xa8, 0x5a, 0xb7, 0xe7, 0xc9, 0x75, 0x9b, 0x50, 0xc3, 0x31, 0xa5, 0x3e, 0x1d,
-	0xd9, 0x65, 0xb4, 0x02, 0xf5, 0x17, 0x3e, 0xf5, 0xa3, 0x73, 0xe2, 0xd9, 0x15, 0x31, 0x61, 0x8f,
-	0x72, 0x12, 0x86, 0xf1, 0x84, 0x13, 0xcf, 0xae, 0x22, 0x80, 0x65, 0x79, 0x93, 0x7a, 0xb6, 0x25,
-	0x15, 0xa4, 0x53, 0x7b, 0x19, 0x35, 0xc0, 0x92, 0xee, 0x65, 0xd7, 0x04, 0xfd, 0x75, 0x4c, 0x62,
-	0xe2, 0xd9, 0xf5, 0xdd, 0x11, 0xd4, 0x92, 0x7b, 0xaf, 0x0e, 0xd5, 0x13, 0x46, 0x89, 0xbd, 0x24,
-	0x78, 0xe5, 0x04, 0x76, 0x49, 0xf0, 0x62, 0x12, 0xc5, 0x63, 0xb1, 0xd9, 0x3a, 0x54, 0xc5, 0xe5,
-	0x6d, 0x57, 0x04, 0xaa, 0xea, 0x25, 0xbb, 0xaa, 0x35, 0x3b, 0xa5, 0x43, 0x62, 0x5b, 0x42, 0xb3,
-	0xe4, 0xf1, 0xc5, 0x5e, 0x16, 0x6c, 0xfb, 0xea, 0xbb, 0xb6, 0xf7, 0xaf, 0xaa, 0xec, 0x16, 0xfb,
-	0xea, 0xcd, 0x17, 0x7d, 0x07, 0xcb, 0xaa, 0x1f, 0x43, 0xfa, 0x62, 0xc9, 0xf5, 0x72, 0x5b, 0x9b,
-	0x79, 0x50, 0xdd, 0x8c, 0xce, 0x92, 0x10, 0x53, 0x0d, 0x54, 0x22, 0x96, 0xeb, 0xd2, 0x12, 0xb1,
-	0x7c, 0x8f, 0xe5, 0x2c, 0xa1, 0x67, 0xd0, 0x48, 0x1b, 0x1f, 0x74, 0x53, 0x31, 0x15, 0x3b, 0xab,
-	0xad, 0x5b, 0x33, 0x78, 0x2a, 0xff, 0x14, 0xea, 0x49, 0xcd, 0x8f, 0xf4, 0x8f, 0x07, 0x85, 0xb6,
-	0x67, 0xeb, 0x66, 0x11, 0x4e, 0x84, 0x1f, 0x94, 0xd0, 0x63, 0xa8, 0xe9, 0x32, 0x1a, 0x65, 0x1b,
-	0x33, 0xea, 0xf0, 0xad, 0x2f, 0x0a, 0x68, 0xba, 0xf0, 0x8f, 0xb2, 0xc9, 0x55, 0xee, 0x11, 0x12,
-	0x77, 0xfc, 0x99, 0xf2, 0x3b, 0xa5, 0x07, 0x25, 0xf4, 0x73, 0x68, 0xa4, 0xbd, 0x02, 0x32, 0xd4,
-	0x34, 0x6b, 0xdb, 0x64, 0xf3, 0x33, 0x4d, 0x85, 0xd4, 0xff, 0x20, 0x69, 0x04, 0xd5, 0x1c, 0x2d,
-	0xd3, 0x50, 0xb9, 0x59, 0xbe, 0x9c, 0x43, 0x49, 0xf7, 0xf2, 0x5a, 0x74, 0xb3, 0xf9, 0x42, 0x19,
-	0xfd, 0x5f, 0x22, 0x30, 0xb7, 0xe2, 0xde, 0xba, 0xb3, 0x88, 0xac, 0x26, 0xdd, 0x7b, 0xa5, 0xba,
-	0xb9, 0xc4, 0xa9, 0x9e, 0x08, 0x67, 0xa6, 0x3c, 0x64, 0x01, 0xd2, 0x4f, 0x44, 0x46, 0x7d, 0x95,
-	0x28, 0x37, 0xa7, 0xe4, 0x72, 0x96, 0x06, 0xcb, 0x32, 0x5b, 0x3e, 0xfa, 0x5f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x70, 0x04, 0xd5, 0x14, 0xc6, 0x1b, 0x00, 0x00,
+	// 3812 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3a, 0x4d, 0x73, 0x1c, 0xc9,
+	0x52, 0x9e, 0x2f, 0x69, 0x26, 0xf5, 0xd5, 0x2e, 0xcb, 0x52, 0x6b, 0xd6, 0xcf, 0xeb, 0xe8, 0x30,
+	0x0f, 0xaf, 0xde, 0x32, 0xb2, 0xe5, 0xf7, 0x1e, 0xf6, 0xb2, 0xbb, 0x81, 0x3c, 0xf2, 0xc7, 0x08,
+	0x7d, 0x6d, 0x8d, 0xf7, 0x2d, 0xc1, 0x57, 0x44, 0x6b, 0xba, 0x3c, 0x6a, 0xab, 0xa7, 0x7b, 0x5e,
+	0x77, 0x8d, 0xad, 0x81, 0x1b, 0x37, 0x08, 0x82, 0x3b, 0x11, 0x70, 0xe2, 0x04, 0x07, 0x4e, 0xdc,
+	0xb9, 0x10, 0x1c, 0xb9, 0xc1, 0x9f, 0xe0, 0xc4, 0x89, 0x2b, 0x91, 0xf5, 0xd1, 0x5d, 0xdd, 0xd3,
+	0x23, 0xc9, 0x6f, 0x0f, 0x52, 0x74, 0xe5, 0x47, 0x55, 0x66, 0x55, 0x66, 0x56, 0x66, 0xd6, 0x00,
+	0xbc, 0x8f, 0xce, 0x92, 0xce, 0x38, 0x8e, 0x78, 0x44, 0xea, 0xf8, 0xdd, 0xde, 0x1a, 0x46, 0xd1,
+	0x30, 0x60, 0x3b, 0x02, 0x76, 0x36, 0x79, 0xb7, 0xe3, 0x86, 0x53, 0x49, 0xd0, 0x7e, 0x36, 0xf4,
+	0xf9, 0xf9, 0xe4, 0xac, 0x33, 0x88, 0x46, 0x3b, 0xe3, 0xa9, 0xe7, 0x47, 0x3b, 0x03, 0x16, 0x04,
+	0xc9, 0xce, 0x20, 0x1a, 0x8d, 0xa2, 0x70, 0x27, 0x61, 0xf1, 0x07, 0x7f, 0xa0, 0x38, 0x15, 0x50,
+	0x71, 0x3e, 0xbd, 0x9a, 0x53, 0x72, 0xf0, 0x98, 0x31, 0xf1, 0x4f, 0x31, 0x3d, 0xb9, 0x09, 0x93,
+	0xef, 0x8d, 0xf0, 0x4f, 0xb1, 0xec, 0xdd, 0x84, 0xc5, 0x1d, 0x70, 0xff, 0x83, 0xcf, 0xa7, 0xe9,
+	0x47, 0xc2, 0x63, 0xe6, 0xaa, 0x29, 0x9c, 0x29, 0xac, 0x1c, 0x47, 0x1e, 0x4b, 0xfa, 0x2c, 0x60,
+	0x03, 0x1e, 0xc5, 0xc4, 0x82, 0xda, 0x5e, 0x10, 0xd8, 0x95, 0x07, 0x95, 0x47, 0x4d, 0x8a, 0x9f,
+	0x64, 0x03, 0x16, 0x4e, 0x5d, 0x7e, 0xce, 0x12, 0xbb, 0xfa, 0xa0, 0xf6, 0xa8, 0x45, 0xd5, 0x88,
+	0x3c, 0x84, 0xc6, 0x77, 0x13, 0x16, 0x4f, 0xed, 0xfa, 0x83, 0xca, 0xa3, 0xa5, 0xdd, 0xd5, 0x8e,
+	0xda, 0x91, 0x8e, 0x80, 0x52, 0x89, 0x24, 0x36, 0x2c, 0x76, 0xa3, 0x00, 0x27, 0xb7, 0x1b, 0x62,
+	0x4e, 0x3d, 0x74, 0xfe, 0xba, 0x02, 0x4b, 0x3d, 0x6f, 0x94, 0xae, 0xfc, 0x05, 0xd4, 0xdf, 0x4e,
+	0xc7, 0x4c, 0x2c, 0xbd, 0xba, 0x7b, 0xb7, 0x23, 0xce, 0xca, 0x20, 0x40, 0x24, 0x15, 0x24, 0x5a,
+	0xc8, 0x6a, 0x26, 0x64, 0x2a, 0x4c, 0xed, 0x86, 0xc2, 0xd4, 0xf3, 0xc2, 0xfc, 0x65, 0x05, 0x56,
+	0xbe, 0x4f, 0x58, 0x7c, 0xd5, 0x46, 0x7c, 0x0e, 0x0d, 0x41, 0x22, 0xf6, 0x61, 0x69, 0xb7, 0xd5,
+	0xc1, 0x93, 0x40, 0x08, 0x95, 0xf0, 0x4f, 0x17, 0xa2, 0xb0, 0x23, 0x5f, 0x01, 0xd9, 0x1b, 0x70,
+	0x3f, 0x0a, 0x4f, 0x26, 0x7c, 0x3c, 0xe1, 0xaf, 0xfc, 0x80, 0xb3, 0x38, 0x9b, 0xb5, 0x72, 0xc5,
+	0xac, 0xce, 0x7b, 0xb8, 0xdd, 0x8d, 0x42, 0xce, 0x2e, 0xf9, 0x11, 0xe3, 0xae, 0x62, 0xdd, 0xc9,
+	0x6d, 0xe9, 0x67, 0x72, 0x4b, 0x67, 0xc8, 0x8c, 0x8d, 0x4d, 0xd7, 0xaa, 0x5e, 0xbd, 0xd6, 0x86,
+	0x31, 0x49, 0xdf, 0x0f, 0x87, 0x01, 0x93, 0xba, 0xdd, 0x83, 0xd6, 0x2b, 0x9f, 0x05, 0xde, 0xb1,
+	0x3b, 0x92, 0xab, 0xb6, 0x68, 0x06, 0x20, 0xbb, 0xd0, 0xea, 0x46, 0xa1, 0xe7, 0xa3, 0x8a, 0x6a,
+	0x85, 0x75, 0xb1, 0x89, 0xa7, 0x51, 0xe0, 0x0f, 0xa6, 0x29, 0x8e, 0x66, 0x64, 0xce, 0xdf, 0x56,
+	0xa0, 0xd9, 0x1f, 0x9c, 0x33, 0x6f, 0x12, 0x30, 0xf2, 0x08, 0xd6, 0x7a, 0x49, 0xf4, 0xec, 0x97,
+	0x8f, 0x9f, 0x68, 0x90, 0x5a, 0xa4, 0x08, 0x36, 0x28, 0x8f, 0xfc, 0x70, 0x9f, 0x05, 0xdc, 0xb5,
+	0x6b, 0x39, 0x4a, 0x0d, 0x26, 0x04, 0xea, 0xdd, 0x38, 0x0a, 0x85, 0x41, 0xb4, 0xa8, 0xf8, 0x26,
+	0x6d, 0x68, 0xbe, 0xf5, 0x47, 0xec, 0x8f, 0xa2, 0x90, 0x89, 0x33, 0x6a, 0xd1, 0x74, 0xec, 0xfc,
+	0xcf, 0x02, 0x2c, 0xc8, 0x53, 0x22, 0xab, 0x50, 0xed, 0xed, 0x2b, 0x09, 0xaa, 0xbd, 0x7d, 0xb2,
+	0x0e, 0x8d, 0x43, 0xf7, 0x8c, 0x05, 0xf6, 0x8a, 0x00, 0xc9, 0x01, 0x79, 0x00, 0x4b, 0xfb, 0x2c,
+	0x19, 0xc4, 0xfe, 0x58, 0xe8, 0xbd, 0x2a, 0x70, 0x26, 0x88, 0x3c, 0x2f, 0x38, 0xa1, 0xda, 0x9b,
+	0x3b, 0xf2, 0xbc, 0x72, 0x28, 0x5a, 0x70, 0xd7, 0xe7, 0x05, 0xb3, 0xb5, 0x6b, 0x26, 0x6b, 0x0e,
+	0x45, 0x0b, 0x06, 0xfe, 0x0b, 0x58, 0x12, 0x73, 0x49, 0x23, 0xb0, 0xeb, 0x26, 0x63, 0x7e, 0x4d,
+	0x93, 0x0e, 0xd9, 0xc4, 0x3c, 0x8a, 0xad, 0x31, 0x7f, 0x3d, 0x93, 0x8e, 0x3c, 0xcd, 0x39, 0xbb,
+	0xdd, 0x12, 0x6c, 0xb7, 0x67, 0x9c, 0x9c, 0x9a, 0x54, 0x64, 0x07, 0x5a, 0x3d, 0x6f, 0xa4, 0x56,
+	0x82, 0x79, 0x2c, 0x19, 0x0d, 0x79, 0x53, 0xe6, 0x41, 0xf6, 0x82, 0xe0, 0xb4, 0x25, 0xe7, 0x2c,
+	0x9e, 0x96, 0x79, 0xdd, 0xcb, 0x12, 0x7f, 0xb2, 0x97, 0xc4, 0x44, 0x9b, 0x73, 0xfc, 0x88, 0xce,
+	0x72, 0x90, 0xaf, 0x01, 0x4e, 0xdd, 0xd8, 0x1d, 0x31, 0x8e, 0x81, 0x63, 0x51, 0x04, 0x8e, 0x7b,
+	0xa6, 0x20, 0x9d, 0x0c, 0xfd, 0x32, 0xe4, 0xf1, 0x94, 0x1a, 0xf4, 0xe4, 0xe7, 0xb0, 0xda, 0x3d,
+	0x77, 0xfd, 0x90, 0x79, 0x92, 0x38, 0xb1, 0x9b, 0x62, 0x86, 0x65, 0x73, 0x06, 0x5a, 0xa0, 0x21,
+	0xdf, 0xc2, 0x9d, 0x57, 0xae, 0x1f, 0x30, 0x4f, 0xca, 0xa0, 0x59, 0x97, 0x4b, 0x58, 0xcb, 0x08,
+	0x31, 0xe0, 0x1f, 0x44, 0x7e, 0xd8, 0xdb, 0xb7, 0xd7, 0x84, 0xad, 0xaa, 0x11, 0x1e, 0x21, 0x65,
+	0x3c, 0x9e, 0x4a, 0x6f, 0xb5, 0x2d, 0xf3, 0x3c, 0x0c, 0x04, 0x35, 0xa9, 0xda, 0xdf, 0xc0, 0x5a,
+	0x41, 0x43, 0x8c, 0xac, 0x17, 0x6c, 0xaa, 0xfc, 0x06, 0x3f, 0xd1, 0x71, 0x3e, 0xb8, 0xc1, 0x84,
+	0x09, 0xc3, 0x6f, 0x51, 0x39, 0xf8, 0xaa, 0xfa, 0xac, 0xe2, 0xfc, 0xc7, 0x02, 0xd4, 0x0e, 0xa2,
+	0xb3, 0xf9, 0xae, 0x56, 0x35, 0x5d, 0x6d, 0x1d, 0x1a, 0x27, 0x1f, 0x43, 0x16, 0x2b, 0x5f, 0x97,
+	0x03, 0xf4, 0xe6, 0x5e, 0x28, 0x6e, 0x3f, 0xa6, 0xc2, 0x7e, 0x3a, 0xc6, 0x80, 0x75, 0xe8, 0x86,
+	0xc3, 0x89, 0x3b, 0x64, 0x89, 0x0d, 0xe2, 0x7e, 0xcb, 0x00, 0xe4, 0x3e, 0xc0, 0xcb, 0x0f, 0x2c,
+	0xe4, 0x18, 0xbd, 0x12, 0xbb, 0x21, 0xd0, 0x06, 0x84, 0x6c, 0x67, 0xb1, 0x49, 0x19, 0xd9, 0xaa,
+	0xdc, 0x0e, 0x0d, 0xa5, 0x29, 0x1e, 0x57, 0xda, 0x9b, 0xf0, 0xa8, 0xcf, 0xdd, 0x98, 0xdb, 0x8b,
+	0x42, 0x8c, 0x0c, 0xa0, 0xb1, 0xdd, 0x80, 0xb9, 0xa1, 0xbd, 0x94, 0x61, 0x05, 0x80, 0xfc, 0x14,
+	0x16, 0xaf, 0x32, 0x00, 0x8d, 0x24, 0x3f, 0x85, 0xd5, 0x23, 0xf7, 0xb2, 0x1b, 0x85, 0x83, 0x49,
+	0x1c, 0xb3, 0x70, 0x30, 0x15, 0x7e, 0xd6, 0xa0, 0x05, 0x28, 0xf9, 0x12, 0x6e, 0xbf, 0x75, 0x93,
+	0x8b, 0xa4, 0xef, 0x07, 0x2c, 0xe4, 0xdf, 0x8f, 0x3d, 0x97, 0x33, 0x7b, 0x59, 0xac, 0x3a, 0x8b,
+	0x20, 0x0f, 0xa0, 0x21, 0x80, 0xf6, 0xaa, 0x58, 0x1b, 0xe4, 0xda, 0x08, 0xa2, 0x12, 0x41, 0xbe,
+	0x81, 0x35, 0x0c, 0x11, 0x62, 0x67, 0x94, 0xab, 0xac, 0xcd, 0x0f, 0x27, 0x45, 0x5a, 0x64, 0xc7,
+	0x50, 0x61, 0xb2, 0x5b, 0xf3, 0xc3, 0x4a, 0x91, 0x36, 0x1f, 0x25, 0x6e, 0xdf, 0x20, 0x4a, 0x94,
+	0xfa, 0x36, 0xf9, 0x64, 0xdf, 0xde, 0xcd, 0xf9, 0xf6, 0x1d, 0xb1, 0x39, 0x44, 0xf2, 0x1f, 0x44,
+	0x67, 0x29, 0x2a, 0xe7, 0xd1, 0x0f, 0xa0, 0x81, 0xde, 0x94, 0xd8, 0xeb, 0xe6, 0x5e, 0x22, 0x88,
+	0x4a, 0x04, 0x79, 0x02, 0xcd, 0xb7, 0xe7, 0x71, 0xc4, 0x79, 0xc0, 0xec, 0xbb, 0x42, 0x26, 0x95,
+	0x0a, 0xbd, 0x8d, 0xfd, 0xe1, 0x90, 0xc5, 0x1a, 0x49, 0x53, 0x32, 0xe2, 0xc0, 0x72, 0xd7, 0x0d,
+	0x58, 0xe8, 0xb9, 0xb1, 0xb8, 0x78, 0x37, 0x84, 0xf5, 0xe7, 0x60, 0xce, 0xbf, 0x54, 0x60, 0xd9,
+	0x94, 0x0a, 0xef, 0x3d, 0xe3, 0x96, 0x16, 0xdf, 0xc5, 0xab, 0xaa, 0x3a, 0x7b, 0x55, 0xad, 0x43,
+	0xe3, 0x57, 0xc2, 0x53, 0xe5, 0x75, 0x29, 0x07, 0x68, 0xbd, 0x47, 0x6e, 0xe8, 0xb9, 0x3c, 0x52,
+	0xc9, 0x4f, 0x93, 0x66, 0x00, 0x5c, 0x49, 0x64, 0x21, 0xf2, 0x26, 0x15, 0xdf, 0xb8, 0xd2, 0x41,
+	0x12, 0x85, 0xdd, 0xf3, 0xc8, 0x1f, 0xb0, 0x44, 0x38, 0x4f, 0x8b, 0x9a, 0x20, 0xe7, 0x8f, 0x61,
+	0xf5, 0x20, 0x3a, 0xeb, 0x9e, 0xbb, 0xe1, 0x50, 0x1a, 0x0b, 0xf9, 0x02, 0xe0, 0x20, 0x3a, 0x93,
+	0x46, 0xe9, 0xa9, 0x6c, 0xa8, 0x95, 0xee, 0x37, 0x35, 0x90, 0xe8, 0xb8, 0x08, 0x62, 0xa3, 0xe8,
+	0x03, 0xf3, 0x94, 0x1e, 0x06, 0xc4, 0xf9, 0x13, 0x58, 0x43, 0xcb, 0x35, 0x67, 0xff, 0x12, 0x96,
+	0x10, 0x94, 0x9f, 0xde, 0xb4, 0x75, 0x13, 0x4d, 0x3e, 0x13, 0x61, 0xc9, 0xae, 0x16, 0x85, 0x40,
+	0xa8, 0xf3, 0x25, 0xac, 0x9c, 0x4e, 0xb8, 0x58, 0xee, 0xd7, 0x13, 0x96, 0x70, 0x4d, 0x5d, 0x29,
+	0xa5, 0xfe, 0x1d, 0x58, 0xd5, 0xd4, 0xc9, 0x38, 0x0a, 0x13, 0x76, 0x35, 0xf9, 0xf7, 0xb0, 0xf2,
+	0x9a, 0x99, 0x93, 0xaf, 0xa3, 0x49, 0x9d, 0xa5, 0xd1, 0x51, 0x0e, 0x48, 0x07, 0x5a, 0x87, 0x91,
+	0xeb, 0x49, 0xc7, 0xad, 0x8a, 0xfc, 0xcf, 0xca, 0x94, 0xe9, 0x73, 0x97, 0x4f, 0x12, 0x9a, 0x91,
+	0xa0, 0x14, 0xaf, 0xd9, 0xcd, 0xa5, 0x38, 0x06, 0x6b, 0x9f, 0x05, 0x8c, 0xb3, 0x6b, 0x05, 0x79,
+	0x08, 0x2b, 0x22, 0x88, 0xb9, 0x67, 0x01, 0x12, 0x27, 0x2a, 0x6b, 0xcf, 0x03, 0x9d, 0x13, 0xb8,
+	0x6d, 0xcc, 0xa7, 0x24, 0xb0, 0x61, 0xb1, 0x3f, 0x19, 0x0c, 0x58, 0x92, 0xa8, 0x34, 0x5c, 0x0f,
+	0xa5, 0xa1, 0x22, 0x79, 0x37, 0x9a, 0x84, 0x5c, 0x4c, 0xd9, 0xa0, 0x26, 0xc8, 0xf9, 0xdf, 0x0a,
+	0xac, 0x1d, 0xfa, 0x09, 0x6a, 0x94, 0x18, 0x02, 0xca, 0xeb, 0xa1, 0x62, 0x5e, 0x0f, 0x3a, 0xc8,
+	0x27, 0x27, 0x61, 0x30, 0x55, 0xd2, 0x19, 0x10, 0xc4, 0x63, 0xf2, 0x17, 0x4b, 0xbc, 0xb4, 0x6e,
+	0x03, 0x92, 0xdf, 0xe9, 0xfa, 0xb5, 0x3b, 0x2d, 0xaf, 0xd7, 0xb3, 0xde, 0xbe, 0xbe, 0x50, 0xd4,
+	0x08, 0x75, 0x12, 0x04, 0x27, 0xef, 0xde, 0x25, 0x8c, 0x0b, 0x97, 0x68, 0x50, 0x13, 0x24, 0x24,
+	0xc1, 0xe1, 0xa1, 0x3f, 0xf2, 0xe5, 0x1d, 0xd2, 0xa0, 0x06, 0xc4, 0xd9, 0x01, 0x2b, 0x53, 0xf9,
+	0x26, 0xa7, 0x48, 0x25, 0x83, 0x98, 0xe2, 0xea, 0x53, 0x7c, 0x04, 0x0b, 0x52, 0x93, 0xb9, 0xb6,
+	0xa4, 0xf0, 0xce, 0x53, 0xb8, 0x6d, 0xcc, 0xa9, 0xa4, 0xb8, 0x0f, 0x75, 0x04, 0x94, 0x78, 0x95,
+	0x80, 0x3b, 0x8f, 0x85, 0x0f, 0x08, 0x80, 0x12, 0xe3, 0x3a, 0x8e, 0x27, 0xb0, 0x96, 0x72, 0xdc,
+	0x70, 0x91, 0xbf, 0xa9, 0x00, 0x91, 0x26, 0x52, 0xa6, 0xb0, 0x67, 0x2a, 0xec, 0xe1, 0x29, 0x21,
+	0x55, 0x6f, 0x5f, 0x57, 0xbd, 0x72, 0x64, 0x6c, 0x44, 0xed, 0x41, 0xed, 0xaa, 0x8d, 0xc0, 0xd3,
+	0x3a, 0x8d, 0x27, 0x21, 0x93, 0xa7, 0x55, 0x97, 0xa7, 0x95, 0x41, 0x9c, 0x1d, 0xb8, 0x93, 0x93,
+	0x26, 0x33, 0x7a, 0x09, 0x46, 0x81, 0x70, 0x65, 0x3d, 0x74, 0x76, 0x60, 0x73, 0x9f, 0x71, 0x36,
+	0xe0, 0x7d, 0x3e, 0x19, 0x5c, 0x14, 0x75, 0xe8, 0xfb, 0xe1, 0x40, 0x46, 0xf3, 0x06, 0x95, 0x03,
+	0xe7, 0x5b, 0xb0, 0x67, 0x19, 0xd4, 0x32, 0x0e, 0x2c, 0xbf, 0xf2, 0x2f, 0x99, 0xb0, 0xc9, 0x9e,
+	0x97, 0xa8, 0xb5, 0x72, 0x30, 0xe7, 0x9f, 0x6a, 0x72, 0x47, 0xcb, 0xb2, 0x2f, 0x69, 0x23, 0xd5,
+	0x72, 0x1b, 0xa9, 0x5d, 0x6d, 0x23, 0x18, 0x13, 0xe4, 0xd7, 0x11, 0x4b, 0x12, 0x77, 0xa8, 0x6f,
+	0x93, 0x3c, 0x10, 0x45, 0x54, 0x77, 0x9e, 0xf4, 0x5a, 0x79, 0x7f, 0xe4, 0x60, 0x78, 0xf3, 0x88,
+	0x04, 0x0a, 0xfd, 0x51, 0xb9, 0x4c, 0x06, 0xc0, 0xbd, 0x7c, 0x19, 0x7a, 0x02, 0x27, 0xbd, 0x45,
+	0x0f, 0x11, 0xd3, 0x75, 0xc3, 0x3e, 0x8f, 0xc6, 0x76, 0x53, 0x15, 0xe1, 0x72, 0x88, 0xd9, 0x62,
+	0xd7, 0x0d, 0x4f, 0xdd, 0x49, 0xc2, 0x44, 0xf6, 0xd4, 0xa4, 0xe9, 0x18, 0x5d, 0xf4, 0x8d, 0x9b,
+	0x9c, 0xc6, 0xd1, 0x30, 0xc6, 0xa0, 0x04, 0x02, 0x6d, 0x82, 0x90, 0x3b, 0x45, 0x63, 0x1a, 0x57,
+	0xa5, 0xe9, 0x98, 0x3c, 0x81, 0x25, 0x95, 0xa8, 0x1d, 0x46, 0x43, 0x9d, 0x8f, 0xaf, 0x99, 0x99,
+	0xdc, 0x61, 0x34, 0xa4, 0x26, 0x0d, 0x96, 0xb1, 0xfd, 0x0b, 0x7f, 0x3c, 0x66, 0x9e, 0xd2, 0x3a,
+	0x11, 0xb5, 0x65, 0x83, 0x16, 0xc1, 0xce, 0x07, 0x58, 0xea, 0xf2, 0x38, 0xe8, 0x46, 0xa3, 0x91,
+	0x1b, 0x7a, 0xe4, 0x73, 0xa8, 0x75, 0x47, 0x9e, 0x2a, 0xfc, 0x57, 0x74, 0x52, 0x23, 0x70, 0x14,
+	0x31, 0x99, 0xd5, 0x57, 0xcb, 0xac, 0xde, 0x53, 0x19, 0xb4, 0x1a, 0xe1, 0x76, 0x89, 0xfd, 0xee,
+	0x79, 0xea, 0xa8, 0xf4, 0xd0, 0xf9, 0x6d, 0xb8, 0x63, 0xac, 0x9b, 0x9a, 0x97, 0x05, 0xb5, 0xa3,
+	0x64, 0xa8, 0x73, 0xfc, 0xa3, 0x64, 0xe8, 0xfc, 0x77, 0x05, 0x5a, 0xa9, 0x96, 0xe4, 0xa1, 0x2e,
+	0xa2, 0x95, 0xb7, 0xe6, 0x13, 0x5a, 0x85, 0x23, 0xbf, 0x0b, 0xcb, 0xbd, 0x70, 0x3c, 0xe1, 0xda,
+	0x4c, 0x72, 0x75, 0xb1, 0xa4, 0x51, 0x28, 0x9a, 0x23, 0xc4, 0xb2, 0x58, 0x56, 0x73, 0x9a, 0xb3,
+	0x36, 0x9f, 0x33, 0x4f, 0x49, 0x76, 0xa0, 0xb9, 0xc7, 0x39, 0x1b, 0x8d, 0x39, 0x46, 0xf3, 0x5a,
+	0x91, 0x4b, 0xe1, 0x68, 0x4a, 0xe4, 0x5c, 0xc0, 0xda, 0x41, 0x74, 0xa6, 0x0e, 0x42, 0xe6, 0x12,
+	0xe5, 0x31, 0xd4, 0xac, 0x16, 0xaa, 0xd7, 0x54, 0x0b, 0x1b, 0xb0, 0x40, 0x27, 0xe1, 0x71, 0xf4,
+	0x51, 0x5d, 0x38, 0x6a, 0xe4, 0xfc, 0x67, 0x05, 0x96, 0xcd, 0x6a, 0xf5, 0x8a, 0x3b, 0xd2, 0x86,
+	0x45, 0xea, 0x7e, 0x7c, 0x11, 0x79, 0xf2, 0x52, 0x5b, 0xa6, 0x7a, 0x88, 0x91, 0xa9, 0xcf, 0x63,
+	0x3f, 0x1c, 0x0a, 0xa4, 0x3c, 0x69, 0x03, 0x82, 0x46, 0x8c, 0x99, 0x98, 0xc0, 0xd6, 0x05, 0x6b,
+	0x3a, 0x46, 0x17, 0x78, 0x19, 0xc7, 0x51, 0x2c, 0xc9, 0x95, 0x4f, 0x9a, 0x20, 0x5c, 0xb7, 0x37,
+	0x0c, 0xa3, 0x98, 0x79, 0xc2, 0x21, 0x9b, 0x54, 0x0f, 0x45, 0x22, 0x98, 0xf9, 0xa2, 0xf8, 0x76,
+	0xfe, 0xb1, 0x0e, 0x9b, 0xa6, 0x42, 0x85, 0x6e, 0x52, 0x2f, 0xc9, 0x6b, 0x97, 0x01, 0xc8, 0x36,
+	0x58, 0x99, 0xcc, 0x94, 0x0d, 0xd9, 0xe5, 0x58, 0x19, 0xf3, 0x0c, 0x9c, 0x7c, 0x0d, 0x5b, 0x19,
+	0xac, 0xef, 0xff, 0x39, 0x7b, 0x1d, 0x33, 0x17, 0x5b, 0x5f, 0xe7, 0x6e, 0x28, 0x36, 0xa0, 0x41,
+	0xe7, 0x13, 0xcc, 0x72, 0xf7, 0x47, 0x6e, 0x10, 0x28, 0xee, 0x7a, 0x19, 0xb7, 0x41, 0x80, 0x45,
+	0x99, 0xde, 0x3d, 0x25, 0xa5, 0xdc, 0xb4, 0x02, 0xd4, 0xa4, 0x7b, 0xe3, 0x26, 0x7f, 0xc0, 0xa6,
+	0x2a, 0x2b, 0x2e, 0x40, 0xc9, 0x33, 0xd8, 0xd4, 0x90, 0xa2, 0x26, 0x72, 0x63, 0xe7, 0xa1, 0x8b,
+	0x9c, 0xa6, 0x16, 0xcd, 0x59, 0x4e, 0x53, 0x07, 0x95, 0x79, 0xe0, 0x89, 0xbd, 0xe6, 0xaa, 0xa8,
+	0x34, 0x20, 0x26, 0xfe, 0x90, 0xdb, 0x90, 0xc7, 0x1f, 0x62, 0x72, 0x7d, 0xdb, 0x30, 0x11, 0xb5,
+	0x0d, 0x4b, 0x42, 0xbd, 0x59, 0x04, 0x06, 0x8f, 0xe3, 0x88, 0xab, 0x82, 0x14, 0x3f, 0x9d, 0xff,
+	0xaa, 0xc2, 0x4a, 0xce, 0x6b, 0xc9, 0x36, 0x34, 0x84, 0xaf, 0xa9, 0xf8, 0xb1, 0xde, 0x91, 0x8d,
+	0xfc, 0x8e, 0x6e, 0xe4, 0x77, 0xf6, 0xc2, 0x29, 0x95, 0x24, 0x58, 0x74, 0x89, 0x0a, 0x54, 0x35,
+	0x6e, 0xa1, 0x23, 0xda, 0xee, 0x08, 0xa2, 0x12, 0x91, 0xb5, 0x76, 0x6b, 0x73, 0x5a, 0xbb, 0x9f,
+	0x43, 0x83, 0x46, 0x81, 0xa8, 0x54, 0x32, 0x02, 0x84, 0x50, 0x09, 0x27, 0x1d, 0x80, 0x1f, 0xa2,
+	0xf8, 0x22, 0x19, 0xbb, 0x03, 0xa6, 0x1b, 0x3d, 0xab, 0x82, 0x2a, 0x05, 0x53, 0x83, 0x82, 0xdc,
+	0x83, 0xfa, 0xde, 0x20, 0xd0, 0xf5, 0x7c, 0x53, 0x50, 0xee, 0x75, 0x0f, 0xa9, 0x80, 0x92, 0xc7,
+	0x00, 0x7b, 0xb2, 0x5d, 0xef, 0x33, 0x1d, 0x86, 0xac, 0x8e, 0xee, 0xe0, 0x77, 0x4e, 0xce, 0xde,
+	0xb3, 0x01, 0xa7, 0x06, 0x0d, 0xf9, 0x39, 0x2c, 0x49, 0x07, 0x12, 0xcd, 0x20, 0xbb, 0x61, 0x56,
+	0xa3, 0xa6, 0x7f, 0x51, 0x93, 0xcc, 0xf9, 0xd7, 0x0a, 0xd4, 0xb1, 0xec, 0xbc, 0x61, 0x7f, 0xc5,
+	0x81, 0xfa, 0x51, 0xe4, 0x31, 0x75, 0xbf, 0xaf, 0x66, 0xc5, 0x2b, 0x42, 0xa9, 0xc0, 0xe1, 0x51,
+	0x63, 0x53, 0xe9, 0x24, 0x7c, 0x11, 0xbb, 0xe1, 0xe0, 0x5c, 0x9c, 0xae, 0x6a, 0xbb, 0xcc, 0x22,
+	0x4a, 0x3a, 0x5c, 0x8d, 0xeb, 0x3b, 0x5c, 0xce, 0xff, 0x55, 0x72, 0xad, 0x28, 0x0c, 0x4a, 0x47,
+	0xee, 0x65, 0x1a, 0xb6, 0x65, 0x12, 0x64, 0x82, 0xd0, 0xb9, 0x7a, 0xa1, 0xcf, 0x7d, 0x37, 0x78,
+	0xe1, 0x0e, 0x2e, 0xa2, 0x77, 0xef, 0x94, 0x62, 0x05, 0x28, 0x1a, 0xf2, 0x91, 0x7b, 0xa9, 0x69,
+	0x54, 0x68, 0xcc, 0x20, 0xa8, 0x9d, 0xfa, 0x3c, 0x9a, 0x04, 0xdc, 0x1f, 0x07, 0xbe, 0x6a, 0x9d,
+	0x56, 0xe9, 0x2c, 0x02, 0xaf, 0x6f, 0x21, 0x26, 0x96, 0x39, 0x42, 0x5f, 0x9d, 0xf3, 0x17, 0xc1,
+	0x28, 0x9f, 0x92, 0x15, 0x3d, 0x26, 0x9a, 0x70, 0xed, 0xfc, 0x79, 0xa8, 0xf3, 0x0f, 0x15, 0xed,
+	0x08, 0x0a, 0x81, 0xe1, 0x56, 0x7d, 0x2a, 0xbd, 0xf5, 0x30, 0x9f, 0x1b, 0x55, 0xaf, 0xc8, 0x8d,
+	0x6a, 0x33, 0xb9, 0x91, 0x0e, 0xba, 0xf5, 0x99, 0xb2, 0xeb, 0xea, 0xe0, 0xef, 0xfc, 0x5d, 0x0d,
+	0xe0, 0xbb, 0x09, 0x9b, 0xc8, 0x24, 0xf2, 0x86, 0x89, 0x63, 0xea, 0xcb, 0xb5, 0xeb, 0x7d, 0xf9,
+	0x04, 0xd6, 0x8c, 0x4e, 0x8c, 0xe7, 0x72, 0x57, 0xb9, 0xc7, 0x6f, 0x49, 0x8b, 0xc9, 0x16, 0xef,
+	0x14, 0xe8, 0x64, 0x7b, 0xb5, 0xc8, 0x2d, 0x74, 0x0a, 0x7f, 0x8d, 0x5c, 0x62, 0x2f, 0x1a, 0xd2,
+	0x76, 0x0c, 0x10, 0xda, 0xc4, 0x21, 0x73, 0x13, 0x26, 0xb3, 0x50, 0x79, 0x2e, 0x06, 0x04, 0x67,
+	0x10, 0xa3, 0x97, 0x97, 0x63, 0x3f, 0x9e, 0xaa, 0x20, 0x6c, 0x82, 0xf0, 0x36, 0xef, 0x06, 0xae,
+	0x3f, 0x4a, 0x54, 0x9c, 0x55, 0xa3, 0xb2, 0xf4, 0xae, 0x55, 0x9a, 0xde, 0xb5, 0x5f, 0xc0, 0x7a,
+	0x99, 0x3a, 0x9f, 0xd4, 0x4b, 0xfd, 0x0a, 0x88, 0x56, 0xcb, 0x28, 0xb4, 0x1e, 0xe6, 0xaa, 0x26,
+	0xab, 0xb8, 0x8b, 0xaa, 0x76, 0xfa, 0x3d, 0xb8, 0x93, 0xe3, 0x55, 0x69, 0xde, 0xcd, 0x98, 0x8f,
+	0xc1, 0x12, 0x0a, 0x9b, 0xcb, 0x6e, 0xc0, 0x02, 0x86, 0xde, 0xd4, 0x3a, 0xd4, 0x08, 0x93, 0x7e,
+	0xb1, 0x73, 0x7d, 0x36, 0x88, 0x42, 0x2f, 0x51, 0x76, 0x9b, 0x83, 0x39, 0xcf, 0xe1, 0xb6, 0x31,
+	0xdf, 0x27, 0x89, 0x32, 0x80, 0xbb, 0x94, 0x85, 0xec, 0x23, 0x0e, 0xc4, 0x9c, 0x5a, 0x9e, 0xa2,
+	0xa5, 0x66, 0xf2, 0x55, 0xaf, 0x94, 0xaf, 0x56, 0x22, 0xdf, 0x2e, 0x6c, 0x14, 0x17, 0xb9, 0xae,
+	0xa3, 0xe1, 0x7c, 0x0d, 0x84, 0xb2, 0x00, 0x89, 0xcd, 0x5d, 0xba, 0xa1, 0x54, 0x58, 0x4b, 0xe6,
+	0xb8, 0xaf, 0x5d, 0x6e, 0x04, 0x6b, 0x85, 0x7e, 0x22, 0x66, 0x7d, 0xfb, 0xec, 0x2c, 0x9a, 0xe8,
+	0x32, 0xb2, 0x45, 0xd3, 0x31, 0x6a, 0x7d, 0xe4, 0x5e, 0x9e, 0xb2, 0xf8, 0xc8, 0x0f, 0x27, 0x5c,
+	0x47, 0x93, 0x1c, 0x4c, 0x14, 0x4e, 0x91, 0x1b, 0xb0, 0x64, 0xc0, 0x54, 0xd2, 0x9a, 0x8e, 0x9d,
+	0x7f, 0xab, 0x00, 0xe9, 0xfb, 0xa3, 0x49, 0xe0, 0xe6, 0x3a, 0x46, 0x57, 0x35, 0x27, 0xc8, 0x53,
+	0x68, 0xa5, 0x8d, 0x62, 0x95, 0x2f, 0xdf, 0xcd, 0x6e, 0x6e, 0xa3, 0x75, 0x47, 0x33, 0x3a, 0xf2,
+	0x25, 0x34, 0x7b, 0xde, 0xc8, 0x8c, 0x26, 0x96, 0xb8, 0x5a, 0x4d, 0xf2, 0x94, 0x82, 0x7c, 0x01,
+	0x0d, 0x51, 0x36, 0xe4, 0x1f, 0xbf, 0xf2, 0xe5, 0x81, 0xa4, 0x70, 0xf6, 0x61, 0x43, 0x29, 0xe0,
+	0x47, 0xa1, 0xea, 0x09, 0xb3, 0x64, 0x12, 0xf0, 0xb4, 0xbd, 0x59, 0x31, 0xda, 0x9b, 0xe2, 0xcd,
+	0x3c, 0x49, 0x54, 0xef, 0xb1, 0x49, 0xd5, 0xc8, 0xf9, 0xe7, 0x2a, 0xac, 0x66, 0xd3, 0xf4, 0x39,
+	0x1b, 0x63, 0x74, 0x91, 0x0b, 0xe2, 0xb3, 0xba, 0x9a, 0xc4, 0x80, 0xe0, 0xb6, 0xca, 0x51, 0x7a,
+	0xe8, 0xe9, 0x98, 0xec, 0x16, 0x2a, 0xee, 0xb6, 0xaa, 0x27, 0x72, 0x2b, 0x14, 0x6a, 0xef, 0x5f,
+	0xc2, 0xa2, 0x14, 0x5f, 0xe7, 0x15, 0xf7, 0x8a, 0x4c, 0xa6, 0x76, 0x54, 0x13, 0x67, 0x7b, 0xd5,
+	0xb8, 0x6e, 0xaf, 0xc8, 0xcf, 0x60, 0x41, 0x26, 0x19, 0xf6, 0xc2, 0x7c, 0xda, 0x85, 0xac, 0x80,
+	0x51, 0x20, 0x11, 0x39, 0x5b, 0x54, 0x0f, 0xf1, 0xae, 0xbb, 0x93, 0x33, 0x1a, 0x65, 0xd5, 0xf7,
+	0xa0, 0xa5, 0x6c, 0x57, 0xf5, 0x69, 0x9b, 0x34, 0x03, 0xe0, 0x8b, 0xdb, 0x41, 0x74, 0xa6, 0x55,
+	0xac, 0xde, 0x40, 0x45, 0x83, 0x1e, 0xaf, 0xa2, 0xb7, 0xb1, 0x2b, 0x2c, 0xb8, 0x26, 0xae, 0xa2,
+	0x92, 0x0d, 0xa5, 0x92, 0xc4, 0xf9, 0xfb, 0x0a, 0x2c, 0xa3, 0xbb, 0xed, 0xc5, 0xdc, 0x7f, 0xe7,
+	0x0e, 0xe6, 0x95, 0x7d, 0x66, 0x27, 0xa9, 0x62, 0x74, 0x92, 0x74, 0x03, 0xbe, 0x66, 0x34, 0xe0,
+	0x09, 0xd4, 0x31, 0xf5, 0x16, 0xf6, 0x58, 0xa3, 0xe2, 0x1b, 0x67, 0x3d, 0x32, 0xae, 0x26, 0x39,
+	0xc0, 0x4b, 0x47, 0x5c, 0x08, 0x21, 0x17, 0xc6, 0xa7, 0x1a, 0xe8, 0x06, 0xc8, 0xf9, 0x43, 0x58,
+	0xdf, 0x1b, 0x8f, 0x59, 0x28, 0xc2, 0x1f, 0x76, 0x16, 0xae, 0x6c, 0xf0, 0x5d, 0x21, 0xe5, 0xbe,
+	0xab, 0x5e, 0xcf, 0x97, 0xa9, 0xf8, 0x76, 0x7e, 0x06, 0x77, 0x0b, 0x33, 0xab, 0x93, 0xd1, 0xe2,
+	0x57, 0x32, 0xf1, 0x9d, 0x31, 0x06, 0x36, 0xf7, 0xc7, 0x09, 0xb1, 0x01, 0x0b, 0xaa, 0x2b, 0x5a,
+	0x13, 0x33, 0xab, 0x91, 0xc8, 0x52, 0x8d, 0xee, 0x9a, 0x1c, 0x38, 0x7f, 0x0a, 0x77, 0x72, 0x2b,
+	0x66, 0xc2, 0x09, 0x4d, 0x2a, 0x99, 0x26, 0xe8, 0x7c, 0xc7, 0xec, 0x92, 0xab, 0xc9, 0xab, 0x62,
+	0x72, 0x03, 0x92, 0x2a, 0x54, 0x33, 0x14, 0xe2, 0xb0, 0xa1, 0x3a, 0x8f, 0xfa, 0xe0, 0xb5, 0x52,
+	0x1d, 0x68, 0x6a, 0x90, 0x8a, 0x69, 0x24, 0x6b, 0x81, 0xa5, 0xc4, 0x29, 0x4d, 0x2a, 0x51, 0xd5,
+	0x90, 0x68, 0x03, 0x16, 0xe4, 0xde, 0xea, 0xc2, 0x5f, 0x8e, 0x9c, 0x1e, 0x6c, 0xce, 0xac, 0xaa,
+	0x14, 0xfb, 0xc4, 0x65, 0x9d, 0xbf, 0xaa, 0xc0, 0xa6, 0xde, 0xa0, 0xa2, 0x0a, 0x3f, 0xde, 0x84,
+	0xb3, 0xb3, 0xaa, 0x97, 0x9f, 0x55, 0xc3, 0x3c, 0xab, 0x3f, 0x03, 0x7b, 0x56, 0x94, 0xdf, 0x4c,
+	0xaf, 0xb2, 0xed, 0x74, 0xde, 0x80, 0xad, 0xbb, 0xd1, 0x9a, 0x2e, 0xf9, 0x8d, 0x74, 0x75, 0x8e,
+	0x60, 0xab, 0x64, 0x26, 0x25, 0xea, 0x63, 0x68, 0xa5, 0x40, 0xd1, 0x4a, 0x2d, 0x97, 0x35, 0x23,
+	0xc2, 0xca, 0xab, 0xa9, 0x1f, 0xe8, 0x4a, 0xdf, 0xe2, 0xca, 0x2b, 0x30, 0xf3, 0x97, 0x29, 0xb5,
+	0xfc, 0x2f, 0x53, 0x48, 0x07, 0x16, 0xf7, 0x82, 0x20, 0xfa, 0xc8, 0x3c, 0xbb, 0x6e, 0x46, 0x2f,
+	0xbd, 0xcc, 0x0f, 0x7e, 0xe8, 0x45, 0x1f, 0xa9, 0x26, 0xc2, 0x9f, 0xe3, 0xbc, 0x8a, 0xe2, 0x33,
+	0xdf, 0xf3, 0x98, 0x2e, 0x18, 0xcb, 0x39, 0x32, 0x32, 0xe7, 0x3d, 0xac, 0xe6, 0x91, 0x28, 0xd1,
+	0x0f, 0x8c, 0x5d, 0x78, 0xee, 0x54, 0x6a, 0xde, 0xa0, 0xe9, 0x18, 0x75, 0xd8, 0x77, 0x79, 0xfa,
+	0xcb, 0x31, 0x39, 0x40, 0xa8, 0x7c, 0x05, 0x57, 0xaf, 0xf4, 0x62, 0x80, 0x99, 0xec, 0xcb, 0x50,
+	0xb7, 0x17, 0xf1, 0x73, 0xfb, 0x1b, 0x58, 0x2b, 0xfc, 0xfc, 0x8b, 0x34, 0xa1, 0x8e, 0xf5, 0xb8,
+	0x75, 0x0b, 0xbf, 0xb0, 0xf0, 0xb6, 0x2a, 0x64, 0x05, 0x5a, 0x69, 0x5d, 0x6d, 0x55, 0xc9, 0x22,
+	0xd4, 0xf6, 0x06, 0x81, 0x55, 0xdb, 0x7e, 0x0e, 0x77, 0x4b, 0x7f, 0xea, 0x44, 0xd6, 0x60, 0x49,
+	0x99, 0x00, 0x22, 0xac, 0x5b, 0x08, 0x50, 0x94, 0x62, 0xf2, 0xca, 0xf6, 0x5f, 0xc8, 0x76, 0x86,
+	0xba, 0x31, 0x97, 0x60, 0xf1, 0xfb, 0xf0, 0x22, 0x8c, 0x3e, 0x86, 0x72, 0xdd, 0x9e, 0x27, 0xd6,
+	0x5d, 0x82, 0x45, 0x3a, 0x09, 0x43, 0x3f, 0x1c, 0x5a, 0x55, 0xb2, 0x0c, 0xcd, 0x57, 0x7e, 0xe8,
+	0x27, 0xe7, 0xcc, 0xb3, 0x6a, 0x38, 0x61, 0x2f, 0xe4, 0x2c, 0x8e, 0x27, 0x63, 0xce, 0x3c, 0xab,
+	0x4e, 0x00, 0xf3, 0x81, 0x49, 0xc2, 0x3c, 0xab, 0x21, 0x04, 0x0c, 0xa7, 0xd6, 0x02, 0x69, 0x41,
+	0x43, 0x94, 0x4e, 0xd6, 0x22, 0xe2, 0x65, 0xaa, 0x6a, 0x35, 0xb7, 0x87, 0xb0, 0xa8, 0xba, 0xa9,
+	0xb8, 0xd8, 0x71, 0x14, 0x32, 0xeb, 0x16, 0xd2, 0x8a, 0x09, 0xac, 0x0a, 0xd2, 0xe2, 0xc5, 0x35,
+	0x42, 0x65, 0x9b, 0x50, 0xc7, 0xa6, 0xb5, 0x55, 0x43, 0xa8, 0x7c, 0x27, 0xb0, 0xea, 0x4a, 0xb2,
+	0x93, 0x70, 0xc0, 0xac, 0x06, 0x4a, 0xa6, 0x7f, 0xed, 0x60, 0x2d, 0x20, 0xd9, 0x9e, 0xfc, 0x5e,
+	0xdc, 0x7e, 0x08, 0x4d, 0x5d, 0xbb, 0x23, 0xcb, 0x0f, 0xae, 0xcf, 0xf7, 0x82, 0xc0, 0xba, 0x95,
+	0x0e, 0xc2, 0xa9, 0x55, 0xd9, 0x7e, 0x0b, 0xeb, 0x65, 0xf9, 0x04, 0x6e, 0xbb, 0x82, 0x33, 0xcf,
+	0xba, 0x45, 0x2c, 0x58, 0x3e, 0x8e, 0x78, 0x06, 0xa9, 0xe0, 0x26, 0xc8, 0x4d, 0x67, 0xde, 0xc9,
+	0x84, 0x5b, 0x55, 0x5c, 0x5b, 0xfe, 0xdc, 0xc4, 0xaa, 0xed, 0xfe, 0x3b, 0x88, 0x6b, 0xba, 0x2f,
+	0x7f, 0x5e, 0x46, 0x7e, 0x01, 0x0b, 0xf2, 0x0d, 0x94, 0xa8, 0x5c, 0x21, 0xf7, 0x7e, 0xda, 0x5e,
+	0xcf, 0x03, 0xa5, 0xd3, 0x39, 0xb7, 0x90, 0xed, 0x35, 0x33, 0xd9, 0x5e, 0xb3, 0x12, 0xb6, 0xfc,
+	0xbb, 0xa6, 0x73, 0x8b, 0x7c, 0x0b, 0xad, 0xf4, 0xb1, 0x91, 0x6c, 0x48, 0xa2, 0xe2, 0x6b, 0x66,
+	0x7b, 0x73, 0x06, 0x9e, 0xf2, 0x7f, 0x03, 0x4d, 0xfd, 0xce, 0x46, 0xd4, 0xe3, 0x7c, 0xe1, 0xa9,
+	0xb1, 0xbd, 0x51, 0x04, 0x6b, 0xe6, 0xc7, 0x15, 0xf2, 0x0c, 0x16, 0x55, 0x28, 0x27, 0x99, 0x62,
+	0x46, 0xd6, 0xdf, 0xbe, 0x5b, 0x80, 0xa6, 0x0b, 0xbf, 0x80, 0x15, 0x05, 0xec, 0x8b, 0x1f, 0x71,
+	0x7e, 0x22, 0xff, 0xa3, 0xca, 0xe3, 0x0a, 0xf9, 0x7d, 0x68, 0xa5, 0xef, 0x73, 0xc4, 0x10, 0xd3,
+	0x7c, 0x4f, 0x6a, 0x6f, 0xce, 0xc0, 0x0d, 0xf9, 0xf7, 0xf5, 0xe3, 0xab, 0x9c, 0xc3, 0x36, 0x37,
+	0x2a, 0x37, 0xcb, 0x56, 0x09, 0x26, 0xd5, 0xe5, 0x3b, 0xb0, 0x8a, 0x8f, 0x53, 0xe4, 0x27, 0x9a,
+	0xa1, 0xf4, 0x95, 0xab, 0x7d, 0x7f, 0x1e, 0x5a, 0x45, 0xe1, 0xfd, 0xac, 0x94, 0xc7, 0xcd, 0x55,
+	0x82, 0xcd, 0xd6, 0xbc, 0xed, 0xad, 0x12, 0x8c, 0x69, 0x1d, 0x69, 0x75, 0xa9, 0x37, 0xa8, 0x58,
+	0xbe, 0xb6, 0x37, 0x67, 0xe0, 0x29, 0xff, 0x11, 0xac, 0xe6, 0xab, 0x3f, 0xf2, 0x99, 0xfe, 0x8d,
+	0x54, 0x49, 0xe1, 0xd9, 0xbe, 0x57, 0x8e, 0x4c, 0xa7, 0xdb, 0x87, 0x25, 0xa3, 0xb4, 0xd3, 0x4a,
+	0xcd, 0xd6, 0x8a, 0xed, 0xad, 0x12, 0x8c, 0x39, 0x8b, 0x91, 0x4a, 0xeb, 0x59, 0x66, 0x4b, 0xb2,
+	0xf6, 0x56, 0x09, 0x26, 0x9d, 0xe5, 0x00, 0x56, 0x72, 0x89, 0x1f, 0x51, 0x05, 0x47, 0x59, 0x9e,
+	0xd9, 0xfe, 0xac, 0x14, 0x97, 0xd7, 0x2b, 0xcd, 0xd2, 0x32, 0xbd, 0x8a, 0xa9, 0x62, 0x7b, 0xab,
+	0x04, 0x93, 0xce, 0x72, 0x9a, 0x3e, 0x03, 0xa7, 0x69, 0xc0, 0xbd, 0x9c, 0xf5, 0x17, 0x12, 0x9c,
+	0xf6, 0x4f, 0xe6, 0x60, 0xd3, 0x19, 0xfb, 0x60, 0x15, 0x33, 0x12, 0x6d, 0x97, 0x73, 0x92, 0xa6,
+	0xf6, 0xfd, 0x79, 0xe8, 0x74, 0xd2, 0x5f, 0x65, 0x8f, 0xe2, 0x1a, 0x9b, 0x90, 0xfb, 0x79, 0x27,
+	0x2b, 0xe6, 0x27, 0xed, 0xcf, 0xe7, 0xe2, 0xf5, 0xbc, 0xbb, 0x6f, 0xe4, 0x6f, 0x06, 0x74, 0x18,
+	0x7d, 0x8e, 0x57, 0x47, 0xc8, 0xe3, 0x28, 0x20, 0xea, 0x17, 0x50, 0xc6, 0xdb, 0x5c, 0x7b, 0x6b,
+	0x06, 0x94, 0xcd, 0x74, 0xb6, 0x20, 0x9a, 0x6d, 0x4f, 0xff, 0x7f, 0x00, 0xd3, 0xe7, 0xc6, 0x96,
+	0x23, 0x2f, 0x00, 0x00,
 }
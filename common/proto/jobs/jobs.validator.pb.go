@@ -132,6 +132,11 @@ func (this *Action) Validate() error {
 			}
 		}
 	}
+	if this.RetryPolicy != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.RetryPolicy); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("RetryPolicy", err)
+		}
+	}
 	return nil
 }
 func (this *Job) Validate() error {
@@ -181,6 +186,18 @@ func (this *Job) Validate() error {
 			}
 		}
 	}
+	for _, item := range this.Joins {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Joins", err)
+			}
+		}
+	}
+	if this.Throttle != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Throttle); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Throttle", err)
+		}
+	}
 	return nil
 }
 func (this *JobParameter) Validate() error {
@@ -322,6 +339,13 @@ func (this *ActionLog) Validate() error {
 			return github_com_mwitkow_go_proto_validators.FieldError("OutputMessage", err)
 		}
 	}
+	for _, item := range this.Attempts {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Attempts", err)
+			}
+		}
+	}
 	return nil
 }
 func (this *JobTriggerEvent) Validate() error {
@@ -395,3 +419,207 @@ func (this *ActionMessage) Validate() error {
 	}
 	return nil
 }
+func (this *Join) Validate() error {
+	for _, item := range this.ChainedActions {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("ChainedActions", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *RetryPolicy) Validate() error {
+	return nil
+}
+func (this *ActionAttempt) Validate() error {
+	return nil
+}
+func (this *QueuedTask) Validate() error {
+	if this.Event != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Event); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Event", err)
+		}
+	}
+	return nil
+}
+func (this *EnqueueTaskRequest) Validate() error {
+	if this.Task != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Task); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Task", err)
+		}
+	}
+	return nil
+}
+func (this *EnqueueTaskResponse) Validate() error {
+	if this.Task != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Task); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Task", err)
+		}
+	}
+	return nil
+}
+func (this *ClaimTaskRequest) Validate() error {
+	return nil
+}
+func (this *ClaimTaskResponse) Validate() error {
+	if this.Task != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Task); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Task", err)
+		}
+	}
+	return nil
+}
+func (this *RenewTaskLeaseRequest) Validate() error {
+	return nil
+}
+func (this *RenewTaskLeaseResponse) Validate() error {
+	return nil
+}
+func (this *ReleaseTaskRequest) Validate() error {
+	return nil
+}
+func (this *ReleaseTaskResponse) Validate() error {
+	return nil
+}
+func (this *TriggerThrottle) Validate() error {
+	return nil
+}
+func (this *SimulateJobRequest) Validate() error {
+	if this.Job != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Job); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Job", err)
+		}
+	}
+	if this.NodeEvent != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.NodeEvent); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("NodeEvent", err)
+		}
+	}
+	if this.IdmEvent != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.IdmEvent); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("IdmEvent", err)
+		}
+	}
+	if this.Input != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Input); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Input", err)
+		}
+	}
+	return nil
+}
+func (this *SimulationFilterResult) Validate() error {
+	return nil
+}
+func (this *SimulationStep) Validate() error {
+	for _, item := range this.Filters {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Filters", err)
+			}
+		}
+	}
+	if this.Input != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Input); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Input", err)
+		}
+	}
+	if this.Output != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Output); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Output", err)
+		}
+	}
+	return nil
+}
+func (this *SimulateJobResponse) Validate() error {
+	for _, item := range this.JobFilters {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("JobFilters", err)
+			}
+		}
+	}
+	for _, item := range this.Trace {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Trace", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *TaskArtifact) Validate() error {
+	return nil
+}
+func (this *AppendTaskLogRequest) Validate() error {
+	return nil
+}
+func (this *AppendTaskLogResponse) Validate() error {
+	return nil
+}
+func (this *ReadTaskLogRequest) Validate() error {
+	return nil
+}
+func (this *ReadTaskLogResponse) Validate() error {
+	return nil
+}
+func (this *PutTaskArtifactRequest) Validate() error {
+	if this.Artifact != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Artifact); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Artifact", err)
+		}
+	}
+	return nil
+}
+func (this *PutTaskArtifactResponse) Validate() error {
+	if this.Artifact != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Artifact); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Artifact", err)
+		}
+	}
+	return nil
+}
+func (this *ReadTaskArtifactRequest) Validate() error {
+	return nil
+}
+func (this *ReadTaskArtifactResponse) Validate() error {
+	if this.Artifact != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Artifact); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Artifact", err)
+		}
+	}
+	return nil
+}
+func (this *ListTaskArtifactsRequest) Validate() error {
+	return nil
+}
+func (this *ListTaskArtifactsResponse) Validate() error {
+	for _, item := range this.Artifacts {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Artifacts", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *Calendar) Validate() error {
+	for _, item := range this.Allowed {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Allowed", err)
+			}
+		}
+	}
+	for _, item := range this.Forbidden {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Forbidden", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *CalendarWindow) Validate() error {
+	return nil
+}
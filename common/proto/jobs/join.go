@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+)
+
+// JoinByID finds a Join by its ID in the job definition
+func (job *Job) JoinByID(id string) (*Join, bool) {
+	for _, j := range job.Joins {
+		if j.ID == id {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+// ValidateJoins checks that all JoinID references point to an existing Join
+// and that each Join has at least one upstream action.
+func (job *Job) ValidateJoins() error {
+	ids := make(map[string]int, len(job.Joins))
+	for _, j := range job.Joins {
+		if j.ID == "" {
+			return fmt.Errorf("join must have an ID")
+		}
+		if _, exists := ids[j.ID]; exists {
+			return fmt.Errorf("duplicate join ID %s", j.ID)
+		}
+		ids[j.ID] = 0
+	}
+	e := job.WalkActions(func(a *Action) error {
+		if a.JoinID == "" {
+			return nil
+		}
+		if _, ok := ids[a.JoinID]; !ok {
+			return fmt.Errorf("action %s references unknown join %s", a.ID, a.JoinID)
+		}
+		ids[a.JoinID]++
+		return nil
+	})
+	if e != nil {
+		return e
+	}
+	for id, count := range ids {
+		if count == 0 {
+			return fmt.Errorf("join %s has no upstream action", id)
+		}
+	}
+	return nil
+}
+
+// MergeActionMessages merges the outputs of multiple branches in a single ActionMessage.
+// Event is taken from the first message, collections are concatenated (nodes and users
+// are deduplicated by Uuid), and the last output of each branch is appended to the OutputChain.
+func MergeActionMessages(messages ...ActionMessage) ActionMessage {
+	var merged ActionMessage
+	if len(messages) == 0 {
+		return merged
+	}
+	merged.Event = messages[0].Event
+	merged.OutputChain = append(merged.OutputChain, messages[0].OutputChain...)
+	nodes := make(map[string]bool)
+	users := make(map[string]bool)
+	for i, m := range messages {
+		for _, n := range m.Nodes {
+			key := n.GetUuid()
+			if key == "" {
+				key = n.GetPath()
+			}
+			if !nodes[key] {
+				nodes[key] = true
+				merged.Nodes = append(merged.Nodes, n)
+			}
+		}
+		for _, u := range m.Users {
+			key := u.GetUuid()
+			if key == "" {
+				key = u.GetLogin()
+			}
+			if !users[key] {
+				users[key] = true
+				merged.Users = append(merged.Users, u)
+			}
+		}
+		merged.Roles = append(merged.Roles, m.Roles...)
+		merged.Workspaces = append(merged.Workspaces, m.Workspaces...)
+		merged.Acls = append(merged.Acls, m.Acls...)
+		merged.Activities = append(merged.Activities, m.Activities...)
+		if i > 0 {
+			if last := m.GetLastOutput(); last != nil {
+				merged.OutputChain = append(merged.OutputChain, last)
+			}
+		}
+	}
+	return merged
+}
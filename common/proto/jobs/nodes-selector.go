@@ -24,6 +24,7 @@ import (
 	"context"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/blevesearch/bleve"
 	"github.com/blevesearch/bleve/search/query"
@@ -375,7 +376,7 @@ func getMemBleveIndex() bleve.Index {
 }
 
 func (n *NodesSelector) evaluatedClone(ctx context.Context, input ActionMessage) *NodesSelector {
-	if len(GetFieldEvaluators()) == 0 {
+	if len(GetFieldEvaluators()) == 0 && !n.hasRelativeDates() {
 		return n
 	}
 	c := proto.Clone(n).(*NodesSelector)
@@ -394,8 +395,34 @@ func (n *NodesSelector) evaluatedClone(ctx context.Context, input ActionMessage)
 			singleQuery.PathPrefix = EvaluateFieldStrSlice(ctx, input, singleQuery.PathPrefix)
 			singleQuery.Paths = EvaluateFieldStrSlice(ctx, input, singleQuery.Paths)
 			singleQuery.UUIDs = EvaluateFieldStrSlice(ctx, input, singleQuery.UUIDs)
+			resolveRelativeDates(singleQuery, time.Now())
 			c.Query.SubQueries[i], _ = ptypes.MarshalAny(singleQuery)
 		}
 	}
 	return c
 }
+
+// hasRelativeDates checks if one of the queries uses a negative MinDate or MaxDate
+func (n *NodesSelector) hasRelativeDates() bool {
+	if n.Query == nil {
+		return false
+	}
+	for _, q := range n.Query.SubQueries {
+		singleQuery := &tree.Query{}
+		if e := ptypes.UnmarshalAny(q, singleQuery); e == nil && (singleQuery.MinDate < 0 || singleQuery.MaxDate < 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRelativeDates transforms negative MinDate and MaxDate, expressed as a number of seconds before now,
+// into absolute timestamps. For example MaxDate: -86400 selects nodes modified more than one day ago.
+func resolveRelativeDates(q *tree.Query, now time.Time) {
+	if q.MinDate < 0 {
+		q.MinDate = now.Unix() + q.MinDate
+	}
+	if q.MaxDate < 0 {
+		q.MaxDate = now.Unix() + q.MaxDate
+	}
+}
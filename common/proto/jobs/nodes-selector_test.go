@@ -23,6 +23,7 @@ package jobs
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/micro/protobuf/ptypes"
@@ -93,6 +94,30 @@ func TestNodesSelector_Filter(t *testing.T) {
 
 	})
 
+	Convey("Relative Dates Query", t, func() {
+
+		old := &tree.Node{Path: "/root/old.txt", MTime: time.Now().Add(-48 * time.Hour).Unix()}
+		recent := &tree.Node{Path: "/root/recent.txt", MTime: time.Now().Add(-1 * time.Hour).Unix()}
+		// Not modified for one day
+		marshalled, _ := ptypes.MarshalAny(&tree.Query{MaxDate: -86400})
+		n := &NodesSelector{
+			Query: &service.Query{
+				SubQueries: []*any.Any{marshalled},
+			},
+		}
+		output, _, _ := n.Filter(bg, ActionMessage{Nodes: []*tree.Node{old, recent}})
+		So(output.Nodes, ShouldHaveLength, 1)
+		So(output.Nodes[0].Path, ShouldEqual, "/root/old.txt")
+
+		// Modified during the last day
+		marshalled, _ = ptypes.MarshalAny(&tree.Query{MinDate: -86400})
+		n.Query.SubQueries = []*any.Any{marshalled}
+		output, _, _ = n.Filter(bg, ActionMessage{Nodes: []*tree.Node{old, recent}})
+		So(output.Nodes, ShouldHaveLength, 1)
+		So(output.Nodes[0].Path, ShouldEqual, "/root/recent.txt")
+
+	})
+
 	Convey("Wrong Query", t, func() {
 
 		q := &tree.Query{
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 1 * time.Second
+	defaultRetryMultiplier     = 2.0
+)
+
+// Check verifies that durations and regular expressions of the policy can be parsed.
+func (p *RetryPolicy) Check() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("MaxAttempts cannot be negative")
+	}
+	if p.BackoffMultiplier < 0 {
+		return fmt.Errorf("BackoffMultiplier cannot be negative")
+	}
+	for _, d := range []string{p.InitialBackoff, p.MaxBackoff, p.AttemptTimeout} {
+		if d == "" {
+			continue
+		}
+		if _, e := time.ParseDuration(d); e != nil {
+			return e
+		}
+	}
+	for _, r := range p.RetryableErrors {
+		if _, e := regexp.Compile(r); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Attempts returns the total number of attempts allowed by this policy (at least 1).
+func (p *RetryPolicy) Attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return int(p.MaxAttempts)
+}
+
+// Backoff computes the delay to wait after the given failed attempt (starting at 1)
+// before running the next one.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	if p == nil {
+		return 0
+	}
+	initial := defaultRetryInitialBackoff
+	if d, e := time.ParseDuration(p.InitialBackoff); e == nil {
+		initial = d
+	}
+	multiplier := defaultRetryMultiplier
+	if p.BackoffMultiplier > 0 {
+		multiplier = float64(p.BackoffMultiplier)
+	}
+	var max time.Duration
+	if d, e := time.ParseDuration(p.MaxBackoff); e == nil {
+		max = d
+	}
+	delay := float64(initial)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if max > 0 && time.Duration(delay) >= max {
+			return max
+		}
+	}
+	if max > 0 && time.Duration(delay) > max {
+		return max
+	}
+	return time.Duration(delay)
+}
+
+// Timeout returns the maximum duration of a single attempt, or 0 if not set.
+func (p *RetryPolicy) Timeout() time.Duration {
+	if p == nil || p.AttemptTimeout == "" {
+		return 0
+	}
+	if d, e := time.ParseDuration(p.AttemptTimeout); e == nil {
+		return d
+	}
+	return 0
+}
+
+// IsRetryable checks if an error should trigger a new attempt. If RetryableErrors
+// is empty, all errors are retried.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	if len(p.RetryableErrors) == 0 {
+		return true
+	}
+	for _, r := range p.RetryableErrors {
+		if reg, e := regexp.Compile(r); e == nil && reg.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryPolicy(t *testing.T) {
+
+	Convey("Test RetryPolicy defaults", t, func() {
+		var p *RetryPolicy
+		So(p.Attempts(), ShouldEqual, 1)
+		So(p.Backoff(1), ShouldEqual, 0)
+		So(p.IsRetryable(fmt.Errorf("error")), ShouldBeFalse)
+
+		p = &RetryPolicy{MaxAttempts: 3}
+		So(p.Check(), ShouldBeNil)
+		So(p.Attempts(), ShouldEqual, 3)
+		So(p.Backoff(1), ShouldEqual, 1*time.Second)
+		So(p.Backoff(2), ShouldEqual, 2*time.Second)
+		So(p.Backoff(3), ShouldEqual, 4*time.Second)
+		So(p.Timeout(), ShouldEqual, 0)
+		So(p.IsRetryable(fmt.Errorf("any error")), ShouldBeTrue)
+	})
+
+	Convey("Test RetryPolicy backoff", t, func() {
+		p := &RetryPolicy{
+			MaxAttempts:       5,
+			InitialBackoff:    "100ms",
+			MaxBackoff:        "250ms",
+			BackoffMultiplier: 1.5,
+			AttemptTimeout:    "10s",
+		}
+		So(p.Check(), ShouldBeNil)
+		So(p.Backoff(1), ShouldEqual, 100*time.Millisecond)
+		So(p.Backoff(2), ShouldEqual, 150*time.Millisecond)
+		So(p.Backoff(3), ShouldEqual, 225*time.Millisecond)
+		So(p.Backoff(4), ShouldEqual, 250*time.Millisecond)
+		So(p.Backoff(10), ShouldEqual, 250*time.Millisecond)
+		So(p.Timeout(), ShouldEqual, 10*time.Second)
+	})
+
+	Convey("Test RetryPolicy retryable errors", t, func() {
+		p := &RetryPolicy{MaxAttempts: 2, RetryableErrors: []string{"timeout", "^connection refused"}}
+		So(p.Check(), ShouldBeNil)
+		So(p.IsRetryable(fmt.Errorf("i/o timeout")), ShouldBeTrue)
+		So(p.IsRetryable(fmt.Errorf("connection refused by peer")), ShouldBeTrue)
+		So(p.IsRetryable(fmt.Errorf("permission denied")), ShouldBeFalse)
+	})
+
+	Convey("Test RetryPolicy check", t, func() {
+		So((&RetryPolicy{InitialBackoff: "1 minute"}).Check(), ShouldNotBeNil)
+		So((&RetryPolicy{RetryableErrors: []string{"("}}).Check(), ShouldNotBeNil)
+		So((&RetryPolicy{MaxAttempts: -1}).Check(), ShouldNotBeNil)
+
+		job := &Job{Actions: []*Action{
+			{ID: "a", ChainedActions: []*Action{
+				{ID: "b", RetryPolicy: &RetryPolicy{MaxBackoff: "forever"}},
+			}},
+		}}
+		So(job.CheckDefinition(), ShouldNotBeNil)
+		job.Actions[0].ChainedActions[0].RetryPolicy.MaxBackoff = "1m"
+		So(job.CheckDefinition(), ShouldBeNil)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/pydio/cells/common/utils/schedule"
+)
+
+// Check validates the ISO8601 or cron expression of the schedule.
+func (s *Schedule) Check() error {
+	if s.Cron != "" {
+		if s.Iso8601Schedule != "" {
+			return fmt.Errorf("schedule cannot define both Cron and Iso8601Schedule")
+		}
+		_, e := schedule.ParseCron(s.Cron, s.TimeZone)
+		return e
+	}
+	if s.TimeZone != "" {
+		return fmt.Errorf("TimeZone can only be used with a Cron expression")
+	}
+	if s.Iso8601Schedule != "" {
+		_, e := schedule.NewTickerScheduleFromISO(s.Iso8601Schedule)
+		return e
+	}
+	return nil
+}
+
+// NewTicker creates a schedule.Ticker calling onTick at each occurrence of the schedule.
+func (s *Schedule) NewTicker(onTick schedule.OnTick) (*schedule.Ticker, error) {
+	var ts *schedule.TickerSchedule
+	var e error
+	if s.Cron != "" {
+		ts, e = schedule.NewTickerScheduleFromCron(s.Cron, s.TimeZone)
+	} else {
+		ts, e = schedule.NewTickerScheduleFromISO(s.Iso8601Schedule)
+	}
+	if e != nil {
+		return nil, e
+	}
+	return schedule.NewTicker(ts, onTick), nil
+}
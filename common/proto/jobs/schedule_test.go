@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchedule_Check(t *testing.T) {
+
+	Convey("Test Schedule Check", t, func() {
+		So((&Schedule{Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:00/PT10M"}).Check(), ShouldBeNil)
+		So((&Schedule{Iso8601Schedule: "R/2012-06-04"}).Check(), ShouldNotBeNil)
+		So((&Schedule{Cron: "0 2 * * 1-5", TimeZone: "America/New_York"}).Check(), ShouldBeNil)
+		So((&Schedule{Cron: "0 2 * * 1-5", TimeZone: "Somewhere"}).Check(), ShouldNotBeNil)
+		So((&Schedule{Cron: "0 25 * * *"}).Check(), ShouldNotBeNil)
+		So((&Schedule{Cron: "@daily", Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:00/PT10M"}).Check(), ShouldNotBeNil)
+		So((&Schedule{Iso8601Schedule: "R/2012-06-04T19:25:16.828696-07:00/PT10M", TimeZone: "UTC"}).Check(), ShouldNotBeNil)
+
+		job := &Job{ID: "job", Schedule: &Schedule{Cron: "not a cron"}}
+		So(job.CheckDefinition(), ShouldNotBeNil)
+		job.Schedule.Cron = "*/5 * * * *"
+		So(job.CheckDefinition(), ShouldBeNil)
+
+		ticker, e := job.Schedule.NewTicker(func() error { return nil })
+		So(e, ShouldBeNil)
+		So(ticker, ShouldNotBeNil)
+	})
+
+}
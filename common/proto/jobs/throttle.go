@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"time"
+)
+
+// Check verifies that the throttle values are consistent.
+func (t *TriggerThrottle) Check() error {
+	if t.Debounce != "" {
+		if d, e := time.ParseDuration(t.Debounce); e != nil {
+			return e
+		} else if d < 0 {
+			return fmt.Errorf("Debounce cannot be negative")
+		}
+	}
+	if t.MaxPerMinute < 0 {
+		return fmt.Errorf("MaxPerMinute cannot be negative")
+	}
+	if t.Coalesce && t.MaxPerMinute == 0 {
+		return fmt.Errorf("Coalesce requires MaxPerMinute")
+	}
+	return nil
+}
+
+// DebounceDuration parses the Debounce value, 0 if it is empty or invalid.
+func (t *TriggerThrottle) DebounceDuration() time.Duration {
+	if t == nil || t.Debounce == "" {
+		return 0
+	}
+	d, e := time.ParseDuration(t.Debounce)
+	if e != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// IsActive returns true if the throttle has any effect on triggers.
+func (t *TriggerThrottle) IsActive() bool {
+	return t != nil && (t.DebounceDuration() > 0 || t.MaxPerMinute > 0)
+}
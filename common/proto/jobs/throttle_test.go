@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTriggerThrottle(t *testing.T) {
+
+	Convey("Check throttle values", t, func() {
+		So((&TriggerThrottle{Debounce: "10s", MaxPerMinute: 5, Coalesce: true}).Check(), ShouldBeNil)
+		So((&TriggerThrottle{Debounce: "ten seconds"}).Check(), ShouldNotBeNil)
+		So((&TriggerThrottle{MaxPerMinute: -1}).Check(), ShouldNotBeNil)
+		So((&TriggerThrottle{Coalesce: true}).Check(), ShouldNotBeNil)
+	})
+
+	Convey("Throttle activity", t, func() {
+		var nilThrottle *TriggerThrottle
+		So(nilThrottle.IsActive(), ShouldBeFalse)
+		So(nilThrottle.DebounceDuration(), ShouldEqual, 0)
+		So((&TriggerThrottle{}).IsActive(), ShouldBeFalse)
+		So((&TriggerThrottle{Debounce: "2s"}).DebounceDuration(), ShouldEqual, 2*time.Second)
+		So((&TriggerThrottle{Debounce: "2s"}).IsActive(), ShouldBeTrue)
+		So((&TriggerThrottle{MaxPerMinute: 1}).IsActive(), ShouldBeTrue)
+	})
+
+	Convey("Job definition is checked", t, func() {
+		job := &Job{ID: "job", Throttle: &TriggerThrottle{Coalesce: true}}
+		So(job.CheckDefinition(), ShouldNotBeNil)
+		job.Throttle.MaxPerMinute = 10
+		So(job.CheckDefinition(), ShouldBeNil)
+	})
+}
@@ -129,6 +129,24 @@ It has these top-level messages:
 	ResetPasswordTokenResponse
 	ResetPasswordRequest
 	ResetPasswordResponse
+	TotpEnrollRequest
+	TotpEnrollResponse
+	TotpVerifyRequest
+	TotpVerifyResponse
+	TotpResetRequest
+	TotpResetResponse
+	PersonalTokenRequest
+	PersonalTokenResponse
+	PersonalTokenListRequest
+	PersonalTokenCollection
+	PersonalTokenRevokeRequest
+	ExplainAccessRequest
+	AccessContribution
+	RightExplanation
+	ExplainAccessResponse
+	PolicySimulationRequest
+	PolicySimulationResult
+	PolicySimulationResponse
 	UserJobRequest
 	UserJobResponse
 	UserJobsCollection
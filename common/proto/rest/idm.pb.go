@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import idm "github.com/pydio/cells/common/proto/idm"
 import service "github.com/pydio/cells/common/service/proto"
+import auth "github.com/pydio/cells/common/proto/auth"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -780,6 +781,597 @@ func (m *ResetPasswordResponse) GetMessage() string {
 	return ""
 }
 
+type TotpEnrollRequest struct {
+}
+
+func (m *TotpEnrollRequest) Reset()                    { *m = TotpEnrollRequest{} }
+func (m *TotpEnrollRequest) String() string            { return proto.CompactTextString(m) }
+func (*TotpEnrollRequest) ProtoMessage()               {}
+func (*TotpEnrollRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{25} }
+
+type TotpEnrollResponse struct {
+	// Secret to be entered in an authenticator application
+	Secret string `protobuf:"bytes,1,opt,name=Secret" json:"Secret,omitempty"`
+	// otpauth:// URI of the secret, to be displayed as a QR code
+	KeyURI string `protobuf:"bytes,2,opt,name=KeyURI" json:"KeyURI,omitempty"`
+	// Single-use codes replacing a one-time password if the device is lost
+	RecoveryCodes []string `protobuf:"bytes,3,rep,name=RecoveryCodes" json:"RecoveryCodes,omitempty"`
+}
+
+func (m *TotpEnrollResponse) Reset()                    { *m = TotpEnrollResponse{} }
+func (m *TotpEnrollResponse) String() string            { return proto.CompactTextString(m) }
+func (*TotpEnrollResponse) ProtoMessage()               {}
+func (*TotpEnrollResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{26} }
+
+func (m *TotpEnrollResponse) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *TotpEnrollResponse) GetKeyURI() string {
+	if m != nil {
+		return m.KeyURI
+	}
+	return ""
+}
+
+func (m *TotpEnrollResponse) GetRecoveryCodes() []string {
+	if m != nil {
+		return m.RecoveryCodes
+	}
+	return nil
+}
+
+type TotpVerifyRequest struct {
+	// One-time password generated by the authenticator application
+	Code string `protobuf:"bytes,1,opt,name=Code" json:"Code,omitempty"`
+}
+
+func (m *TotpVerifyRequest) Reset()                    { *m = TotpVerifyRequest{} }
+func (m *TotpVerifyRequest) String() string            { return proto.CompactTextString(m) }
+func (*TotpVerifyRequest) ProtoMessage()               {}
+func (*TotpVerifyRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{27} }
+
+func (m *TotpVerifyRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type TotpVerifyResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *TotpVerifyResponse) Reset()                    { *m = TotpVerifyResponse{} }
+func (m *TotpVerifyResponse) String() string            { return proto.CompactTextString(m) }
+func (*TotpVerifyResponse) ProtoMessage()               {}
+func (*TotpVerifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{28} }
+
+func (m *TotpVerifyResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type TotpResetRequest struct {
+	// Login of the user, admins only, current user if empty
+	Login string `protobuf:"bytes,1,opt,name=Login" json:"Login,omitempty"`
+	// One-time password or recovery code, required when resetting one's own second factor
+	Code string `protobuf:"bytes,2,opt,name=Code" json:"Code,omitempty"`
+}
+
+func (m *TotpResetRequest) Reset()                    { *m = TotpResetRequest{} }
+func (m *TotpResetRequest) String() string            { return proto.CompactTextString(m) }
+func (*TotpResetRequest) ProtoMessage()               {}
+func (*TotpResetRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{29} }
+
+func (m *TotpResetRequest) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+func (m *TotpResetRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type TotpResetResponse struct {
+	Success bool `protobuf:"varint,1,opt,name=Success" json:"Success,omitempty"`
+}
+
+func (m *TotpResetResponse) Reset()                    { *m = TotpResetResponse{} }
+func (m *TotpResetResponse) String() string            { return proto.CompactTextString(m) }
+func (*TotpResetResponse) ProtoMessage()               {}
+func (*TotpResetResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{30} }
+
+func (m *TotpResetResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type PersonalTokenRequest struct {
+	// Label of the token
+	Label string `protobuf:"bytes,1,opt,name=Label" json:"Label,omitempty"`
+	// Expiration timestamp, mandatory
+	ExpiresAt int32 `protobuf:"varint,2,opt,name=ExpiresAt" json:"ExpiresAt,omitempty"`
+	// Restrictions applied to the token, "read-only" and/or "workspace:SLUG" entries
+	Scopes []string `protobuf:"bytes,3,rep,name=Scopes" json:"Scopes,omitempty"`
+	// Login of the owner, admins only, current user if empty
+	UserLogin string `protobuf:"bytes,4,opt,name=UserLogin" json:"UserLogin,omitempty"`
+}
+
+func (m *PersonalTokenRequest) Reset()                    { *m = PersonalTokenRequest{} }
+func (m *PersonalTokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*PersonalTokenRequest) ProtoMessage()               {}
+func (*PersonalTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{31} }
+
+func (m *PersonalTokenRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *PersonalTokenRequest) GetExpiresAt() int32 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *PersonalTokenRequest) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *PersonalTokenRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+type PersonalTokenResponse struct {
+	// Value of the token, it cannot be retrieved later
+	AccessToken string                    `protobuf:"bytes,1,opt,name=AccessToken" json:"AccessToken,omitempty"`
+	Token       *auth.PersonalAccessToken `protobuf:"bytes,2,opt,name=Token" json:"Token,omitempty"`
+}
+
+func (m *PersonalTokenResponse) Reset()                    { *m = PersonalTokenResponse{} }
+func (m *PersonalTokenResponse) String() string            { return proto.CompactTextString(m) }
+func (*PersonalTokenResponse) ProtoMessage()               {}
+func (*PersonalTokenResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{32} }
+
+func (m *PersonalTokenResponse) GetAccessToken() string {
+	if m != nil {
+		return m.AccessToken
+	}
+	return ""
+}
+
+func (m *PersonalTokenResponse) GetToken() *auth.PersonalAccessToken {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+type PersonalTokenListRequest struct {
+	// Login of the owner, admins only, current user if empty
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+}
+
+func (m *PersonalTokenListRequest) Reset()                    { *m = PersonalTokenListRequest{} }
+func (m *PersonalTokenListRequest) String() string            { return proto.CompactTextString(m) }
+func (*PersonalTokenListRequest) ProtoMessage()               {}
+func (*PersonalTokenListRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{33} }
+
+func (m *PersonalTokenListRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+type PersonalTokenCollection struct {
+	Tokens []*auth.PersonalAccessToken `protobuf:"bytes,1,rep,name=Tokens" json:"Tokens,omitempty"`
+}
+
+func (m *PersonalTokenCollection) Reset()                    { *m = PersonalTokenCollection{} }
+func (m *PersonalTokenCollection) String() string            { return proto.CompactTextString(m) }
+func (*PersonalTokenCollection) ProtoMessage()               {}
+func (*PersonalTokenCollection) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{34} }
+
+func (m *PersonalTokenCollection) GetTokens() []*auth.PersonalAccessToken {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type PersonalTokenRevokeRequest struct {
+	Uuid string `protobuf:"bytes,1,opt,name=Uuid" json:"Uuid,omitempty"`
+}
+
+func (m *PersonalTokenRevokeRequest) Reset()                    { *m = PersonalTokenRevokeRequest{} }
+func (m *PersonalTokenRevokeRequest) String() string            { return proto.CompactTextString(m) }
+func (*PersonalTokenRevokeRequest) ProtoMessage()               {}
+func (*PersonalTokenRevokeRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{35} }
+
+func (m *PersonalTokenRevokeRequest) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+type ExplainAccessRequest struct {
+	// Login of the user whose access is explained
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	// Uuid of the node, takes precedence over NodePath
+	NodeUuid string `protobuf:"bytes,2,opt,name=NodeUuid" json:"NodeUuid,omitempty"`
+	// Path of the node, starting with a datasource name
+	NodePath string `protobuf:"bytes,3,opt,name=NodePath" json:"NodePath,omitempty"`
+}
+
+func (m *ExplainAccessRequest) Reset()                    { *m = ExplainAccessRequest{} }
+func (m *ExplainAccessRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExplainAccessRequest) ProtoMessage()               {}
+func (*ExplainAccessRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{36} }
+
+func (m *ExplainAccessRequest) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *ExplainAccessRequest) GetNodeUuid() string {
+	if m != nil {
+		return m.NodeUuid
+	}
+	return ""
+}
+
+func (m *ExplainAccessRequest) GetNodePath() string {
+	if m != nil {
+		return m.NodePath
+	}
+	return ""
+}
+
+type AccessContribution struct {
+	Acl *idm.ACL `protobuf:"bytes,1,opt,name=Acl" json:"Acl,omitempty"`
+	// Label of the role carrying the ACL
+	RoleLabel string `protobuf:"bytes,2,opt,name=RoleLabel" json:"RoleLabel,omitempty"`
+	// Position of the role in the user roles, later roles override previous ones
+	RolePosition int32 `protobuf:"varint,3,opt,name=RolePosition" json:"RolePosition,omitempty"`
+	// Path of the node carrying the ACL
+	NodePath string `protobuf:"bytes,4,opt,name=NodePath" json:"NodePath,omitempty"`
+	// 0 for the node itself, 1 for its parent, etc.
+	Depth int32 `protobuf:"varint,5,opt,name=Depth" json:"Depth,omitempty"`
+	// A role coming later carries ACLs on the same node
+	Overridden bool `protobuf:"varint,6,opt,name=Overridden" json:"Overridden,omitempty"`
+	// Result of the evaluation of a policy-based ACL
+	PolicyAllowed bool `protobuf:"varint,7,opt,name=PolicyAllowed" json:"PolicyAllowed,omitempty"`
+	// This ACL determined the resulting right
+	Decisive bool `protobuf:"varint,8,opt,name=Decisive" json:"Decisive,omitempty"`
+}
+
+func (m *AccessContribution) Reset()                    { *m = AccessContribution{} }
+func (m *AccessContribution) String() string            { return proto.CompactTextString(m) }
+func (*AccessContribution) ProtoMessage()               {}
+func (*AccessContribution) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{37} }
+
+func (m *AccessContribution) GetAcl() *idm.ACL {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
+func (m *AccessContribution) GetRoleLabel() string {
+	if m != nil {
+		return m.RoleLabel
+	}
+	return ""
+}
+
+func (m *AccessContribution) GetRolePosition() int32 {
+	if m != nil {
+		return m.RolePosition
+	}
+	return 0
+}
+
+func (m *AccessContribution) GetNodePath() string {
+	if m != nil {
+		return m.NodePath
+	}
+	return ""
+}
+
+func (m *AccessContribution) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *AccessContribution) GetOverridden() bool {
+	if m != nil {
+		return m.Overridden
+	}
+	return false
+}
+
+func (m *AccessContribution) GetPolicyAllowed() bool {
+	if m != nil {
+		return m.PolicyAllowed
+	}
+	return false
+}
+
+func (m *AccessContribution) GetDecisive() bool {
+	if m != nil {
+		return m.Decisive
+	}
+	return false
+}
+
+type RightExplanation struct {
+	// One of read, write, deny, lock, quota
+	Right         string                `protobuf:"bytes,1,opt,name=Right" json:"Right,omitempty"`
+	Granted       bool                  `protobuf:"varint,2,opt,name=Granted" json:"Granted,omitempty"`
+	Reason        string                `protobuf:"bytes,3,opt,name=Reason" json:"Reason,omitempty"`
+	Contributions []*AccessContribution `protobuf:"bytes,4,rep,name=Contributions" json:"Contributions,omitempty"`
+}
+
+func (m *RightExplanation) Reset()                    { *m = RightExplanation{} }
+func (m *RightExplanation) String() string            { return proto.CompactTextString(m) }
+func (*RightExplanation) ProtoMessage()               {}
+func (*RightExplanation) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{38} }
+
+func (m *RightExplanation) GetRight() string {
+	if m != nil {
+		return m.Right
+	}
+	return ""
+}
+
+func (m *RightExplanation) GetGranted() bool {
+	if m != nil {
+		return m.Granted
+	}
+	return false
+}
+
+func (m *RightExplanation) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RightExplanation) GetContributions() []*AccessContribution {
+	if m != nil {
+		return m.Contributions
+	}
+	return nil
+}
+
+type ExplainAccessResponse struct {
+	UserLogin string `protobuf:"bytes,1,opt,name=UserLogin" json:"UserLogin,omitempty"`
+	NodeUuid  string `protobuf:"bytes,2,opt,name=NodeUuid" json:"NodeUuid,omitempty"`
+	NodePath  string `protobuf:"bytes,3,opt,name=NodePath" json:"NodePath,omitempty"`
+	// Roles of the user, in resolution order
+	Roles []*idm.Role `protobuf:"bytes,4,rep,name=Roles" json:"Roles,omitempty"`
+	// Workspaces containing the node
+	Workspaces []*idm.Workspace `protobuf:"bytes,5,rep,name=Workspaces" json:"Workspaces,omitempty"`
+	// Policies referenced by policy-based ACLs
+	Policies []*idm.PolicyGroup  `protobuf:"bytes,6,rep,name=Policies" json:"Policies,omitempty"`
+	Rights   []*RightExplanation `protobuf:"bytes,7,rep,name=Rights" json:"Rights,omitempty"`
+}
+
+func (m *ExplainAccessResponse) Reset()                    { *m = ExplainAccessResponse{} }
+func (m *ExplainAccessResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExplainAccessResponse) ProtoMessage()               {}
+func (*ExplainAccessResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{39} }
+
+func (m *ExplainAccessResponse) GetUserLogin() string {
+	if m != nil {
+		return m.UserLogin
+	}
+	return ""
+}
+
+func (m *ExplainAccessResponse) GetNodeUuid() string {
+	if m != nil {
+		return m.NodeUuid
+	}
+	return ""
+}
+
+func (m *ExplainAccessResponse) GetNodePath() string {
+	if m != nil {
+		return m.NodePath
+	}
+	return ""
+}
+
+func (m *ExplainAccessResponse) GetRoles() []*idm.Role {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *ExplainAccessResponse) GetWorkspaces() []*idm.Workspace {
+	if m != nil {
+		return m.Workspaces
+	}
+	return nil
+}
+
+func (m *ExplainAccessResponse) GetPolicies() []*idm.PolicyGroup {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+func (m *ExplainAccessResponse) GetRights() []*RightExplanation {
+	if m != nil {
+		return m.Rights
+	}
+	return nil
+}
+
+type PolicySimulationRequest struct {
+	// Candidate policy groups, replacing stored groups with the same Uuid
+	PolicyGroups []*idm.PolicyGroup `protobuf:"bytes,1,rep,name=PolicyGroups" json:"PolicyGroups,omitempty"`
+	// Evaluate the stored policy groups along with the candidates
+	IncludeStored bool `protobuf:"varint,2,opt,name=IncludeStored" json:"IncludeStored,omitempty"`
+	// Requests to evaluate
+	Requests []*idm.PolicyEngineRequest `protobuf:"bytes,3,rep,name=Requests" json:"Requests,omitempty"`
+}
+
+func (m *PolicySimulationRequest) Reset()                    { *m = PolicySimulationRequest{} }
+func (m *PolicySimulationRequest) String() string            { return proto.CompactTextString(m) }
+func (*PolicySimulationRequest) ProtoMessage()               {}
+func (*PolicySimulationRequest) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{40} }
+
+func (m *PolicySimulationRequest) GetPolicyGroups() []*idm.PolicyGroup {
+	if m != nil {
+		return m.PolicyGroups
+	}
+	return nil
+}
+
+func (m *PolicySimulationRequest) GetIncludeStored() bool {
+	if m != nil {
+		return m.IncludeStored
+	}
+	return false
+}
+
+func (m *PolicySimulationRequest) GetRequests() []*idm.PolicyEngineRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type PolicySimulationResult struct {
+	Request      *idm.PolicyEngineRequest `protobuf:"bytes,1,opt,name=Request" json:"Request,omitempty"`
+	Allowed      bool                     `protobuf:"varint,2,opt,name=Allowed" json:"Allowed,omitempty"`
+	ExplicitDeny bool                     `protobuf:"varint,3,opt,name=ExplicitDeny" json:"ExplicitDeny,omitempty"`
+	DefaultDeny  bool                     `protobuf:"varint,4,opt,name=DefaultDeny" json:"DefaultDeny,omitempty"`
+	// Id of the deciding policy: the denying one on explicit deny, the first allowing one otherwise
+	PolicyId          string `protobuf:"bytes,5,opt,name=PolicyId" json:"PolicyId,omitempty"`
+	PolicyDescription string `protobuf:"bytes,6,opt,name=PolicyDescription" json:"PolicyDescription,omitempty"`
+	PolicyGroupUuid   string `protobuf:"bytes,7,opt,name=PolicyGroupUuid" json:"PolicyGroupUuid,omitempty"`
+	PolicyGroupName   string `protobuf:"bytes,8,opt,name=PolicyGroupName" json:"PolicyGroupName,omitempty"`
+	// Evaluation error, if any
+	Error string `protobuf:"bytes,9,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *PolicySimulationResult) Reset()                    { *m = PolicySimulationResult{} }
+func (m *PolicySimulationResult) String() string            { return proto.CompactTextString(m) }
+func (*PolicySimulationResult) ProtoMessage()               {}
+func (*PolicySimulationResult) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{41} }
+
+func (m *PolicySimulationResult) GetRequest() *idm.PolicyEngineRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *PolicySimulationResult) GetAllowed() bool {
+	if m != nil {
+		return m.Allowed
+	}
+	return false
+}
+
+func (m *PolicySimulationResult) GetExplicitDeny() bool {
+	if m != nil {
+		return m.ExplicitDeny
+	}
+	return false
+}
+
+func (m *PolicySimulationResult) GetDefaultDeny() bool {
+	if m != nil {
+		return m.DefaultDeny
+	}
+	return false
+}
+
+func (m *PolicySimulationResult) GetPolicyId() string {
+	if m != nil {
+		return m.PolicyId
+	}
+	return ""
+}
+
+func (m *PolicySimulationResult) GetPolicyDescription() string {
+	if m != nil {
+		return m.PolicyDescription
+	}
+	return ""
+}
+
+func (m *PolicySimulationResult) GetPolicyGroupUuid() string {
+	if m != nil {
+		return m.PolicyGroupUuid
+	}
+	return ""
+}
+
+func (m *PolicySimulationResult) GetPolicyGroupName() string {
+	if m != nil {
+		return m.PolicyGroupName
+	}
+	return ""
+}
+
+func (m *PolicySimulationResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type PolicySimulationResponse struct {
+	Results []*PolicySimulationResult `protobuf:"bytes,1,rep,name=Results" json:"Results,omitempty"`
+}
+
+func (m *PolicySimulationResponse) Reset()                    { *m = PolicySimulationResponse{} }
+func (m *PolicySimulationResponse) String() string            { return proto.CompactTextString(m) }
+func (*PolicySimulationResponse) ProtoMessage()               {}
+func (*PolicySimulationResponse) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{42} }
+
+func (m *PolicySimulationResponse) GetResults() []*PolicySimulationResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ResourcePolicyQuery)(nil), "rest.ResourcePolicyQuery")
 	proto.RegisterType((*SearchRoleRequest)(nil), "rest.SearchRoleRequest")
@@ -806,65 +1398,127 @@ func init() {
 	proto.RegisterType((*ResetPasswordTokenResponse)(nil), "rest.ResetPasswordTokenResponse")
 	proto.RegisterType((*ResetPasswordRequest)(nil), "rest.ResetPasswordRequest")
 	proto.RegisterType((*ResetPasswordResponse)(nil), "rest.ResetPasswordResponse")
+	proto.RegisterType((*TotpEnrollRequest)(nil), "rest.TotpEnrollRequest")
+	proto.RegisterType((*TotpEnrollResponse)(nil), "rest.TotpEnrollResponse")
+	proto.RegisterType((*TotpVerifyRequest)(nil), "rest.TotpVerifyRequest")
+	proto.RegisterType((*TotpVerifyResponse)(nil), "rest.TotpVerifyResponse")
+	proto.RegisterType((*TotpResetRequest)(nil), "rest.TotpResetRequest")
+	proto.RegisterType((*TotpResetResponse)(nil), "rest.TotpResetResponse")
+	proto.RegisterType((*PersonalTokenRequest)(nil), "rest.PersonalTokenRequest")
+	proto.RegisterType((*PersonalTokenResponse)(nil), "rest.PersonalTokenResponse")
+	proto.RegisterType((*PersonalTokenListRequest)(nil), "rest.PersonalTokenListRequest")
+	proto.RegisterType((*PersonalTokenCollection)(nil), "rest.PersonalTokenCollection")
+	proto.RegisterType((*PersonalTokenRevokeRequest)(nil), "rest.PersonalTokenRevokeRequest")
+	proto.RegisterType((*ExplainAccessRequest)(nil), "rest.ExplainAccessRequest")
+	proto.RegisterType((*AccessContribution)(nil), "rest.AccessContribution")
+	proto.RegisterType((*RightExplanation)(nil), "rest.RightExplanation")
+	proto.RegisterType((*ExplainAccessResponse)(nil), "rest.ExplainAccessResponse")
+	proto.RegisterType((*PolicySimulationRequest)(nil), "rest.PolicySimulationRequest")
+	proto.RegisterType((*PolicySimulationResult)(nil), "rest.PolicySimulationResult")
+	proto.RegisterType((*PolicySimulationResponse)(nil), "rest.PolicySimulationResponse")
 	proto.RegisterEnum("rest.ResourcePolicyQuery_QueryType", ResourcePolicyQuery_QueryType_name, ResourcePolicyQuery_QueryType_value)
 }
 
 func init() { proto.RegisterFile("idm.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 855 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0x46, 0xfe, 0x8d, 0x4e, 0x48, 0x6a, 0x36, 0xa9, 0x51, 0x42, 0x66, 0x30, 0xcb, 0x8d, 0x19,
-	0x06, 0x79, 0x70, 0xa1, 0x85, 0x4b, 0x47, 0xcd, 0x74, 0x3a, 0x75, 0xec, 0xb0, 0x71, 0x06, 0x18,
-	0xae, 0x54, 0xe9, 0xd4, 0xd5, 0x58, 0xd2, 0x1a, 0xad, 0xd4, 0x8e, 0x5f, 0x80, 0xb7, 0xe0, 0x0d,
-	0xb8, 0xe6, 0x6d, 0x78, 0x17, 0x66, 0x57, 0x5a, 0x59, 0x76, 0x5d, 0xcc, 0xcf, 0x0d, 0x33, 0xbd,
-	0xc9, 0xe8, 0x7c, 0xe7, 0x3b, 0x67, 0xbf, 0xf3, 0x1d, 0xaf, 0x14, 0x30, 0x03, 0x3f, 0xb2, 0x97,
-	0x09, 0x4f, 0x39, 0x69, 0x24, 0x28, 0xd2, 0xf3, 0x2f, 0xe7, 0x41, 0xfa, 0x32, 0x7b, 0x6e, 0x7b,
-	0x3c, 0x1a, 0x2c, 0x57, 0x7e, 0xc0, 0x07, 0x1e, 0x86, 0xa1, 0x18, 0x78, 0x3c, 0x8a, 0x78, 0x3c,
-	0x50, 0xd4, 0x41, 0xe0, 0x47, 0x83, 0xb2, 0xf0, 0xfc, 0x9b, 0xbf, 0x2e, 0x11, 0x98, 0xbc, 0x0a,
-	0x3c, 0x2c, 0x4a, 0x73, 0x30, 0xaf, 0xa4, 0xbf, 0x1a, 0x70, 0xc2, 0x50, 0xf0, 0x2c, 0xf1, 0xf0,
-	0x86, 0x87, 0x81, 0xb7, 0xfa, 0x2e, 0xc3, 0x64, 0x45, 0x1e, 0x41, 0x63, 0xb6, 0x5a, 0xa2, 0x65,
-	0xf4, 0x8c, 0xfe, 0xf1, 0xf0, 0x53, 0x5b, 0x2a, 0xb3, 0x77, 0x10, 0x6d, 0xf5, 0x57, 0x52, 0x99,
-	0x2a, 0x20, 0x5d, 0x68, 0xdd, 0x09, 0x4c, 0x9e, 0xfa, 0x56, 0xad, 0x67, 0xf4, 0x4d, 0x56, 0x44,
-	0xf4, 0x6b, 0x30, 0x4b, 0x2a, 0x39, 0x84, 0xb6, 0x33, 0x9d, 0xcc, 0xae, 0x7e, 0x98, 0x75, 0xde,
-	0x23, 0x6d, 0xa8, 0x8f, 0x26, 0x3f, 0x76, 0x0c, 0x72, 0x00, 0x8d, 0xc9, 0x74, 0x72, 0xd5, 0xa9,
-	0xc9, 0xa7, 0xbb, 0xdb, 0x2b, 0xd6, 0xa9, 0xd3, 0xdf, 0x6a, 0xf0, 0xc1, 0x2d, 0xba, 0x89, 0xf7,
-	0x92, 0xf1, 0x10, 0x19, 0xfe, 0x9c, 0xa1, 0x48, 0x89, 0x0d, 0x6d, 0xd9, 0x2c, 0x40, 0x61, 0x19,
-	0xbd, 0x7a, 0xff, 0x70, 0x78, 0x6a, 0x4b, 0x33, 0x24, 0xe5, 0x36, 0x88, 0xe7, 0x21, 0xaa, 0xa3,
-	0x98, 0x26, 0x91, 0x67, 0x3b, 0x87, 0xb4, 0xda, 0x3d, 0xa3, 0x7f, 0x38, 0x3c, 0x7b, 0xeb, 0x70,
-	0x6c, 0xa7, 0x35, 0x5d, 0x68, 0x4d, 0x5f, 0xbc, 0x10, 0x98, 0xaa, 0x09, 0xeb, 0xac, 0x88, 0xc8,
-	0x29, 0x34, 0xc7, 0x41, 0x14, 0xa4, 0x56, 0x5d, 0xc1, 0x79, 0x40, 0x2c, 0x68, 0x3f, 0x49, 0x78,
-	0xb6, 0xbc, 0x5c, 0x59, 0x8d, 0x9e, 0xd1, 0x6f, 0x32, 0x1d, 0x92, 0x0b, 0x30, 0x1d, 0x9e, 0xc5,
-	0xe9, 0x34, 0x0e, 0x57, 0x56, 0xb3, 0x67, 0xf4, 0x0f, 0xd8, 0x1a, 0x20, 0x5f, 0x81, 0x39, 0x5d,
-	0x62, 0xe2, 0xa6, 0x01, 0x8f, 0xad, 0x96, 0xda, 0x42, 0xd7, 0x2e, 0x16, 0x69, 0x97, 0x19, 0x65,
-	0xfc, 0x9a, 0x48, 0x87, 0x70, 0x4f, 0x9a, 0x20, 0x1c, 0x1e, 0x86, 0xe8, 0x49, 0x88, 0x7c, 0x0c,
-	0x4d, 0x05, 0x15, 0x4e, 0x99, 0xa5, 0x53, 0x2c, 0xc7, 0x2b, 0x16, 0xcb, 0x55, 0xed, 0xb1, 0x58,
-	0x52, 0xde, 0x6d, 0x8b, 0x17, 0x70, 0x4f, 0x9a, 0x50, 0xb5, 0xf8, 0x13, 0x68, 0xa9, 0x13, 0x37,
-	0x3d, 0x56, 0x6e, 0x16, 0x09, 0xb9, 0x05, 0x55, 0x65, 0xd5, 0xb6, 0x19, 0x39, 0x2e, 0x47, 0x9b,
-	0xf1, 0xd4, 0x0d, 0xd5, 0x68, 0x4d, 0x96, 0x07, 0xb4, 0x0f, 0xef, 0x5f, 0x06, 0xb1, 0xcf, 0x50,
-	0x2c, 0x79, 0x2c, 0x50, 0x8e, 0x7a, 0x9b, 0x79, 0x1e, 0x0a, 0xa1, 0x6e, 0xe6, 0x01, 0xd3, 0x21,
-	0xfd, 0xc3, 0x80, 0x4e, 0xbe, 0xc5, 0x91, 0x33, 0xd6, 0x4b, 0xfc, 0x62, 0x7b, 0x89, 0x27, 0xea,
-	0xdc, 0x91, 0x33, 0xde, 0xb9, 0xc3, 0xff, 0xb3, 0xed, 0x0e, 0x1c, 0x8d, 0x9c, 0x71, 0xc5, 0xf4,
-	0x0b, 0x68, 0x8c, 0x9c, 0xb1, 0x1e, 0xec, 0x40, 0x0f, 0xc6, 0x14, 0xba, 0xb6, 0xb3, 0x56, 0xb5,
-	0xf3, 0xf7, 0x1a, 0x74, 0x73, 0x93, 0xbe, 0xe7, 0xc9, 0x42, 0x2c, 0x5d, 0xaf, 0x7c, 0xa5, 0x3c,
-	0xd8, 0xb6, 0xea, 0x4c, 0x75, 0x2c, 0x79, 0xef, 0xf6, 0x8f, 0xfe, 0x27, 0x38, 0x29, 0x9d, 0xa8,
-	0xec, 0xc0, 0x06, 0x28, 0x61, 0xed, 0xdb, 0xf1, 0xa6, 0x6f, 0xac, 0xc2, 0x78, 0xcb, 0x56, 0x46,
-	0x40, 0xe4, 0x1d, 0xb8, 0xc6, 0xd4, 0xad, 0xf4, 0xfe, 0x1c, 0x4c, 0x89, 0xf8, 0x6e, 0xea, 0xea,
-	0xd6, 0x47, 0xe5, 0xad, 0x91, 0x19, 0xb6, 0xce, 0xd3, 0x3b, 0xf8, 0x48, 0xc3, 0x13, 0x37, 0xc2,
-	0x6d, 0x9d, 0x0f, 0x01, 0x4a, 0x58, 0x37, 0xeb, 0x6e, 0x34, 0x2b, 0xd3, 0xac, 0xc2, 0xa4, 0x8f,
-	0xe0, 0xc3, 0x71, 0x20, 0x52, 0x4d, 0x9a, 0xb9, 0x73, 0xa1, 0x7f, 0x2f, 0x17, 0x60, 0x96, 0x44,
-	0x75, 0x17, 0x4d, 0xb6, 0x06, 0xa8, 0x0d, 0xd6, 0x9b, 0x85, 0xc5, 0x1d, 0x26, 0xd0, 0x90, 0xb1,
-	0x92, 0x61, 0x32, 0xf5, 0x4c, 0x9f, 0xc0, 0xfd, 0x9b, 0xac, 0x4a, 0xff, 0x5b, 0xc7, 0x90, 0x0e,
-	0xd4, 0x67, 0xee, 0xbc, 0xf8, 0xd2, 0xca, 0x47, 0x3a, 0x84, 0xee, 0x76, 0xa3, 0xbd, 0xaf, 0x8e,
-	0x6b, 0x38, 0x7b, 0x8c, 0x21, 0xa6, 0xf8, 0x8f, 0xe7, 0x2c, 0x67, 0xc9, 0x15, 0xe4, 0xb3, 0x3c,
-	0x84, 0xf3, 0x5d, 0xed, 0xf6, 0xca, 0xe8, 0xc2, 0xa9, 0xac, 0xb8, 0xe4, 0x7c, 0x11, 0xb9, 0xc9,
-	0x42, 0x2b, 0xa0, 0x9f, 0xc1, 0x11, 0xc3, 0x57, 0x7c, 0x51, 0x5e, 0x55, 0x0b, 0xda, 0x33, 0xbe,
-	0xc0, 0xf8, 0xa9, 0x5f, 0x08, 0xd2, 0x21, 0x7d, 0x0c, 0xc7, 0x9a, 0xba, 0xef, 0x38, 0x99, 0xb9,
-	0x46, 0x21, 0xdc, 0x39, 0x16, 0xea, 0x75, 0x48, 0xbf, 0x85, 0x33, 0x86, 0x02, 0xd3, 0x1b, 0x57,
-	0x88, 0xd7, 0x3c, 0xf1, 0x55, 0xf7, 0x8a, 0x1f, 0x52, 0xe5, 0x98, 0xcf, 0x83, 0x58, 0xfb, 0x51,
-	0x02, 0xf4, 0x06, 0xce, 0x77, 0x95, 0xfe, 0x07, 0x31, 0xbf, 0x18, 0x70, 0xba, 0xd1, 0x72, 0xfd,
-	0x81, 0x26, 0x6f, 0x1e, 0x55, 0x28, 0xda, 0x91, 0xd9, 0x14, 0x5e, 0xdb, 0x12, 0x4e, 0x7a, 0x70,
-	0x38, 0xc1, 0xd7, 0xba, 0x42, 0xbd, 0x6a, 0x4c, 0x56, 0x85, 0xe8, 0x33, 0xb8, 0xbf, 0xa5, 0xe3,
-	0xdf, 0x4f, 0xf5, 0xbc, 0xa5, 0xfe, 0xfb, 0x7c, 0xf0, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x08,
-	0xfb, 0x58, 0x69, 0xfd, 0x0a, 0x00, 0x00,
+	// 1564 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xc7, 0xff, 0x7d, 0x93, 0xa6, 0x75, 0x36, 0x89, 0x7b, 0x09, 0x11, 0x98, 0x03, 0x09, 0x23,
+	0x5a, 0x87, 0xa6, 0xa5, 0x2d, 0x12, 0x42, 0x72, 0x1d, 0xab, 0x8a, 0xea, 0x26, 0x61, 0xe3, 0xf0,
+	0x47, 0x3c, 0x5d, 0xee, 0x36, 0xce, 0x35, 0xe7, 0x5b, 0x73, 0x7b, 0x97, 0xd6, 0x6f, 0x3c, 0xf5,
+	0x43, 0x20, 0xf8, 0x04, 0xf0, 0xcc, 0xb7, 0x41, 0xe2, 0xa3, 0xa0, 0xfd, 0x77, 0xbe, 0xbb, 0x38,
+	0x38, 0xa8, 0x42, 0x42, 0xea, 0x8b, 0x75, 0x33, 0xfb, 0xdb, 0x99, 0xd9, 0xdf, 0xcc, 0xec, 0xcd,
+	0x19, 0x0c, 0xcf, 0x1d, 0x77, 0x26, 0x21, 0x8d, 0x28, 0x2a, 0x87, 0x84, 0x45, 0x9b, 0xf7, 0x46,
+	0x5e, 0x74, 0x16, 0x9f, 0x74, 0x1c, 0x3a, 0xde, 0x9e, 0x4c, 0x5d, 0x8f, 0x6e, 0x3b, 0xc4, 0xf7,
+	0xd9, 0xb6, 0x43, 0xc7, 0x63, 0x1a, 0x6c, 0x0b, 0xe8, 0xb6, 0xe7, 0x8e, 0xb7, 0x93, 0x8d, 0x9b,
+	0x8f, 0xff, 0x79, 0x0b, 0x23, 0xe1, 0x85, 0xe7, 0x10, 0xb5, 0x55, 0x2a, 0xd5, 0xce, 0xfb, 0xd7,
+	0x71, 0x66, 0xc7, 0xd1, 0x99, 0xf8, 0x91, 0x9b, 0xac, 0x5f, 0x0b, 0xb0, 0x8a, 0x09, 0xa3, 0x71,
+	0xe8, 0x90, 0x43, 0xea, 0x7b, 0xce, 0xf4, 0xeb, 0x98, 0x84, 0x53, 0xf4, 0x08, 0xca, 0xc3, 0xe9,
+	0x84, 0x98, 0x85, 0x56, 0xa1, 0x7d, 0x73, 0xe7, 0xc3, 0x0e, 0x3f, 0x4e, 0x67, 0x0e, 0xb0, 0x23,
+	0x7e, 0x39, 0x14, 0x8b, 0x0d, 0xa8, 0x09, 0xd5, 0x63, 0x46, 0xc2, 0x3d, 0xd7, 0x2c, 0xb6, 0x0a,
+	0x6d, 0x03, 0x2b, 0xc9, 0xfa, 0x1c, 0x8c, 0x04, 0x8a, 0x96, 0xa0, 0xd6, 0x3b, 0xd8, 0x1f, 0xf6,
+	0xbf, 0x1b, 0x36, 0xde, 0x41, 0x35, 0x28, 0x75, 0xf7, 0xbf, 0x6f, 0x14, 0x50, 0x1d, 0xca, 0xfb,
+	0x07, 0xfb, 0xfd, 0x46, 0x91, 0x3f, 0x1d, 0x1f, 0xf5, 0x71, 0xa3, 0x64, 0xfd, 0x5e, 0x84, 0x95,
+	0x23, 0x62, 0x87, 0xce, 0x19, 0xa6, 0x3e, 0xc1, 0xe4, 0xc7, 0x98, 0xb0, 0x08, 0x75, 0xa0, 0xc6,
+	0x8d, 0x79, 0x84, 0x99, 0x85, 0x56, 0xa9, 0xbd, 0xb4, 0xb3, 0xd6, 0xe1, 0x0c, 0x72, 0xc8, 0x91,
+	0x17, 0x8c, 0x7c, 0x22, 0x5c, 0x61, 0x0d, 0x42, 0xcf, 0xe6, 0x1e, 0xd2, 0xac, 0xb5, 0x0a, 0xed,
+	0xa5, 0x9d, 0x8d, 0x2b, 0x0f, 0x87, 0xe7, 0x52, 0xd3, 0x84, 0xea, 0xc1, 0xe9, 0x29, 0x23, 0x91,
+	0x38, 0x61, 0x09, 0x2b, 0x09, 0xad, 0x41, 0x65, 0xe0, 0x8d, 0xbd, 0xc8, 0x2c, 0x09, 0xb5, 0x14,
+	0x90, 0x09, 0xb5, 0xa7, 0x21, 0x8d, 0x27, 0x4f, 0xa6, 0x66, 0xb9, 0x55, 0x68, 0x57, 0xb0, 0x16,
+	0xd1, 0x16, 0x18, 0x3d, 0x1a, 0x07, 0xd1, 0x41, 0xe0, 0x4f, 0xcd, 0x4a, 0xab, 0xd0, 0xae, 0xe3,
+	0x99, 0x02, 0x3d, 0x00, 0xe3, 0x60, 0x42, 0x42, 0x3b, 0xf2, 0x68, 0x60, 0x56, 0x45, 0x16, 0x9a,
+	0x1d, 0x95, 0xfd, 0x4e, 0xb2, 0x22, 0x88, 0x9f, 0x01, 0xad, 0x1d, 0xb8, 0xc5, 0x49, 0x60, 0x3d,
+	0xea, 0xfb, 0xc4, 0xe1, 0x2a, 0xf4, 0x3e, 0x54, 0x84, 0x4a, 0x31, 0x65, 0x24, 0x4c, 0x61, 0xa9,
+	0x4f, 0x51, 0xcc, 0x53, 0xb5, 0x80, 0x62, 0x0e, 0x79, 0xbb, 0x29, 0x3e, 0x87, 0x5b, 0x9c, 0x84,
+	0x34, 0xc5, 0x1f, 0x40, 0x55, 0x78, 0xcc, 0x72, 0x2c, 0xd8, 0x54, 0x0b, 0x3c, 0x0b, 0x62, 0x97,
+	0x59, 0xcc, 0x23, 0xa4, 0x9e, 0x1f, 0x6d, 0x48, 0x23, 0xdb, 0x17, 0x47, 0xab, 0x60, 0x29, 0x58,
+	0x6d, 0xb8, 0xf1, 0xc4, 0x0b, 0x5c, 0x4c, 0xd8, 0x84, 0x06, 0x8c, 0xf0, 0xa3, 0x1e, 0xc5, 0x8e,
+	0x43, 0x18, 0x13, 0x9d, 0x59, 0xc7, 0x5a, 0xb4, 0xfe, 0x2c, 0x40, 0x43, 0x66, 0xb1, 0xdb, 0x1b,
+	0xe8, 0x24, 0xde, 0xcd, 0x27, 0x71, 0x55, 0xf8, 0xed, 0xf6, 0x06, 0x73, 0x73, 0xf8, 0x7f, 0xa6,
+	0xbd, 0x07, 0xcb, 0xdd, 0xde, 0x20, 0x45, 0xfa, 0x16, 0x94, 0xbb, 0xbd, 0x81, 0x3e, 0x58, 0x5d,
+	0x1f, 0x0c, 0x0b, 0xed, 0x8c, 0xce, 0x62, 0x9a, 0xce, 0x3f, 0x8a, 0xd0, 0x94, 0x24, 0x7d, 0x4b,
+	0xc3, 0x73, 0x36, 0xb1, 0x9d, 0xe4, 0x4a, 0xb9, 0x9f, 0xa7, 0x6a, 0x43, 0x58, 0x4c, 0x70, 0x6f,
+	0x77, 0xd1, 0xff, 0x00, 0xab, 0x09, 0x13, 0xa9, 0x1c, 0x74, 0x00, 0x12, 0xb5, 0xe6, 0xed, 0x66,
+	0x96, 0x37, 0x9c, 0x42, 0x5c, 0x91, 0x95, 0x2e, 0x20, 0xde, 0x03, 0xcf, 0x49, 0x64, 0xa7, 0x6c,
+	0x7f, 0x0a, 0x06, 0xd7, 0xb8, 0x76, 0x64, 0x6b, 0xd3, 0xcb, 0x49, 0xd7, 0xf0, 0x15, 0x3c, 0x5b,
+	0xb7, 0x8e, 0xe1, 0x5d, 0xad, 0xde, 0xb7, 0xc7, 0x24, 0x1f, 0xe7, 0x43, 0x80, 0x44, 0xad, 0x8d,
+	0x35, 0x33, 0xc6, 0x92, 0x65, 0x9c, 0x42, 0x5a, 0x8f, 0xe0, 0xf6, 0xc0, 0x63, 0x91, 0x06, 0x0d,
+	0xed, 0x11, 0xd3, 0xf5, 0xb2, 0x05, 0x46, 0x02, 0x14, 0xbd, 0x68, 0xe0, 0x99, 0xc2, 0xea, 0x80,
+	0x79, 0x79, 0xa3, 0xea, 0x61, 0x04, 0x65, 0x2e, 0x8b, 0x30, 0x0c, 0x2c, 0x9e, 0xad, 0xa7, 0xb0,
+	0x7e, 0x18, 0xa7, 0xe1, 0xd7, 0x72, 0x83, 0x1a, 0x50, 0x1a, 0xda, 0x23, 0xf5, 0xa6, 0xe5, 0x8f,
+	0xd6, 0x0e, 0x34, 0xf3, 0x86, 0x16, 0x5e, 0x1d, 0xcf, 0x61, 0x63, 0x97, 0xf8, 0x24, 0x22, 0xff,
+	0xfa, 0x9c, 0xc9, 0x59, 0x64, 0x04, 0xf2, 0x2c, 0x0f, 0x61, 0x73, 0x9e, 0xb9, 0x85, 0x61, 0x34,
+	0x61, 0x8d, 0xef, 0x78, 0x42, 0xe9, 0xf9, 0xd8, 0x0e, 0xcf, 0x75, 0x04, 0xd6, 0x27, 0xb0, 0x8c,
+	0xc9, 0x05, 0x3d, 0x4f, 0x5a, 0xd5, 0x84, 0xda, 0x90, 0x9e, 0x93, 0x60, 0xcf, 0x55, 0x01, 0x69,
+	0xd1, 0xda, 0x85, 0x9b, 0x1a, 0xba, 0xc8, 0x1d, 0x5f, 0x79, 0x4e, 0x18, 0xb3, 0x47, 0x44, 0x45,
+	0xaf, 0x45, 0xeb, 0x0b, 0xd8, 0xc0, 0x84, 0x91, 0xe8, 0xd0, 0x66, 0xec, 0x25, 0x0d, 0x5d, 0x61,
+	0x3d, 0xc5, 0x07, 0x8f, 0x72, 0x40, 0x47, 0x5e, 0xa0, 0xf9, 0x48, 0x14, 0xd6, 0x21, 0x6c, 0xce,
+	0xdb, 0xfa, 0x06, 0xc1, 0xbc, 0x2e, 0xc0, 0x5a, 0xc6, 0xe4, 0xec, 0x05, 0x8d, 0x2e, 0xbb, 0x52,
+	0x11, 0xcd, 0x59, 0xc9, 0x06, 0x5e, 0xcc, 0x05, 0x8e, 0x5a, 0xb0, 0xb4, 0x4f, 0x5e, 0xea, 0x1d,
+	0xe2, 0xaa, 0x31, 0x70, 0x5a, 0x65, 0x3d, 0x83, 0xf5, 0x5c, 0x1c, 0x6f, 0x70, 0xaa, 0x55, 0x58,
+	0x19, 0xd2, 0x68, 0xd2, 0x0f, 0x42, 0xea, 0xfb, 0x3a, 0xd1, 0x2f, 0x00, 0xa5, 0x95, 0xca, 0x7c,
+	0x13, 0xaa, 0x47, 0xc4, 0x09, 0x49, 0xa4, 0xce, 0xa6, 0x24, 0xae, 0x7f, 0x46, 0xa6, 0xc7, 0x78,
+	0x4f, 0x0f, 0x9a, 0x52, 0x42, 0x1f, 0xf1, 0x72, 0x71, 0xe8, 0x05, 0x09, 0xa7, 0x3d, 0xea, 0x12,
+	0x66, 0x96, 0x44, 0x9f, 0x65, 0x95, 0xd6, 0xc7, 0x32, 0x80, 0x6f, 0x48, 0xe8, 0x9d, 0x4e, 0x35,
+	0xa5, 0x08, 0xca, 0x7c, 0x55, 0x39, 0x12, 0xcf, 0x56, 0x07, 0x50, 0x1a, 0xb8, 0xb0, 0x8a, 0xbf,
+	0x84, 0x06, 0xc7, 0x0b, 0xaa, 0xb4, 0x5d, 0x7e, 0x83, 0xa7, 0xea, 0x45, 0x0a, 0x89, 0xb7, 0x62,
+	0xca, 0xdb, 0x5d, 0x58, 0x49, 0xed, 0x5e, 0xe8, 0xec, 0xa7, 0x02, 0xac, 0x1d, 0x92, 0x90, 0xd1,
+	0xc0, 0xf6, 0x33, 0x55, 0xca, 0x3d, 0xda, 0x27, 0xc4, 0x4f, 0x3c, 0x72, 0x81, 0x97, 0x40, 0xff,
+	0xd5, 0xc4, 0x0b, 0x09, 0xeb, 0x46, 0xea, 0x0a, 0x9e, 0x29, 0x04, 0xd1, 0x0e, 0x9d, 0x24, 0x8c,
+	0x29, 0x29, 0x5b, 0x38, 0xe5, 0x7c, 0xc5, 0xbf, 0x80, 0xf5, 0x5c, 0x04, 0x2a, 0xea, 0x16, 0x2c,
+	0x75, 0x45, 0x94, 0xe9, 0xc2, 0x4c, 0xab, 0xd0, 0x36, 0x54, 0xe4, 0x5a, 0x51, 0xbd, 0x2f, 0xc5,
+	0x77, 0x89, 0xb6, 0x96, 0x42, 0x62, 0x89, 0xb3, 0x1e, 0x83, 0x99, 0xf1, 0xc5, 0xaf, 0xd8, 0xeb,
+	0xf5, 0xe5, 0x00, 0x6e, 0x67, 0x76, 0xa6, 0xde, 0x0d, 0xf7, 0xa0, 0x2a, 0x54, 0xb3, 0xf7, 0xfe,
+	0x95, 0x61, 0x28, 0xa0, 0xf5, 0x19, 0x6c, 0xe6, 0xce, 0x9c, 0xbe, 0x9e, 0x10, 0x94, 0x8f, 0x63,
+	0x4f, 0xdf, 0x4d, 0xe2, 0xd9, 0xf2, 0x61, 0xad, 0xff, 0x6a, 0xe2, 0xdb, 0x5e, 0x20, 0xed, 0x5d,
+	0x2b, 0x6a, 0xb4, 0x09, 0xf5, 0x7d, 0xea, 0x12, 0x61, 0x4d, 0x56, 0x49, 0x22, 0xeb, 0xb5, 0x43,
+	0x3b, 0x3a, 0x53, 0xdd, 0x9a, 0xc8, 0xd6, 0xeb, 0x22, 0x20, 0xe9, 0xa7, 0x47, 0x83, 0x28, 0xf4,
+	0x4e, 0x62, 0x71, 0xd2, 0x4d, 0x28, 0x75, 0x1d, 0x59, 0x12, 0xe9, 0x81, 0x89, 0x2b, 0x79, 0x20,
+	0xfc, 0x6b, 0x40, 0x16, 0x8d, 0xba, 0x1d, 0x12, 0x05, 0xb2, 0xe0, 0x06, 0x17, 0x0e, 0x29, 0xf3,
+	0xb8, 0x25, 0x35, 0xa3, 0x66, 0x74, 0x99, 0x80, 0xca, 0xd9, 0x80, 0x78, 0x39, 0xee, 0x92, 0x49,
+	0x74, 0x26, 0xc6, 0x91, 0x0a, 0x96, 0x02, 0x7a, 0x0f, 0xe0, 0xe0, 0x82, 0x84, 0xa1, 0xe7, 0xba,
+	0x44, 0xce, 0x22, 0x75, 0x9c, 0xd2, 0xf0, 0x4e, 0x96, 0xf3, 0x51, 0xd7, 0xf7, 0xe9, 0x4b, 0xe2,
+	0x8a, 0xb9, 0xaa, 0x8e, 0xb3, 0x4a, 0xee, 0x77, 0x97, 0x38, 0x1e, 0xf3, 0x2e, 0x88, 0x59, 0x17,
+	0x80, 0x44, 0xb6, 0x7e, 0x2e, 0x40, 0x03, 0x7b, 0xa3, 0xb3, 0x48, 0x90, 0x1f, 0x88, 0x59, 0x86,
+	0x07, 0x23, 0x74, 0xba, 0x37, 0x84, 0x20, 0xe7, 0x29, 0x3b, 0x88, 0x88, 0xa4, 0xba, 0x8e, 0xb5,
+	0xc8, 0xfb, 0x02, 0x13, 0x9b, 0xa9, 0x63, 0x1b, 0x58, 0x49, 0xe8, 0x2b, 0x58, 0x4e, 0xd3, 0xcb,
+	0xcc, 0xb2, 0xa8, 0x1f, 0x53, 0x8e, 0x7d, 0x97, 0xf9, 0xc7, 0x59, 0xb8, 0xf5, 0x4b, 0x11, 0xd6,
+	0x73, 0x45, 0xa1, 0x5a, 0xe7, 0x3f, 0xa9, 0x8a, 0xd9, 0x87, 0x60, 0x79, 0xfe, 0x87, 0x60, 0x6e,
+	0x9a, 0xab, 0x2c, 0x9c, 0xe6, 0xee, 0x40, 0x5d, 0xa4, 0x82, 0xcf, 0xcc, 0x55, 0x81, 0x6e, 0x08,
+	0xb4, 0xcc, 0x8f, 0x18, 0x47, 0x71, 0x82, 0x40, 0x1d, 0xa8, 0x0a, 0xa6, 0x99, 0x59, 0x53, 0xf3,
+	0x97, 0x1c, 0x8f, 0x73, 0xe9, 0xc1, 0x0a, 0x65, 0xfd, 0x56, 0x80, 0xdb, 0xd2, 0xd2, 0x91, 0x37,
+	0x8e, 0x7d, 0xb9, 0xa8, 0xda, 0xe6, 0x01, 0xdc, 0x48, 0x39, 0xd1, 0x9d, 0x7b, 0xd9, 0x7b, 0x06,
+	0xc5, 0xeb, 0x69, 0x2f, 0x70, 0xfc, 0xd8, 0x25, 0x47, 0x11, 0x0d, 0x93, 0x44, 0x67, 0x95, 0xe8,
+	0x01, 0xd4, 0x95, 0x1b, 0x79, 0x11, 0xf2, 0x8c, 0xce, 0xec, 0xf6, 0x83, 0x91, 0x17, 0xe8, 0x56,
+	0xc7, 0x09, 0xd2, 0xfa, 0xab, 0x08, 0xcd, 0xcb, 0xd1, 0xb2, 0xd8, 0x8f, 0xd0, 0x0e, 0xd4, 0x14,
+	0x4c, 0xb5, 0xde, 0xd5, 0xf6, 0x6a, 0xa9, 0x11, 0x47, 0x17, 0xbd, 0xaa, 0x46, 0x25, 0xf2, 0x56,
+	0xe4, 0x6c, 0x79, 0x8e, 0x17, 0xed, 0x92, 0x60, 0x2a, 0xb2, 0x5c, 0xc7, 0x19, 0x1d, 0xbf, 0x7a,
+	0x77, 0xc9, 0xa9, 0x1d, 0xfb, 0x12, 0x52, 0x16, 0x90, 0xb4, 0x8a, 0xd7, 0x89, 0xf4, 0xbf, 0xe7,
+	0x8a, 0x9e, 0x34, 0x70, 0x22, 0xa3, 0x3b, 0xb0, 0x22, 0x9f, 0x77, 0x09, 0x73, 0x42, 0x6f, 0x92,
+	0x7c, 0x29, 0x18, 0xf8, 0xf2, 0x02, 0x6a, 0xc3, 0xad, 0x14, 0xc9, 0xa2, 0x28, 0x6b, 0x02, 0x9b,
+	0x57, 0xe7, 0x90, 0x7c, 0x86, 0x34, 0xeb, 0x97, 0x90, 0x5c, 0xcd, 0x3b, 0xb4, 0x1f, 0x86, 0x34,
+	0x34, 0x0d, 0xd9, 0xa1, 0x42, 0xb0, 0x30, 0x98, 0x73, 0x18, 0x96, 0x1d, 0xf3, 0x10, 0x6a, 0x92,
+	0x6d, 0x5d, 0x0b, 0x5b, 0xb2, 0xba, 0xe6, 0xa7, 0x04, 0x6b, 0xf0, 0x49, 0x55, 0xfc, 0x0b, 0x76,
+	0xff, 0xef, 0x01, 0x00, 0xba, 0x8b, 0x99, 0x11, 0xba, 0x13, 0x00, 0x00,
 }
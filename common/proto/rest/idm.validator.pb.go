@@ -9,6 +9,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/pydio/cells/common/proto/idm"
 import _ "github.com/pydio/cells/common/service/proto"
+import _ "github.com/pydio/cells/common/proto/auth"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -182,3 +183,135 @@ func (this *ResetPasswordRequest) Validate() error {
 func (this *ResetPasswordResponse) Validate() error {
 	return nil
 }
+func (this *TotpEnrollRequest) Validate() error {
+	return nil
+}
+func (this *TotpEnrollResponse) Validate() error {
+	return nil
+}
+func (this *TotpVerifyRequest) Validate() error {
+	return nil
+}
+func (this *TotpVerifyResponse) Validate() error {
+	return nil
+}
+func (this *TotpResetRequest) Validate() error {
+	return nil
+}
+func (this *TotpResetResponse) Validate() error {
+	return nil
+}
+func (this *PersonalTokenRequest) Validate() error {
+	return nil
+}
+func (this *PersonalTokenResponse) Validate() error {
+	if this.Token != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Token); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Token", err)
+		}
+	}
+	return nil
+}
+func (this *PersonalTokenListRequest) Validate() error {
+	return nil
+}
+func (this *PersonalTokenCollection) Validate() error {
+	for _, item := range this.Tokens {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Tokens", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *PersonalTokenRevokeRequest) Validate() error {
+	return nil
+}
+func (this *ExplainAccessRequest) Validate() error {
+	return nil
+}
+func (this *AccessContribution) Validate() error {
+	if this.Acl != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Acl); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Acl", err)
+		}
+	}
+	return nil
+}
+func (this *RightExplanation) Validate() error {
+	for _, item := range this.Contributions {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Contributions", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *ExplainAccessResponse) Validate() error {
+	for _, item := range this.Roles {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Roles", err)
+			}
+		}
+	}
+	for _, item := range this.Workspaces {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Workspaces", err)
+			}
+		}
+	}
+	for _, item := range this.Policies {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Policies", err)
+			}
+		}
+	}
+	for _, item := range this.Rights {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Rights", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *PolicySimulationRequest) Validate() error {
+	for _, item := range this.PolicyGroups {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("PolicyGroups", err)
+			}
+		}
+	}
+	for _, item := range this.Requests {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Requests", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *PolicySimulationResult) Validate() error {
+	if this.Request != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Request); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Request", err)
+		}
+	}
+	return nil
+}
+func (this *PolicySimulationResponse) Validate() error {
+	for _, item := range this.Results {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Results", err)
+			}
+		}
+	}
+	return nil
+}
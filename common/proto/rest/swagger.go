@@ -126,6 +126,33 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/acl/explain": {
+      "post": {
+        "summary": "Explain which roles, ACLs, policies and workspaces grant or deny rights to a user on a node",
+        "operationId": "ExplainAccess",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restExplainAccessResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restExplainAccessRequest"
+            }
+          }
+        ],
+        "tags": [
+          "ACLService"
+        ]
+      }
+    },
     "/activity/stream": {
       "post": {
         "summary": "Load the the feeds of the currently logged user",
@@ -259,6 +286,82 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/auth/token/personal": {
+      "get": {
+        "summary": "List personal access tokens of the current user, or of any user for admins",
+        "operationId": "ListPersonalTokens",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restPersonalTokenCollection"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "UserLogin",
+            "description": "Login of the owner, admins only, current user if empty.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "TokenService"
+        ]
+      },
+      "post": {
+        "summary": "Generate a personal access token, to be used as a bearer by scripts and tools",
+        "operationId": "CreatePersonalToken",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restPersonalTokenResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restPersonalTokenRequest"
+            }
+          }
+        ],
+        "tags": [
+          "TokenService"
+        ]
+      }
+    },
+    "/auth/token/personal/{Uuid}": {
+      "delete": {
+        "summary": "Revoke a personal access token",
+        "operationId": "RevokePersonalToken",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restRevokeResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Uuid",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "TokenService"
+        ]
+      }
+    },
     "/auth/token/revoke": {
       "post": {
         "summary": "Revoke a JWT token",
@@ -1500,6 +1603,33 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/jobs/simulate": {
+      "post": {
+        "summary": "Simulate a job definition against a sample event or input, without side effects",
+        "operationId": "SimulateJob",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/jobsSimulateJobResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/jobsSimulateJobRequest"
+            }
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
     "/jobs/tasks/delete": {
       "post": {
         "summary": "Send a control command to clean tasks on a given job",
@@ -1554,6 +1684,137 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/jobs/tasks/{JobID}/{TaskID}/artifacts": {
+      "get": {
+        "summary": "List artifacts produced by a task",
+        "operationId": "ListTaskArtifacts",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/jobsListTaskArtifactsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "JobID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "TaskID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
+    "/jobs/tasks/{JobID}/{TaskID}/artifacts/{Name}": {
+      "get": {
+        "summary": "Download an artifact produced by a task",
+        "operationId": "DownloadTaskArtifact",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/jobsReadTaskArtifactResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "JobID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "TaskID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "Name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "Offset",
+            "description": "Start reading at this offset.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "Limit",
+            "description": "Maximum number of bytes to read, 1MB by default.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
+    "/jobs/tasks/{JobID}/{TaskID}/log": {
+      "get": {
+        "summary": "Read a chunk of a task log stream, use a negative offset to tail the log",
+        "operationId": "ReadTaskLog",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/jobsReadTaskLogResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "JobID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "TaskID",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "Offset",
+            "description": "Start reading at this offset, or at this number of bytes before the end if negative.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "Limit",
+            "description": "Maximum number of bytes to read, 64KB by default.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          }
+        ],
+        "tags": [
+          "JobsService"
+        ]
+      }
+    },
     "/jobs/user": {
       "post": {
         "summary": "List jobs associated with current user",
@@ -1846,6 +2107,33 @@ var SwaggerJson = `{
         ]
       }
     },
+    "/policy/simulate": {
+      "post": {
+        "summary": "Evaluate requests against candidate security policies without storing them",
+        "operationId": "SimulatePolicies",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restPolicySimulationResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restPolicySimulationRequest"
+            }
+          }
+        ],
+        "tags": [
+          "PolicyService"
+        ]
+      }
+    },
     "/role": {
       "post": {
         "summary": "Search Roles",
@@ -2809,34 +3097,115 @@ var SwaggerJson = `{
         ]
       }
     },
-    "/user/{Login}": {
-      "get": {
-        "summary": "Get a user by login",
-        "operationId": "GetUser",
+    "/user/totp/enroll": {
+      "post": {
+        "summary": "Start the enrollment of a one-time password second factor for the current user",
+        "operationId": "TotpEnroll",
         "responses": {
           "200": {
             "description": "",
             "schema": {
-              "$ref": "#/definitions/idmUser"
+              "$ref": "#/definitions/restTotpEnrollResponse"
             }
           }
         },
         "parameters": [
           {
-            "name": "Login",
-            "in": "path",
+            "name": "body",
+            "in": "body",
             "required": true,
-            "type": "string"
-          },
-          {
-            "name": "Uuid",
-            "description": "User unique identifier.",
-            "in": "query",
-            "required": false,
-            "type": "string"
-          },
-          {
-            "name": "GroupPath",
+            "schema": {
+              "$ref": "#/definitions/restTotpEnrollRequest"
+            }
+          }
+        ],
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/totp/reset": {
+      "post": {
+        "summary": "Remove the second factor of the current user, or of any user for admins",
+        "operationId": "TotpReset",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restTotpResetResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restTotpResetRequest"
+            }
+          }
+        ],
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/totp/verify": {
+      "post": {
+        "summary": "Activate the second factor by verifying a first one-time password",
+        "operationId": "TotpVerify",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/restTotpVerifyResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/restTotpVerifyRequest"
+            }
+          }
+        ],
+        "tags": [
+          "UserService"
+        ]
+      }
+    },
+    "/user/{Login}": {
+      "get": {
+        "summary": "Get a user by login",
+        "operationId": "GetUser",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/idmUser"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "Login",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "Uuid",
+            "description": "User unique identifier.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "GroupPath",
             "description": "Path to the parent group.",
             "in": "query",
             "required": false,
@@ -3514,6 +3883,45 @@ var SwaggerJson = `{
       ],
       "default": "GENERIC"
     },
+    "authPersonalAccessToken": {
+      "type": "object",
+      "properties": {
+        "Uuid": {
+          "type": "string"
+        },
+        "Label": {
+          "type": "string"
+        },
+        "UserUuid": {
+          "type": "string"
+        },
+        "UserLogin": {
+          "type": "string"
+        },
+        "Scopes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Restrictions applied to the token, \"read-only\" and/or \"workspace:SLUG\" entries"
+        },
+        "ExpiresAt": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Expiration timestamp, mandatory"
+        },
+        "CreatedAt": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "LastUsedAt": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Last time the token was used, 0 if never"
+        }
+      },
+      "title": "PersonalAccessToken describes a token, only its hash is stored"
+    },
     "authToken": {
       "type": "object",
       "properties": {
@@ -3848,6 +4256,51 @@ var SwaggerJson = `{
         }
       }
     },
+    "idmChangeEvent": {
+      "type": "object",
+      "properties": {
+        "@type": {
+          "type": "string"
+        },
+        "Type": {
+          "$ref": "#/definitions/idmChangeEventType"
+        },
+        "User": {
+          "$ref": "#/definitions/idmUser"
+        },
+        "Role": {
+          "$ref": "#/definitions/idmRole"
+        },
+        "Workspace": {
+          "$ref": "#/definitions/idmWorkspace"
+        },
+        "Acl": {
+          "$ref": "#/definitions/idmACL"
+        },
+        "MetaNamespace": {
+          "$ref": "#/definitions/idmUserMetaNamespace"
+        },
+        "Attributes": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        }
+      },
+      "title": "Global Event message for IDM"
+    },
+    "idmChangeEventType": {
+      "type": "string",
+      "enum": [
+        "CREATE",
+        "READ",
+        "UPDATE",
+        "DELETE",
+        "BIND",
+        "LOGOUT"
+      ],
+      "default": "CREATE"
+    },
     "idmListPolicyGroupsRequest": {
       "type": "object"
     },
@@ -3933,6 +4386,29 @@ var SwaggerJson = `{
       ],
       "default": "unknown"
     },
+    "idmPolicyEngineRequest": {
+      "type": "object",
+      "properties": {
+        "Resource": {
+          "type": "string"
+        },
+        "Action": {
+          "type": "string"
+        },
+        "Subjects": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Context": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "idmPolicyGroup": {
       "type": "object",
       "properties": {
@@ -4770,9 +5246,45 @@ var SwaggerJson = `{
             "$ref": "#/definitions/jobsAction"
           },
           "title": "If any Filter is used, next actions can be triggered on Failure\nThis adds ability to create conditional Yes/No branches"
+        },
+        "JoinID": {
+          "type": "string",
+          "title": "Send the output of this action to the Join with this ID"
+        },
+        "RetryPolicy": {
+          "$ref": "#/definitions/jobsRetryPolicy",
+          "title": "Optional policy to retry this action when it returns an error"
         }
       }
     },
+    "jobsActionAttempt": {
+      "type": "object",
+      "properties": {
+        "Attempt": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Attempt number, starting at 1"
+        },
+        "StartTime": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "EndTime": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "Success": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "True if this attempt succeeded"
+        },
+        "ErrorString": {
+          "type": "string",
+          "title": "Error returned by this attempt"
+        }
+      },
+      "title": "ActionAttempt records one run of an action"
+    },
     "jobsActionLog": {
       "type": "object",
       "properties": {
@@ -4784,6 +5296,13 @@ var SwaggerJson = `{
         },
         "OutputMessage": {
           "$ref": "#/definitions/jobsActionMessage"
+        },
+        "Attempts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsActionAttempt"
+          },
+          "title": "History of attempts if the action has a RetryPolicy"
         }
       }
     },
@@ -5128,6 +5647,21 @@ var SwaggerJson = `{
             "$ref": "#/definitions/jobsJobParameter"
           },
           "title": "Job-level parameters that can be passed to underlying actions"
+        },
+        "Joins": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsJoin"
+          },
+          "title": "Join nodes waiting for multiple branches before continuing"
+        },
+        "Throttle": {
+          "$ref": "#/definitions/jobsTriggerThrottle",
+          "title": "Limit the number of tasks started by events"
+        },
+        "CalendarName": {
+          "type": "string",
+          "description": "Name of a Calendar restricting when tasks can be started by the timer or by events.\nTriggers received outside of the calendar windows are deferred until it opens."
         }
       }
     },
@@ -5161,6 +5695,46 @@ var SwaggerJson = `{
         }
       }
     },
+    "jobsJoin": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "string",
+          "title": "Unique identifier of this Join inside the Job"
+        },
+        "Label": {
+          "type": "string",
+          "title": "User-defined label for this Join"
+        },
+        "Mode": {
+          "$ref": "#/definitions/jobsJoinMode",
+          "title": "Wait for all or any upstream branch"
+        },
+        "FailOnBranchError": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Fail the Join as soon as one upstream branch fails"
+        },
+        "ChainedActions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsAction"
+          },
+          "title": "Actions to perform with the merged output"
+        }
+      },
+      "description": "Join waits for a set of upstream branches and merges their outputs\nbefore triggering its own chain. Branches are connected to a Join\nby setting their Action.JoinID."
+    },
+    "jobsJoinMode": {
+      "type": "string",
+      "enum": [
+        "WaitAll",
+        "WaitAny"
+      ],
+      "default": "WaitAll",
+      "description": " - WaitAll: Wait for all upstream branches to finish\n - WaitAny: Continue as soon as one upstream branch has finished",
+      "title": "Possible values for Join.Mode"
+    },
     "jobsListJobsRequest": {
       "type": "object",
       "properties": {
@@ -5201,6 +5775,17 @@ var SwaggerJson = `{
         }
       }
     },
+    "jobsListTaskArtifactsResponse": {
+      "type": "object",
+      "properties": {
+        "Artifacts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsTaskArtifact"
+          }
+        }
+      }
+    },
     "jobsNodesSelector": {
       "type": "object",
       "properties": {
@@ -5228,36 +5813,217 @@ var SwaggerJson = `{
       },
       "title": "/////////////////\nJOB  SERVICE  //\n/////////////////"
     },
-    "jobsSchedule": {
+    "jobsReadTaskArtifactResponse": {
       "type": "object",
       "properties": {
-        "Iso8601Schedule": {
-          "type": "string",
-          "description": "ISO 8601 Description of the scheduling for instance \"R2/2015-06-04T19:25:16.828696-07:00/PT4S\"\nwhere first part is the number of repetitions (if 0, infinite repetition), \nsecond part the starting date and last part, the duration between 2 occurrences."
+        "Artifact": {
+          "$ref": "#/definitions/jobsTaskArtifact"
         },
-        "Iso8601MinDelta": {
+        "Data": {
           "type": "string",
-          "title": "Minimum time between two runs"
+          "format": "byte"
         }
       }
     },
-    "jobsTask": {
+    "jobsReadTaskLogResponse": {
       "type": "object",
       "properties": {
-        "ID": {
-          "type": "string"
-        },
-        "JobID": {
-          "type": "string"
-        },
-        "Status": {
-          "$ref": "#/definitions/jobsTaskStatus"
+        "Data": {
+          "type": "string",
+          "format": "byte"
         },
-        "StatusMessage": {
-          "type": "string"
+        "NextOffset": {
+          "type": "string",
+          "format": "int64",
+          "title": "Offset to use for the next call"
         },
-        "TriggerOwner": {
-          "type": "string"
+        "Size": {
+          "type": "string",
+          "format": "int64",
+          "title": "Total size of the log stream"
+        }
+      }
+    },
+    "jobsRetryPolicy": {
+      "type": "object",
+      "properties": {
+        "MaxAttempts": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Maximum number of attempts, including the first one"
+        },
+        "InitialBackoff": {
+          "type": "string",
+          "title": "Delay before the first retry, as a duration string (e.g. \"10s\"), 1s by default"
+        },
+        "MaxBackoff": {
+          "type": "string",
+          "title": "Maximum delay between two attempts, as a duration string"
+        },
+        "BackoffMultiplier": {
+          "type": "number",
+          "format": "float",
+          "title": "Multiplier applied to the delay after each attempt, 2 by default"
+        },
+        "RetryableErrors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Retry only errors matching one of these regular expressions (all errors if empty)"
+        },
+        "AttemptTimeout": {
+          "type": "string",
+          "title": "Maximum duration of each attempt, as a duration string"
+        }
+      },
+      "title": "RetryPolicy defines how an action is retried when it returns an error"
+    },
+    "jobsSchedule": {
+      "type": "object",
+      "properties": {
+        "Iso8601Schedule": {
+          "type": "string",
+          "description": "ISO 8601 Description of the scheduling for instance \"R2/2015-06-04T19:25:16.828696-07:00/PT4S\"\nwhere first part is the number of repetitions (if 0, infinite repetition), \nsecond part the starting date and last part, the duration between 2 occurrences."
+        },
+        "Iso8601MinDelta": {
+          "type": "string",
+          "title": "Minimum time between two runs"
+        },
+        "Cron": {
+          "type": "string",
+          "description": "Standard 5-fields cron expression, for instance \"0 2 * * 1-5\" (every weekday at 2am).\nDescriptors @yearly, @monthly, @weekly, @daily and @hourly are also supported.\nCron and Iso8601Schedule are mutually exclusive."
+        },
+        "TimeZone": {
+          "type": "string",
+          "description": "IANA time zone name used to evaluate the Cron expression, for instance \"Europe/Paris\".\nDefaults to the server local time zone."
+        }
+      }
+    },
+    "jobsSimulateJobRequest": {
+      "type": "object",
+      "properties": {
+        "Job": {
+          "$ref": "#/definitions/jobsJob",
+          "title": "Job definition, it does not have to be saved"
+        },
+        "NodeEvent": {
+          "$ref": "#/definitions/treeNodeChangeEvent",
+          "title": "Sample node event used as trigger"
+        },
+        "IdmEvent": {
+          "$ref": "#/definitions/idmChangeEvent",
+          "title": "Sample idm event used as trigger"
+        },
+        "Input": {
+          "$ref": "#/definitions/jobsActionMessage",
+          "title": "Input passed to the first actions, built from the event if empty"
+        }
+      },
+      "description": "SimulateJobRequest evaluates a job definition against a sample event or input,\nwithout any side effects."
+    },
+    "jobsSimulateJobResponse": {
+      "type": "object",
+      "properties": {
+        "Triggered": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "False if the event does not pass the job-level filters"
+        },
+        "JobFilters": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsSimulationFilterResult"
+          },
+          "title": "Job-level filters evaluated on the event"
+        },
+        "Trace": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsSimulationStep"
+          },
+          "title": "Ordered list of the actions run"
+        }
+      }
+    },
+    "jobsSimulationFilterResult": {
+      "type": "object",
+      "properties": {
+        "Type": {
+          "type": "string",
+          "title": "Filter type (NodesFilter, IdmFilter, ContextMetaFilter, etc.)"
+        },
+        "Passed": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "True if the input passed this filter"
+        }
+      },
+      "title": "Result of a single filter or selector evaluation"
+    },
+    "jobsSimulationStep": {
+      "type": "object",
+      "properties": {
+        "ActionPath": {
+          "type": "string",
+          "title": "Path of the action in the chain, as used in tasks"
+        },
+        "ActionID": {
+          "type": "string",
+          "title": "Action identifier"
+        },
+        "Status": {
+          "$ref": "#/definitions/jobsSimulationStepStatus"
+        },
+        "Filters": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/jobsSimulationFilterResult"
+          },
+          "title": "Filters evaluated on the input"
+        },
+        "Input": {
+          "$ref": "#/definitions/jobsActionMessage"
+        },
+        "Output": {
+          "$ref": "#/definitions/jobsActionMessage"
+        },
+        "Message": {
+          "type": "string",
+          "title": "Additional information (error, join, etc.)"
+        }
+      },
+      "title": "One action run during a simulation"
+    },
+    "jobsSimulationStepStatus": {
+      "type": "string",
+      "enum": [
+        "Simulated",
+        "NotSimulated",
+        "FilteredOut",
+        "Failed"
+      ],
+      "default": "Simulated",
+      "description": " - Simulated: Action was run in simulation mode\n - NotSimulated: Action does not support simulation, its input is passed as output\n - FilteredOut: Action filters did not pass\n - Failed: Action is unknown or its simulation returned an error",
+      "title": "Possible values for SimulationStep.Status"
+    },
+    "jobsTask": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "string"
+        },
+        "JobID": {
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/jobsTaskStatus"
+        },
+        "StatusMessage": {
+          "type": "string"
+        },
+        "TriggerOwner": {
+          "type": "string"
         },
         "StartTime": {
           "type": "integer",
@@ -5293,6 +6059,37 @@ var SwaggerJson = `{
             "$ref": "#/definitions/jobsActionLog"
           },
           "title": "Logs of all the actions performed"
+        },
+        "SkippedTriggers": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Number of triggers skipped by the job throttle since the previous task"
+        }
+      }
+    },
+    "jobsTaskArtifact": {
+      "type": "object",
+      "properties": {
+        "JobID": {
+          "type": "string"
+        },
+        "TaskID": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string",
+          "title": "File name, unique for a given task"
+        },
+        "Size": {
+          "type": "string",
+          "format": "int64"
+        },
+        "MTime": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "ContentType": {
+          "type": "string"
         }
       }
     },
@@ -5312,6 +6109,26 @@ var SwaggerJson = `{
       "default": "Unknown",
       "title": "/////////////////\nTASK SERVICE  //\n/////////////////"
     },
+    "jobsTriggerThrottle": {
+      "type": "object",
+      "properties": {
+        "Debounce": {
+          "type": "string",
+          "description": "Wait for this duration without new events on the same node before starting a task,\nas a duration string (e.g. \"10s\"). Only the latest event is kept."
+        },
+        "MaxPerMinute": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Maximum number of tasks started per minute, unlimited if 0"
+        },
+        "Coalesce": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "When the rate limit is reached, keep the latest trigger and start it as soon\nas possible instead of dropping it"
+        }
+      },
+      "description": "TriggerThrottle limits the number of tasks started by events for a given job.\nTimer triggers and manual runs are never throttled."
+    },
     "jobsUsersSelector": {
       "type": "object",
       "properties": {
@@ -5717,6 +6534,47 @@ var SwaggerJson = `{
       },
       "title": "Response for search request"
     },
+    "restAccessContribution": {
+      "type": "object",
+      "properties": {
+        "Acl": {
+          "$ref": "#/definitions/idmACL"
+        },
+        "RoleLabel": {
+          "type": "string",
+          "title": "Label of the role carrying the ACL"
+        },
+        "RolePosition": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Position of the role in the user roles, later roles override previous ones"
+        },
+        "NodePath": {
+          "type": "string",
+          "title": "Path of the node carrying the ACL"
+        },
+        "Depth": {
+          "type": "integer",
+          "format": "int32",
+          "title": "0 for the node itself, 1 for its parent, etc."
+        },
+        "Overridden": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "A role coming later carries ACLs on the same node"
+        },
+        "PolicyAllowed": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Result of the evaluation of a policy-based ACL"
+        },
+        "Decisive": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "This ACL determined the resulting right"
+        }
+      }
+    },
     "restActionDescription": {
       "type": "object",
       "properties": {
@@ -6142,6 +7000,64 @@ var SwaggerJson = `{
         }
       }
     },
+    "restExplainAccessRequest": {
+      "type": "object",
+      "properties": {
+        "UserLogin": {
+          "type": "string",
+          "title": "Login of the user whose access is explained"
+        },
+        "NodeUuid": {
+          "type": "string",
+          "title": "Uuid of the node, takes precedence over NodePath"
+        },
+        "NodePath": {
+          "type": "string",
+          "title": "Path of the node, starting with a datasource name"
+        }
+      }
+    },
+    "restExplainAccessResponse": {
+      "type": "object",
+      "properties": {
+        "UserLogin": {
+          "type": "string"
+        },
+        "NodeUuid": {
+          "type": "string"
+        },
+        "NodePath": {
+          "type": "string"
+        },
+        "Roles": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/idmRole"
+          },
+          "title": "Roles of the user, in resolution order"
+        },
+        "Workspaces": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/idmWorkspace"
+          },
+          "title": "Workspaces containing the node"
+        },
+        "Policies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/idmPolicyGroup"
+          },
+          "title": "Policies referenced by policy-based ACLs"
+        },
+        "Rights": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restRightExplanation"
+          }
+        }
+      }
+    },
     "restFrontBinaryRequest": {
       "type": "object",
       "properties": {
@@ -6563,6 +7479,126 @@ var SwaggerJson = `{
       },
       "title": "Generic container for responses sending pagination information"
     },
+    "restPersonalTokenCollection": {
+      "type": "object",
+      "properties": {
+        "Tokens": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/authPersonalAccessToken"
+          }
+        }
+      }
+    },
+    "restPersonalTokenRequest": {
+      "type": "object",
+      "properties": {
+        "Label": {
+          "type": "string",
+          "title": "Label of the token"
+        },
+        "ExpiresAt": {
+          "type": "integer",
+          "format": "int32",
+          "title": "Expiration timestamp, mandatory"
+        },
+        "Scopes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Restrictions applied to the token, \"read-only\" and/or \"workspace:SLUG\" entries"
+        },
+        "UserLogin": {
+          "type": "string",
+          "title": "Login of the owner, admins only, current user if empty"
+        }
+      }
+    },
+    "restPersonalTokenResponse": {
+      "type": "object",
+      "properties": {
+        "AccessToken": {
+          "type": "string",
+          "title": "Value of the token, it cannot be retrieved later"
+        },
+        "Token": {
+          "$ref": "#/definitions/authPersonalAccessToken"
+        }
+      }
+    },
+    "restPolicySimulationRequest": {
+      "type": "object",
+      "properties": {
+        "PolicyGroups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/idmPolicyGroup"
+          },
+          "title": "Candidate policy groups, replacing stored groups with the same Uuid"
+        },
+        "IncludeStored": {
+          "type": "boolean",
+          "format": "boolean",
+          "title": "Evaluate the stored policy groups along with the candidates"
+        },
+        "Requests": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/idmPolicyEngineRequest"
+          },
+          "title": "Requests to evaluate"
+        }
+      }
+    },
+    "restPolicySimulationResponse": {
+      "type": "object",
+      "properties": {
+        "Results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restPolicySimulationResult"
+          }
+        }
+      }
+    },
+    "restPolicySimulationResult": {
+      "type": "object",
+      "properties": {
+        "Request": {
+          "$ref": "#/definitions/idmPolicyEngineRequest"
+        },
+        "Allowed": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "ExplicitDeny": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "DefaultDeny": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "PolicyId": {
+          "type": "string",
+          "title": "Id of the deciding policy: the denying one on explicit deny, the first allowing one otherwise"
+        },
+        "PolicyDescription": {
+          "type": "string"
+        },
+        "PolicyGroupUuid": {
+          "type": "string"
+        },
+        "PolicyGroupName": {
+          "type": "string"
+        },
+        "Error": {
+          "type": "string",
+          "title": "Evaluation error, if any"
+        }
+      }
+    },
     "restProcess": {
       "type": "object",
       "properties": {
@@ -6783,6 +7819,28 @@ var SwaggerJson = `{
       },
       "title": "Rest response"
     },
+    "restRightExplanation": {
+      "type": "object",
+      "properties": {
+        "Right": {
+          "type": "string",
+          "title": "One of read, write, deny, lock, quota"
+        },
+        "Granted": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "Reason": {
+          "type": "string"
+        },
+        "Contributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/restAccessContribution"
+          }
+        }
+      }
+    },
     "restRolesCollection": {
       "type": "object",
       "properties": {
@@ -7305,6 +8363,69 @@ var SwaggerJson = `{
       },
       "title": "A template node is representing a file or a folder"
     },
+    "restTotpEnrollRequest": {
+      "type": "object"
+    },
+    "restTotpEnrollResponse": {
+      "type": "object",
+      "properties": {
+        "Secret": {
+          "type": "string",
+          "title": "Secret to be entered in an authenticator application"
+        },
+        "KeyURI": {
+          "type": "string",
+          "title": "otpauth:// URI of the secret, to be displayed as a QR code"
+        },
+        "RecoveryCodes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "title": "Single-use codes replacing a one-time password if the device is lost"
+        }
+      }
+    },
+    "restTotpResetRequest": {
+      "type": "object",
+      "properties": {
+        "Login": {
+          "type": "string",
+          "title": "Login of the user, admins only, current user if empty"
+        },
+        "Code": {
+          "type": "string",
+          "title": "One-time password or recovery code, required when resetting one's own second factor"
+        }
+      }
+    },
+    "restTotpResetResponse": {
+      "type": "object",
+      "properties": {
+        "Success": {
+          "type": "boolean",
+          "format": "boolean"
+        }
+      }
+    },
+    "restTotpVerifyRequest": {
+      "type": "object",
+      "properties": {
+        "Code": {
+          "type": "string",
+          "title": "One-time password generated by the authenticator application"
+        }
+      }
+    },
+    "restTotpVerifyResponse": {
+      "type": "object",
+      "properties": {
+        "Success": {
+          "type": "boolean",
+          "format": "boolean"
+        }
+      }
+    },
     "restUpdateSharePoliciesRequest": {
       "type": "object",
       "properties": {
@@ -7804,7 +8925,7 @@ var SwaggerJson = `{
         "MinDate": {
           "type": "string",
           "format": "int64",
-          "title": "Range for date"
+          "title": "Range for date, negative values are relative to the current time (in seconds) when used in jobs selectors"
         },
         "MaxDate": {
           "type": "string",
@@ -1456,7 +1456,7 @@ type Query struct {
 	// Range for size
 	MinSize int64 `protobuf:"varint,2,opt,name=MinSize" json:"MinSize,omitempty"`
 	MaxSize int64 `protobuf:"varint,3,opt,name=MaxSize" json:"MaxSize,omitempty"`
-	// Range for date
+	// Range for date, negative values are relative to the current time (in seconds) when used in jobs selectors
 	MinDate int64 `protobuf:"varint,4,opt,name=MinDate" json:"MinDate,omitempty"`
 	MaxDate int64 `protobuf:"varint,5,opt,name=MaxDate" json:"MaxDate,omitempty"`
 	// Limit to a given node type
@@ -22,8 +22,11 @@ package servicecontext
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/micro/go-micro/metadata"
@@ -45,6 +48,106 @@ const (
 	CtxWorkspaceUuid = "CtxWorkspaceUuid"
 )
 
+var (
+	trustedProxies     []*net.IPNet
+	trustedProxiesLock sync.RWMutex
+)
+
+// SetTrustedProxies registers the addresses or CIDR ranges of the reverse proxies placed in front of
+// the services. Loopback addresses are always trusted, as the bundled proxy runs on the same host.
+func SetTrustedProxies(proxies []string) error {
+	var nets []*net.IPNet
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, e := net.ParseCIDR(p)
+		if e != nil {
+			return fmt.Errorf("invalid trusted proxy %s: %v", p, e)
+		}
+		nets = append(nets, n)
+	}
+	trustedProxiesLock.Lock()
+	trustedProxies = nets
+	trustedProxiesLock.Unlock()
+	return nil
+}
+
+// isTrustedProxy checks if an address (with or without port) is a loopback or a registered trusted proxy.
+func isTrustedProxy(address string) bool {
+	if host, _, e := net.SplitHostPort(address); e == nil {
+		address = host
+	}
+	ip := net.ParseIP(strings.Trim(address, "[]"))
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	trustedProxiesLock.RLock()
+	defer trustedProxiesLock.RUnlock()
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForwardedClientAddress finds the client address in X-Forwarded-For values. Each proxy appends the address
+// it received the request from, so entries are read from right to left, skipping trusted proxies: entries
+// on the left of the first untrusted one may have been forged by the client.
+func ForwardedClientAddress(forwarded ...string) string {
+	var entries []string
+	for _, h := range forwarded {
+		for _, e := range strings.Split(h, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				entries = append(entries, e)
+			}
+		}
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !isTrustedProxy(entries[i]) {
+			return entries[i]
+		}
+	}
+	if len(entries) > 0 {
+		return entries[0]
+	}
+	return ""
+}
+
+// ClientAddress resolves the remote address of a request. X-Forwarded-For is only used when the
+// request comes from a trusted proxy, otherwise the connection address is returned.
+func ClientAddress(req *http.Request) string {
+	if h, ok := req.Header["X-Forwarded-For"]; ok && (req.RemoteAddr == "" || isTrustedProxy(req.RemoteAddr)) {
+		if a := ForwardedClientAddress(h...); a != "" {
+			return a
+		}
+	}
+	return req.RemoteAddr
+}
+
+// PeerClientAddress resolves the client address of a connection established from peer. Forwarded values
+// are only used when the peer is a trusted proxy, otherwise the peer address is returned.
+func PeerClientAddress(peer string, forwarded ...string) string {
+	if len(forwarded) > 0 && peer != "" && isTrustedProxy(peer) {
+		if a := ForwardedClientAddress(forwarded...); a != "" {
+			return a
+		}
+	}
+	return peer
+}
+
 // HttpRequestInfoToMetadata extracts as much HTTP metadata as possible and stores it in the context as metadata.
 func HttpRequestInfoToMetadata(ctx context.Context, req *http.Request) context.Context {
 
@@ -66,11 +169,8 @@ func HttpRequestInfoToMetadata(ctx context.Context, req *http.Request) context.C
 	meta[ClientTime] = t.Format(layout)
 
 	// We might want to also support new standard "Forwarded" header.
-	if h, ok := req.Header["X-Forwarded-For"]; ok {
-		ips := strings.Split(strings.Join(h, ""), ",")
-		meta[HttpMetaRemoteAddress] = ips[0]
-	} else if req.RemoteAddr != "" {
-		meta[HttpMetaRemoteAddress] = req.RemoteAddr
+	if remote := ClientAddress(req); remote != "" {
+		meta[HttpMetaRemoteAddress] = remote
 	}
 
 	if h, ok := req.Header["User-Agent"]; ok {
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package servicecontext
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClientAddress(t *testing.T) {
+
+	Convey("Test client address resolution", t, func() {
+
+		So(SetTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"}), ShouldBeNil)
+		defer SetTrustedProxies(nil)
+
+		req := func(remote string, forwarded ...string) *http.Request {
+			r, _ := http.NewRequest("GET", "http://localhost/", nil)
+			r.RemoteAddr = remote
+			for _, f := range forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+			return r
+		}
+
+		// Direct connection, forwarded header is ignored
+		So(ClientAddress(req("8.8.8.8:1234", "1.2.3.4")), ShouldEqual, "8.8.8.8:1234")
+		// Bundled proxy appends the real client after a forged value
+		So(ClientAddress(req("127.0.0.1:1234", "1.2.3.4, 8.8.8.8")), ShouldEqual, "8.8.8.8")
+		So(ClientAddress(req("[::1]:1234", "1.2.3.4", "8.8.8.8")), ShouldEqual, "8.8.8.8")
+		// Trusted proxies are skipped
+		So(ClientAddress(req("127.0.0.1:1234", "1.2.3.4, 8.8.8.8, 10.1.2.3, 192.168.1.1")), ShouldEqual, "8.8.8.8")
+		So(ClientAddress(req("10.0.0.5:1234", "8.8.8.8")), ShouldEqual, "8.8.8.8")
+		So(ClientAddress(req("192.168.1.2:1234", "8.8.8.8")), ShouldEqual, "192.168.1.2:1234")
+		// Only trusted entries
+		So(ClientAddress(req("127.0.0.1:1234", "10.1.2.3")), ShouldEqual, "10.1.2.3")
+
+		// Connection peers
+		So(PeerClientAddress("8.8.8.8:1234", "1.2.3.4"), ShouldEqual, "8.8.8.8:1234")
+		So(PeerClientAddress("10.0.0.5:1234", "1.2.3.4, 8.8.8.8"), ShouldEqual, "8.8.8.8")
+		So(PeerClientAddress("", "1.2.3.4"), ShouldEqual, "")
+
+		So(SetTrustedProxies([]string{"not-a-cidr"}), ShouldNotBeNil)
+	})
+}
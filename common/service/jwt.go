@@ -23,8 +23,11 @@ package service
 import (
 	"context"
 	"net/http"
+	"regexp"
 	"strings"
+	"sync"
 
+	"github.com/go-openapi/spec"
 	"github.com/micro/go-micro"
 	"github.com/micro/go-micro/server"
 
@@ -60,6 +63,164 @@ func NewClaimsHandlerWrapper() server.HandlerWrapper {
 	}
 }
 
+// readOnlyOperations lists the REST operations (swagger operation ids) accepted with a read-only token.
+// Any other operation is denied, including POST operations that are not explicitly listed here.
+var readOnlyOperations = map[string]bool{
+	// GET operations
+	"ListPersonalTokens":           true,
+	"ListServices":                 true,
+	"ListDataSources":              true,
+	"GetDataSource":                true,
+	"EndpointsDiscovery":           true,
+	"ConfigFormsDiscovery":         true,
+	"OpenApiDiscovery":             true,
+	"ListPeersAddresses":           true,
+	"SchedulerActionsDiscovery":    true,
+	"SchedulerActionFormDiscovery": true,
+	"ListVersioningPolicies":       true,
+	"GetVersioningPolicy":          true,
+	"ListVirtualNodes":             true,
+	"GetConfig":                    true,
+	"FrontServeBinary":             true,
+	"FrontBootConf":                true,
+	"FrontMessages":                true,
+	"FrontPlugins":                 true,
+	"SettingsMenu":                 true,
+	"FrontState":                   true,
+	"Relation":                     true,
+	"UserState":                    true,
+	"GetAgreement":                 true,
+	"ListTaskArtifacts":            true,
+	"DownloadTaskArtifact":         true,
+	"ReadTaskLog":                  true,
+	"GetRole":                      true,
+	"GetCell":                      true,
+	"GetShareLink":                 true,
+	"ListTemplates":                true,
+	"HeadNode":                     true,
+	"ListUserMetaNamespace":        true,
+	"ListUserMetaTags":             true,
+	"GetUser":                      true,
+	// POST operations that only read data
+	"SearchAcls":          true,
+	"ExplainAccess":       true,
+	"Stream":              true,
+	"SearchSubscriptions": true,
+	"ListEncryptionKeys":  true,
+	"ListPeerFolders":     true,
+	"ListProcesses":       true,
+	"SimulateJob":         true,
+	"ListTasksLogs":       true,
+	"UserListJobs":        true,
+	"Syslog":              true,
+	"GetBulkMeta":         true,
+	"GetMeta":             true,
+	"ListPolicies":        true,
+	"SimulatePolicies":    true,
+	"SearchRoles":         true,
+	"Nodes":               true,
+	"ListSharedResources": true,
+	"ListAdminTree":       true,
+	"StatAdminTree":       true,
+	"BulkStatNodes":       true,
+	"SearchUsers":         true,
+	"UserBookmarks":       true,
+	"SearchUserMeta":      true,
+	"SearchWorkspaces":    true,
+}
+
+// restRoute matches a request against a swagger path
+type restRoute struct {
+	method    string
+	pattern   *regexp.Regexp
+	literals  int
+	operation string
+}
+
+var (
+	restRoutes     []*restRoute
+	restRoutesOnce sync.Once
+)
+
+// loadRestRoutes builds the routes table from the swagger definitions. Path parameters match a single
+// segment, except the last one which may contain slashes (e.g. {NodePath}).
+func loadRestRoutes() {
+	doc := SwaggerSpec()
+	if doc == nil {
+		return
+	}
+	paramRegexp := regexp.MustCompile(`\{[^}]+\}`)
+	for path, item := range doc.Spec().Paths.Paths {
+		locs := paramRegexp.FindAllStringIndex(path, -1)
+		var expr string
+		var last int
+		for i, loc := range locs {
+			expr += regexp.QuoteMeta(path[last:loc[0]])
+			if i == len(locs)-1 && loc[1] == len(path) {
+				expr += ".+"
+			} else {
+				expr += "[^/]+"
+			}
+			last = loc[1]
+		}
+		expr += regexp.QuoteMeta(path[last:])
+		pattern := regexp.MustCompile("^" + expr + "/?$")
+		literals := len(paramRegexp.ReplaceAllString(path, ""))
+		for method, op := range map[string]*spec.Operation{
+			http.MethodGet:    item.Get,
+			http.MethodHead:   item.Head,
+			http.MethodPost:   item.Post,
+			http.MethodPut:    item.Put,
+			http.MethodPatch:  item.Patch,
+			http.MethodDelete: item.Delete,
+		} {
+			if op != nil {
+				restRoutes = append(restRoutes, &restRoute{method: method, pattern: pattern, literals: literals, operation: op.ID})
+			}
+		}
+	}
+}
+
+// restOperation finds the swagger operation id of a request, preferring the most specific path.
+func restOperation(method, path string) string {
+	restRoutesOnce.Do(loadRestRoutes)
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	var found *restRoute
+	for _, r := range restRoutes {
+		if r.method != method || !r.pattern.MatchString(path) {
+			continue
+		}
+		if found == nil || r.literals > found.literals {
+			found = r
+		}
+	}
+	if found == nil {
+		return ""
+	}
+	return found.operation
+}
+
+// readOnlyRequest checks if a request is allowed for a read-only token.
+func readOnlyRequest(r *http.Request) bool {
+	if r.Method == http.MethodOptions {
+		return true
+	}
+	return readOnlyOperations[restOperation(r.Method, r.URL.Path)]
+}
+
+// allowReadOnly answers with a 403 error and returns false if the claims are restricted to read accesses
+// and the request is not a read-only operation.
+func allowReadOnly(claims claim.Claims, w http.ResponseWriter, r *http.Request) bool {
+	if !claims.ReadOnly() || readOnlyRequest(r) {
+		return true
+	}
+	w.WriteHeader(403)
+	w.Write([]byte("Forbidden: read-only token.\n"))
+	return false
+}
+
 // JWTHttpWrapper captures and verifies a JWT token if it's present in the headers.
 // Warning: it goes through if there is no JWT => the next handlers
 // must verify if a valid user was found or not.
@@ -76,10 +237,10 @@ func JWTHttpWrapper(h http.Handler) http.Handler {
 
 			whole := strings.Join(val, "")
 			rawIDToken := strings.TrimPrefix(strings.Trim(whole, ""), "Bearer ")
-			//var claims claim.Claims
+			var claims claim.Claims
 			var err error
 
-			c, _, err = jwtVerifier.Verify(c, rawIDToken)
+			c, claims, err = jwtVerifier.Verify(c, rawIDToken)
 			if err != nil {
 				// This is a wrong JWT go out with error
 				w.WriteHeader(401)
@@ -93,6 +254,11 @@ func JWTHttpWrapper(h http.Handler) http.Handler {
 				return
 			}
 
+			// Writes on data are denied by the access list, other resources are protected here
+			if !allowReadOnly(claims, w, r) {
+				return
+			}
+
 		}
 
 		r = r.WithContext(c)
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pydio/cells/common/auth/claim"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadOnlyToken(t *testing.T) {
+
+	readOnly := claim.Claims{Scopes: claim.ScopeReadOnly}
+
+	check := func(c claim.Claims, method, path string) (bool, int) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, nil)
+		allowed := allowReadOnly(c, w, r)
+		return allowed, w.Code
+	}
+
+	Convey("Test read-only token on read operations", t, func() {
+		for _, op := range [][]string{
+			{http.MethodGet, "/user/admin"},
+			{http.MethodHead, "/tree/stat/personal-files/folder/file.txt"},
+			{http.MethodGet, "/config/datasource"},
+			{http.MethodGet, "/config/services/pydio.grpc.mailer"},
+			{http.MethodPost, "/meta/get/personal-files/file.txt"},
+			{http.MethodPost, "/search/nodes"},
+			{http.MethodPost, "/tree/stats"},
+			{http.MethodOptions, "/meta/set/personal-files/file.txt"},
+		} {
+			allowed, _ := check(readOnly, op[0], op[1])
+			So(allowed, ShouldBeTrue)
+		}
+	})
+
+	Convey("Test read-only token on mutating operations", t, func() {
+		for _, op := range [][]string{
+			{http.MethodPost, "/meta/set/personal-files/file.txt"},
+			{http.MethodPost, "/meta/delete/personal-files/file.txt"},
+			{http.MethodPost, "/config/datasource/pydiods1"},
+			{http.MethodPost, "/config/ctl"},
+			{http.MethodPost, "/config/encryption/create"},
+			{http.MethodPost, "/acl/bulk/delete"},
+			{http.MethodPost, "/jobs/tasks/delete"},
+			{http.MethodPost, "/update"},
+			{http.MethodPost, "/auth/token/revoke"},
+			{http.MethodPost, "/user/totp/enroll"},
+			{http.MethodPost, "/user/totp/reset"},
+			{http.MethodPut, "/user/admin"},
+			{http.MethodDelete, "/role/some-role"},
+			{http.MethodPost, "/unknown/route"},
+		} {
+			allowed, code := check(readOnly, op[0], op[1])
+			So(allowed, ShouldBeFalse)
+			So(code, ShouldEqual, 403)
+		}
+	})
+
+	Convey("Test unrestricted token", t, func() {
+		allowed, _ := check(claim.Claims{}, http.MethodPost, "/meta/set/personal-files/file.txt")
+		So(allowed, ShouldBeTrue)
+	})
+}
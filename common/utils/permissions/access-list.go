@@ -179,6 +179,7 @@ type AccessList struct {
 	WorkspacesNodes    map[string]map[string]Bitmask
 	OrderedRoles       []*idm.Role
 	FrontPluginsValues []*idm.ACL
+	ReadOnly           bool
 }
 
 // NewAccessList creates a new AccessList.
@@ -377,6 +378,9 @@ func (a *AccessList) CanRead(ctx context.Context, nodes ...*tree.Node) bool {
 
 // CanWrite checks if a node has WRITE access.
 func (a *AccessList) CanWrite(ctx context.Context, nodes ...*tree.Node) bool {
+	if a.ReadOnly {
+		return false
+	}
 	deny, mask := a.ParentMaskOrDeny(ctx, nodes...)
 	return !deny && mask.HasFlag(ctx, FlagWrite, nodes[0])
 }
@@ -396,6 +400,41 @@ func (a *AccessList) IsLocked(ctx context.Context, nodes ...*tree.Node) bool {
 	return false
 }
 
+// RestrictToScopes applies the restrictions of a scoped token: it denies all writes if readOnly is set,
+// and drops the workspaces that do not match one of the given slugs or uuids, if any.
+func (a *AccessList) RestrictToScopes(readOnly bool, workspaces []string) {
+	a.ReadOnly = readOnly
+	if len(workspaces) == 0 {
+		return
+	}
+	allowed := make(map[string]bool, len(workspaces))
+	for _, w := range workspaces {
+		allowed[w] = true
+	}
+	kept := make(map[string]bool)
+	for wsId, ws := range a.Workspaces {
+		if allowed[wsId] || allowed[ws.Slug] {
+			for rootId := range a.WorkspacesNodes[wsId] {
+				kept[rootId] = true
+			}
+			continue
+		}
+		delete(a.Workspaces, wsId)
+	}
+	for wsId, roots := range a.WorkspacesNodes {
+		if _, ok := a.Workspaces[wsId]; ok {
+			continue
+		}
+		delete(a.WorkspacesNodes, wsId)
+		// Remove roots as well, unless they are shared with a kept workspace
+		for rootId := range roots {
+			if !kept[rootId] {
+				delete(a.NodesAcls, rootId)
+			}
+		}
+	}
+}
+
 // BelongsToWorkspaces finds corresponding workspace parents for this node.
 func (a *AccessList) BelongsToWorkspaces(ctx context.Context, nodes ...*tree.Node) (workspaces []*idm.Workspace, workspacesRoots map[string]string) {
 
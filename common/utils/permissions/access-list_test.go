@@ -156,3 +156,39 @@ func TestAccessList_Flatten(t *testing.T) {
 
 	})
 }
+
+func TestAccessList_RestrictToScopes(t *testing.T) {
+	ctx := context.Background()
+	load := func() *AccessList {
+		list := NewAccessList(roles)
+		list.Append(acls)
+		list.Flatten(ctx)
+		list.Workspaces["ws1"] = &idm.Workspace{UUID: "ws1", Slug: "first"}
+		list.Workspaces["ws2"] = &idm.Workspace{UUID: "ws2", Slug: "second"}
+		return list
+	}
+	writable := listParents("root/folder1/subfolder2/file1")
+
+	Convey("Test read-only scope", t, func() {
+		list := load()
+		list.RestrictToScopes(true, nil)
+		So(list.Workspaces, ShouldHaveLength, 2)
+		So(list.CanRead(ctx, writable...), ShouldBeTrue)
+		So(list.CanWrite(ctx, writable...), ShouldBeFalse)
+	})
+
+	Convey("Test workspace scope", t, func() {
+		list := load()
+		list.RestrictToScopes(false, []string{"second"})
+		So(list.Workspaces, ShouldHaveLength, 1)
+		So(list.Workspaces, ShouldContainKey, "ws2")
+		So(list.GetWorkspacesNodes(), ShouldHaveLength, 1)
+		So(list.CanWrite(ctx, writable...), ShouldBeTrue)
+		So(list.CanRead(ctx, listParents("root/folder1/other")...), ShouldBeFalse)
+
+		list = load()
+		list.RestrictToScopes(false, []string{"ws1"})
+		So(list.Workspaces, ShouldContainKey, "ws1")
+		So(list.CanRead(ctx, listParents("root/folder1/other")...), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pydio/cells/common"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// AccessContribution is an ACL found on a node or one of its parents while resolving a right.
+type AccessContribution struct {
+	Acl  *idm.ACL
+	Role *idm.Role
+	// RolePosition is the index of the role in the ordered roles, later roles override previous ones
+	RolePosition int
+	Node         *tree.Node
+	// Depth is 0 for the node itself, 1 for its parent, etc.
+	Depth int
+	// Overridden is set if a role coming later carries ACLs on the same node
+	Overridden bool
+	// PolicyAllowed is the result of the evaluation of a policy-based ACL
+	PolicyAllowed bool
+	// Decisive is set on the ACL(s) that determined the resulting right
+	Decisive bool
+}
+
+// RightExplanation details how a right was resolved.
+type RightExplanation struct {
+	Right         string
+	Granted       bool
+	Reason        string
+	Contributions []*AccessContribution
+}
+
+// AccessListForExplanation loads the roles and ACLs of a user the same way AccessListFromContextClaims
+// does, adding the quota ACLs. The context should impersonate the user for policies evaluation.
+func AccessListForExplanation(ctx context.Context, user *idm.User) *AccessList {
+
+	roles := GetRolesForUser(ctx, user, false)
+	accessList := NewAccessList(roles)
+	accessList.Append(GetACLsForRoles(ctx, roles, AclRead, AclDeny, AclWrite, AclLock, AclPolicy, AclQuota))
+	ResolvePolicyRequest = func(ctx context.Context, request *idm.PolicyEngineRequest) (*idm.PolicyEngineResponse, error) {
+		cli := idm.NewPolicyEngineServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_POLICY, defaults.NewClient())
+		return cli.IsAllowed(ctx, request)
+	}
+	accessList.Flatten(ctx)
+	for _, workspace := range GetWorkspacesForACLs(ctx, accessList) {
+		accessList.Workspaces[workspace.UUID] = workspace
+	}
+
+	return accessList
+}
+
+// Explain replays the resolution of the read, write, deny, lock and quota rights on a node. Nodes are the node
+// itself followed by its parents, closest first, as for CanRead. Aliases map the uuid of a node to the uuid
+// carrying its ACLs, for resolved virtual nodes.
+func (a *AccessList) Explain(ctx context.Context, aliases map[string]string, nodes ...*tree.Node) []*RightExplanation {
+
+	positions := make(map[string]int, len(a.OrderedRoles))
+	for i, r := range a.OrderedRoles {
+		positions[r.Uuid] = i
+	}
+	aclNodeId := func(n *tree.Node) string {
+		if id, ok := aliases[n.Uuid]; ok {
+			return id
+		}
+		return n.Uuid
+	}
+
+	// Like in flattenNodes, the last role carrying any ACL on a node wins
+	winners := make(map[string]int)
+	for _, acl := range a.Acls {
+		if p, ok := positions[acl.RoleID]; ok && acl.NodeID != "" {
+			if w, has := winners[acl.NodeID]; !has || p > w {
+				winners[acl.NodeID] = p
+			}
+		}
+	}
+
+	var contributions []*AccessContribution
+	for depth, node := range nodes {
+		nodeId := aclNodeId(node)
+		for _, acl := range a.Acls {
+			p, ok := positions[acl.RoleID]
+			if !ok || acl.NodeID != nodeId || acl.Action == nil {
+				continue
+			}
+			contributions = append(contributions, &AccessContribution{
+				Acl:          acl,
+				Role:         a.OrderedRoles[p],
+				RolePosition: p,
+				Node:         node,
+				Depth:        depth,
+				Overridden:   winners[nodeId] != p,
+			})
+		}
+	}
+	sort.SliceStable(contributions, func(i, j int) bool {
+		if contributions[i].Depth != contributions[j].Depth {
+			return contributions[i].Depth < contributions[j].Depth
+		}
+		return contributions[i].RolePosition < contributions[j].RolePosition
+	})
+
+	// closest returns the depth of the first node whose flattened mask matches, or -1
+	closest := func(match func(Bitmask) bool) int {
+		for depth, node := range nodes {
+			if mask, ok := a.NodesAcls[node.Uuid]; ok && match(mask) {
+				return depth
+			}
+		}
+		return -1
+	}
+	// pick copies the contributions with the given action names, flagging the winning ones at decisive depth
+	pick := func(decisiveDepth int, names ...string) (picked []*AccessContribution, decisive *AccessContribution) {
+		for _, c := range contributions {
+			for _, n := range names {
+				if c.Acl.Action.Name != n {
+					continue
+				}
+				cc := *c
+				if c.Depth == decisiveDepth && !c.Overridden {
+					cc.Decisive = true
+					if decisive == nil {
+						decisive = &cc
+					}
+				}
+				picked = append(picked, &cc)
+			}
+		}
+		return
+	}
+	describe := func(c *AccessContribution) string {
+		label := c.Role.Label
+		if label == "" {
+			label = c.Role.Uuid
+		}
+		return fmt.Sprintf("role %s on %s", label, nodeLabel(c.Node))
+	}
+
+	denyDepth := closest(func(b Bitmask) bool { return b.HasFlag(ctx, FlagDeny) })
+	var explanations []*RightExplanation
+
+	for _, right := range []BitmaskFlag{FlagRead, FlagWrite} {
+		name := FlagsToNames[right]
+		exp := &RightExplanation{Right: name}
+		if right == FlagRead {
+			exp.Granted = a.CanRead(ctx, nodes...)
+		} else {
+			exp.Granted = a.CanWrite(ctx, nodes...)
+		}
+		if denyDepth > -1 {
+			var decisive *AccessContribution
+			exp.Contributions, decisive = pick(denyDepth, name, AclPolicy.Name, AclDeny.Name)
+			for _, c := range exp.Contributions {
+				c.Decisive = c.Decisive && c.Acl.Action.Name == AclDeny.Name
+			}
+			exp.Reason = "Denied on " + nodeLabel(nodes[denyDepth])
+			if decisive != nil {
+				exp.Reason = "Denied by " + describe(decisive)
+			}
+		} else {
+			depth := closest(func(b Bitmask) bool { return b.BitmaskFlag != 0 })
+			exp.Contributions, _ = pick(depth, name, AclPolicy.Name)
+			var decisive *AccessContribution
+			for _, c := range exp.Contributions {
+				if c.Acl.Action.Name == AclPolicy.Name {
+					b := Bitmask{}
+					b.AddPolicyFlag(c.Acl.Action.Value)
+					c.PolicyAllowed = b.HasFlag(ctx, right, c.Node)
+					c.Decisive = c.Decisive && c.PolicyAllowed == exp.Granted
+				}
+				if c.Decisive && decisive == nil {
+					decisive = c
+				}
+			}
+			switch {
+			case decisive != nil && exp.Granted:
+				exp.Reason = "Granted by " + describe(decisive)
+			case decisive != nil:
+				exp.Reason = "Refused by policy of " + describe(decisive)
+			case depth > -1:
+				exp.Reason = fmt.Sprintf("Closest permissions are set on %s and do not grant %s", nodeLabel(nodes[depth]), name)
+			default:
+				exp.Reason = "No role sets permissions on the node or its parents"
+			}
+			if right == FlagWrite && a.ReadOnly {
+				exp.Reason = "Restricted to read-only access"
+			}
+		}
+		explanations = append(explanations, exp)
+	}
+
+	deny := &RightExplanation{Right: AclDeny.Name, Granted: denyDepth > -1}
+	var decisive *AccessContribution
+	deny.Contributions, decisive = pick(denyDepth, AclDeny.Name)
+	if decisive != nil {
+		deny.Reason = "Set by " + describe(decisive)
+	} else if deny.Granted {
+		deny.Reason = "Set on " + nodeLabel(nodes[denyDepth])
+	}
+	explanations = append(explanations, deny)
+
+	lock := &RightExplanation{Right: AclLock.Name, Granted: a.IsLocked(ctx, nodes...)}
+	lockDepth := -1
+	if _, node := a.FirstMaskForParents(ctx, nodes...); node != nil {
+		for depth, n := range nodes {
+			if n == node && a.NodesAcls[n.Uuid].HasFlag(ctx, FlagLock, n) {
+				lockDepth = depth
+			}
+		}
+	}
+	lock.Contributions, decisive = pick(lockDepth, AclLock.Name)
+	if decisive != nil {
+		lock.Reason = "Locked by " + describe(decisive)
+	} else if lock.Granted {
+		lock.Reason = "A child of the node is locked"
+	}
+	explanations = append(explanations, lock)
+
+	quotaDepth := closest(func(b Bitmask) bool { return b.BitmaskFlag&FlagQuota != 0 })
+	quota := &RightExplanation{Right: AclQuota.Name, Granted: quotaDepth > -1}
+	quota.Contributions, decisive = pick(quotaDepth, AclQuota.Name)
+	if decisive != nil {
+		quota.Reason = fmt.Sprintf("Quota of %s set by %s", decisive.Acl.Action.Value, describe(decisive))
+	}
+	explanations = append(explanations, quota)
+
+	return explanations
+}
+
+func nodeLabel(n *tree.Node) string {
+	if n.Path != "" {
+		return n.Path
+	}
+	return n.Uuid
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func explained(exps []*RightExplanation, right string) *RightExplanation {
+	for _, e := range exps {
+		if e.Right == right {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestAccessList_Explain(t *testing.T) {
+	Convey("Test Explain", t, func() {
+		ctx := context.Background()
+		list := NewAccessList(roles)
+		list.Append(acls)
+		list.Flatten(ctx)
+
+		Convey("Rights inherited from a parent", func() {
+			exps := list.Explain(ctx, nil, listParents("root/folder1/subfolder2/file1")...)
+			So(exps, ShouldHaveLength, 5)
+			read := explained(exps, "read")
+			So(read.Granted, ShouldBeTrue)
+			So(read.Contributions, ShouldHaveLength, 2)
+			So(read.Contributions[0].Depth, ShouldEqual, 1)
+			So(read.Contributions[0].Role.Uuid, ShouldEqual, "role")
+			So(read.Contributions[0].Decisive, ShouldBeTrue)
+			So(read.Contributions[1].Depth, ShouldEqual, 2)
+			So(read.Contributions[1].Decisive, ShouldBeFalse)
+			So(explained(exps, "write").Granted, ShouldBeTrue)
+			So(explained(exps, "deny").Granted, ShouldBeFalse)
+		})
+
+		Convey("Closest node overrides parents", func() {
+			exps := list.Explain(ctx, nil, listParents("root/folder1/subfolder2/file2")...)
+			So(explained(exps, "read").Granted, ShouldBeTrue)
+			So(explained(exps, "read").Contributions[0].Role.Uuid, ShouldEqual, "user_id")
+			write := explained(exps, "write")
+			So(write.Granted, ShouldBeFalse)
+			So(write.Reason, ShouldContainSubstring, "do not grant write")
+			for _, c := range write.Contributions {
+				So(c.Decisive, ShouldBeFalse)
+			}
+		})
+
+		Convey("Deny is decisive", func() {
+			exps := list.Explain(ctx, nil, listParents("root/folder1/subfolder1/fileA")...)
+			read := explained(exps, "read")
+			So(read.Granted, ShouldBeFalse)
+			So(read.Reason, ShouldStartWith, "Denied by role root")
+			deny := explained(exps, "deny")
+			So(deny.Granted, ShouldBeTrue)
+			So(deny.Contributions, ShouldHaveLength, 1)
+			So(deny.Contributions[0].Decisive, ShouldBeTrue)
+		})
+
+		Convey("Aliases are used for resolved nodes", func() {
+			exps := list.Explain(ctx, map[string]string{"virtual": "root/folder1/subfolder2"}, listParents("virtual/file1")...)
+			read := explained(exps, "read")
+			So(read.Contributions, ShouldHaveLength, 1)
+			So(read.Contributions[0].Node.Uuid, ShouldEqual, "virtual")
+		})
+	})
+}
@@ -437,6 +437,10 @@ func AccessListFromContextClaims(ctx context.Context) (accessList *AccessList, e
 		accessList.Workspaces[workspace.UUID] = workspace
 	}
 
+	if len(claims.GetScopes()) > 0 {
+		accessList.RestrictToScopes(claims.ReadOnly(), claims.Workspaces())
+	}
+
 	return accessList, nil
 }
 
@@ -22,6 +22,7 @@ package permissions
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strings"
@@ -40,8 +41,18 @@ const (
 	PolicyNodeMetaExtension = "NodeMetaExtension"
 	PolicyNodeMetaSize      = "NodeMetaSize"
 	PolicyNodeMetaMTime     = "NodeMetaMTime"
-	// Todo - Problem, usermeta are not loaded at this point
-	PolicyNodeMeta_ = "NodeMeta:"
+	PolicyNodeUuid          = "NodeUuid"
+	// Metadata carried by the node, user metadata may not be loaded at this point
+	PolicyNodeMeta_         = "NodeMeta:"
+	PolicyUserLogin         = "UserLogin"
+	PolicyUserAttribute_    = "UserAttribute:"
+	PolicyClientApplication = "ClientApplication"
+
+	ClientApplicationWeb   = "web"
+	ClientApplicationSync  = "sync"
+	ClientApplicationDav   = "dav"
+	ClientApplicationCli   = "cli"
+	ClientApplicationOther = "other"
 )
 
 // PolicyRequestSubjectsFromUser builds an array of string subjects from the passed User.
@@ -93,10 +104,40 @@ func PolicyContextFromMetadata(policyContext map[string]string, ctx context.Cont
 			}
 		}
 	}
+	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); ok && claims.Name != "" {
+		policyContext[PolicyUserLogin] = claims.Name
+	}
+	if app := ClientApplicationFromContext(ctx); app != "" {
+		policyContext[PolicyClientApplication] = app
+	}
+}
+
+// ClientApplicationFromContext classifies the application sending the request: web, sync, dav, cli or other.
+// It returns an empty string for anonymous requests.
+func ClientApplicationFromContext(ctx context.Context) string {
+	if ctxMeta, has := metadata.FromContext(ctx); has {
+		if uri := ctxMeta[servicecontext.HttpMetaRequestURI]; uri == "/dav" || strings.HasPrefix(uri, "/dav/") {
+			return ClientApplicationDav
+		}
+	}
+	claims, ok := ctx.Value(claim.ContextKey).(claim.Claims)
+	if !ok {
+		return ""
+	}
+	switch claims.GetClientApp() {
+	case "cells-frontend":
+		return ClientApplicationWeb
+	case "cells-sync":
+		return ClientApplicationSync
+	case "cells-client":
+		return ClientApplicationCli
+	}
+	return ClientApplicationOther
 }
 
 // PolicyContextFromNode extracts metadata from the Node and enriches the passed policyContext.
 func PolicyContextFromNode(policyContext map[string]string, node *tree.Node) {
+	policyContext[PolicyNodeUuid] = node.Uuid
 	policyContext[PolicyNodeMetaName] = node.GetStringMeta("name")
 	policyContext[PolicyNodeMetaPath] = node.Path
 	policyContext[PolicyNodeMetaMTime] = string(node.MTime)
@@ -104,4 +145,11 @@ func PolicyContextFromNode(policyContext map[string]string, node *tree.Node) {
 	if node.IsLeaf() {
 		policyContext[PolicyNodeMetaExtension] = strings.TrimLeft(path.Ext(node.Path), ".")
 	}
+	for namespace, value := range node.MetaStore {
+		var s string
+		if e := json.Unmarshal([]byte(value), &s); e == nil {
+			value = s
+		}
+		policyContext[PolicyNodeMeta_+namespace] = value
+	}
 }
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonths = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDays = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronField describes the bounds and aliases of one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: cronMonths},
+	{name: "day of week", min: 0, max: 7, names: cronDays},
+}
+
+// CronSchedule is a parsed standard 5-fields cron expression (minute, hour, day of month,
+// month, day of week) evaluated in a given time zone.
+type CronSchedule struct {
+	expression string
+	location   *time.Location
+
+	minutes, hours, doms, months, dows uint64
+	// domStar and dowStar are true if the field starts with "*", which changes the way
+	// day of month and day of week are combined.
+	domStar, dowStar bool
+}
+
+// ParseCron parses a cron expression. Fields support lists (1,2), ranges (1-5), steps (*/15, 0-30/5)
+// and names for months (JAN-DEC) and days of week (SUN-SAT). Day of week 7 is an alias of Sunday.
+// If timeZone is empty, the server local time zone is used.
+func ParseCron(expression string, timeZone string) (*CronSchedule, error) {
+
+	c := &CronSchedule{expression: expression, location: time.Local}
+	if timeZone != "" {
+		loc, e := time.LoadLocation(timeZone)
+		if e != nil {
+			return nil, fmt.Errorf("invalid time zone %s: %s", timeZone, e.Error())
+		}
+		c.location = loc
+	}
+
+	expr := strings.TrimSpace(expression)
+	if d, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected %d fields, found %d", expression, len(cronFields), len(parts))
+	}
+	sets := make([]uint64, len(parts))
+	for i, part := range parts {
+		set, e := cronFields[i].parse(part)
+		if e != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s", expression, e.Error())
+		}
+		sets[i] = set
+	}
+	c.minutes, c.hours, c.doms, c.months, c.dows = sets[0], sets[1], sets[2], sets[3], sets[4]
+	// Sunday can be written 0 or 7
+	if c.dows&(1<<7) > 0 {
+		c.dows |= 1
+	}
+	c.domStar = strings.HasPrefix(parts[2], "*") || parts[2] == "?"
+	c.dowStar = strings.HasPrefix(parts[4], "*") || parts[4] == "?"
+
+	return c, nil
+}
+
+// parse converts a field value into a bit set of matching values
+func (f cronField) parse(value string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i > -1 {
+			s, e := strconv.Atoi(item[i+1:])
+			if e != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, item)
+			}
+			rangePart, step = item[:i], s
+		}
+		var start, end int
+		if rangePart == "*" || rangePart == "?" {
+			start, end = f.min, f.max
+		} else if i := strings.Index(rangePart, "-"); i > -1 {
+			var e error
+			if start, e = f.value(rangePart[:i]); e != nil {
+				return 0, e
+			}
+			if end, e = f.value(rangePart[i+1:]); e != nil {
+				return 0, e
+			}
+		} else {
+			var e error
+			if start, e = f.value(rangePart); e != nil {
+				return 0, e
+			}
+			end = start
+			if step > 1 {
+				// "5/15" means every 15 starting at 5
+				end = f.max
+			}
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %s field: %s", f.name, item)
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name and checks its bounds
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, e := strconv.Atoi(s)
+	if e != nil {
+		return 0, fmt.Errorf("invalid value in %s field: %s", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value out of range [%d-%d] in %s field: %d", f.min, f.max, f.name, v)
+	}
+	return v, nil
+}
+
+// String returns the original expression
+func (c *CronSchedule) String() string {
+	return c.expression
+}
+
+// Location returns the time zone used to evaluate the expression
+func (c *CronSchedule) Location() *time.Location {
+	return c.location
+}
+
+// Next computes the first activation time strictly after t. It returns a zero time
+// if no activation can be found in the next five years (e.g. "0 0 30 2 *"). Activation
+// times falling in a daylight saving gap of the time zone are skipped.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+
+	t = t.In(c.location)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, c.location).Add(time.Minute)
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, c.location).Add(time.Hour)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies the standard cron rule: if both day of month and day of week
+// are restricted, a day matches if any of them matches.
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom := c.doms&(1<<uint(t.Day())) > 0
+	dow := c.dows&(1<<uint(t.Weekday())) > 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseCron(t *testing.T) {
+
+	Convey("Parse valid expressions", t, func() {
+		for _, expr := range []string{"* * * * *", "0 2 * * 1-5", "*/15 0-6,22,23 1,15 jan-jun MON-FRI", "5/10 * * * 7", "@daily", "@Hourly"} {
+			_, e := ParseCron(expr, "")
+			So(e, ShouldBeNil)
+		}
+	})
+
+	Convey("Parse invalid expressions", t, func() {
+		for _, expr := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "* * * * funday"} {
+			_, e := ParseCron(expr, "")
+			So(e, ShouldNotBeNil)
+		}
+		_, e := ParseCron("* * * * *", "Mars/Olympus_Mons")
+		So(e, ShouldNotBeNil)
+	})
+
+}
+
+func TestCronNext(t *testing.T) {
+
+	utc := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return t
+	}
+
+	Convey("Every weekday at 2am", t, func() {
+		c, e := ParseCron("0 2 * * 1-5", "UTC")
+		So(e, ShouldBeNil)
+		// Friday 2019-03-01 at 10:00 => Monday 2019-03-04 at 02:00
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2019-03-04T02:00:00Z"))
+		// Exactly at activation time => next day
+		So(c.Next(utc("2019-03-04T02:00:00Z")), ShouldEqual, utc("2019-03-05T02:00:00Z"))
+	})
+
+	Convey("Steps and lists", t, func() {
+		c, _ := ParseCron("*/20 9,17 * * *", "UTC")
+		So(c.Next(utc("2019-03-01T09:45:10Z")), ShouldEqual, utc("2019-03-01T17:00:00Z"))
+		So(c.Next(utc("2019-03-01T17:00:00Z")), ShouldEqual, utc("2019-03-01T17:20:00Z"))
+		So(c.Next(utc("2019-03-01T17:40:00Z")), ShouldEqual, utc("2019-03-02T09:00:00Z"))
+	})
+
+	Convey("Day of month or day of week", t, func() {
+		// 1st of month OR sundays
+		c, _ := ParseCron("0 0 1 * sun", "UTC")
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2019-03-03T00:00:00Z"))
+		So(c.Next(utc("2019-03-31T10:00:00Z")), ShouldEqual, utc("2019-04-01T00:00:00Z"))
+		// Sunday as 7
+		c, _ = ParseCron("0 0 * * 7", "UTC")
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2019-03-03T00:00:00Z"))
+	})
+
+	Convey("Impossible dates", t, func() {
+		c, _ := ParseCron("0 0 30 2 *", "UTC")
+		So(c.Next(utc("2019-03-01T10:00:00Z")).IsZero(), ShouldBeTrue)
+		// Leap years
+		c, _ = ParseCron("@yearly", "UTC")
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2020-01-01T00:00:00Z"))
+		c, _ = ParseCron("0 0 29 2 *", "UTC")
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2020-02-29T00:00:00Z"))
+	})
+
+	Convey("Time zones", t, func() {
+		c, e := ParseCron("0 2 * * *", "Asia/Kolkata")
+		So(e, ShouldBeNil)
+		// 02:00 IST is 20:30 UTC on the previous day
+		So(c.Next(utc("2019-03-01T10:00:00Z")), ShouldEqual, utc("2019-03-01T20:30:00Z"))
+
+		c, e = ParseCron("30 2 * * *", "Europe/Paris")
+		So(e, ShouldBeNil)
+		// 2019-03-31 02:30 does not exist in Paris (DST), this occurrence is skipped
+		So(c.Next(utc("2019-03-30T12:00:00Z")), ShouldEqual, utc("2019-04-01T00:30:00Z"))
+		// Winter and summer times
+		So(c.Next(utc("2019-01-15T12:00:00Z")), ShouldEqual, utc("2019-01-16T01:30:00Z"))
+		So(c.Next(utc("2019-07-15T12:00:00Z")), ShouldEqual, utc("2019-07-16T00:30:00Z"))
+	})
+
+}
+
+func TestCronTicker(t *testing.T) {
+
+	Convey("Compute Next Wait with cron", t, func() {
+		s, e := NewTickerScheduleFromCron("* * * * *", "")
+		So(e, ShouldBeNil)
+		waiter := NewTicker(s, func() error { return nil })
+		wait, stop := waiter.computeNextWait()
+		So(stop, ShouldBeFalse)
+		So(wait, ShouldBeGreaterThan, 0)
+		So(wait, ShouldBeLessThanOrEqualTo, time.Minute)
+
+		_, e = NewTickerScheduleFromCron("* * * * *", "Not/AZone")
+		So(e, ShouldNotBeNil)
+	})
+
+}
@@ -19,7 +19,7 @@
  */
 
 // Package schedule provides a fixed ticker based on a start time
-// iso8601 interval periods are supported
+// iso8601 interval periods and cron expressions are supported
 package schedule
 
 import (
@@ -45,6 +45,8 @@ type TickerSchedule struct {
 	startTime time.Time
 	// Interval between ticks
 	interval time.Duration
+	// Cron expression, replaces all values above if set
+	cron *CronSchedule
 }
 
 // ParseSchedule parses the given Iso 8601 string and stores corresponding values.
@@ -109,6 +111,17 @@ func NewTickerScheduleFromISO(is8601 string) (*TickerSchedule, error) {
 	return s, nil
 }
 
+// NewTickerScheduleFromCron creates a schedule from a cron expression evaluated in the given
+// time zone (server local time zone if empty). It can return an error if the expression or
+// the time zone cannot be parsed.
+func NewTickerScheduleFromCron(expression string, timeZone string) (*TickerSchedule, error) {
+	c, err := ParseCron(expression, timeZone)
+	if err != nil {
+		return nil, err
+	}
+	return &TickerSchedule{cron: c}, nil
+}
+
 // NewTickerSchedule creates a schedule from parameters
 func NewTickerSchedule(interval time.Duration, startTime time.Time, repeat int64) *TickerSchedule {
 	s := &TickerSchedule{
@@ -185,6 +198,13 @@ func (w *Ticker) WaitUntilNext() {
 func (w *Ticker) computeNextWait() (time.Duration, bool) {
 
 	now := time.Now()
+	if w.cron != nil {
+		next := w.cron.Next(now)
+		if next.IsZero() {
+			return 0, true
+		}
+		return next.Sub(now), false
+	}
 	var wait time.Duration
 	// First let's wait until start time
 	wait = w.startTime.Sub(now)
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+var (
+	// They can be replaced in tests
+	sensitiveLinksBlocked = BlockSensitiveLinks
+	isPublicLinkUser      = publicLinkUser
+)
+
+// AclSensitiveFilter refuses to serve the content of nodes flagged by the sensitive data scanner
+// to public links users. As the flag is checked on each read, it applies to any node below the link
+// root nodes, even if it was flagged after the link creation.
+type AclSensitiveFilter struct {
+	AbstractHandler
+}
+
+// GetObject checks the sensitive flag of the node before reading its content
+func (a *AclSensitiveFilter) GetObject(ctx context.Context, node *tree.Node, requestData *GetRequestData) (io.ReadCloser, error) {
+	if branchInfo, ok := GetBranchInfo(ctx, "in"); ok && branchInfo.Binary {
+		return a.next.GetObject(ctx, node, requestData)
+	}
+	if sensitiveLinksBlocked() && isPublicLinkUser(ctx) {
+		resp, e := a.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+		if e != nil {
+			return nil, e
+		}
+		if flag := resp.Node.GetStringMeta(common.META_FLAG_SENSITIVE); flag != "" {
+			return nil, errors.Forbidden(VIEWS_LIBRARY_NAME, "This resource was flagged as containing sensitive data (%s), it cannot be shared publicly.", flag)
+		}
+	}
+	return a.next.GetObject(ctx, node, requestData)
+}
+
+// BlockSensitiveLinks reads the share service configuration to check if nodes flagged by the sensitive data scanner
+// can be shared via public links.
+func BlockSensitiveLinks() bool {
+	return config.Get("services", common.SERVICE_REST_NAMESPACE_+common.SERVICE_SHARE, "blockSensitiveLinks").Bool(false)
+}
+
+// publicLinkUser checks if the current user is the hidden user of a public link.
+func publicLinkUser(ctx context.Context) bool {
+	userLogin, claims := permissions.FindUserNameInContext(ctx)
+	if claims.Profile != common.PYDIO_PROFILE_SHARED {
+		return false
+	}
+	user, e := permissions.SearchUniqueUser(context.Background(), userLogin, "", &idm.UserSingleQuery{AttributeName: "hidden", AttributeValue: "true"})
+	return e == nil && user != nil
+}
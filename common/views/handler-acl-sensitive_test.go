@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func TestAclSensitiveFilter_GetObject(t *testing.T) {
+
+	blocked, publicUser := true, true
+	sensitiveLinksBlocked = func() bool { return blocked }
+	isPublicLinkUser = func(ctx context.Context) bool { return publicUser }
+	defer func() {
+		sensitiveLinksBlocked = BlockSensitiveLinks
+		isPublicLinkUser = publicLinkUser
+	}()
+
+	mock := NewHandlerMock()
+	flagged := &tree.Node{Path: "shared/folder/sub/cards.csv", Type: tree.NodeType_LEAF}
+	flagged.SetMeta(common.META_FLAG_SENSITIVE, "creditcard")
+	mock.Nodes[flagged.Path] = flagged
+	mock.Nodes["shared/folder/clean.txt"] = &tree.Node{Path: "shared/folder/clean.txt", Type: tree.NodeType_LEAF}
+	handler := &AclSensitiveFilter{}
+	handler.SetNextHandler(mock)
+
+	Convey("Flagged descendants are not served to public links users", t, func() {
+		_, e := handler.GetObject(context.Background(), &tree.Node{Path: "shared/folder/sub/cards.csv"}, &GetRequestData{})
+		So(e, ShouldNotBeNil)
+		_, e = handler.GetObject(context.Background(), &tree.Node{Path: "shared/folder/clean.txt"}, &GetRequestData{})
+		So(e, ShouldBeNil)
+	})
+
+	Convey("Flagged nodes are served to other users or when blocking is disabled", t, func() {
+		publicUser = false
+		_, e := handler.GetObject(context.Background(), &tree.Node{Path: "shared/folder/sub/cards.csv"}, &GetRequestData{})
+		So(e, ShouldBeNil)
+		publicUser, blocked = true, false
+		_, e = handler.GetObject(context.Background(), &tree.Node{Path: "shared/folder/sub/cards.csv"}, &GetRequestData{})
+		So(e, ShouldBeNil)
+	})
+}
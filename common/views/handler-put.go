@@ -198,6 +198,10 @@ func (m *PutHandler) PutObject(ctx context.Context, node *tree.Node, reader io.R
 
 		requestData.Metadata[common.X_AMZ_META_NODE_UUID] = newNode.Uuid
 		node.Uuid = newNode.Uuid
+		if onErrorFunc != nil {
+			// Node was just created, let next handlers know it is temporary
+			node.Etag = newNode.Etag
+		}
 		size, err := m.next.PutObject(ctx, node, reader, requestData)
 		if err != nil && onErrorFunc != nil {
 			log.Logger(ctx).Debug("Return of PutObject", zap.String("path", node.Path), zap.Int64("size", size), zap.Error(err))
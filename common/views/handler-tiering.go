@@ -0,0 +1,330 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"github.com/pydio/minio-go"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// TieredLocation is stored as node metadata when the content of a node has been moved to another datasource.
+// The original node keeps its UUID and path, but its content is replaced by an empty stub.
+type TieredLocation struct {
+	// Name of the datasource storing the content
+	DataSource string `json:"DataSource"`
+	// Full path of the content node, starting with the datasource name
+	Path string `json:"Path"`
+	// Uuid of the content node
+	Uuid string `json:"Uuid"`
+	// Original properties of the node
+	Size  int64  `json:"Size"`
+	Etag  string `json:"Etag"`
+	MTime int64  `json:"MTime"`
+	// Timestamp of the migration
+	TieredAt int64 `json:"TieredAt"`
+}
+
+type ctxTieringBypassKey struct{}
+
+// tieringEnabled can be replaced in tests
+var tieringEnabled = TieringEnabled
+
+// TieringEnabled tells whether nodes may have been tiered. If not, nodes that were not loaded
+// with their metadata are not looked up for a tier location.
+func TieringEnabled() bool {
+	if !config.Loaded() {
+		return false
+	}
+	return config.Get("services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_TREE, "tiering").Bool(false)
+}
+
+// EnableTiering stores the tiering flag in the configuration, if it is not already set.
+func EnableTiering() error {
+	if TieringEnabled() {
+		return nil
+	}
+	config.Set(true, "services", common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_TREE, "tiering")
+	return config.Save(common.PYDIO_SYSTEM_USERNAME, "Enable storage tiering")
+}
+
+// tieringMoves registers the tier copies of nodes being moved, so that deleting the source
+// of the move does not delete the content.
+var tieringMoves sync.Map
+
+// WithTieringBypass flags the context so that TieringHandler does not interfere with the operation.
+// It is used by the tiering actions to write the stub and restore the content.
+func WithTieringBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxTieringBypassKey{}, true)
+}
+
+// GetTieredLocation reads the tiering metadata of a node, if any.
+func GetTieredLocation(node *tree.Node) (*TieredLocation, bool) {
+	if node == nil || !node.HasMetaKey(common.META_NAMESPACE_TIERING) {
+		return nil, false
+	}
+	var loc TieredLocation
+	if e := node.GetMeta(common.META_NAMESPACE_TIERING, &loc); e != nil || loc.DataSource == "" || loc.Path == "" {
+		return nil, false
+	}
+	return &loc, true
+}
+
+// TieringHandler transparently serves the content of nodes that were moved to another datasource
+// by the tiering actions. Listings show the original size, reads are redirected to the tier location,
+// and overwriting a tiered node discards the tier copy.
+type TieringHandler struct {
+	AbstractHandler
+	MetaClient tree.NodeReceiverClient
+}
+
+func (a *TieringHandler) skipContext(ctx context.Context) bool {
+	return ctx.Value(ctxTieringBypassKey{}) != nil
+}
+
+// ReadNode restores the original properties of tiered nodes
+func (a *TieringHandler) ReadNode(ctx context.Context, in *tree.ReadNodeRequest, opts ...client.CallOption) (*tree.ReadNodeResponse, error) {
+	resp, e := a.next.ReadNode(ctx, in, opts...)
+	if e != nil || a.skipContext(ctx) || resp.Node == nil {
+		return resp, e
+	}
+	if loc, ok := GetTieredLocation(resp.Node); ok {
+		resp.Node = a.restoreProperties(resp.Node, loc)
+	}
+	return resp, nil
+}
+
+// ListNodes restores the original properties of tiered nodes
+func (a *TieringHandler) ListNodes(ctx context.Context, in *tree.ListNodesRequest, opts ...client.CallOption) (tree.NodeProvider_ListNodesClient, error) {
+	stream, err := a.next.ListNodes(ctx, in, opts...)
+	if err != nil || a.skipContext(ctx) {
+		return stream, err
+	}
+	s := NewWrappingStreamer()
+	go func() {
+		defer stream.Close()
+		defer s.Close()
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && err != io.ErrUnexpectedEOF {
+					s.SendError(err)
+				}
+				break
+			}
+			if resp == nil {
+				continue
+			}
+			if loc, ok := GetTieredLocation(resp.Node); ok {
+				resp.Node = a.restoreProperties(resp.Node, loc)
+			}
+			s.Send(resp)
+		}
+	}()
+	return s, nil
+}
+
+// GetObject reads the content from the tier location
+func (a *TieringHandler) GetObject(ctx context.Context, node *tree.Node, requestData *GetRequestData) (io.ReadCloser, error) {
+	if requestData != nil && requestData.VersionId != "" {
+		// Versions are not tiered
+		return a.next.GetObject(ctx, node, requestData)
+	}
+	loc, ok := a.tieredLocation(ctx, node)
+	if !ok {
+		return a.next.GetObject(ctx, node, requestData)
+	}
+	tCtx, target, e := a.tierBranch(ctx, "in", loc)
+	if e != nil {
+		return nil, e
+	}
+	log.Logger(ctx).Debug("Reading tiered content", zap.String("from", loc.Path), node.ZapPath())
+	return a.next.GetObject(tCtx, target, requestData)
+}
+
+// CopyObject copies from the tier location when the source is tiered. Moves copy the stub instead:
+// the node keeps its uuid and metadata, and the tier copy is kept when the source is deleted.
+func (a *TieringHandler) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *CopyRequestData) (int64, error) {
+	var movedLoc *TieredLocation
+	if requestData != nil && requestData.SrcVersionId != "" {
+		// Versions are not tiered
+	} else if loc, ok := a.tieredLocation(ctx, from); ok {
+		if requestData != nil && requestData.Metadata[common.X_AMZ_META_DIRECTIVE] == "COPY" {
+			movedLoc = loc
+			tieringMoves.Store(loc.Uuid, true)
+		} else {
+			tCtx, source, e := a.tierBranch(ctx, "from", loc)
+			if e != nil {
+				return 0, e
+			}
+			ctx, from = tCtx, source
+		}
+	}
+	targetLoc, targetTiered := a.tieredLocation(ctx, to)
+	n, e := a.next.CopyObject(ctx, from, to, requestData)
+	if e != nil && movedLoc != nil {
+		tieringMoves.Delete(movedLoc.Uuid)
+	}
+	if e == nil && targetTiered {
+		a.discardTier(ctx, to, targetLoc)
+	}
+	return n, e
+}
+
+// PutObject discards the tier location when a tiered node is overwritten
+func (a *TieringHandler) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *PutRequestData) (int64, error) {
+	loc, tiered := a.tieredLocation(ctx, node)
+	n, e := a.next.PutObject(ctx, node, reader, requestData)
+	if e == nil && tiered {
+		a.discardTier(ctx, node, loc)
+	}
+	return n, e
+}
+
+// MultipartComplete discards the tier location when a tiered node is overwritten
+func (a *TieringHandler) MultipartComplete(ctx context.Context, target *tree.Node, uploadID string, uploadedParts []minio.CompletePart) (minio.ObjectInfo, error) {
+	loc, tiered := a.tieredLocation(ctx, target)
+	info, e := a.next.MultipartComplete(ctx, target, uploadID, uploadedParts)
+	if e == nil && tiered {
+		a.discardTier(ctx, target, loc)
+	}
+	return info, e
+}
+
+// DeleteNode removes the tier copy along with the node, unless the node was just moved
+func (a *TieringHandler) DeleteNode(ctx context.Context, in *tree.DeleteNodeRequest, opts ...client.CallOption) (*tree.DeleteNodeResponse, error) {
+	var loc *TieredLocation
+	var tiered bool
+	if in.Node.IsLeaf() {
+		loc, tiered = a.tieredLocation(ctx, in.Node)
+	}
+	resp, e := a.next.DeleteNode(ctx, in, opts...)
+	if e == nil && tiered {
+		if _, moved := tieringMoves.Load(loc.Uuid); moved {
+			tieringMoves.Delete(loc.Uuid)
+		} else {
+			a.deleteTierCopy(ctx, loc)
+		}
+	}
+	return resp, e
+}
+
+// tieredLocation finds the tiering metadata, reloading the node if it was not fully loaded and tiering is enabled
+func (a *TieringHandler) tieredLocation(ctx context.Context, node *tree.Node) (*TieredLocation, bool) {
+	if a.skipContext(ctx) || node == nil {
+		return nil, false
+	}
+	if loc, ok := GetTieredLocation(node); ok {
+		return loc, true
+	}
+	if node.HasMetaKey(common.META_NAMESPACE_NODENAME) || node.Etag == common.NODE_FLAG_ETAG_TEMPORARY {
+		// Node was loaded with its metadata or is being created, it is not tiered
+		return nil, false
+	}
+	if !tieringEnabled() {
+		return nil, false
+	}
+	resp, e := a.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+	if e != nil || resp.Node == nil {
+		return nil, false
+	}
+	return GetTieredLocation(resp.Node)
+}
+
+// tierBranch prepares a context and a node pointing to the tier location
+func (a *TieringHandler) tierBranch(ctx context.Context, identifier string, loc *TieredLocation) (context.Context, *tree.Node, error) {
+	branchInfo, ok := GetBranchInfo(ctx, identifier)
+	if !ok {
+		return ctx, nil, errors.InternalServerError(VIEWS_LIBRARY_NAME, "Cannot find branch info for tiered node")
+	}
+	source, e := a.clientsPool.GetDataSourceInfo(loc.DataSource)
+	if e != nil {
+		return ctx, nil, e
+	}
+	branchInfo.LoadedSource = source
+	target := &tree.Node{
+		Uuid:      loc.Uuid,
+		Path:      loc.Path,
+		Size:      loc.Size,
+		Type:      tree.NodeType_LEAF,
+		MetaStore: make(map[string]string),
+	}
+	target.SetMeta(common.META_NAMESPACE_DATASOURCE_NAME, loc.DataSource)
+	target.SetMeta(common.META_NAMESPACE_DATASOURCE_PATH, strings.TrimPrefix(strings.TrimPrefix(loc.Path, loc.DataSource), "/"))
+	return WithBranchInfo(ctx, identifier, branchInfo), target, nil
+}
+
+// discardTier removes the tiering metadata and the tier copy after the node content was replaced
+func (a *TieringHandler) discardTier(ctx context.Context, node *tree.Node, loc *TieredLocation) {
+	uuid := node.Uuid
+	if uuid == "" {
+		if resp, e := a.next.ReadNode(ctx, &tree.ReadNodeRequest{Node: node}); e == nil && resp.Node != nil {
+			uuid = resp.Node.Uuid
+		}
+	}
+	if uuid != "" {
+		metaNode := &tree.Node{Uuid: uuid, Path: node.Path, MetaStore: map[string]string{common.META_NAMESPACE_TIERING: ""}}
+		if _, e := a.getMetaClient().UpdateNode(ctx, &tree.UpdateNodeRequest{From: metaNode, To: metaNode}); e != nil {
+			log.Logger(ctx).Error("Cannot remove tiering metadata", node.ZapPath(), zap.Error(e))
+			return
+		}
+	}
+	a.deleteTierCopy(ctx, loc)
+}
+
+// deleteTierCopy removes the content stored on the tier datasource
+func (a *TieringHandler) deleteTierCopy(ctx context.Context, loc *TieredLocation) {
+	tCtx, target, e := a.tierBranch(ctx, "in", loc)
+	if e != nil {
+		log.Logger(ctx).Error("Cannot delete tier copy", zap.String("path", loc.Path), zap.Error(e))
+		return
+	}
+	if _, e := a.next.DeleteNode(tCtx, &tree.DeleteNodeRequest{Node: target}); e != nil {
+		log.Logger(ctx).Error("Cannot delete tier copy", zap.String("path", loc.Path), zap.Error(e))
+	}
+}
+
+func (a *TieringHandler) restoreProperties(node *tree.Node, loc *TieredLocation) *tree.Node {
+	out := node.Clone()
+	out.Size = loc.Size
+	out.Etag = loc.Etag
+	out.MTime = loc.MTime
+	return out
+}
+
+func (a *TieringHandler) getMetaClient() tree.NodeReceiverClient {
+	if a.MetaClient == nil {
+		a.MetaClient = tree.NewNodeReceiverClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_META, defaults.NewClient())
+	}
+	return a.MetaClient
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package views
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func newTieringTestHandler() (*TieringHandler, *HandlerMock, *HandlerMock) {
+	tieringEnabled = func() bool { return true }
+	mock := NewHandlerMock()
+	metaMock := NewHandlerMock()
+	handler := &TieringHandler{MetaClient: metaMock}
+	handler.SetNextHandler(mock)
+	handler.SetClientsPool(&ClientsPool{Sources: map[string]LoadedSource{"cold": {}}})
+
+	tiered := &tree.Node{Uuid: "tiered-uuid", Path: "pydiods1/tiered.bin", Type: tree.NodeType_LEAF, Size: 0, Etag: "stub"}
+	tiered.SetMeta(common.META_NAMESPACE_TIERING, &TieredLocation{
+		DataSource: "cold",
+		Path:       "cold/tiering/tiered-uuid",
+		Uuid:       "cold-uuid",
+		Size:       2048,
+		Etag:       "original-etag",
+		MTime:      1500000000,
+	})
+	mock.Nodes[tiered.Path] = tiered
+	mock.Nodes["cold/tiering/tiered-uuid"] = &tree.Node{Uuid: "cold-uuid", Path: "cold/tiering/tiered-uuid", Size: 2048}
+	mock.Nodes["pydiods1/regular.txt"] = &tree.Node{Uuid: "regular-uuid", Path: "pydiods1/regular.txt", Size: 12, Type: tree.NodeType_LEAF}
+	return handler, mock, metaMock
+}
+
+func TestTieringHandler_ReadNode(t *testing.T) {
+
+	Convey("Tiered nodes expose their original properties", t, func() {
+		handler, _, _ := newTieringTestHandler()
+		resp, e := handler.ReadNode(context.Background(), &tree.ReadNodeRequest{Node: &tree.Node{Path: "pydiods1/tiered.bin"}})
+		So(e, ShouldBeNil)
+		So(resp.Node.Size, ShouldEqual, 2048)
+		So(resp.Node.Etag, ShouldEqual, "original-etag")
+		So(resp.Node.MTime, ShouldEqual, 1500000000)
+
+		resp, e = handler.ReadNode(context.Background(), &tree.ReadNodeRequest{Node: &tree.Node{Path: "pydiods1/regular.txt"}})
+		So(e, ShouldBeNil)
+		So(resp.Node.Size, ShouldEqual, 12)
+	})
+
+	Convey("Bypass context returns the stub", t, func() {
+		handler, _, _ := newTieringTestHandler()
+		resp, e := handler.ReadNode(WithTieringBypass(context.Background()), &tree.ReadNodeRequest{Node: &tree.Node{Path: "pydiods1/tiered.bin"}})
+		So(e, ShouldBeNil)
+		So(resp.Node.Size, ShouldEqual, 0)
+	})
+
+	Convey("Listing restores original properties", t, func() {
+		handler, _, _ := newTieringTestHandler()
+		stream, e := handler.ListNodes(context.Background(), &tree.ListNodesRequest{Node: &tree.Node{Path: "pydiods1"}})
+		So(e, ShouldBeNil)
+		sizes := map[string]int64{}
+		for {
+			resp, er := stream.Recv()
+			if er != nil {
+				break
+			}
+			sizes[resp.Node.Path] = resp.Node.Size
+		}
+		So(sizes, ShouldHaveLength, 2)
+		So(sizes["pydiods1/tiered.bin"], ShouldEqual, 2048)
+		So(sizes["pydiods1/regular.txt"], ShouldEqual, 12)
+	})
+
+}
+
+func TestTieringHandler_GetObject(t *testing.T) {
+
+	Convey("Reading a tiered node is redirected to the tier location", t, func() {
+		handler, mock, _ := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		reader, e := handler.GetObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, &GetRequestData{Length: -1})
+		So(e, ShouldBeNil)
+		data, _ := ioutil.ReadAll(reader)
+		So(string(data), ShouldEqual, "cold/tiering/tiered-uuidhello world")
+		So(mock.Nodes["in"].GetStringMeta(common.META_NAMESPACE_DATASOURCE_NAME), ShouldEqual, "cold")
+		So(mock.Nodes["in"].GetStringMeta(common.META_NAMESPACE_DATASOURCE_PATH), ShouldEqual, "tiering/tiered-uuid")
+	})
+
+	Convey("Regular nodes and versions are not redirected", t, func() {
+		handler, mock, _ := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		_, e := handler.GetObject(ctx, &tree.Node{Path: "pydiods1/regular.txt"}, &GetRequestData{Length: -1})
+		So(e, ShouldBeNil)
+		So(mock.Nodes["in"].Path, ShouldEqual, "pydiods1/regular.txt")
+
+		_, e = handler.GetObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, &GetRequestData{Length: -1, VersionId: "v1"})
+		So(e, ShouldBeNil)
+		So(mock.Nodes["in"].Path, ShouldEqual, "pydiods1/tiered.bin")
+	})
+
+}
+
+func TestTieringHandler_PutObject(t *testing.T) {
+
+	Convey("Overwriting a tiered node discards the tier copy", t, func() {
+		handler, mock, metaMock := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		_, e := handler.PutObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, strings.NewReader("new content"), &PutRequestData{})
+		So(e, ShouldBeNil)
+		So(metaMock.Nodes["to"], ShouldNotBeNil)
+		So(metaMock.Nodes["to"].Uuid, ShouldEqual, "tiered-uuid")
+		So(metaMock.Nodes["to"].GetStringMeta(common.META_NAMESPACE_TIERING), ShouldEqual, "")
+		So(mock.Nodes, ShouldNotContainKey, "cold/tiering/tiered-uuid")
+	})
+
+	Convey("Creating a node does not look up a tier location", t, func() {
+		handler, mock, metaMock := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		_, e := handler.PutObject(ctx, &tree.Node{Uuid: "tiered-uuid", Path: "pydiods1/tiered.bin", Etag: common.NODE_FLAG_ETAG_TEMPORARY}, strings.NewReader("new content"), &PutRequestData{})
+		So(e, ShouldBeNil)
+		So(metaMock.Nodes, ShouldNotContainKey, "to")
+		So(mock.Nodes, ShouldContainKey, "cold/tiering/tiered-uuid")
+	})
+
+	Convey("Nodes are not looked up when tiering is disabled", t, func() {
+		handler, mock, metaMock := newTieringTestHandler()
+		tieringEnabled = func() bool { return false }
+		defer func() { tieringEnabled = TieringEnabled }()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		_, e := handler.PutObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, strings.NewReader("new content"), &PutRequestData{})
+		So(e, ShouldBeNil)
+		So(metaMock.Nodes, ShouldNotContainKey, "to")
+		So(mock.Nodes, ShouldContainKey, "cold/tiering/tiered-uuid")
+
+		// Nodes carrying the tiering metadata are still handled
+		_, e = handler.PutObject(ctx, mock.Nodes["pydiods1/tiered.bin"].Clone(), strings.NewReader("new content"), &PutRequestData{})
+		So(e, ShouldBeNil)
+		So(metaMock.Nodes["to"], ShouldNotBeNil)
+		So(mock.Nodes, ShouldNotContainKey, "cold/tiering/tiered-uuid")
+	})
+
+	Convey("Writing the stub with bypass keeps the tier copy", t, func() {
+		handler, mock, metaMock := newTieringTestHandler()
+		ctx := WithTieringBypass(WithBranchInfo(context.Background(), "in", BranchInfo{}))
+		_, e := handler.PutObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, strings.NewReader(""), &PutRequestData{})
+		So(e, ShouldBeNil)
+		So(metaMock.Nodes, ShouldNotContainKey, "to")
+		So(mock.Nodes, ShouldContainKey, "cold/tiering/tiered-uuid")
+	})
+
+	Convey("Deleting a tiered node deletes the tier copy", t, func() {
+		handler, mock, _ := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "in", BranchInfo{})
+		_, e := handler.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: &tree.Node{Path: "pydiods1/tiered.bin", Type: tree.NodeType_LEAF}})
+		So(e, ShouldBeNil)
+		So(mock.Nodes, ShouldNotContainKey, "cold/tiering/tiered-uuid")
+	})
+
+}
+
+func TestTieringHandler_CopyObject(t *testing.T) {
+
+	Convey("Copying a tiered node reads from the tier location", t, func() {
+		handler, mock, _ := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "from", BranchInfo{})
+		_, e := handler.CopyObject(ctx, &tree.Node{Path: "pydiods1/tiered.bin"}, &tree.Node{Path: "pydiods1/copy.bin"}, &CopyRequestData{
+			Metadata: map[string]string{common.X_AMZ_META_DIRECTIVE: "REPLACE"},
+		})
+		So(e, ShouldBeNil)
+		So(mock.Nodes["from"].Uuid, ShouldEqual, "cold-uuid")
+		So(mock.Nodes["from"].Path, ShouldEqual, "cold/tiering/tiered-uuid")
+	})
+
+	Convey("Moving a tiered node keeps its uuid and its tier copy", t, func() {
+		handler, mock, _ := newTieringTestHandler()
+		ctx := WithBranchInfo(context.Background(), "from", BranchInfo{})
+		source := &tree.Node{Uuid: "tiered-uuid", Path: "pydiods1/tiered.bin", Type: tree.NodeType_LEAF}
+		tierMeta := mock.Nodes[source.Path].MetaStore[common.META_NAMESPACE_TIERING]
+		_, e := handler.CopyObject(ctx, source, &tree.Node{Path: "pydiods1/renamed.bin"}, &CopyRequestData{
+			Metadata: map[string]string{common.X_AMZ_META_DIRECTIVE: "COPY"},
+		})
+		So(e, ShouldBeNil)
+		So(mock.Nodes["from"].Uuid, ShouldEqual, "tiered-uuid")
+		So(mock.Nodes["from"].Path, ShouldEqual, "pydiods1/tiered.bin")
+
+		_, e = handler.DeleteNode(WithBranchInfo(ctx, "in", BranchInfo{}), &tree.DeleteNodeRequest{Node: source})
+		So(e, ShouldBeNil)
+		So(mock.Nodes, ShouldNotContainKey, "pydiods1/tiered.bin")
+		So(mock.Nodes, ShouldContainKey, "cold/tiering/tiered-uuid")
+
+		// Later deletions remove the tier copy again
+		mock.Nodes[source.Path] = &tree.Node{Uuid: "tiered-uuid", Path: source.Path, Type: tree.NodeType_LEAF, MetaStore: map[string]string{
+			common.META_NAMESPACE_TIERING: tierMeta,
+		}}
+		_, e = handler.DeleteNode(WithBranchInfo(ctx, "in", BranchInfo{}), &tree.DeleteNodeRequest{Node: source})
+		So(e, ShouldBeNil)
+		So(mock.Nodes, ShouldNotContainKey, "cold/tiering/tiered-uuid")
+	})
+
+}
@@ -88,11 +88,13 @@ func NewStandardRouter(options RouterOptions) *Router {
 		handlers = append(handlers, &UploadLimitFilter{})
 		handlers = append(handlers, &AclContentLockFilter{})
 		handlers = append(handlers, &AclQuotaFilter{})
+		handlers = append(handlers, &AclSensitiveFilter{})
 	}
 
 	if options.SynchronousTasks {
 		handlers = append(handlers, &SyncFolderTasksHandler{})
 	}
+	handlers = append(handlers, &TieringHandler{})
 	handlers = append(handlers, &EncryptionHandler{})
 	handlers = append(handlers, &VersionHandler{})
 	handlers = append(handlers, &Executor{})
@@ -123,7 +125,9 @@ func NewUuidRouter(options RouterOptions) *Router {
 		handlers = append(handlers, &UploadLimitFilter{})
 		handlers = append(handlers, &AclLockFilter{})
 		handlers = append(handlers, &AclQuotaFilter{})
+		handlers = append(handlers, &AclSensitiveFilter{})
 	}
+	handlers = append(handlers, &TieringHandler{})    // redirects reads of tiered nodes to their tier location
 	handlers = append(handlers, &EncryptionHandler{}) // retrieves encryption materials from encryption service
 	handlers = append(handlers, &VersionHandler{})
 	handlers = append(handlers, &Executor{})
@@ -28,14 +28,13 @@ import (
 
 	servicecontext "github.com/pydio/cells/common/service/context"
 
-	"github.com/ory/ladon"
-
 	"github.com/pydio/cells/common/proto/jobs"
 
 	"github.com/pydio/cells/common/forms"
 	"github.com/pydio/cells/common/forms/protos"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/idm/policy/conditions"
 
 	"github.com/pydio/cells/scheduler/actions"
 
@@ -284,21 +283,7 @@ func (s *Handler) SchedulerActionFormDiscovery(req *restful.Request, rsp *restfu
 				{servicecontext.ServerTime: servicecontext.ServerTime},
 			}
 			// Add SwitchField for PolicyCondition
-			condField := &forms.SwitchField{
-				Name:        "Condition",
-				Label:       "Condition",
-				Description: "Condition",
-			}
-			for name, f := range ladon.ConditionFactories {
-				condition := f()
-				condForm := protos.GenerateProtoToForm(condition, false)
-				condField.Values = append(condField.Values, &forms.SwitchValue{
-					Name:   name,
-					Value:  name,
-					Label:  name,
-					Fields: condForm.Groups[0].Fields,
-				})
-			}
+			condField := conditions.ConditionsSwitchField("Condition", "Condition")
 			if asSwitch {
 				sw := form.Groups[0].Fields[0].(*forms.SwitchField)
 				sw.Values[0].Fields[0].(*forms.FormField).Type = forms.ParamSelect
@@ -2,16 +2,22 @@ package modifiers
 
 import (
 	"errors"
+	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/emicklei/go-restful"
 	"github.com/gorilla/sessions"
 	"github.com/ory/fosite"
+	"github.com/pborman/uuid"
 
 	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/claim"
 	"github.com/pydio/cells/common/auth/hydra"
+	pauth "github.com/pydio/cells/common/proto/auth"
 	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/service/frontend"
+	"github.com/pydio/cells/common/utils/permissions"
 )
 
 // LoginExternalAuth allows users having a valid Cells session to create an authorization code directly
@@ -21,6 +27,10 @@ func LoginExternalAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddlewa
 			return middleware(req, rsp, in, out, session)
 		}
 
+		if connectorID, ok := in.AuthInfo["connector"]; ok {
+			return loginConnector(middleware, connectorID, req, rsp, in, out, session)
+		}
+
 		challenge, ok := in.AuthInfo["challenge"]
 		if !ok {
 			return errors.New("Challenge is required")
@@ -62,3 +72,123 @@ func LoginExternalAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddlewa
 		return middleware(req, rsp, in, out, session)
 	}
 }
+
+// loginConnector delegates the login to an upstream provider using a redirect flow. A first call
+// returns the provider URL to redirect to, a second call with the code and state received by the
+// callback logs the user in, either creating a session or answering the login challenge.
+func loginConnector(middleware frontend.AuthMiddleware, connectorID string, req *restful.Request, rsp *restful.Response, in *rest.FrontSessionRequest, out *rest.FrontSessionResponse, session *sessions.Session) error {
+
+	var connector auth.CallbackConnector
+	for _, c := range auth.GetConnectors() {
+		if cc, ok := c.Conn().(auth.CallbackConnector); ok && c.ID() == connectorID {
+			connector = cc
+			break
+		}
+	}
+	if connector == nil {
+		return errors.New("Unknown connector " + connectorID)
+	}
+
+	code, hasCode := in.AuthInfo["code"]
+	if !hasCode {
+		state := uuid.New()
+		session.Values["connector_state"] = state
+		session.Values["connector_id"] = connectorID
+		if challenge, ok := in.AuthInfo["challenge"]; ok {
+			session.Values["connector_challenge"] = challenge
+		} else {
+			delete(session.Values, "connector_challenge")
+		}
+		loginURL, err := connector.LoginURL(auth.Scopes{}, "", state)
+		if err != nil {
+			return err
+		}
+		out.RedirectTo = loginURL
+		return middleware(req, rsp, in, out, session)
+	}
+
+	state, ok := session.Values["connector_state"].(string)
+	if !ok || state == "" || state != in.AuthInfo["state"] || session.Values["connector_id"] != connectorID {
+		return errors.New("Invalid state")
+	}
+	delete(session.Values, "connector_state")
+
+	ctx := req.Request.Context()
+	callback, err := http.NewRequest(http.MethodGet, "?"+url.Values{"code": {code}, "state": {state}}.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	identity, err := connector.HandleCallback(auth.Scopes{}, callback.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	user, err := permissions.SearchUniqueUser(ctx, identity.Username, "")
+	if err != nil {
+		return err
+	}
+	if permissions.IsUserLocked(user) {
+		return errors.New("User " + user.Login + " has been blocked. Contact your sysadmin.")
+	}
+
+	claims := claim.Claims{
+		Subject: identity.UserID,
+		Name:    identity.Username,
+		Email:   identity.Email,
+	}
+	challenge, hasChallenge := session.Values["connector_challenge"].(string)
+	delete(session.Values, "connector_challenge")
+	if !hasChallenge {
+		// Login to Cells itself, using a generated challenge
+		authCode, err := auth.DefaultJWTVerifier().LoginChallengeCode(ctx, claims)
+		if err != nil {
+			return err
+		}
+		token, err := auth.DefaultJWTVerifier().Exchange(ctx, authCode)
+		if err != nil {
+			return err
+		}
+
+		idToken, ok := token.Extra("id_token").(string)
+		if !ok {
+			return errors.New("no id_token in token response")
+		}
+		session.Values["access_token"] = token.AccessToken
+		session.Values["id_token"] = idToken
+		session.Values["expires_at"] = strconv.Itoa(int(token.Expiry.Unix()))
+		session.Values["refresh_token"] = token.RefreshToken
+
+		out.Token = &pauth.Token{
+			AccessToken: session.Values["access_token"].(string),
+			IDToken:     session.Values["id_token"].(string),
+			ExpiresAt:   session.Values["expires_at"].(string),
+		}
+
+		return middleware(req, rsp, in, out, session)
+	}
+
+	authCode, err := auth.DefaultJWTVerifier().LoginChallengeCode(ctx, claims, auth.SetChallenge(challenge))
+	if err != nil {
+		return err
+	}
+
+	login, err := hydra.GetLogin(challenge)
+	if err != nil {
+		return err
+	}
+	requestURL, err := url.Parse(login.GetRequestURL())
+	if err != nil {
+		return err
+	}
+
+	requestURLValues := requestURL.Query()
+
+	redirectURL, err := fosite.GetRedirectURIFromRequestValues(requestURLValues)
+	if err != nil {
+		return err
+	}
+
+	out.RedirectTo = redirectURL + "?code=" + authCode + "&state=" + requestURLValues.Get("state")
+
+	return middleware(req, rsp, in, out, session)
+}
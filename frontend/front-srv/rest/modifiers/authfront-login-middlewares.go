@@ -1,10 +1,12 @@
 package modifiers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/gorilla/sessions"
@@ -12,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
 	"github.com/pydio/cells/common/log"
 	defaults "github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/idm"
@@ -35,6 +38,10 @@ func LoginSuccessWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddle
 		}
 
 		// AFTER MIDDLEWARE
+		if out.Trigger == TriggerTotp || out.Trigger == TriggerTotpEnroll {
+			// Second factor is still expected, login is not complete yet
+			return nil
+		}
 
 		// retrieving user
 		username, ok := in.AuthInfo["login"]
@@ -51,7 +58,7 @@ func LoginSuccessWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddle
 		}
 
 		// Checking user is locked
-		if permissions.IsUserLocked(user) {
+		if permissions.IsUserLocked(user) && !releaseExpiredLock(ctx, user) {
 			log.Auditer(ctx).Error(
 				"Locked user ["+user.Login+"] tried to log in.",
 				log.GetAuditId(common.AUDIT_LOGIN_POLICY_DENIAL),
@@ -124,7 +131,8 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 
 		username := in.AuthInfo["login"]
 
-		const maxFailedLogins = 10
+		policy := auth.LoadPasswordPolicy()
+		maxFailedLogins := int64(policy.MaxFailedLogins)
 
 		// Searching user for attributes
 		user, _ := permissions.SearchUniqueUser(ctx, username, "")
@@ -134,7 +142,7 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 		}
 
 		// double check if user was already locked to reduce work load
-		if permissions.IsUserLocked(user) {
+		if permissions.IsUserLocked(user) && !releaseExpiredLock(ctx, user) {
 			msg := fmt.Sprintf("locked user %s is still trying to connect", user.GetLogin())
 			log.Logger(ctx).Warn(msg, user.ZapLogin())
 			return errors.New("user.locked", "User is locked - Please contact your admin", http.StatusUnauthorized)
@@ -167,6 +175,9 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 			locks = append(locks, "logout")
 			data, _ := json.Marshal(locks)
 			user.Attributes["locks"] = string(data)
+			if d := policy.LockoutDuration(); d > 0 {
+				user.Attributes[lockedUntilAttribute] = fmt.Sprintf("%d", time.Now().Add(d).Unix())
+			}
 			msg := fmt.Sprintf("Locked user [%s] after %d failed connections", user.GetLogin(), maxFailedLogins)
 			log.Logger(ctx).Error(msg, user.ZapLogin())
 			log.Auditer(ctx).Error(
@@ -192,3 +203,41 @@ func LoginFailedWrapper(middleware frontend.AuthMiddleware) frontend.AuthMiddlew
 		}
 	}
 }
+
+// lockedUntilAttribute stores the expiry of a temporary lock set after too many failed logins
+const lockedUntilAttribute = "lockedUntil"
+
+// releaseExpiredLock removes the logout lock of a user if it was temporary and has expired.
+// It returns true if the user is not locked anymore.
+func releaseExpiredLock(ctx context.Context, user *idm.User) bool {
+	until, ok := user.Attributes[lockedUntilAttribute]
+	if !ok {
+		return false
+	}
+	stamp, e := strconv.ParseInt(until, 10, 64)
+	if e != nil || time.Now().Unix() < stamp {
+		return false
+	}
+	var locks, newLocks []string
+	json.Unmarshal([]byte(user.Attributes["locks"]), &locks)
+	for _, lock := range locks {
+		if lock != "logout" {
+			newLocks = append(newLocks, lock)
+		}
+	}
+	if len(newLocks) > 0 {
+		data, _ := json.Marshal(newLocks)
+		user.Attributes["locks"] = string(data)
+	} else {
+		delete(user.Attributes, "locks")
+	}
+	delete(user.Attributes, lockedUntilAttribute)
+	delete(user.Attributes, "failedConnections")
+	userClient := idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, defaults.NewClient())
+	if _, e := userClient.CreateUser(ctx, &idm.CreateUserRequest{User: user}); e != nil {
+		log.Logger(ctx).Error("could not release expired lock", user.ZapLogin(), zap.Error(e))
+		return false
+	}
+	log.Logger(ctx).Info("Released expired lock for user "+user.Login, user.ZapLogin())
+	return true
+}
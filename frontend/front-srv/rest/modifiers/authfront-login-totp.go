@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package modifiers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/gorilla/sessions"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/totp"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service/frontend"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+const (
+	// TriggerTotp asks the client to prompt the user for a one-time code
+	TriggerTotp = "totp"
+	// TriggerTotpEnroll asks the client to display a new secret and prompt for a first code
+	TriggerTotpEnroll = "totp_enroll"
+)
+
+// LoginTotpAuth requires a second factor for local users that have enrolled, or whose roles make it mandatory.
+// Once the password is verified, the client is asked for a code (passed in AuthInfo["totp"]) before any token is issued.
+func LoginTotpAuth(middleware frontend.AuthMiddleware) frontend.AuthMiddleware {
+	return func(req *restful.Request, rsp *restful.Response, in *rest.FrontSessionRequest, out *rest.FrontSessionResponse, session *sessions.Session) error {
+		if a, ok := in.AuthInfo["type"]; !ok || a != "credentials" { // Ignore this middleware
+			return middleware(req, rsp, in, out, session)
+		}
+
+		ctx := req.Request.Context()
+		username := in.AuthInfo["login"]
+		user, err := permissions.SearchUniqueUser(ctx, username, "")
+		if err != nil || user == nil || !totp.IsLocalUser(user) {
+			return middleware(req, rsp, in, out, session)
+		}
+
+		enrollment, err := totp.Load(user.Uuid)
+		if err != nil {
+			return err
+		}
+		active := enrollment != nil && enrollment.Active
+		if !active && !totp.Required(permissions.GetRolesForUser(ctx, user, false)) {
+			return middleware(req, rsp, in, out, session)
+		}
+
+		// Do not reveal anything about the second factor before the password is verified
+		userClient := idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, defaults.NewClient())
+		if _, err := userClient.BindUser(ctx, &idm.BindUserRequest{UserName: username, Password: in.AuthInfo["password"]}); err != nil {
+			return middleware(req, rsp, in, out, session)
+		}
+
+		code := strings.TrimSpace(in.AuthInfo["totp"])
+		if active {
+			if code == "" {
+				out.Trigger = TriggerTotp
+				out.TriggerInfo = map[string]string{"login": user.Login}
+				return nil
+			}
+			if _, ok, err := totp.Verify(user.Uuid, code, time.Now(), false); err != nil {
+				return err
+			} else if !ok {
+				log.Auditer(ctx).Error(
+					"Invalid second factor code for user ["+user.Login+"]",
+					log.GetAuditId(common.AUDIT_LOGIN_FAILED),
+					zap.String(common.KEY_USER_UUID, user.Uuid),
+				)
+				return errors.Unauthorized(common.SERVICE_USER, "Invalid verification code")
+			}
+			req.Request = req.Request.WithContext(totp.WithVerified(ctx, username))
+			return middleware(req, rsp, in, out, session)
+		}
+
+		// Second factor is mandatory but user has not enrolled yet: enrollment is completed during login
+		if code == "" || enrollment == nil {
+			pending, codes, err := totp.NewEnrollment()
+			if err != nil {
+				return err
+			}
+			if err := totp.Save(user.Uuid, pending); err != nil {
+				return err
+			}
+			out.Trigger = TriggerTotpEnroll
+			out.TriggerInfo = map[string]string{
+				"login":         user.Login,
+				"secret":        pending.Secret,
+				"uri":           totp.KeyURI(totp.Issuer(), user.Login, pending.Secret),
+				"recoveryCodes": strings.Join(codes, ","),
+			}
+			return nil
+		}
+		if _, ok, err := totp.Verify(user.Uuid, code, time.Now(), true); err != nil {
+			return err
+		} else if !ok {
+			return errors.Unauthorized(common.SERVICE_USER, "Invalid verification code")
+		}
+		log.Auditer(ctx).Info(
+			"User ["+user.Login+"] activated a second factor at login",
+			log.GetAuditId(common.AUDIT_USER_UPDATE),
+			zap.String(common.KEY_USER_UUID, user.Uuid),
+		)
+		req.Request = req.Request.WithContext(totp.WithVerified(ctx, username))
+		return middleware(req, rsp, in, out, session)
+	}
+}
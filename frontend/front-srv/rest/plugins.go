@@ -89,6 +89,7 @@ func init() {
 		frontend.WrapAuthMiddleware(modifiers.RefreshAuth)
 
 		frontend.WrapAuthMiddleware(modifiers.LoginPasswordAuth)
+		frontend.WrapAuthMiddleware(modifiers.LoginTotpAuth)
 		frontend.WrapAuthMiddleware(modifiers.LoginExternalAuth)
 		frontend.WrapAuthMiddleware(modifiers.AuthorizationCodeAuth)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/micro/go-micro/metadata"
 	"github.com/micro/go-micro/server"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/peer"
 
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/auth"
@@ -97,6 +98,7 @@ func httpMetaWrapper() func(handlerFunc server.HandlerFunc) server.HandlerFunc {
 func ctxRequestInfoToMetadata(ctx context.Context) context.Context {
 
 	meta := metadata.Metadata{}
+	var forwarded []string
 	if existing, ok := metadata.FromContext(ctx); ok {
 		if _, already := existing[servicecontext.HttpMetaExtracted]; already {
 			return ctx
@@ -104,7 +106,6 @@ func ctxRequestInfoToMetadata(ctx context.Context) context.Context {
 		translate := map[string]string{
 			"user-agent":      servicecontext.HttpMetaUserAgent,
 			"content-type":    servicecontext.HttpMetaContentType,
-			"x-forwarded-for": servicecontext.HttpMetaRemoteAddress,
 			"x-pydio-span-id": servicecontext.SpanMetadataId,
 		}
 		for k, v := range existing {
@@ -114,11 +115,25 @@ func ctxRequestInfoToMetadata(ctx context.Context) context.Context {
 				meta[k] = v
 			}
 		}
+		if f, ok := existing["x-forwarded-for"]; ok {
+			forwarded = append(forwarded, f)
+			delete(meta, "x-forwarded-for")
+		}
 		// Override with specific header
 		if ua, ok := existing["x-pydio-grpc-user-agent"]; ok {
 			meta[servicecontext.HttpMetaUserAgent] = ua
 		}
 	}
+	// Resolve client address from the connection, forwarded chain is only read if it comes from a trusted proxy
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	}
+	if addr := servicecontext.PeerClientAddress(remote, forwarded...); addr != "" {
+		meta[servicecontext.HttpMetaRemoteAddress] = addr
+	} else {
+		delete(meta, servicecontext.HttpMetaRemoteAddress)
+	}
 	meta[servicecontext.HttpMetaExtracted] = servicecontext.HttpMetaExtracted
 	layout := "2006-01-02T15:04-0700"
 	t := time.Now()
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/micro/go-micro/metadata"
+	"google.golang.org/grpc/peer"
+
+	servicecontext "github.com/pydio/cells/common/service/context"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCtxRequestInfoToMetadata(t *testing.T) {
+
+	Convey("Test client address is read from the connection peer", t, func() {
+
+		remote := func(peerAddr string, forwarded string) string {
+			ctx := context.Background()
+			if peerAddr != "" {
+				addr, _ := net.ResolveTCPAddr("tcp", peerAddr)
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+			}
+			if forwarded != "" {
+				ctx = metadata.NewContext(ctx, metadata.Metadata{"x-forwarded-for": forwarded})
+			}
+			meta, _ := metadata.FromContext(ctxRequestInfoToMetadata(ctx))
+			So(meta, ShouldNotContainKey, "x-forwarded-for")
+			return meta[servicecontext.HttpMetaRemoteAddress]
+		}
+
+		// Forwarded header sent by a direct client is ignored
+		So(remote("8.8.8.8:1234", "1.2.3.4"), ShouldEqual, "8.8.8.8:1234")
+		So(remote("", "1.2.3.4"), ShouldEqual, "")
+		// Forwarded header sent by the bundled proxy
+		So(remote("127.0.0.1:1234", "1.2.3.4, 8.8.8.8"), ShouldEqual, "8.8.8.8")
+		So(remote("127.0.0.1:1234", ""), ShouldEqual, "127.0.0.1:1234")
+	})
+}
@@ -0,0 +1,411 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a parsed SCIM filter expression (RFC 7644, section 3.4.2.2).
+type Filter interface {
+	Match(r Resource) bool
+}
+
+type attrExpr struct {
+	path  string
+	op    string
+	value interface{}
+}
+
+type logicalExpr struct {
+	and         bool
+	left, right Filter
+}
+
+type notExpr struct {
+	inner Filter
+}
+
+// valuePathExpr matches multi-valued attributes, e.g. emails[type eq "work"]
+type valuePathExpr struct {
+	path  string
+	inner Filter
+}
+
+// ParseFilter parses a filter string.
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %s", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOpen
+	tokClose
+	tokOpenBracket
+	tokCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	r := []rune(s)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokClose, text: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokOpenBracket, text: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokCloseBracket, text: "]"})
+			i++
+		case c == '"':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(r) {
+				if r[i] == '\\' && i+1 < len(r) {
+					sb.WriteRune(r[i+1])
+					i += 2
+					continue
+				}
+				if r[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(r[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String()})
+		default:
+			start := i
+			for i < len(r) && !unicode.IsSpace(r[i]) && !strings.ContainsRune("()[]\"", r[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: string(r[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *filterParser) isKeyword(word string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokWord && strings.EqualFold(t.text, word)
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	if p.isKeyword("not") {
+		p.pos++
+		if t := p.peek(); t == nil || t.kind != tokOpen {
+			return nil, fmt.Errorf("expected ( after not")
+		}
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner: inner}, nil
+	}
+	if t.kind == tokOpen {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tokClose {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	}
+	if t.kind != tokWord {
+		return nil, fmt.Errorf("expected attribute name, got %s", t.text)
+	}
+	path := t.text
+	p.pos++
+	if n := p.peek(); n != nil && n.kind == tokOpenBracket {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tokCloseBracket {
+			return nil, fmt.Errorf("missing closing bracket")
+		}
+		p.pos++
+		return &valuePathExpr{path: path, inner: inner}, nil
+	}
+	opTok := p.peek()
+	if opTok == nil || opTok.kind != tokWord {
+		return nil, fmt.Errorf("expected operator after %s", path)
+	}
+	op := strings.ToLower(opTok.text)
+	p.pos++
+	switch op {
+	case "pr":
+		return &attrExpr{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", opTok.text)
+	}
+	vTok := p.peek()
+	if vTok == nil {
+		return nil, fmt.Errorf("expected value after %s %s", path, op)
+	}
+	p.pos++
+	var value interface{}
+	if vTok.kind == tokString {
+		value = vTok.text
+	} else if vTok.kind == tokWord {
+		switch strings.ToLower(vTok.text) {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			n, err := strconv.ParseFloat(vTok.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %s", vTok.text)
+			}
+			value = n
+		}
+	} else {
+		return nil, fmt.Errorf("invalid value %s", vTok.text)
+	}
+	return &attrExpr{path: path, op: op, value: value}, nil
+}
+
+// Match implements Filter.
+func (e *logicalExpr) Match(r Resource) bool {
+	if e.and {
+		return e.left.Match(r) && e.right.Match(r)
+	}
+	return e.left.Match(r) || e.right.Match(r)
+}
+
+// Match implements Filter.
+func (e *notExpr) Match(r Resource) bool {
+	return !e.inner.Match(r)
+}
+
+// Match implements Filter.
+func (e *valuePathExpr) Match(r Resource) bool {
+	for _, v := range r.values(e.path) {
+		if m, ok := v.(map[string]interface{}); ok && e.inner.Match(Resource(m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match implements Filter.
+func (e *attrExpr) Match(r Resource) bool {
+	values := r.values(e.path)
+	if e.op == "pr" {
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if e.op == "ne" {
+		for _, v := range values {
+			if compare(v, "eq", e.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compare(v, e.op, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compare(v interface{}, op string, expected interface{}) bool {
+	switch ev := expected.(type) {
+	case nil:
+		return op == "eq" && v == nil
+	case bool:
+		b, ok := toBool(v)
+		return ok && op == "eq" && b == ev
+	case float64:
+		var n float64
+		switch tv := v.(type) {
+		case float64:
+			n = tv
+		case int:
+			n = float64(tv)
+		case string:
+			var e error
+			if n, e = strconv.ParseFloat(tv, 64); e != nil {
+				return false
+			}
+		default:
+			return false
+		}
+		switch op {
+		case "eq":
+			return n == ev
+		case "gt":
+			return n > ev
+		case "ge":
+			return n >= ev
+		case "lt":
+			return n < ev
+		case "le":
+			return n <= ev
+		}
+		return false
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		s, e := strings.ToLower(s), strings.ToLower(ev)
+		switch op {
+		case "eq":
+			return s == e
+		case "co":
+			return strings.Contains(s, e)
+		case "sw":
+			return strings.HasPrefix(s, e)
+		case "ew":
+			return strings.HasSuffix(s, e)
+		case "gt":
+			return s > e
+		case "ge":
+			return s >= e
+		case "lt":
+			return s < e
+		case "le":
+			return s <= e
+		}
+	}
+	return false
+}
+
+func toBool(v interface{}) (bool, bool) {
+	switch tv := v.(type) {
+	case bool:
+		return tv, true
+	case string:
+		b, e := strconv.ParseBool(tv)
+		return b, e == nil
+	}
+	return false, false
+}
+
+// equalityValue returns the value of a simple "attr eq value" filter, used to narrow
+// searches on the underlying service.
+func equalityValue(f Filter, attr string) (string, bool) {
+	if e, ok := f.(*attrExpr); ok && e.op == "eq" && strings.EqualFold(stripSchema(e.path), attr) {
+		if s, ok := e.value.(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
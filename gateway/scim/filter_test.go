@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func testUser() Resource {
+	return userResource(&idm.User{
+		Uuid:  "u1",
+		Login: "jdoe",
+		Attributes: map[string]string{
+			idm.UserAttrDisplayName: "John Doe",
+			idm.UserAttrEmail:       "john@example.com",
+			AttrExternalID:          "ext-42",
+			AttrGivenName:           "John",
+		},
+	}, "https://cells.example.com/scim/v2")
+}
+
+func match(filter string, r Resource) bool {
+	f, err := ParseFilter(filter)
+	So(err, ShouldBeNil)
+	return f.Match(r)
+}
+
+func TestParseFilter(t *testing.T) {
+
+	Convey("Test attribute expressions", t, func() {
+		u := testUser()
+		So(match(`userName eq "jdoe"`, u), ShouldBeTrue)
+		So(match(`USERNAME eq "JDOE"`, u), ShouldBeTrue)
+		So(match(`userName eq "other"`, u), ShouldBeFalse)
+		So(match(`userName ne "other"`, u), ShouldBeTrue)
+		So(match(`displayName co "ohn D"`, u), ShouldBeTrue)
+		So(match(`displayName sw "john"`, u), ShouldBeTrue)
+		So(match(`displayName ew "doe"`, u), ShouldBeTrue)
+		So(match(`name.givenName eq "John"`, u), ShouldBeTrue)
+		So(match(`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jdoe"`, u), ShouldBeTrue)
+		So(match(`emails.value eq "john@example.com"`, u), ShouldBeTrue)
+		So(match(`externalId pr`, u), ShouldBeTrue)
+		So(match(`name.familyName pr`, u), ShouldBeFalse)
+		So(match(`active eq true`, u), ShouldBeTrue)
+		So(match(`active eq false`, u), ShouldBeFalse)
+	})
+
+	Convey("Test logical expressions and value paths", t, func() {
+		u := testUser()
+		So(match(`userName eq "jdoe" and active eq true`, u), ShouldBeTrue)
+		So(match(`userName eq "other" or externalId eq "ext-42"`, u), ShouldBeTrue)
+		So(match(`not (userName eq "jdoe")`, u), ShouldBeFalse)
+		So(match(`(userName eq "a" or userName eq "jdoe") and displayName pr`, u), ShouldBeTrue)
+		So(match(`emails[type eq "work" and value ew "example.com"]`, u), ShouldBeTrue)
+		So(match(`emails[type eq "home"]`, u), ShouldBeFalse)
+	})
+
+	Convey("Test invalid filters", t, func() {
+		for _, f := range []string{``, `userName`, `userName xx "a"`, `userName eq "a`, `(userName eq "a"`, `userName eq "a" and`, `emails[type eq "work"`} {
+			_, err := ParseFilter(f)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Test equality fast path", t, func() {
+		f, _ := ParseFilter(`userName eq "jdoe"`)
+		v, ok := equalityValue(f, "userName")
+		So(ok, ShouldBeTrue)
+		So(v, ShouldEqual, "jdoe")
+		f, _ = ParseFilter(`userName eq "jdoe" and active eq true`)
+		_, ok = equalityValue(f, "userName")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,325 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// Groups are mapped to first-level groups of the users tree. As a user belongs to a single group
+// path, adding a user to a group moves it out of its previous group, and removing a member moves
+// it back to the default users group path.
+
+// ListGroups lists groups matching the optional filter, with pagination.
+func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var filter Filter
+	if fs := r.URL.Query().Get("filter"); fs != "" {
+		var err error
+		if filter, err = ParseFilter(fs); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+	}
+	query := &idm.UserSingleQuery{GroupPath: "/", Recursive: true, NodeType: idm.NodeType_GROUP}
+	var groups []*idm.User
+	var total, start int
+	var err error
+	if filter == nil {
+		// Let the users service paginate, so that members are only loaded for the listed groups
+		var count int
+		start, count = pageRange(r)
+		groups, total, err = h.searchPage(ctx, start-1, count, query)
+	} else {
+		groups, err = h.search(ctx, query)
+	}
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	base := baseURL(r)
+	withMembers := wantsMembers(r)
+	resources := []Resource{}
+	for _, g := range groups {
+		if g.GroupPath == "/" || g.GroupPath == "" {
+			continue
+		}
+		var members []*idm.User
+		if withMembers {
+			if members, err = h.members(ctx, g); err != nil {
+				writeServiceError(w, err)
+				return
+			}
+		}
+		res := groupResource(g, members, base)
+		if filter == nil || filter.Match(res) {
+			resources = append(resources, res)
+		}
+	}
+	if filter == nil {
+		writeJSON(w, http.StatusOK, listPage(r, resources, total, start))
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(r, resources))
+}
+
+// GetGroup returns a single group with its members.
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, err := h.findOne(ctx, mux.Vars(r)["id"], idm.NodeType_GROUP)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	var members []*idm.User
+	if wantsMembers(r) {
+		if members, err = h.members(ctx, g); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, project(r, groupResource(g, members, baseURL(r))))
+}
+
+// CreateGroup creates a group with its group role, and moves the listed members into it.
+func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res, err := readResource(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	display := res.str("displayName")
+	label := groupLabel(display)
+	if label == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+	if existing, err := h.search(ctx, &idm.UserSingleQuery{FullPath: "/" + label, NodeType: idm.NodeType_GROUP}); err != nil {
+		writeServiceError(w, err)
+		return
+	} else if len(existing) > 0 {
+		writeError(w, http.StatusConflict, "uniqueness", fmt.Sprintf("Group %s already exists", display))
+		return
+	}
+	g := &idm.User{
+		IsGroup:    true,
+		GroupLabel: label,
+		GroupPath:  "/" + label,
+		Attributes: map[string]string{
+			idm.UserAttrDisplayName: display,
+			idm.UserAttrOrigin:      OriginSCIM,
+		},
+	}
+	setOrDelete(g.Attributes, AttrExternalID, res.str("externalId"))
+	created, err := h.save(ctx, g)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if _, e := h.roleClient().CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+		Uuid:      created.Uuid,
+		GroupRole: true,
+		Label:     "Group " + created.GroupLabel,
+	}}); e != nil {
+		writeServiceError(w, e)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Group [%s] provisioned through SCIM", created.GroupPath),
+		log.GetAuditId(common.AUDIT_GROUP_CREATE),
+		created.ZapUuid(),
+	)
+	members, err := h.syncMembers(ctx, created, memberIds(res))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.Header().Set("Location", baseURL(r)+"/Groups/"+created.Uuid)
+	writeJSON(w, http.StatusCreated, groupResource(created, members, baseURL(r)))
+}
+
+// ReplaceGroup replaces the display name, external id and members of a group.
+func (h *Handler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, err := h.findOne(ctx, mux.Vars(r)["id"], idm.NodeType_GROUP)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	res, err := readResource(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	h.writeUpdatedGroup(w, r, g, res)
+}
+
+// PatchGroup applies PATCH operations to a group, typically to add or remove members.
+func (h *Handler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, err := h.findOne(ctx, mux.Vars(r)["id"], idm.NodeType_GROUP)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	var patch PatchRequest
+	if err := decodePatch(r, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	members, err := h.members(ctx, g)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	res := groupResource(g, members, baseURL(r))
+	if err := applyPatch(res, patch.Operations); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidPath", err.Error())
+		return
+	}
+	h.writeUpdatedGroup(w, r, g, res)
+}
+
+func (h *Handler) writeUpdatedGroup(w http.ResponseWriter, r *http.Request, g *idm.User, res Resource) {
+	ctx := r.Context()
+	if g.Attributes == nil {
+		g.Attributes = map[string]string{}
+	}
+	if display := res.str("displayName"); display != "" {
+		g.Attributes[idm.UserAttrDisplayName] = display
+	}
+	setOrDelete(g.Attributes, AttrExternalID, res.str("externalId"))
+	updated, err := h.save(ctx, g)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	members, err := h.syncMembers(ctx, updated, memberIds(res))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Group [%s] updated through SCIM", updated.GroupPath),
+		log.GetAuditId(common.AUDIT_GROUP_UPDATE),
+		updated.ZapUuid(),
+	)
+	writeJSON(w, http.StatusOK, groupResource(updated, members, baseURL(r)))
+}
+
+// DeleteGroup moves the members out of the group, then deletes it.
+func (h *Handler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, err := h.findOne(ctx, mux.Vars(r)["id"], idm.NodeType_GROUP)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if _, err := h.syncMembers(ctx, g, nil); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if err := h.deleteByUuid(ctx, g.Uuid, idm.NodeType_GROUP); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Group [%s] deleted through SCIM", g.GroupPath),
+		log.GetAuditId(common.AUDIT_GROUP_DELETE),
+		g.ZapUuid(),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// members lists the users directly contained in a group.
+func (h *Handler) members(ctx context.Context, g *idm.User) ([]*idm.User, error) {
+	users, err := h.search(ctx, &idm.UserSingleQuery{GroupPath: g.GroupPath, NodeType: idm.NodeType_USER})
+	if err != nil {
+		return nil, err
+	}
+	members := []*idm.User{}
+	for _, u := range users {
+		if u.GroupPath == g.GroupPath {
+			members = append(members, u)
+		}
+	}
+	return members, nil
+}
+
+// syncMembers moves users so that the group contains exactly the given user ids, and returns the new members.
+// It refuses to move users that are not managed through SCIM.
+func (h *Handler) syncMembers(ctx context.Context, g *idm.User, ids []string) ([]*idm.User, error) {
+	current, err := h.members(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var members, removed []*idm.User
+	for _, u := range current {
+		if wanted[u.Uuid] {
+			members = append(members, u)
+			delete(wanted, u.Uuid)
+			continue
+		}
+		if err := checkManaged(u); err != nil {
+			return nil, err
+		}
+		removed = append(removed, u)
+	}
+	var added []*idm.User
+	for id := range wanted {
+		u, err := h.findManaged(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, u)
+	}
+	for _, u := range removed {
+		u.GroupPath = usersGroupPath()
+		if _, err := h.save(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	for _, u := range added {
+		u.GroupPath = g.GroupPath
+		moved, err := h.save(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, moved)
+	}
+	if members == nil {
+		members = []*idm.User{}
+	}
+	return members, nil
+}
@@ -0,0 +1,439 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+const (
+	// Prefix of all SCIM routes
+	Prefix = "/scim/v2"
+
+	defaultCount = 100
+	maxCount     = 1000
+
+	// DeprovisionDelete deletes users, which triggers the clean-user-data job on deletion events
+	DeprovisionDelete = "delete"
+	// DeprovisionLock only locks users, keeping their data untouched
+	DeprovisionLock = "lock"
+)
+
+// Handler serves the SCIM endpoints on top of the users service.
+type Handler struct{}
+
+func configValue(key string) string {
+	return config.Get("services", common.SERVICE_GATEWAY_SCIM, key).String("")
+}
+
+// usersGroupPath is the group where new users are created, and where users removed from a group are moved.
+func usersGroupPath() string {
+	if p := configValue("groupPath"); p != "" {
+		return "/" + strings.Trim(p, "/")
+	}
+	return "/"
+}
+
+func deprovisionMode() string {
+	if m := configValue("deprovision"); m == DeprovisionLock {
+		return m
+	}
+	return DeprovisionDelete
+}
+
+// authenticate checks the bearer token against the secret referenced by the "token" configuration.
+func authenticate(inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var expected string
+		if key := configValue("token"); key != "" {
+			expected = config.GetSecret(key).String("")
+		}
+		if expected == "" {
+			writeError(w, http.StatusUnauthorized, "", "SCIM provisioning is not configured")
+			return
+		}
+		header := r.Header.Get("Authorization")
+		if len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimSpace(header[7:])), []byte(expected)) != 1 {
+			log.Logger(r.Context()).Error("Invalid bearer token on SCIM endpoint", zap.String("remote", r.RemoteAddr))
+			writeError(w, http.StatusUnauthorized, "", "Invalid bearer token")
+			return
+		}
+		inner.ServeHTTP(w, r.WithContext(context2.WithUserNameMetadata(r.Context(), common.PYDIO_SYSTEM_USERNAME)))
+	})
+}
+
+func logger(inner http.Handler, name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		inner.ServeHTTP(w, r)
+		log.Logger(r.Context()).Debug(fmt.Sprintf("%s %s %s %s", r.Method, r.RequestURI, name, time.Since(start)))
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType string, detail string) {
+	body := map[string]interface{}{
+		"schemas": []string{SchemaError},
+		"status":  strconv.Itoa(status),
+		"detail":  detail,
+	}
+	if scimType != "" {
+		body["scimType"] = scimType
+	}
+	writeJSON(w, status, body)
+}
+
+// writeServiceError converts an error returned by a grpc service.
+func writeServiceError(w http.ResponseWriter, err error) {
+	parsed := errors.Parse(err.Error())
+	switch parsed.Code {
+	case http.StatusNotFound, http.StatusForbidden, http.StatusConflict:
+		writeError(w, int(parsed.Code), "", parsed.Detail)
+	case http.StatusBadRequest:
+		writeError(w, http.StatusBadRequest, "invalidValue", parsed.Detail)
+	default:
+		writeError(w, http.StatusInternalServerError, "", err.Error())
+	}
+}
+
+func readResource(r *http.Request) (Resource, error) {
+	res := Resource{}
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %v", err)
+	}
+	return res, nil
+}
+
+// baseURL computes the public URL of the SCIM endpoint, used in meta.location.
+func baseURL(r *http.Request) string {
+	scheme := r.Header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		if r.TLS != nil {
+			scheme = "https"
+		} else {
+			scheme = "http"
+		}
+	}
+	return scheme + "://" + r.Host + Prefix
+}
+
+type listResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []Resource `json:"Resources"`
+}
+
+// pageRange reads the 1-based startIndex and count parameters.
+func pageRange(r *http.Request) (start int, count int) {
+	start, count = 1, defaultCount
+	if s, e := strconv.Atoi(r.URL.Query().Get("startIndex")); e == nil && s > 1 {
+		start = s
+	}
+	if c, e := strconv.Atoi(r.URL.Query().Get("count")); e == nil && c >= 0 {
+		count = c
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	return
+}
+
+// paginate applies the 1-based startIndex and count parameters to the list of resources.
+func paginate(r *http.Request, all []Resource) *listResponse {
+	start, count := pageRange(r)
+	page := []Resource{}
+	if start-1 < len(all) {
+		end := start - 1 + count
+		if end > len(all) {
+			end = len(all)
+		}
+		page = all[start-1 : end]
+	}
+	return listPage(r, page, len(all), start)
+}
+
+// listPage builds the list response for a page of resources starting at the 1-based start index.
+func listPage(r *http.Request, page []Resource, total int, start int) *listResponse {
+	for i, p := range page {
+		page[i] = project(r, p)
+	}
+	return &listResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+// project applies the attributes and excludedAttributes parameters on top-level attributes.
+func project(r *http.Request, res Resource) Resource {
+	attributes := splitList(r.URL.Query().Get("attributes"))
+	excluded := splitList(r.URL.Query().Get("excludedAttributes"))
+	if len(attributes) == 0 && len(excluded) == 0 {
+		return res
+	}
+	out := Resource{}
+	for k, v := range res {
+		keep := k == "id" || k == "schemas" || k == "meta" || len(attributes) == 0
+		for _, a := range attributes {
+			if strings.EqualFold(strings.SplitN(a, ".", 2)[0], k) {
+				keep = true
+			}
+		}
+		for _, e := range excluded {
+			if strings.EqualFold(e, k) && k != "id" && k != "schemas" {
+				keep = false
+			}
+		}
+		if keep {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = stripSchema(strings.TrimSpace(p)); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// wantsMembers tells whether group members must be loaded for this request.
+func wantsMembers(r *http.Request) bool {
+	q := r.URL.Query()
+	if q.Get("filter") != "" {
+		return true
+	}
+	for _, e := range splitList(q.Get("excludedAttributes")) {
+		if strings.EqualFold(e, "members") {
+			return false
+		}
+	}
+	if attributes := splitList(q.Get("attributes")); len(attributes) > 0 {
+		for _, a := range attributes {
+			if strings.HasPrefix(strings.ToLower(a), "members") {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (h *Handler) userClient() idm.UserServiceClient {
+	return idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, defaults.NewClient())
+}
+
+func (h *Handler) roleClient() idm.RoleServiceClient {
+	return idm.NewRoleServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_ROLE, defaults.NewClient())
+}
+
+// search lists users or groups matching a single query.
+func (h *Handler) search(ctx context.Context, query *idm.UserSingleQuery) ([]*idm.User, error) {
+	q, _ := ptypes.MarshalAny(query)
+	return h.searchQuery(ctx, &service.Query{SubQueries: []*any.Any{q}})
+}
+
+// searchPage lists a page of users or groups matching all the queries, along with the total number of results.
+func (h *Handler) searchPage(ctx context.Context, offset, limit int, queries ...*idm.UserSingleQuery) ([]*idm.User, int, error) {
+	query := &service.Query{Offset: int64(offset), Limit: int64(limit), Operation: service.OperationType_AND}
+	for _, sq := range queries {
+		q, _ := ptypes.MarshalAny(sq)
+		query.SubQueries = append(query.SubQueries, q)
+	}
+	rsp, err := h.userClient().CountUser(ctx, &idm.SearchUserRequest{Query: query})
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int(rsp.Count)
+	// A zero limit means no limit for the users service
+	if limit == 0 || offset >= total {
+		return nil, total, nil
+	}
+	users, err := h.searchQuery(ctx, query)
+	return users, total, err
+}
+
+func (h *Handler) searchQuery(ctx context.Context, query *service.Query) ([]*idm.User, error) {
+	stream, err := h.userClient().SearchUser(ctx, &idm.SearchUserRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	var users []*idm.User
+	for {
+		rsp, e := stream.Recv()
+		if e != nil {
+			if e == io.EOF || e == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, e
+		}
+		if rsp == nil || rsp.User == nil {
+			continue
+		}
+		users = append(users, rsp.User)
+	}
+	return users, nil
+}
+
+// findManaged loads a user that can be modified through SCIM, see checkManaged.
+func (h *Handler) findManaged(ctx context.Context, id string) (*idm.User, error) {
+	u, err := h.findOne(ctx, id, idm.NodeType_USER)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManaged(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// checkManaged verifies that a user was provisioned through SCIM or is stored under the configured
+// groupPath, and that it is not an administrator.
+func checkManaged(u *idm.User) error {
+	if u.Attributes[idm.UserAttrProfile] == common.PYDIO_PROFILE_ADMIN {
+		return errors.Forbidden(common.SERVICE_GATEWAY_SCIM, "User %s is an administrator and cannot be modified through SCIM", u.Login)
+	}
+	if u.Attributes[idm.UserAttrOrigin] == OriginSCIM {
+		return nil
+	}
+	if root := usersGroupPath(); root != "/" && (u.GroupPath == root || strings.HasPrefix(u.GroupPath, root+"/")) {
+		return nil
+	}
+	return errors.Forbidden(common.SERVICE_GATEWAY_SCIM, "User %s is not managed through SCIM", u.Login)
+}
+
+// findOne loads a single user or group by its Uuid.
+func (h *Handler) findOne(ctx context.Context, id string, nodeType idm.NodeType) (*idm.User, error) {
+	results, err := h.search(ctx, &idm.UserSingleQuery{Uuid: id, NodeType: nodeType})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range results {
+		if u.Uuid == id {
+			return u, nil
+		}
+	}
+	return nil, errors.NotFound(common.SERVICE_GATEWAY_SCIM, "Resource %s not found", id)
+}
+
+func (h *Handler) save(ctx context.Context, u *idm.User) (*idm.User, error) {
+	rsp, err := h.userClient().CreateUser(ctx, &idm.CreateUserRequest{User: u})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.User, nil
+}
+
+// ServiceProviderConfig describes the supported features.
+func (h *Handler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{SchemaServiceProvider},
+		"patch":          map[string]interface{}{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxCount},
+		"changePassword": map[string]interface{}{"supported": true},
+		"sort":           map[string]interface{}{"supported": false},
+		"etag":           map[string]interface{}{"supported": false},
+		"authenticationSchemes": []interface{}{map[string]interface{}{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Static bearer token configured on the server",
+			"primary":     true,
+		}},
+		"meta": map[string]interface{}{"resourceType": "ServiceProviderConfig", "location": baseURL(r) + "/ServiceProviderConfig"},
+	})
+}
+
+// ResourceTypes lists the Users and Groups resource types.
+func (h *Handler) ResourceTypes(w http.ResponseWriter, r *http.Request) {
+	base := baseURL(r)
+	types := []Resource{
+		{
+			"schemas":  []interface{}{SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   SchemaUser,
+			"meta":     map[string]interface{}{"resourceType": "ResourceType", "location": base + "/ResourceTypes/User"},
+		},
+		{
+			"schemas":  []interface{}{SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   SchemaGroup,
+			"meta":     map[string]interface{}{"resourceType": "ResourceType", "location": base + "/ResourceTypes/Group"},
+		},
+	}
+	writeJSON(w, http.StatusOK, paginate(r, types))
+}
+
+func decodePatch(r *http.Request, patch *PatchRequest) error {
+	if err := json.NewDecoder(r.Body).Decode(patch); err != nil {
+		return fmt.Errorf("invalid JSON body: %v", err)
+	}
+	if len(patch.Operations) == 0 {
+		return fmt.Errorf("no operations found in PATCH request")
+	}
+	return nil
+}
+
+func (h *Handler) deleteByUuid(ctx context.Context, id string, nodeType idm.NodeType) error {
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Uuid: id, NodeType: nodeType})
+	_, err := h.userClient().DeleteUser(ctx, &idm.DeleteUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	return err
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestPageRange(t *testing.T) {
+
+	Convey("Test startIndex and count parameters", t, func() {
+		start, count := pageRange(httptest.NewRequest("GET", "/scim/v2/Users", nil))
+		So(start, ShouldEqual, 1)
+		So(count, ShouldEqual, defaultCount)
+
+		start, count = pageRange(httptest.NewRequest("GET", "/scim/v2/Users?startIndex=11&count=5000", nil))
+		So(start, ShouldEqual, 11)
+		So(count, ShouldEqual, maxCount)
+
+		start, count = pageRange(httptest.NewRequest("GET", "/scim/v2/Users?startIndex=0&count=0", nil))
+		So(start, ShouldEqual, 1)
+		So(count, ShouldEqual, 0)
+	})
+}
+
+func TestCheckManaged(t *testing.T) {
+
+	Convey("Test users that can be modified through SCIM", t, func() {
+		scimUser := &idm.User{Login: "scim", GroupPath: "/", Attributes: map[string]string{idm.UserAttrOrigin: OriginSCIM}}
+		So(checkManaged(scimUser), ShouldBeNil)
+
+		admin := &idm.User{Login: "admin", GroupPath: "/", Attributes: map[string]string{
+			idm.UserAttrOrigin:  OriginSCIM,
+			idm.UserAttrProfile: common.PYDIO_PROFILE_ADMIN,
+		}}
+		So(checkManaged(admin), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatchOperation is a single operation of a PATCH request (RFC 7644, section 3.5.2).
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchRequest is the body of a PATCH request.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// applyPatch applies operations on the generic representation of a resource.
+func applyPatch(r Resource, ops []PatchOperation) error {
+	for _, op := range ops {
+		action := strings.ToLower(op.Op)
+		if action != "add" && action != "replace" && action != "remove" {
+			return fmt.Errorf("unsupported operation %s", op.Op)
+		}
+		if op.Path == "" {
+			if action == "remove" {
+				return fmt.Errorf("remove operation requires a path")
+			}
+			values, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("operation without path requires an object value")
+			}
+			for k, v := range values {
+				if err := patchPath(r, action, k, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := patchPath(r, action, op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitPatchPath splits attr[filter].sub into its components.
+func splitPatchPath(path string) (attr string, filter string, sub string) {
+	path = stripSchema(path)
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		// Extension attributes are kept as a single key
+		return path, "", ""
+	}
+	if i := strings.Index(path, "["); i > 0 {
+		if j := strings.LastIndex(path, "]"); j > i {
+			attr, filter = path[:i], path[i+1:j]
+			sub = strings.TrimPrefix(path[j+1:], ".")
+			return
+		}
+	}
+	parts := strings.SplitN(path, ".", 2)
+	attr = parts[0]
+	if len(parts) > 1 {
+		sub = parts[1]
+	}
+	return
+}
+
+func patchPath(r Resource, action string, path string, value interface{}) error {
+	attr, filter, sub := splitPatchPath(path)
+	if strings.ContainsAny(attr+sub, "[]") {
+		return fmt.Errorf("invalid path %s", path)
+	}
+	key, exists := r.key(attr)
+
+	if filter != "" {
+		f, err := ParseFilter(filter)
+		if err != nil {
+			return fmt.Errorf("invalid filter in path %s: %v", path, err)
+		}
+		items, _ := r[key].([]interface{})
+		var out []interface{}
+		matched := false
+		for _, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok || !f.Match(Resource(m)) {
+				out = append(out, item)
+				continue
+			}
+			matched = true
+			switch {
+			case action == "remove" && sub == "":
+				// Drop the element
+			case action == "remove":
+				subKey, _ := Resource(m).key(sub)
+				delete(m, subKey)
+				out = append(out, m)
+			case sub != "":
+				subKey, _ := Resource(m).key(sub)
+				m[subKey] = value
+				out = append(out, m)
+			default:
+				out = append(out, value)
+			}
+		}
+		if !matched && action != "remove" {
+			// Create the element from the filter equality, e.g. emails[type eq "work"].value
+			m := map[string]interface{}{}
+			if e, ok := f.(*attrExpr); ok && e.op == "eq" {
+				m[e.path] = e.value
+			}
+			if sub != "" {
+				m[sub] = value
+			} else if v, ok := value.(map[string]interface{}); ok {
+				for k, val := range v {
+					m[k] = val
+				}
+			}
+			out = append(out, m)
+		}
+		if out == nil {
+			delete(r, key)
+		} else {
+			r[key] = out
+		}
+		return nil
+	}
+
+	if sub != "" {
+		m, _ := r[key].(map[string]interface{})
+		if action == "remove" {
+			if m != nil {
+				subKey, _ := Resource(m).key(sub)
+				delete(m, subKey)
+			}
+			return nil
+		}
+		if m == nil {
+			m = map[string]interface{}{}
+			r[key] = m
+		}
+		subKey, _ := Resource(m).key(sub)
+		m[subKey] = value
+		return nil
+	}
+
+	switch action {
+	case "remove":
+		// Some clients send the values to remove from a multi-valued attribute instead of a filter
+		if toRemove, ok := value.([]interface{}); ok && exists {
+			if items, ok := r[key].([]interface{}); ok {
+				r[key] = removeByValue(items, toRemove)
+				return nil
+			}
+		}
+		delete(r, key)
+	case "add":
+		if items, ok := r[key].([]interface{}); ok {
+			r[key] = appendUnique(items, value)
+			return nil
+		}
+		if m, ok := r[key].(map[string]interface{}); ok {
+			if v, ok := value.(map[string]interface{}); ok {
+				for k, val := range v {
+					m[k] = val
+				}
+				return nil
+			}
+		}
+		r[key] = value
+	case "replace":
+		if m, ok := r[key].(map[string]interface{}); ok {
+			if v, ok := value.(map[string]interface{}); ok && !isMultiValued(attr) {
+				for k, val := range v {
+					m[k] = val
+				}
+				return nil
+			}
+		}
+		r[key] = value
+	}
+	return nil
+}
+
+func isMultiValued(attr string) bool {
+	switch strings.ToLower(attr) {
+	case "emails", "members", "groups", "phonenumbers", "addresses":
+		return true
+	}
+	return false
+}
+
+func itemValue(item interface{}) (string, bool) {
+	if m, ok := item.(map[string]interface{}); ok {
+		s, ok := m["value"].(string)
+		return s, ok
+	}
+	return "", false
+}
+
+func appendUnique(items []interface{}, value interface{}) []interface{} {
+	toAdd, ok := value.([]interface{})
+	if !ok {
+		toAdd = []interface{}{value}
+	}
+	for _, a := range toAdd {
+		duplicate := false
+		if av, ok := itemValue(a); ok {
+			for _, existing := range items {
+				if ev, ok := itemValue(existing); ok && ev == av {
+					duplicate = true
+					break
+				}
+			}
+		}
+		if !duplicate {
+			items = append(items, a)
+		}
+	}
+	return items
+}
+
+func removeByValue(items []interface{}, toRemove []interface{}) []interface{} {
+	var out []interface{}
+	for _, item := range items {
+		iv, _ := itemValue(item)
+		remove := false
+		for _, r := range toRemove {
+			if rv, ok := itemValue(r); ok && rv == iv {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			out = append(out, item)
+		}
+	}
+	if out == nil {
+		out = []interface{}{}
+	}
+	return out
+}
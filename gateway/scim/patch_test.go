@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func ops(s string) []PatchOperation {
+	var req PatchRequest
+	So(json.Unmarshal([]byte(s), &req), ShouldBeNil)
+	return req.Operations
+}
+
+func TestPatchUser(t *testing.T) {
+
+	Convey("Test simple replace and remove", t, func() {
+		u := &idm.User{Uuid: "u1", Login: "jdoe", Attributes: map[string]string{idm.UserAttrEmail: "john@example.com"}}
+		r := userResource(u, "")
+		So(applyPatch(r, ops(`{"Operations":[
+			{"op":"replace","path":"displayName","value":"Johnny"},
+			{"op":"Replace","path":"name.familyName","value":"Doe"},
+			{"op":"replace","value":{"active":"False"}},
+			{"op":"remove","path":"emails"}
+		]}`)), ShouldBeNil)
+		So(applyUserResource(r, u), ShouldBeNil)
+		So(u.Attributes[idm.UserAttrDisplayName], ShouldEqual, "Johnny")
+		So(u.Attributes[AttrFamilyName], ShouldEqual, "Doe")
+		So(u.Attributes[idm.UserAttrEmail], ShouldEqual, "")
+		So(isLocked(u), ShouldBeTrue)
+
+		r = userResource(u, "")
+		So(applyPatch(r, ops(`{"Operations":[{"op":"replace","path":"active","value":true}]}`)), ShouldBeNil)
+		So(applyUserResource(r, u), ShouldBeNil)
+		So(isLocked(u), ShouldBeFalse)
+	})
+
+	Convey("Test value path with sub-attribute", t, func() {
+		u := &idm.User{Uuid: "u1", Login: "jdoe", Attributes: map[string]string{}}
+		r := userResource(u, "")
+		So(applyPatch(r, ops(`{"Operations":[{"op":"replace","path":"emails[type eq \"work\"].value","value":"new@example.com"}]}`)), ShouldBeNil)
+		So(applyUserResource(r, u), ShouldBeNil)
+		So(u.Attributes[idm.UserAttrEmail], ShouldEqual, "new@example.com")
+
+		r = userResource(u, "")
+		So(applyPatch(r, ops(`{"Operations":[{"op":"replace","path":"emails[type eq \"work\"].value","value":"other@example.com"}]}`)), ShouldBeNil)
+		So(r.values("emails"), ShouldHaveLength, 1)
+		So(primaryValue(r, "emails"), ShouldEqual, "other@example.com")
+	})
+
+	Convey("Test invalid operations", t, func() {
+		r := Resource{}
+		So(applyPatch(r, ops(`{"Operations":[{"op":"move","path":"a"}]}`)), ShouldNotBeNil)
+		So(applyPatch(r, ops(`{"Operations":[{"op":"remove"}]}`)), ShouldNotBeNil)
+		So(applyPatch(r, ops(`{"Operations":[{"op":"add","value":"string"}]}`)), ShouldNotBeNil)
+		So(applyPatch(r, ops(`{"Operations":[{"op":"remove","path":"members[value eq"}]}`)), ShouldNotBeNil)
+	})
+}
+
+func TestPatchGroupMembers(t *testing.T) {
+
+	g := &idm.User{Uuid: "g1", IsGroup: true, GroupLabel: "sales", GroupPath: "/sales"}
+	members := []*idm.User{{Uuid: "u1", Login: "a"}, {Uuid: "u2", Login: "b"}}
+
+	Convey("Test add and remove members", t, func() {
+		r := groupResource(g, members, "")
+		So(applyPatch(r, ops(`{"Operations":[
+			{"op":"add","path":"members","value":[{"value":"u3"},{"value":"u1"}]},
+			{"op":"remove","path":"members[value eq \"u2\"]"}
+		]}`)), ShouldBeNil)
+		So(memberIds(r), ShouldResemble, []string{"u1", "u3"})
+	})
+
+	Convey("Test remove members by value and replace", t, func() {
+		r := groupResource(g, members, "")
+		So(applyPatch(r, ops(`{"Operations":[{"op":"remove","path":"members","value":[{"value":"u1"}]}]}`)), ShouldBeNil)
+		So(memberIds(r), ShouldResemble, []string{"u2"})
+
+		So(applyPatch(r, ops(`{"Operations":[{"op":"replace","path":"members","value":[{"value":"u4"}]}]}`)), ShouldBeNil)
+		So(memberIds(r), ShouldResemble, []string{"u4"})
+
+		So(applyPatch(r, ops(`{"Operations":[{"op":"remove","path":"members"}]}`)), ShouldBeNil)
+		So(memberIds(r), ShouldBeEmpty)
+	})
+
+	Convey("Test replace display name without path", t, func() {
+		r := groupResource(g, members, "")
+		So(applyPatch(r, ops(`{"Operations":[{"op":"replace","value":{"displayName":"Sales Team"}}]}`)), ShouldBeNil)
+		So(r.str("displayName"), ShouldEqual, "Sales Team")
+		So(memberIds(r), ShouldHaveLength, 2)
+	})
+}
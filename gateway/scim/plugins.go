@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+// Package scim exposes a SCIM 2.0 provisioning endpoint for users and groups, on top of the users service.
+//
+// The endpoint is served under /scim/v2 and requires a bearer token, stored in the vault and referenced by
+// the services/pydio.gateway.scim/token configuration (see the "config scim-token" command).
+package scim
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+
+	micro "github.com/micro/go-micro"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/caddy"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/plugins"
+	"github.com/pydio/cells/common/service"
+)
+
+var (
+	caddyTemplate    *template.Template
+	caddyTemplateStr = `
+	proxy /scim/ {{.SCIM | urls}} {
+		header_upstream Host {host}
+		header_upstream X-Real-IP {remote}
+		header_upstream X-Forwarded-Proto {scheme}
+	}
+	`
+)
+
+func init() {
+	plugins.Register(func() {
+		service.NewService(
+			service.Name(common.SERVICE_GATEWAY_SCIM),
+			service.Tag(common.SERVICE_TAG_GATEWAY),
+			service.Description("SCIM 2.0 provisioning endpoint for users and groups"),
+			service.Dependency(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, []string{}),
+			service.Dependency(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_ROLE, []string{}),
+			service.WithGeneric(func(ctx context.Context, cancel context.CancelFunc) (service.Runner, service.Checker, service.Stopper, error) {
+
+				return service.RunnerFunc(func() error {
+						return nil
+					}), service.CheckerFunc(func() error {
+						return nil
+					}), service.StopperFunc(func() error {
+						return nil
+					}), nil
+			}, func(s service.Service) (micro.Option, error) {
+				srv := defaults.NewHTTPServer()
+
+				hd := srv.NewHandler(NewRouter())
+				if err := srv.Handle(hd); err != nil {
+					return nil, err
+				}
+
+				return micro.Server(srv), nil
+			}),
+		)
+
+		tmpl, err := template.New("caddyfile").Funcs(caddy.FuncMap).Parse(caddyTemplateStr)
+		if err != nil {
+			log.Fatal("Could not read template ", zap.Error(err))
+		}
+		caddyTemplate = tmpl
+		caddy.RegisterPluginTemplate(caddy.TemplateFunc(play), nil, "/scim/")
+	})
+}
+
+func play() (*bytes.Buffer, error) {
+	buf := bytes.NewBufferString("")
+	if err := caddyTemplate.Execute(buf, struct{ SCIM string }{SCIM: common.SERVICE_GATEWAY_SCIM}); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
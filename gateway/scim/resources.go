@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	SchemaUser            = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup           = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse    = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp         = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError           = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProvider = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType    = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	// Private attributes storing SCIM values that have no equivalent in the user model
+	AttrExternalID = idm.UserAttrPrivatePrefix + "scimExternalId"
+	AttrGivenName  = idm.UserAttrPrivatePrefix + "givenName"
+	AttrFamilyName = idm.UserAttrPrivatePrefix + "familyName"
+	// Origin of users and groups created through SCIM
+	OriginSCIM = "scim"
+)
+
+// Resource is the generic JSON representation of a SCIM User or Group, used for filtering and patching.
+type Resource map[string]interface{}
+
+// stripSchema removes the schema URN prefix of a fully qualified attribute path.
+func stripSchema(path string) string {
+	for _, s := range []string{SchemaUser, SchemaGroup} {
+		if len(path) > len(s) && strings.EqualFold(path[:len(s)+1], s+":") {
+			return path[len(s)+1:]
+		}
+	}
+	return path
+}
+
+// key finds the actual key of an attribute, names being case-insensitive.
+func (r Resource) key(name string) (string, bool) {
+	if _, ok := r[name]; ok {
+		return name, true
+	}
+	for k := range r {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return name, false
+}
+
+// values resolves a dotted attribute path to all its values, flattening multi-valued attributes.
+func (r Resource) values(path string) []interface{} {
+	parts := strings.SplitN(stripSchema(path), ".", 2)
+	k, ok := r.key(parts[0])
+	if !ok {
+		return nil
+	}
+	var current []interface{}
+	if arr, isArr := r[k].([]interface{}); isArr {
+		current = arr
+	} else {
+		current = []interface{}{r[k]}
+	}
+	if len(parts) == 1 {
+		return current
+	}
+	var out []interface{}
+	for _, c := range current {
+		if m, ok := c.(map[string]interface{}); ok {
+			out = append(out, Resource(m).values(parts[1])...)
+		}
+	}
+	return out
+}
+
+func (r Resource) str(path string) string {
+	for _, v := range r.values(path) {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// toResource converts any JSON-serializable value to a generic Resource.
+func toResource(v interface{}) (Resource, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	r := Resource{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func isLocked(u *idm.User) bool {
+	var locks []string
+	if l, ok := u.Attributes["locks"]; ok {
+		json.Unmarshal([]byte(l), &locks)
+	}
+	for _, l := range locks {
+		if l == "logout" {
+			return true
+		}
+	}
+	return false
+}
+
+func setLocked(u *idm.User, locked bool) {
+	var locks, newLocks []string
+	if l, ok := u.Attributes["locks"]; ok {
+		json.Unmarshal([]byte(l), &locks)
+	}
+	for _, l := range locks {
+		if l != "logout" {
+			newLocks = append(newLocks, l)
+		}
+	}
+	if locked {
+		newLocks = append(newLocks, "logout")
+	}
+	if len(newLocks) == 0 {
+		delete(u.Attributes, "locks")
+		return
+	}
+	data, _ := json.Marshal(newLocks)
+	u.Attributes["locks"] = string(data)
+}
+
+// userResource builds the SCIM representation of a user.
+func userResource(u *idm.User, baseURL string) Resource {
+	r := Resource{
+		"schemas":  []interface{}{SchemaUser},
+		"id":       u.Uuid,
+		"userName": u.Login,
+		"active":   !isLocked(u),
+		"meta": map[string]interface{}{
+			"resourceType": "User",
+			"location":     baseURL + "/Users/" + u.Uuid,
+		},
+	}
+	a := u.Attributes
+	if v := a[AttrExternalID]; v != "" {
+		r["externalId"] = v
+	}
+	if v := a[idm.UserAttrDisplayName]; v != "" {
+		r["displayName"] = v
+	}
+	name := map[string]interface{}{}
+	if v := a[AttrGivenName]; v != "" {
+		name["givenName"] = v
+	}
+	if v := a[AttrFamilyName]; v != "" {
+		name["familyName"] = v
+	}
+	if v := a[idm.UserAttrDisplayName]; v != "" {
+		name["formatted"] = v
+	}
+	if len(name) > 0 {
+		r["name"] = name
+	}
+	if v := a[idm.UserAttrEmail]; v != "" {
+		r["emails"] = []interface{}{map[string]interface{}{"value": v, "primary": true, "type": "work"}}
+	}
+	return r
+}
+
+// applyUserResource updates a user from its SCIM representation. Attributes that are not part
+// of the resource are left untouched, so that replacing a user does not remove internal attributes.
+func applyUserResource(r Resource, u *idm.User) error {
+	if u.Attributes == nil {
+		u.Attributes = map[string]string{}
+	}
+	if login := r.str("userName"); login != "" {
+		u.Login = login
+	}
+	if u.Login == "" {
+		return fmt.Errorf("userName is required")
+	}
+	setOrDelete(u.Attributes, AttrExternalID, r.str("externalId"))
+	setOrDelete(u.Attributes, AttrGivenName, r.str("name.givenName"))
+	setOrDelete(u.Attributes, AttrFamilyName, r.str("name.familyName"))
+	display := r.str("displayName")
+	if display == "" {
+		display = r.str("name.formatted")
+	}
+	if display == "" {
+		display = strings.TrimSpace(r.str("name.givenName") + " " + r.str("name.familyName"))
+	}
+	setOrDelete(u.Attributes, idm.UserAttrDisplayName, display)
+	setOrDelete(u.Attributes, idm.UserAttrEmail, primaryValue(r, "emails"))
+	if vs := r.values("active"); len(vs) > 0 {
+		if active, ok := toBool(vs[0]); ok {
+			setLocked(u, !active)
+		}
+	}
+	if pwd := r.str("password"); pwd != "" {
+		u.Password = pwd
+	}
+	return nil
+}
+
+// primaryValue returns the value of the primary item of a multi-valued attribute, or the first one.
+func primaryValue(r Resource, attr string) string {
+	var first string
+	for _, v := range r.values(attr) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value := Resource(m).str("value")
+		if p, ok := toBool(Resource(m)["primary"]); ok && p {
+			return value
+		}
+		if first == "" {
+			first = value
+		}
+	}
+	return first
+}
+
+func setOrDelete(attributes map[string]string, key, value string) {
+	if value == "" {
+		delete(attributes, key)
+	} else {
+		attributes[key] = value
+	}
+}
+
+// groupResource builds the SCIM representation of a group with its direct members.
+func groupResource(g *idm.User, members []*idm.User, baseURL string) Resource {
+	display := g.Attributes[idm.UserAttrDisplayName]
+	if display == "" {
+		display = g.GroupLabel
+	}
+	r := Resource{
+		"schemas":     []interface{}{SchemaGroup},
+		"id":          g.Uuid,
+		"displayName": display,
+		"meta": map[string]interface{}{
+			"resourceType": "Group",
+			"location":     baseURL + "/Groups/" + g.Uuid,
+		},
+	}
+	if v := g.Attributes[AttrExternalID]; v != "" {
+		r["externalId"] = v
+	}
+	if members != nil {
+		list := []interface{}{}
+		for _, m := range members {
+			list = append(list, map[string]interface{}{
+				"value":   m.Uuid,
+				"display": m.Login,
+				"type":    "User",
+				"$ref":    baseURL + "/Users/" + m.Uuid,
+			})
+		}
+		r["members"] = list
+	}
+	return r
+}
+
+// memberIds lists the user ids declared in the members attribute of a group resource.
+func memberIds(r Resource) []string {
+	var ids []string
+	for _, v := range r.values("members.value") {
+		if s, ok := v.(string); ok && s != "" {
+			ids = append(ids, s)
+		}
+	}
+	return ids
+}
+
+// groupLabel computes a valid group node name from a display name.
+func groupLabel(display string) string {
+	return strings.Trim(strings.Replace(display, "/", "-", -1), " ")
+}
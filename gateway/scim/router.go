@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type route struct {
+	name        string
+	method      string
+	pattern     string
+	handlerFunc func(h *Handler) http.HandlerFunc
+}
+
+// NewRouter creates and configures a new mux router serving the SCIM endpoints.
+func NewRouter() *mux.Router {
+	h := &Handler{}
+	router := mux.NewRouter().StrictSlash(true)
+	for _, route := range myRoutes {
+		var handler http.Handler
+		handler = route.handlerFunc(h)
+		handler = logger(handler, route.name)
+		handler = authenticate(handler)
+
+		router.
+			Methods(route.method).
+			Path(Prefix + route.pattern).
+			Name(route.name).
+			Handler(handler)
+	}
+	return router
+}
+
+var myRoutes = []route{
+	{"ServiceProviderConfig", "GET", "/ServiceProviderConfig", func(h *Handler) http.HandlerFunc { return h.ServiceProviderConfig }},
+	{"ResourceTypes", "GET", "/ResourceTypes", func(h *Handler) http.HandlerFunc { return h.ResourceTypes }},
+
+	{"ListUsers", "GET", "/Users", func(h *Handler) http.HandlerFunc { return h.ListUsers }},
+	{"CreateUser", "POST", "/Users", func(h *Handler) http.HandlerFunc { return h.CreateUser }},
+	{"GetUser", "GET", "/Users/{id}", func(h *Handler) http.HandlerFunc { return h.GetUser }},
+	{"ReplaceUser", "PUT", "/Users/{id}", func(h *Handler) http.HandlerFunc { return h.ReplaceUser }},
+	{"PatchUser", "PATCH", "/Users/{id}", func(h *Handler) http.HandlerFunc { return h.PatchUser }},
+	{"DeleteUser", "DELETE", "/Users/{id}", func(h *Handler) http.HandlerFunc { return h.DeleteUser }},
+
+	{"ListGroups", "GET", "/Groups", func(h *Handler) http.HandlerFunc { return h.ListGroups }},
+	{"CreateGroup", "POST", "/Groups", func(h *Handler) http.HandlerFunc { return h.CreateGroup }},
+	{"GetGroup", "GET", "/Groups/{id}", func(h *Handler) http.HandlerFunc { return h.GetGroup }},
+	{"ReplaceGroup", "PUT", "/Groups/{id}", func(h *Handler) http.HandlerFunc { return h.ReplaceGroup }},
+	{"PatchGroup", "PATCH", "/Groups/{id}", func(h *Handler) http.HandlerFunc { return h.PatchGroup }},
+	{"DeleteGroup", "DELETE", "/Groups/{id}", func(h *Handler) http.HandlerFunc { return h.DeleteGroup }},
+}
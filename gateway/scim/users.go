@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scim
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+)
+
+// ListUsers lists users matching the optional filter, with pagination.
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var filter Filter
+	if fs := r.URL.Query().Get("filter"); fs != "" {
+		var err error
+		if filter, err = ParseFilter(fs); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+	}
+	query := &idm.UserSingleQuery{GroupPath: "/", Recursive: true, NodeType: idm.NodeType_USER}
+	if filter == nil {
+		h.listUsersPage(w, r, query)
+		return
+	}
+	if login, ok := equalityValue(filter, "userName"); ok {
+		query = &idm.UserSingleQuery{Login: login, NodeType: idm.NodeType_USER}
+	} else if ext, ok := equalityValue(filter, "externalId"); ok {
+		query = &idm.UserSingleQuery{AttributeName: AttrExternalID, AttributeValue: ext, NodeType: idm.NodeType_USER}
+	}
+	users, err := h.search(ctx, query)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	base := baseURL(r)
+	resources := []Resource{}
+	for _, u := range users {
+		if u.Attributes["hidden"] == "true" {
+			continue
+		}
+		res := userResource(u, base)
+		if filter == nil || filter.Match(res) {
+			resources = append(resources, res)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(r, resources))
+}
+
+// listUsersPage lists users without filter, letting the users service paginate results.
+func (h *Handler) listUsersPage(w http.ResponseWriter, r *http.Request, query *idm.UserSingleQuery) {
+	start, count := pageRange(r)
+	notHidden := &idm.UserSingleQuery{AttributeName: "hidden", AttributeValue: "true", Not: true}
+	users, total, err := h.searchPage(r.Context(), start-1, count, query, notHidden)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	base := baseURL(r)
+	resources := []Resource{}
+	for _, u := range users {
+		resources = append(resources, userResource(u, base))
+	}
+	writeJSON(w, http.StatusOK, listPage(r, resources, total, start))
+}
+
+// GetUser returns a single user.
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	u, err := h.findOne(r.Context(), mux.Vars(r)["id"], idm.NodeType_USER)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, userResource(u, baseURL(r))))
+}
+
+// CreateUser provisions a new user, with its personal role.
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res, err := readResource(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	u := &idm.User{
+		GroupPath: usersGroupPath(),
+		Attributes: map[string]string{
+			idm.UserAttrProfile: common.PYDIO_PROFILE_STANDARD,
+			idm.UserAttrOrigin:  OriginSCIM,
+		},
+	}
+	if err := applyUserResource(res, u); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	if existing, err := h.search(ctx, &idm.UserSingleQuery{Login: u.Login, NodeType: idm.NodeType_USER}); err != nil {
+		writeServiceError(w, err)
+		return
+	} else if len(existing) > 0 {
+		writeError(w, http.StatusConflict, "uniqueness", fmt.Sprintf("User %s already exists", u.Login))
+		return
+	}
+	created, err := h.save(ctx, u)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	builder := service.NewResourcePoliciesBuilder()
+	builder = builder.WithOwner(created.Uuid)
+	builder = builder.WithProfileWrite(common.PYDIO_PROFILE_ADMIN)
+	builder = builder.WithUserRead(created.Login)
+	builder = builder.WithUserWrite(created.Login)
+	if _, e := h.roleClient().CreateRole(ctx, &idm.CreateRoleRequest{Role: &idm.Role{
+		Uuid:     created.Uuid,
+		Label:    "User " + created.Login,
+		UserRole: true,
+		Policies: builder.Policies(),
+	}}); e != nil {
+		writeServiceError(w, e)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] provisioned through SCIM", created.Login),
+		log.GetAuditId(common.AUDIT_USER_CREATE),
+		created.ZapUuid(),
+	)
+	out := userResource(created, baseURL(r))
+	w.Header().Set("Location", baseURL(r)+"/Users/"+created.Uuid)
+	writeJSON(w, http.StatusCreated, out)
+}
+
+// ReplaceUser replaces all SCIM attributes of an existing user.
+// Like PatchUser and DeleteUser, it only applies to users managed through SCIM, see findManaged.
+func (h *Handler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, err := h.findManaged(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	res, err := readResource(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if err := applyUserResource(res, u); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	h.writeUpdatedUser(w, r, u)
+}
+
+// PatchUser applies PATCH operations to an existing user.
+func (h *Handler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, err := h.findManaged(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	var patch PatchRequest
+	if err := decodePatch(r, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	res := userResource(u, baseURL(r))
+	if err := applyPatch(res, patch.Operations); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidPath", err.Error())
+		return
+	}
+	if err := applyUserResource(res, u); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	h.writeUpdatedUser(w, r, u)
+}
+
+func (h *Handler) writeUpdatedUser(w http.ResponseWriter, r *http.Request, u *idm.User) {
+	ctx := r.Context()
+	updated, err := h.save(ctx, u)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] updated through SCIM", updated.Login),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		updated.ZapUuid(),
+	)
+	writeJSON(w, http.StatusOK, userResource(updated, baseURL(r)))
+}
+
+// DeleteUser deprovisions a user: it is either deleted, which triggers the clean-user-data job,
+// or only locked depending on the "deprovision" configuration.
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, err := h.findManaged(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if deprovisionMode() == DeprovisionLock {
+		setLocked(u, true)
+		if _, err := h.save(ctx, u); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		log.Auditer(ctx).Info(
+			fmt.Sprintf("User [%s] locked by SCIM deprovisioning", u.Login),
+			log.GetAuditId(common.AUDIT_LOCK_USER),
+			u.ZapUuid(),
+		)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.deleteByUuid(ctx, u.Uuid, idm.NodeType_USER); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] deleted by SCIM deprovisioning", u.Login),
+		log.GetAuditId(common.AUDIT_USER_DELETE),
+		u.ZapUuid(),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
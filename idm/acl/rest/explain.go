@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"context"
+	"io"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/auth/claim"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/proto/tree"
+	service2 "github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
+)
+
+// ExplainAccess replays the resolution of the permissions of a user on a node and details
+// which roles, ACLs, policies and workspaces contributed to each right
+func (a *Handler) ExplainAccess(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	var input rest.ExplainAccessRequest
+	if err := req.ReadEntity(&input); err != nil {
+		log.Logger(ctx).Error("While fetching rest.ExplainAccessRequest", zap.Error(err))
+		service2.RestError500(req, rsp, err)
+		return
+	}
+	if claims, ok := ctx.Value(claim.ContextKey).(claim.Claims); !ok || claims.Profile != common.PYDIO_PROFILE_ADMIN {
+		service2.RestError403(req, rsp, errors.Forbidden(common.SERVICE_ACL, "Only administrators can explain access"))
+		return
+	}
+	if input.UserLogin == "" || (input.NodeUuid == "" && input.NodePath == "") {
+		service2.RestError500(req, rsp, errors.BadRequest(common.SERVICE_ACL, "Please provide a user login and a node uuid or path"))
+		return
+	}
+
+	user, err := permissions.SearchUniqueUser(ctx, input.UserLogin, "")
+	if err != nil {
+		service2.RestError404(req, rsp, err)
+		return
+	}
+	// Policies and virtual nodes are evaluated as the target user
+	userCtx := auth.WithImpersonate(ctx, user)
+	accessList := permissions.AccessListForExplanation(userCtx, user)
+
+	parentNodes, err := a.nodeWithAncestors(ctx, &tree.Node{Uuid: input.NodeUuid, Path: input.NodePath})
+	if err != nil {
+		service2.RestErrorDetect(req, rsp, err)
+		return
+	}
+
+	// Update Access List with resolved virtual nodes, remembering the virtual node carrying the ACLs
+	aliases := make(map[string]string)
+	virtualManager := views.GetVirtualNodesManager()
+	cPool := views.NewClientsPool(false)
+	for _, vNode := range virtualManager.ListNodes() {
+		if aclNodeMask, has := accessList.GetNodesBitmasks()[vNode.Uuid]; has {
+			if resolvedRoot, err := virtualManager.ResolveInContext(userCtx, vNode, cPool, false); err == nil {
+				accessList.GetNodesBitmasks()[resolvedRoot.Uuid] = aclNodeMask
+				aliases[resolvedRoot.Uuid] = vNode.Uuid
+			}
+		}
+	}
+
+	response := &rest.ExplainAccessResponse{
+		UserLogin: user.Login,
+		NodeUuid:  parentNodes[0].Uuid,
+		NodePath:  parentNodes[0].Path,
+		Roles:     accessList.OrderedRoles,
+	}
+
+	chain := make(map[string]bool, len(parentNodes))
+	for _, n := range parentNodes {
+		chain[n.Uuid] = true
+		if alias, ok := aliases[n.Uuid]; ok {
+			chain[alias] = true
+		}
+	}
+	for wsId, roots := range accessList.GetWorkspacesNodes() {
+		ws, ok := accessList.Workspaces[wsId]
+		if !ok {
+			continue
+		}
+		for rootId := range roots {
+			if chain[rootId] {
+				response.Workspaces = append(response.Workspaces, ws)
+				break
+			}
+		}
+	}
+
+	policies := make(map[string]bool)
+	for _, exp := range accessList.Explain(userCtx, aliases, parentNodes...) {
+		rExp := &rest.RightExplanation{
+			Right:   exp.Right,
+			Granted: exp.Granted,
+			Reason:  exp.Reason,
+		}
+		for _, c := range exp.Contributions {
+			if c.Acl.Action.Name == permissions.AclPolicy.Name {
+				policies[c.Acl.Action.Value] = true
+			}
+			rExp.Contributions = append(rExp.Contributions, &rest.AccessContribution{
+				Acl:           c.Acl,
+				RoleLabel:     c.Role.Label,
+				RolePosition:  int32(c.RolePosition),
+				NodePath:      c.Node.Path,
+				Depth:         int32(c.Depth),
+				Overridden:    c.Overridden,
+				PolicyAllowed: c.PolicyAllowed,
+				Decisive:      c.Decisive,
+			})
+		}
+		response.Rights = append(response.Rights, rExp)
+	}
+
+	if len(policies) > 0 {
+		policyClient := idm.NewPolicyEngineServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_POLICY, defaults.NewClient())
+		if groups, e := policyClient.ListPolicyGroups(ctx, &idm.ListPolicyGroupsRequest{}); e == nil {
+			for _, g := range groups.PolicyGroups {
+				if policies[g.Uuid] {
+					response.Policies = append(response.Policies, g)
+				}
+			}
+		} else {
+			log.Logger(ctx).Error("Cannot load policy groups", zap.Error(e))
+		}
+	}
+
+	rsp.WriteEntity(response)
+
+}
+
+// nodeWithAncestors reads a node by uuid or path and lists its parents, closest first
+func (a *Handler) nodeWithAncestors(ctx context.Context, node *tree.Node) ([]*tree.Node, error) {
+
+	treeClient := tree.NewNodeProviderClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_TREE, defaults.NewClient())
+	if node.Uuid != "" {
+		node.Path = ""
+	}
+	readResp, err := treeClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: node})
+	if err != nil {
+		return nil, err
+	}
+
+	ancestorStream, lErr := treeClient.ListNodes(ctx, &tree.ListNodesRequest{
+		Node:      readResp.Node,
+		Ancestors: true,
+	})
+	if lErr != nil {
+		return nil, lErr
+	}
+	defer ancestorStream.Close()
+	parentNodes := []*tree.Node{readResp.Node}
+	for {
+		parent, e := ancestorStream.Recv()
+		if e != nil {
+			if e == io.EOF || e == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, e
+		}
+		if parent == nil {
+			continue
+		}
+		parentNodes = append(parentNodes, parent.Node)
+	}
+
+	return parentNodes, nil
+}
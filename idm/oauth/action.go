@@ -73,6 +73,50 @@ func InsertPruningJob(ctx context.Context) error {
 	})
 }
 
+// InsertLdapSyncJob creates or updates the job periodically importing users of an ldap connector.
+// The job is removed if the schedule is empty.
+func InsertLdapSyncJob(ctx context.Context, connectorID, connectorName, schedule string) error {
+
+	jobID := "ldap-sync-" + connectorID
+
+	return service.Retry(func() error {
+
+		cli := jobs.NewJobServiceClient(registry.GetClient(common.SERVICE_JOBS))
+		if schedule == "" {
+			if _, e := cli.GetJob(ctx, &jobs.GetJobRequest{JobID: jobID}); e != nil {
+				return nil
+			}
+			_, e := cli.DeleteJob(ctx, &jobs.DeleteJobRequest{JobID: jobID})
+			return e
+		}
+
+		label := connectorName
+		if label == "" {
+			label = connectorID
+		}
+		log.Logger(ctx).Info("Inserting synchronization job for ldap connector " + connectorID)
+		_, e := cli.PutJob(ctx, &jobs.PutJobRequest{Job: &jobs.Job{
+			ID:    jobID,
+			Owner: common.PYDIO_SYSTEM_USERNAME,
+			Label: fmt.Sprintf("Synchronize users from directory %s", label),
+			Schedule: &jobs.Schedule{
+				Iso8601Schedule: schedule,
+			},
+			AutoStart:      false,
+			MaxConcurrency: 1,
+			Actions: []*jobs.Action{{
+				ID: "actions.etl.users",
+				Parameters: map[string]string{
+					"left":      "ldap",
+					"connector": connectorID,
+				},
+			}},
+		}})
+
+		return e
+	})
+}
+
 var (
 	pruneTokensActionName = "actions.auth.prune.tokens"
 )
@@ -22,13 +22,29 @@
 package oauth
 
 import (
+	"time"
+
 	"github.com/pydio/cells/common/dao"
+	"github.com/pydio/cells/common/proto/auth"
 	"github.com/pydio/cells/common/sql"
 )
 
 // DAO interface
 type DAO interface {
 	dao.DAO
+
+	// StorePersonalToken saves a new personal access token, only known by its hash
+	StorePersonalToken(token *auth.PersonalAccessToken, hash string) error
+	// LoadPersonalToken finds a personal access token by its hash
+	LoadPersonalToken(hash string) (*auth.PersonalAccessToken, error)
+	// ListPersonalTokens lists the tokens of a user, or all tokens if login is empty
+	ListPersonalTokens(login string) ([]*auth.PersonalAccessToken, error)
+	// DeletePersonalToken revokes a token
+	DeletePersonalToken(uuid string) error
+	// TouchPersonalToken updates the last usage time of a token
+	TouchPersonalToken(uuid string, at time.Time) error
+	// PruneExpiredPersonalTokens deletes the tokens expired before the given time and returns their uuids
+	PruneExpiredPersonalTokens(before time.Time) ([]string, error)
 }
 
 func NewDAO(o dao.DAO) dao.DAO {
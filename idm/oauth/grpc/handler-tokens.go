@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/errors"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
+	"github.com/pydio/cells/common/log"
+	pauth "github.com/pydio/cells/common/proto/auth"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/oauth"
+)
+
+const (
+	// personalTokenAudience is used as client app for the claims of personal access tokens
+	personalTokenAudience = "personal-access-token"
+	// personalTokenTouchDelay avoids writing the last usage time at each request
+	personalTokenTouchDelay = time.Minute
+)
+
+// PatHandler implements the PersonalAccessTokenService
+type PatHandler struct{}
+
+var _ pauth.PersonalAccessTokenServiceHandler = (*PatHandler)(nil)
+
+// Generate creates a token for a user, identified by uuid or login
+func (p *PatHandler) Generate(ctx context.Context, in *pauth.PatGenerateRequest, out *pauth.PatGenerateResponse) error {
+
+	if in.Label == "" {
+		return errors.BadRequest(common.SERVICE_OAUTH, "please provide a label for the token")
+	}
+	now := time.Now()
+	if int64(in.ExpiresAt) <= now.Unix() {
+		return errors.BadRequest(common.SERVICE_OAUTH, "please provide an expiration date in the future")
+	}
+	if e := auth.ValidatePersonalTokenScopes(in.Scopes); e != nil {
+		return errors.BadRequest(common.SERVICE_OAUTH, "%s", e.Error())
+	}
+
+	userUuid, userLogin := in.UserUuid, in.UserLogin
+	if userUuid == "" || userLogin == "" {
+		u, e := permissions.SearchUniqueUser(ctx, userLogin, userUuid)
+		if e != nil {
+			return e
+		}
+		userUuid, userLogin = u.Uuid, u.Login
+	}
+
+	value, hash, e := auth.NewPersonalToken()
+	if e != nil {
+		return e
+	}
+	token := &pauth.PersonalAccessToken{
+		Uuid:      uuid.New(),
+		Label:     in.Label,
+		UserUuid:  userUuid,
+		UserLogin: userLogin,
+		Scopes:    in.Scopes,
+		ExpiresAt: in.ExpiresAt,
+		CreatedAt: int32(now.Unix()),
+	}
+	dao := servicecontext.GetDAO(ctx).(oauth.DAO)
+	if e := dao.StorePersonalToken(token, hash); e != nil {
+		return e
+	}
+
+	out.AccessToken = value
+	out.Token = token
+	return nil
+}
+
+// Revoke deletes a token
+func (p *PatHandler) Revoke(ctx context.Context, in *pauth.PatRevokeRequest, out *pauth.PatRevokeResponse) error {
+	dao := servicecontext.GetDAO(ctx).(oauth.DAO)
+	if e := dao.DeletePersonalToken(in.Uuid); e != nil {
+		return e
+	}
+	out.Success = true
+	return nil
+}
+
+// List tokens of a user, or all tokens
+func (p *PatHandler) List(ctx context.Context, in *pauth.PatListRequest, out *pauth.PatListResponse) error {
+	dao := servicecontext.GetDAO(ctx).(oauth.DAO)
+	tokens, e := dao.ListPersonalTokens(in.ByUserLogin)
+	if e != nil {
+		return e
+	}
+	out.Tokens = tokens
+	return nil
+}
+
+// verifyPersonalToken looks up a personal access token and builds the claims of its owner, restricted by its scopes.
+func verifyPersonalToken(ctx context.Context, raw string, out *pauth.VerifyTokenResponse) error {
+
+	dao := servicecontext.GetDAO(ctx).(oauth.DAO)
+	token, e := dao.LoadPersonalToken(auth.HashPersonalToken(raw))
+	if e != nil {
+		return errors.Unauthorized(common.SERVICE_OAUTH, "invalid personal access token")
+	}
+	now := time.Now()
+	if int64(token.ExpiresAt) <= now.Unix() {
+		return errors.Unauthorized(common.SERVICE_OAUTH, "personal access token has expired")
+	}
+	if now.Sub(time.Unix(int64(token.LastUsedAt), 0)) > personalTokenTouchDelay {
+		if e := dao.TouchPersonalToken(token.Uuid, now); e != nil {
+			log.Logger(ctx).Warn("could not update last usage of personal token", zap.String("uuid", token.Uuid), zap.Error(e))
+		}
+	}
+
+	claims := &jwt.IDTokenClaims{
+		JTI:       token.Uuid,
+		Subject:   token.UserUuid,
+		Issuer:    strings.TrimRight(auth.GetConfigurationProvider().IssuerURL().String(), "/") + "/",
+		Audience:  []string{personalTokenAudience},
+		IssuedAt:  time.Unix(int64(token.CreatedAt), 0),
+		ExpiresAt: time.Unix(int64(token.ExpiresAt), 0),
+		Extra: map[string]interface{}{
+			"name":   token.UserLogin,
+			"scopes": strings.Join(token.Scopes, ","),
+		},
+	}
+	b, e := json.Marshal(claims)
+	if e != nil {
+		return e
+	}
+
+	out.Success = true
+	out.Data = b
+
+	return nil
+}
@@ -43,6 +43,8 @@ import (
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/log"
 	pauth "github.com/pydio/cells/common/proto/auth"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	"github.com/pydio/cells/idm/oauth"
 )
 
 // Handler for the plugin
@@ -431,6 +433,10 @@ func (h *Handler) AcceptLogout(ctx context.Context, in *pauth.AcceptLogoutReques
 
 // Verify checks if the token is valid for hydra
 func (h *Handler) Verify(ctx context.Context, in *pauth.VerifyTokenRequest, out *pauth.VerifyTokenResponse) error {
+	if auth.IsPersonalToken(in.GetToken()) {
+		return verifyPersonalToken(ctx, in.GetToken(), out)
+	}
+
 	session := oauth2.NewSession("")
 
 	tokenType, ar, err := auth.GetRegistry().OAuth2Provider().IntrospectToken(ctx, in.GetToken(), fosite.AccessToken, session)
@@ -554,5 +560,12 @@ func (h *Handler) Revoke(ctx context.Context, in *pauth.RevokeTokenRequest, out
 func (h *Handler) PruneTokens(ctx context.Context, in *pauth.PruneTokensRequest, out *pauth.PruneTokensResponse) error {
 	auth.GetRegistry().OAuth2Storage().FlushInactiveAccessTokens(ctx, time.Now())
 
+	// Expired personal access tokens
+	pruned, err := servicecontext.GetDAO(ctx).(oauth.DAO).PruneExpiredPersonalTokens(time.Now())
+	if err != nil {
+		return err
+	}
+	out.Tokens = append(out.Tokens, pruned...)
+
 	return nil
 }
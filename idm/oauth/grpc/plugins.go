@@ -22,6 +22,7 @@
 package grpc
 
 import (
+	"encoding/json"
 	"log"
 
 	"github.com/micro/go-micro"
@@ -55,6 +56,7 @@ func init() {
 				proto.RegisterAuthTokenVerifierHandler(m.Options().Server, &Handler{})
 				proto.RegisterAuthTokenRefresherHandler(m.Options().Server, &Handler{})
 				proto.RegisterAuthTokenRevokerHandler(m.Options().Server, &Handler{})
+				proto.RegisterPersonalAccessTokenServiceHandler(m.Options().Server, &PatHandler{})
 
 				return nil
 			}),
@@ -94,20 +96,32 @@ func initialize(s service.Service) error {
 	dao := servicecontext.GetDAO(ctx).(sql.DAO)
 
 	auth.OnConfigurationInit(func() {
-		var m []struct {
-			ID   string `"json:id"`
-			Name string `"json:id"`
-			Type string `"json:type"`
-		}
+		var m []auth.ConnectorData
 
 		if err := auth.GetConfigurationProvider().Connectors().Scan(&m); err != nil {
 			log.Fatal("Wrong configuration ", err)
 		}
 
 		for _, mm := range m {
-			if mm.Type == "pydio" {
+			switch mm.Type {
+			case "pydio":
 				// Registering the first connector
 				auth.RegisterConnector(mm.ID, mm.Name, mm.Type, nil)
+			case "ldap":
+				conf := &proto.LdapConnectorConfig{}
+				if err := json.Unmarshal(mm.Config, conf); err != nil {
+					log.Println("Wrong ldap connector configuration ", mm.ID, err)
+					continue
+				}
+				auth.RegisterConnector(mm.ID, mm.Name, mm.Type, conf)
+				go oauth.InsertLdapSyncJob(ctx, mm.ID, mm.Name, conf.SyncSchedule)
+			case "oidc":
+				conf := &proto.OidcConnectorConfig{}
+				if err := json.Unmarshal(mm.Config, conf); err != nil {
+					log.Println("Wrong oidc connector configuration ", mm.ID, err)
+					continue
+				}
+				auth.RegisterConnector(mm.ID, mm.Name, mm.Type, conf)
 			}
 		}
 	})
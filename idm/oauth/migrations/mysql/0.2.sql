@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS idm_oauth_personal_token (
+    uuid         VARCHAR(128) NOT NULL,
+    hash         VARCHAR(64) NOT NULL,
+    label        VARCHAR(255) NOT NULL,
+    user_uuid    VARCHAR(128) NOT NULL,
+    user_login   VARCHAR(255) NOT NULL,
+    scopes       VARCHAR(1024) NOT NULL,
+    expires_at   INT(11) NOT NULL,
+    created_at   INT(11) NOT NULL,
+    last_used_at INT(11) NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (uuid),
+    UNIQUE KEY (hash),
+    INDEX (user_login),
+    INDEX (expires_at)
+);
+
+-- +migrate Down
+DROP TABLE idm_oauth_personal_token;
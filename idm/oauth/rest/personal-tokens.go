@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+func patClient() auth.PersonalAccessTokenServiceClient {
+	return auth.NewPersonalAccessTokenServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_OAUTH, defaults.NewClient())
+}
+
+// patTargetLogin resolves the owner of the tokens managed by the request: the current user,
+// or any user for admins.
+func patTargetLogin(ctx context.Context, login string) (string, error) {
+	ctxLogin, claims := permissions.FindUserNameInContext(ctx)
+	if ctxLogin == "" {
+		return "", errors.Unauthorized(common.SERVICE_AUTH, "Cannot find current user")
+	}
+	// A scoped token must not be used to get broader accesses
+	if claims.Scopes != "" {
+		return "", errors.Forbidden(common.SERVICE_AUTH, "Personal tokens cannot be managed with a restricted token")
+	}
+	if login == "" || login == ctxLogin {
+		return ctxLogin, nil
+	}
+	if claims.Profile != common.PYDIO_PROFILE_ADMIN {
+		return "", errors.Forbidden(common.SERVICE_AUTH, "Only admins can manage tokens of other users")
+	}
+	return login, nil
+}
+
+// CreatePersonalToken generates a token for the current user, or any user for admins.
+func (a *TokenHandler) CreatePersonalToken(req *restful.Request, resp *restful.Response) {
+
+	var input rest.PersonalTokenRequest
+	if e := req.ReadEntity(&input); e != nil {
+		service.RestError500(req, resp, e)
+		return
+	}
+	ctx := req.Request.Context()
+	login, e := patTargetLogin(ctx, input.UserLogin)
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	user, e := permissions.SearchUniqueUser(ctx, login, "")
+	if e != nil || user == nil {
+		service.RestError404(req, resp, errors.NotFound(common.SERVICE_AUTH, "Cannot find user %s", login))
+		return
+	}
+
+	response, e := patClient().Generate(ctx, &auth.PatGenerateRequest{
+		Label:     input.Label,
+		UserUuid:  user.Uuid,
+		UserLogin: user.Login,
+		ExpiresAt: input.ExpiresAt,
+		Scopes:    input.Scopes,
+	})
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+
+	ctxLogin, _ := permissions.FindUserNameInContext(ctx)
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Personal access token [%s] created for user [%s] by [%s]", input.Label, user.Login, ctxLogin),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		user.ZapUuid(),
+	)
+
+	resp.WriteEntity(&rest.PersonalTokenResponse{
+		AccessToken: response.AccessToken,
+		Token:       response.Token,
+	})
+}
+
+// ListPersonalTokens lists the tokens of the current user, or of any user for admins.
+func (a *TokenHandler) ListPersonalTokens(req *restful.Request, resp *restful.Response) {
+
+	ctx := req.Request.Context()
+	login, e := patTargetLogin(ctx, req.QueryParameter("UserLogin"))
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	response, e := patClient().List(ctx, &auth.PatListRequest{ByUserLogin: login})
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+
+	resp.WriteEntity(&rest.PersonalTokenCollection{Tokens: response.Tokens})
+}
+
+// RevokePersonalToken deletes a token of the current user, or any token for admins.
+func (a *TokenHandler) RevokePersonalToken(req *restful.Request, resp *restful.Response) {
+
+	ctx := req.Request.Context()
+	tokenUuid := req.PathParameter("Uuid")
+	ctxLogin, claims := permissions.FindUserNameInContext(ctx)
+	if _, e := patTargetLogin(ctx, ""); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+
+	cli := patClient()
+	var byLogin string
+	if claims.Profile != common.PYDIO_PROFILE_ADMIN {
+		byLogin = ctxLogin
+	}
+	list, e := cli.List(ctx, &auth.PatListRequest{ByUserLogin: byLogin})
+	if e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	var token *auth.PersonalAccessToken
+	for _, t := range list.Tokens {
+		if t.Uuid == tokenUuid {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		service.RestError404(req, resp, errors.NotFound(common.SERVICE_AUTH, "Cannot find token %s", tokenUuid))
+		return
+	}
+
+	if _, e := cli.Revoke(ctx, &auth.PatRevokeRequest{Uuid: token.Uuid}); e != nil {
+		service.RestErrorDetect(req, resp, e)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Personal access token [%s] of user [%s] revoked by [%s]", token.Label, token.UserLogin, ctxLogin),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		zap.String(common.KEY_USER_UUID, token.UserUuid),
+	)
+
+	resp.WriteEntity(&rest.RevokeResponse{Success: true, Message: "Token successfully revoked"})
+}
@@ -21,10 +21,33 @@
 package oauth
 
 import (
-	"github.com/pydio/cells/common"
-	"github.com/pydio/cells/common/sql"
+	databasesql "database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/errors"
 	"github.com/pydio/packr"
 	migrate "github.com/rubenv/sql-migrate"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/auth"
+	"github.com/pydio/cells/common/sql"
+)
+
+const personalTokenColumns = `uuid, label, user_uuid, user_login, scopes, expires_at, created_at, last_used_at`
+
+var (
+	queries = map[string]string{
+		"InsertPersonalToken": `insert into idm_oauth_personal_token (uuid, hash, label, user_uuid, user_login, scopes, expires_at, created_at, last_used_at) values (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+		"GetPersonalToken":    `select ` + personalTokenColumns + ` from idm_oauth_personal_token where hash = ?`,
+		"ListPersonalTokens":  `select ` + personalTokenColumns + ` from idm_oauth_personal_token order by user_login, created_at`,
+		"ListUserTokens":      `select ` + personalTokenColumns + ` from idm_oauth_personal_token where user_login = ? order by created_at`,
+		"DeletePersonalToken": `delete from idm_oauth_personal_token where uuid = ?`,
+		"TouchPersonalToken":  `update idm_oauth_personal_token set last_used_at = ? where uuid = ?`,
+		"ListExpiredTokens":   `select uuid from idm_oauth_personal_token where expires_at < ?`,
+		"DeleteExpiredTokens": `delete from idm_oauth_personal_token where expires_at < ?`,
+	}
 )
 
 type sqlimpl struct {
@@ -49,5 +72,142 @@ func (s *sqlimpl) Init(options common.ConfigValues) error {
 		return err
 	}
 
+	if options.Bool("prepare", true) {
+		for key, query := range queries {
+			if err := s.Prepare(key, query); err != nil {
+				return fmt.Errorf("unable to prepare query[%s]: %s - error: %v", key, query, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// StorePersonalToken saves a new personal access token
+func (s *sqlimpl) StorePersonalToken(token *auth.PersonalAccessToken, hash string) error {
+	stmt, er := s.GetStmt("InsertPersonalToken")
+	if er != nil {
+		return er
+	}
+	_, er = stmt.Exec(token.Uuid, hash, token.Label, token.UserUuid, token.UserLogin, strings.Join(token.Scopes, ","), token.ExpiresAt, token.CreatedAt)
+	return er
+}
+
+// LoadPersonalToken finds a token by its hash
+func (s *sqlimpl) LoadPersonalToken(hash string) (*auth.PersonalAccessToken, error) {
+	stmt, er := s.GetStmt("GetPersonalToken")
+	if er != nil {
+		return nil, er
+	}
+	token, er := scanPersonalToken(stmt.QueryRow(hash))
+	if er == databasesql.ErrNoRows {
+		return nil, errors.NotFound(common.SERVICE_OAUTH, "token not found")
+	}
+	return token, er
+}
+
+// ListPersonalTokens lists the tokens of a user, or all tokens if login is empty
+func (s *sqlimpl) ListPersonalTokens(login string) ([]*auth.PersonalAccessToken, error) {
+	var rows *databasesql.Rows
+	if login == "" {
+		stmt, er := s.GetStmt("ListPersonalTokens")
+		if er != nil {
+			return nil, er
+		}
+		if rows, er = stmt.Query(); er != nil {
+			return nil, er
+		}
+	} else {
+		stmt, er := s.GetStmt("ListUserTokens")
+		if er != nil {
+			return nil, er
+		}
+		if rows, er = stmt.Query(login); er != nil {
+			return nil, er
+		}
+	}
+	defer rows.Close()
+	var tokens []*auth.PersonalAccessToken
+	for rows.Next() {
+		token, er := scanPersonalToken(rows)
+		if er != nil {
+			return nil, er
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// DeletePersonalToken revokes a token
+func (s *sqlimpl) DeletePersonalToken(uuid string) error {
+	stmt, er := s.GetStmt("DeletePersonalToken")
+	if er != nil {
+		return er
+	}
+	res, er := stmt.Exec(uuid)
+	if er != nil {
+		return er
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.NotFound(common.SERVICE_OAUTH, "token not found")
+	}
 	return nil
 }
+
+// TouchPersonalToken updates the last usage time of a token
+func (s *sqlimpl) TouchPersonalToken(uuid string, at time.Time) error {
+	stmt, er := s.GetStmt("TouchPersonalToken")
+	if er != nil {
+		return er
+	}
+	_, er = stmt.Exec(at.Unix(), uuid)
+	return er
+}
+
+// PruneExpiredPersonalTokens deletes the tokens expired before the given time and returns their uuids
+func (s *sqlimpl) PruneExpiredPersonalTokens(before time.Time) ([]string, error) {
+	list, er := s.GetStmt("ListExpiredTokens")
+	if er != nil {
+		return nil, er
+	}
+	rows, er := list.Query(before.Unix())
+	if er != nil {
+		return nil, er
+	}
+	defer rows.Close()
+	var uuids []string
+	for rows.Next() {
+		var uuid string
+		if er := rows.Scan(&uuid); er != nil {
+			return nil, er
+		}
+		uuids = append(uuids, uuid)
+	}
+	if len(uuids) == 0 {
+		return nil, rows.Err()
+	}
+	del, er := s.GetStmt("DeleteExpiredTokens")
+	if er != nil {
+		return nil, er
+	}
+	if _, er := del.Exec(before.Unix()); er != nil {
+		return nil, er
+	}
+	return uuids, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPersonalToken(row scanner) (*auth.PersonalAccessToken, error) {
+	token := &auth.PersonalAccessToken{}
+	var scopes string
+	if er := row.Scan(&token.Uuid, &token.Label, &token.UserUuid, &token.UserLogin, &scopes, &token.ExpiresAt, &token.CreatedAt, &token.LastUsedAt); er != nil {
+		return nil, er
+	}
+	if scopes != "" {
+		token.Scopes = strings.Split(scopes, ",")
+	}
+	return token, nil
+}
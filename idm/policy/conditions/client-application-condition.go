@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"github.com/ory/ladon"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// ClientApplicationCondition is a condition which is fulfilled if the application sending the request
+// is one of the comma separated Matches, among web, sync, dav, cli and other. It is expected on the
+// ClientApplication key of the request context.
+type ClientApplicationCondition struct {
+	Matches string `json:"matches"`
+}
+
+// Fulfills returns true if the given value is one of the listed applications.
+func (c *ClientApplicationCondition) Fulfills(value interface{}, _ *ladon.Request) bool {
+
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, app := range splitList(c.Matches) {
+		if app == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GetName returns the condition's name.
+func (c *ClientApplicationCondition) GetName() string {
+	return "ClientApplicationCondition"
+}
+
+// GetForm returns the form used to edit the condition options.
+func (c *ClientApplicationCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Applications",
+			Description: "Comma separated list of applications among " + permissions.ClientApplicationWeb + ", " + permissions.ClientApplicationSync + ", " + permissions.ClientApplicationDav + ", " + permissions.ClientApplicationCli + " and " + permissions.ClientApplicationOther,
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -22,8 +22,11 @@
 package conditions
 
 import (
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -33,6 +36,8 @@ const (
 )
 
 var (
+	// compiledPatterns caches the regular expressions used by conditions, as they are evaluated on each request
+	compiledPatterns sync.Map
 	// Ease implementation by defining this map
 	daysMap = map[string]uint{
 		time.Sunday.String():    0,
@@ -87,8 +92,8 @@ func parseOfficeHours(pattern string) (days uint, minuteBegin int, minuteEnd int
 		days += 1 << daysMap[cleaned]
 	}
 
-	// Then transforms hours that have a 15:04 format to minutes from midnight
-	// TODO manage time zone
+	// Then transforms hours that have a 15:04 format to minutes from midnight,
+	// time zone is handled by the caller by converting the checked time
 
 	minuteBegin = timeStringToMinutes(tokens[1])
 	minuteEnd = timeStringToMinutes(tokens[2])
@@ -103,3 +108,39 @@ func timeStringToMinutes(str string) int {
 	minutes, _ := strconv.Atoi(tokens[1])
 	return hours*60 + minutes
 }
+
+// splitList splits a comma or line separated list of values, ignoring empty ones
+func splitList(list string) (values []string) {
+	for _, v := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return
+}
+
+// parseRemoteAddress extracts an IP from a remote address that may contain a port
+func parseRemoteAddress(value interface{}) net.IP {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	s = strings.TrimSpace(s)
+	if host, _, e := net.SplitHostPort(s); e == nil {
+		s = host
+	}
+	return net.ParseIP(strings.Trim(s, "[]"))
+}
+
+// matchPattern checks value against a regular expression, compiling each pattern only once.
+func matchPattern(pattern string, value string) (bool, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp).MatchString(value), nil
+	}
+	re, e := regexp.Compile(pattern)
+	if e != nil {
+		return false, e
+	}
+	compiledPatterns.Store(pattern, re)
+	return re.MatchString(value), nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ory/ladon"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+)
+
+// CountryCondition is a condition which is fulfilled if the country of the remote address is not
+// one of the Deny countries and, if Allow countries are defined, is one of them. Countries are comma
+// separated ISO 3166 codes, for instance FR,DE. They are resolved with a local GeoIP database: when
+// the country cannot be found, the condition is only fulfilled if no Allow countries are defined.
+type CountryCondition struct {
+	Allow string `json:"allow,omitempty"`
+	Deny  string `json:"deny,omitempty"`
+}
+
+// Fulfills returns true if the given value is an IP address, with an optional port, located in an accepted country.
+func (c *CountryCondition) Fulfills(value interface{}, _ *ladon.Request) bool {
+
+	allow := splitList(c.Allow)
+	ip := parseRemoteAddress(value)
+	if ip == nil {
+		log.Logger(context.Background()).Debug("passed value must be an IP address", zap.Any("input param", value))
+		return false
+	}
+	country, e := lookupCountry(ip)
+	if e != nil {
+		log.Logger(context.Background()).Error("cannot resolve country of "+ip.String(), zap.Error(e))
+	}
+	if country == "" {
+		return len(allow) == 0
+	}
+
+	for _, d := range splitList(c.Deny) {
+		if strings.EqualFold(d, country) {
+			return false
+		}
+	}
+	if len(allow) == 0 {
+		return true
+	}
+	for _, a := range allow {
+		if strings.EqualFold(a, country) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetName returns the condition's name.
+func (c *CountryCondition) GetName() string {
+	return "CountryCondition"
+}
+
+// GetForm returns the form used to edit the condition options.
+func (c *CountryCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "allow",
+			Type:        forms.ParamString,
+			Label:       "Allowed countries",
+			Description: "Comma separated ISO country codes, e.g. FR,DE. Any country is allowed if empty",
+			Editable:    true,
+		},
+		&forms.FormField{
+			Name:        "deny",
+			Type:        forms.ParamString,
+			Label:       "Denied countries",
+			Description: "Comma separated ISO country codes, they take precedence over allowed countries",
+			Editable:    true,
+		},
+	)
+}
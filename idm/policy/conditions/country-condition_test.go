@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/ory/ladon"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCountryCondition(t *testing.T) {
+
+	Convey("Canonical country tests", t, func() {
+
+		original := lookupCountry
+		defer func() { lookupCountry = original }()
+		lookupCountry = func(ip net.IP) (string, error) {
+			switch ip.String() {
+			case "1.1.1.1":
+				return "FR", nil
+			case "2.2.2.2":
+				return "US", nil
+			case "3.3.3.3":
+				return "", nil
+			}
+			return "", fmt.Errorf("no database")
+		}
+
+		for _, c := range []struct {
+			allow string
+			deny  string
+			value interface{}
+			pass  bool
+		}{
+			{allow: "FR,DE", value: "1.1.1.1", pass: true},
+			{allow: "fr", value: "1.1.1.1:8080", pass: true},
+			{allow: "FR,DE", value: "2.2.2.2", pass: false},
+			{deny: "US", value: "2.2.2.2", pass: false},
+			{deny: "US", value: "1.1.1.1", pass: true},
+			{allow: "FR", deny: "FR", value: "1.1.1.1", pass: false},
+			{deny: "US", value: "3.3.3.3", pass: true},
+			{allow: "FR", value: "3.3.3.3", pass: false},
+			{allow: "FR", value: "4.4.4.4", pass: false},
+			{allow: "FR", value: "", pass: false},
+		} {
+			condition := &CountryCondition{Allow: c.allow, Deny: c.deny}
+			So(condition.Fulfills(c.value, new(ladon.Request)), ShouldEqual, c.pass)
+		}
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ory/ladon"
 	"go.uber.org/zap"
 
+	"github.com/pydio/cells/common/forms"
 	"github.com/pydio/cells/common/log"
 )
 
@@ -64,3 +65,17 @@ func (c *DateAfterCondition) Fulfills(value interface{}, _ *ladon.Request) bool
 func (c *DateAfterCondition) GetName() string {
 	return "DateAfterCondition"
 }
+
+// GetForm returns the form used to edit the condition options.
+func (c *DateAfterCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Date",
+			Description: "Date after which the condition is fulfilled, formatted as 2006-01-02T15:04-0700",
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"sort"
+
+	"github.com/ory/ladon"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/forms/protos"
+)
+
+// FormProvider is implemented by conditions publishing a form to edit their options in the admin UI.
+type FormProvider interface {
+	GetForm() *forms.Form
+}
+
+func conditionForm(fields ...forms.Field) *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{{Fields: fields}}}
+}
+
+// FormForCondition returns the form published by the condition, or a form generated from its structure.
+func FormForCondition(condition ladon.Condition) *forms.Form {
+	if p, ok := condition.(FormProvider); ok {
+		return p.GetForm()
+	}
+	return protos.GenerateProtoToForm(condition, false)
+}
+
+// ConditionsSwitchField builds a switch field listing all registered conditions with their options.
+func ConditionsSwitchField(name, label string) *forms.SwitchField {
+	field := &forms.SwitchField{
+		Name:        name,
+		Label:       label,
+		Description: label,
+	}
+	var names []string
+	for n := range ladon.ConditionFactories {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		form := FormForCondition(ladon.ConditionFactories[n]())
+		field.Values = append(field.Values, &forms.SwitchValue{
+			Name:   n,
+			Value:  n,
+			Label:  n,
+			Fields: form.Groups[0].Fields,
+		})
+	}
+	return field
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"net"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+)
+
+// GeoIPDatabaseName is the file looked up in the data directory of the policy service
+// when no geoipDatabase path is configured. MaxMind GeoLite2 Country or City databases can be used.
+const GeoIPDatabaseName = "GeoLite2-Country.mmdb"
+
+var (
+	geoIPLock        sync.Mutex
+	geoIPCurrent     *geoIPHandle
+	geoIPPath        string
+	geoIPError       error
+	geoIPLastAttempt time.Time
+
+	// lookupCountry returns the ISO code of the country of an IP address
+	lookupCountry = func(ip net.IP) (string, error) {
+		handle, e := geoIPDatabase()
+		if e != nil {
+			return "", e
+		}
+		defer handle.release()
+		var record struct {
+			Country struct {
+				ISOCode string `maxminddb:"iso_code"`
+			} `maxminddb:"country"`
+		}
+		if e := handle.reader.Lookup(ip, &record); e != nil {
+			return "", e
+		}
+		return record.Country.ISOCode, nil
+	}
+)
+
+// geoIPHandle counts the lookups in progress on a reader, so that a reader replaced
+// after a configuration change is only closed once it is not used anymore.
+type geoIPHandle struct {
+	reader  *maxminddb.Reader
+	refs    int
+	retired bool
+}
+
+// release must be called once the lookup is done.
+func (h *geoIPHandle) release() {
+	geoIPLock.Lock()
+	defer geoIPLock.Unlock()
+	h.refs--
+	if h.retired && h.refs == 0 {
+		h.reader.Close()
+	}
+}
+
+func geoIPDatabasePath() string {
+	serviceName := common.SERVICE_GRPC_NAMESPACE_ + common.SERVICE_POLICY
+	if p := config.Get("services", serviceName, "geoipDatabase").String(""); p != "" {
+		return p
+	}
+	dir, _ := config.ServiceDataDir(serviceName)
+	return filepath.Join(dir, GeoIPDatabaseName)
+}
+
+// geoIPDatabase opens the database once, failed attempts are retried at most every minute.
+// The returned handle must be released after use.
+func geoIPDatabase() (*geoIPHandle, error) {
+	geoIPLock.Lock()
+	defer geoIPLock.Unlock()
+
+	path := geoIPDatabasePath()
+	if path == geoIPPath {
+		if geoIPCurrent != nil {
+			geoIPCurrent.refs++
+			return geoIPCurrent, nil
+		}
+		if time.Since(geoIPLastAttempt) < time.Minute {
+			return nil, geoIPError
+		}
+	}
+	if geoIPCurrent != nil {
+		geoIPCurrent.retired = true
+		if geoIPCurrent.refs == 0 {
+			geoIPCurrent.reader.Close()
+		}
+		geoIPCurrent = nil
+	}
+	geoIPPath = path
+	geoIPLastAttempt = time.Now()
+	var reader *maxminddb.Reader
+	if reader, geoIPError = maxminddb.Open(path); geoIPError != nil {
+		return nil, geoIPError
+	}
+	geoIPCurrent = &geoIPHandle{reader: reader, refs: 1}
+	return geoIPCurrent, nil
+}
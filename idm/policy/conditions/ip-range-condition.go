@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/ory/ladon"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+)
+
+// IPRangeCondition is a condition which is fulfilled if the remote address is not in one of the
+// Deny ranges and, if Allow ranges are defined, is in one of them. Ranges are comma or line
+// separated lists of CIDR blocks or single IPs, for instance 192.168.0.0/16,10.0.0.1
+type IPRangeCondition struct {
+	Allow string `json:"allow,omitempty"`
+	Deny  string `json:"deny,omitempty"`
+}
+
+// Fulfills returns true if the given value is an IP address, with an optional port, matching the ranges.
+func (c *IPRangeCondition) Fulfills(value interface{}, _ *ladon.Request) bool {
+
+	ip := parseRemoteAddress(value)
+	if ip == nil {
+		log.Logger(context.Background()).Debug("passed value must be an IP address", zap.Any("input param", value))
+		return false
+	}
+
+	if ipInRanges(ip, c.Deny) {
+		return false
+	}
+	if allow := splitList(c.Allow); len(allow) > 0 {
+		return ipInRanges(ip, c.Allow)
+	}
+	return true
+}
+
+// GetName returns the condition's name.
+func (c *IPRangeCondition) GetName() string {
+	return "IPRangeCondition"
+}
+
+// GetForm returns the form used to edit the condition options.
+func (c *IPRangeCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "allow",
+			Type:        forms.ParamTextarea,
+			Label:       "Allowed ranges",
+			Description: "CIDR blocks or IPs separated by commas or new lines, e.g. 192.168.0.0/16. Any address is allowed if empty",
+			Editable:    true,
+		},
+		&forms.FormField{
+			Name:        "deny",
+			Type:        forms.ParamTextarea,
+			Label:       "Denied ranges",
+			Description: "CIDR blocks or IPs separated by commas or new lines, they take precedence over allowed ranges",
+			Editable:    true,
+		},
+	)
+}
+
+func ipInRanges(ip net.IP, ranges string) bool {
+	for _, r := range splitList(ranges) {
+		if !strings.Contains(r, "/") {
+			if ip.Equal(net.ParseIP(r)) {
+				return true
+			}
+			continue
+		}
+		_, network, e := net.ParseCIDR(r)
+		if e != nil {
+			log.Logger(context.Background()).Error("cannot parse IP range "+r, zap.Error(e))
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
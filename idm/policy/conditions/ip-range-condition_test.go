@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIPRangeCondition(t *testing.T) {
+
+	Convey("Canonical IP range tests", t, func() {
+
+		for _, c := range []struct {
+			allow string
+			deny  string
+			value interface{}
+			pass  bool
+		}{
+			{allow: "192.168.0.0/16", value: "192.168.1.10", pass: true},
+			{allow: "192.168.0.0/16", value: "192.168.1.10:52341", pass: true},
+			{allow: "192.168.0.0/16, 10.0.0.1", value: "10.0.0.1", pass: true},
+			{allow: "192.168.0.0/16\n10.0.0.0/8", value: "10.2.3.4", pass: true},
+			{allow: "192.168.0.0/16", value: "172.16.0.1", pass: false},
+			{deny: "192.168.1.0/24", value: "192.168.1.10", pass: false},
+			{deny: "192.168.1.0/24", value: "192.168.2.10", pass: true},
+			{allow: "192.168.0.0/16", deny: "192.168.1.10", value: "192.168.1.10", pass: false},
+			{allow: "2001:db8::/32", value: "[2001:db8::1]:443", pass: true},
+			{allow: "192.168.0.0/16", value: "not-an-ip", pass: false},
+			{value: 12, pass: false},
+		} {
+			condition := &IPRangeCondition{Allow: c.allow, Deny: c.deny}
+			So(condition.Fulfills(c.value, new(ladon.Request)), ShouldEqual, c.pass)
+		}
+	})
+}
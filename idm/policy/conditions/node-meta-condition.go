@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ory/ladon"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+var (
+	nodeMetaCache = cache.New(10*time.Second, time.Minute)
+	// loadNodeMeta is used to find the metadata of a node that were not passed in the request context
+	loadNodeMeta = func(uuid string) (map[string]string, error) {
+		cli := tree.NewNodeProviderClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_META, defaults.NewClient())
+		resp, e := cli.ReadNode(context.Background(), &tree.ReadNodeRequest{Node: &tree.Node{Uuid: uuid}})
+		if e != nil {
+			return nil, e
+		}
+		return resp.Node.MetaStore, nil
+	}
+)
+
+// NodeMetaCondition is a condition which is fulfilled if a metadata of the node, for instance
+// a confidentiality tag, matches the regex pattern specified in Matches. The metadata is read
+// from the NodeMeta:NAMESPACE key of the request context if it is set, otherwise it is loaded
+// from the metadata service using the NodeUuid key.
+type NodeMetaCondition struct {
+	Namespace string `json:"namespace"`
+	Matches   string `json:"matches"`
+}
+
+// Fulfills returns true if the metadata of the node matches the pattern. The passed value is ignored.
+func (c *NodeMetaCondition) Fulfills(_ interface{}, r *ladon.Request) bool {
+
+	value, ok := c.metaValue(r)
+	if !ok {
+		return false
+	}
+	matches, e := matchPattern(c.Matches, value)
+	if e != nil {
+		log.Logger(context.Background()).Error("cannot parse node metadata pattern "+c.Matches, zap.Error(e))
+		return false
+	}
+	return matches
+}
+
+func (c *NodeMetaCondition) metaValue(r *ladon.Request) (string, bool) {
+	if v, ok := r.Context[permissions.PolicyNodeMeta_+c.Namespace]; ok {
+		return fmt.Sprintf("%v", v), true
+	}
+	uuid, ok := r.Context[permissions.PolicyNodeUuid].(string)
+	if !ok || uuid == "" {
+		return "", false
+	}
+	var meta map[string]string
+	if cached, found := nodeMetaCache.Get(uuid); found {
+		meta = cached.(map[string]string)
+	} else {
+		var e error
+		if meta, e = loadNodeMeta(uuid); e != nil {
+			log.Logger(context.Background()).Debug("cannot load node metadata for condition", zap.String("uuid", uuid), zap.Error(e))
+			return "", false
+		}
+		nodeMetaCache.Set(uuid, meta, cache.DefaultExpiration)
+	}
+	value, ok := meta[c.Namespace]
+	if !ok {
+		return "", false
+	}
+	var s string
+	if e := json.Unmarshal([]byte(value), &s); e == nil {
+		value = s
+	}
+	return value, true
+}
+
+// GetName returns the condition's name.
+func (c *NodeMetaCondition) GetName() string {
+	return "NodeMetaCondition"
+}
+
+// GetForm returns the form used to edit the condition options.
+func (c *NodeMetaCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "namespace",
+			Type:        forms.ParamString,
+			Label:       "Metadata",
+			Description: "Namespace of the node metadata, e.g. usermeta-confidentiality",
+			Mandatory:   true,
+			Editable:    true,
+		},
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Matches",
+			Description: "Regular expression the metadata value must match, e.g. ^(internal|secret)$",
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/ory/ladon"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNodeMetaCondition(t *testing.T) {
+
+	Convey("Node metadata tests", t, func() {
+
+		original := loadNodeMeta
+		defer func() { loadNodeMeta = original }()
+		var loaded int
+		loadNodeMeta = func(uuid string) (map[string]string, error) {
+			loaded++
+			return map[string]string{"usermeta-confidentiality": "\"secret\""}, nil
+		}
+		request := func(ctx ladon.Context) *ladon.Request {
+			return &ladon.Request{Context: ctx}
+		}
+
+		condition := &NodeMetaCondition{Namespace: "usermeta-confidentiality", Matches: "^secret$"}
+		So(condition.Fulfills(nil, request(ladon.Context{"NodeMeta:usermeta-confidentiality": "secret"})), ShouldBeTrue)
+		So(condition.Fulfills(nil, request(ladon.Context{"NodeMeta:usermeta-confidentiality": "public"})), ShouldBeFalse)
+		So(loaded, ShouldEqual, 0)
+
+		So(condition.Fulfills(nil, request(ladon.Context{"NodeUuid": "node-uuid"})), ShouldBeTrue)
+		So(condition.Fulfills(nil, request(ladon.Context{"NodeUuid": "node-uuid"})), ShouldBeTrue)
+		So(loaded, ShouldEqual, 1)
+		So(condition.Fulfills(nil, request(ladon.Context{})), ShouldBeFalse)
+
+		condition = &NodeMetaCondition{Namespace: "usermeta-other", Matches: ".*"}
+		So(condition.Fulfills(nil, request(ladon.Context{"NodeUuid": "node-uuid"})), ShouldBeFalse)
+	})
+}
+
+func TestClientApplicationCondition(t *testing.T) {
+
+	Convey("Client application tests", t, func() {
+		condition := &ClientApplicationCondition{Matches: "web, sync"}
+		So(condition.Fulfills("web", new(ladon.Request)), ShouldBeTrue)
+		So(condition.Fulfills("sync", new(ladon.Request)), ShouldBeTrue)
+		So(condition.Fulfills("dav", new(ladon.Request)), ShouldBeFalse)
+		So(condition.Fulfills(nil, new(ladon.Request)), ShouldBeFalse)
+	})
+}
@@ -27,13 +27,16 @@ import (
 	"github.com/ory/ladon"
 	"go.uber.org/zap"
 
+	"github.com/pydio/cells/common/forms"
 	"github.com/pydio/cells/common/log"
 )
 
 // OfficeHoursCondition is a condition which is fulfilled if the current time is
-// within one of the week periods defined by the Matches string, for instance Monday-Friday/08:00/17:30
+// within one of the week periods defined by the Matches string, for instance Monday-Friday/08:00/17:30.
+// Days and hours are checked in the optional TimeZone, or in the time zone of the checked time.
 type OfficeHoursCondition struct {
-	Matches string `json:"matches"`
+	Matches  string `json:"matches"`
+	TimeZone string `json:"timezone,omitempty"`
 }
 
 // Fulfills returns true if the given value is a valid Time and is within one of the defined period.
@@ -58,7 +61,14 @@ func (c *OfficeHoursCondition) Fulfills(value interface{}, _ *ladon.Request) boo
 		return false
 	}
 
-	// TODO manage timezone
+	if c.TimeZone != "" {
+		loc, e := time.LoadLocation(c.TimeZone)
+		if e != nil {
+			log.Logger(context.Background()).Error("cannot load time zone "+c.TimeZone, zap.Error(e))
+			return false
+		}
+		t = t.In(loc)
+	}
 
 	// check week day
 	if !isWeekdayValid(days, t.Weekday()) {
@@ -72,3 +82,24 @@ func (c *OfficeHoursCondition) Fulfills(value interface{}, _ *ladon.Request) boo
 func (c *OfficeHoursCondition) GetName() string {
 	return "OfficeHoursCondition"
 }
+
+// GetForm returns the form used to edit the condition options.
+func (c *OfficeHoursCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Office hours",
+			Description: "Days and hours, e.g. Monday-Friday/08:00/17:30 or Monday,Wednesday/09:00/12:00",
+			Mandatory:   true,
+			Editable:    true,
+		},
+		&forms.FormField{
+			Name:        "timezone",
+			Type:        forms.ParamString,
+			Label:       "Time zone",
+			Description: "IANA time zone of the office hours, e.g. Europe/Paris. Time of the request is used if empty",
+			Editable:    true,
+		},
+	)
+}
@@ -36,17 +36,25 @@ func TestOfficeHoursCondition(t *testing.T) {
 	Convey("Canonical within period tests", t, func() {
 
 		for _, c := range []struct {
-			matches string
-			value   interface{}
-			pass    bool
+			matches  string
+			timezone string
+			value    interface{}
+			pass     bool
 		}{
 			{matches: "Monday-Friday/08:00/17:30", value: "2018-02-14T15:04+0100", pass: true},
 			{matches: "Monday, Wednesday, Friday/08:00/17:30", value: "2018-02-14T15:04+0100", pass: true},
 			{matches: "Monday,Wednesday,Friday/08:00/17:30", value: "2018-02-14T15:04+0100", pass: true},
 			{matches: "Friday/08:00/17:30", value: "2018-02-14T15:04+0100", pass: false},
+			// 15:04 in Paris is 23:04 in Tokyo
+			{matches: "Monday-Friday/08:00/17:30", timezone: "Asia/Tokyo", value: "2018-02-14T15:04+0100", pass: false},
+			{matches: "Monday-Friday/20:00/23:30", timezone: "Asia/Tokyo", value: "2018-02-14T15:04+0100", pass: true},
+			// 23:30 on Friday in New York is Saturday in Paris
+			{matches: "Monday-Friday/08:00/23:59", timezone: "Europe/Paris", value: "2018-02-16T23:30-0500", pass: false},
+			{matches: "Monday-Friday/08:00/17:30", timezone: "Unknown/Zone", value: "2018-02-14T15:04+0100", pass: false},
 		} {
 			condition := &OfficeHoursCondition{
-				Matches: c.matches,
+				Matches:  c.matches,
+				TimeZone: c.timezone,
 			}
 			So(condition.Fulfills(c.value, new(ladon.Request)), ShouldEqual, c.pass)
 		}
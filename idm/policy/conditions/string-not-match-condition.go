@@ -24,6 +24,8 @@ import (
 	"regexp"
 
 	"github.com/ory/ladon"
+
+	"github.com/pydio/cells/common/forms"
 )
 
 // StringNotMatchCondition is a condition which is fulfilled if the given
@@ -49,3 +51,17 @@ func (c *StringNotMatchCondition) Fulfills(value interface{}, _ *ladon.Request)
 func (c *StringNotMatchCondition) GetName() string {
 	return "StringNotMatchCondition"
 }
+
+// GetForm returns the form used to edit the condition options.
+func (c *StringNotMatchCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Does not match",
+			Description: "Regular expression the value must not match",
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ory/ladon"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+var (
+	usersCache = cache.New(10*time.Second, time.Minute)
+	// loadUser is used to find the attributes of the user sending the request
+	loadUser = func(login string) (*idm.User, error) {
+		return permissions.SearchUniqueUser(context.Background(), login, "")
+	}
+)
+
+// UserAttributeCondition is a condition which is fulfilled if an attribute of the user sending the
+// request matches the regex pattern specified in Matches. Besides "login" and "groupPath", attributes
+// are read from the private attributes managed by administrators ("department" reads "pydio:department"),
+// as users can edit the other ones on their own profile. The attribute is read from the UserAttribute:NAME
+// key of the request context if it is set, otherwise the user is loaded from the UserLogin key.
+type UserAttributeCondition struct {
+	Attribute string `json:"attribute"`
+	Matches   string `json:"matches"`
+}
+
+// Fulfills returns true if the attribute of the user matches the pattern. The passed value is ignored.
+func (c *UserAttributeCondition) Fulfills(_ interface{}, r *ladon.Request) bool {
+
+	value, ok := c.attributeValue(r)
+	if !ok {
+		return false
+	}
+	matches, e := matchPattern(c.Matches, value)
+	if e != nil {
+		log.Logger(context.Background()).Error("cannot parse user attribute pattern "+c.Matches, zap.Error(e))
+		return false
+	}
+	return matches
+}
+
+func (c *UserAttributeCondition) attributeValue(r *ladon.Request) (string, bool) {
+	if v, ok := r.Context[permissions.PolicyUserAttribute_+c.Attribute]; ok {
+		return fmt.Sprintf("%v", v), true
+	}
+	login, ok := r.Context[permissions.PolicyUserLogin].(string)
+	if !ok || login == "" {
+		return "", false
+	}
+	var user *idm.User
+	if cached, found := usersCache.Get(login); found {
+		user = cached.(*idm.User)
+	} else {
+		var e error
+		if user, e = loadUser(login); e != nil || user == nil {
+			log.Logger(context.Background()).Error("cannot load user for attribute condition", zap.String("login", login), zap.Error(e))
+			return "", false
+		}
+		usersCache.Set(login, user, cache.DefaultExpiration)
+	}
+	switch c.Attribute {
+	case "login":
+		return user.Login, true
+	case "groupPath":
+		return user.GroupPath, true
+	}
+	attribute := c.Attribute
+	if !strings.HasPrefix(attribute, idm.UserAttrPrivatePrefix) {
+		attribute = idm.UserAttrPrivatePrefix + attribute
+	}
+	v, ok := user.Attributes[attribute]
+	return v, ok
+}
+
+// GetName returns the condition's name.
+func (c *UserAttributeCondition) GetName() string {
+	return "UserAttributeCondition"
+}
+
+// GetForm returns the form used to edit the condition options.
+func (c *UserAttributeCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "attribute",
+			Type:        forms.ParamString,
+			Label:       "User attribute",
+			Description: "Name of the user attribute, e.g. login, groupPath or an attribute managed by administrators like department (stored as pydio:department)",
+			Mandatory:   true,
+			Editable:    true,
+		},
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Matches",
+			Description: "Regular expression the attribute value must match, e.g. ^(sales|marketing)$",
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package conditions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ory/ladon"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestUserAttributeCondition(t *testing.T) {
+
+	Convey("User attribute tests", t, func() {
+
+		original := loadUser
+		defer func() { loadUser = original }()
+		usersCache.Flush()
+		var loaded int
+		loadUser = func(login string) (*idm.User, error) {
+			loaded++
+			if login == "john" {
+				return &idm.User{Login: "john", GroupPath: "/sales", Attributes: map[string]string{
+					"pydio:department": "sales",
+					"team":             "sales",
+				}}, nil
+			}
+			return nil, fmt.Errorf("not found")
+		}
+		request := func(ctx ladon.Context) *ladon.Request {
+			return &ladon.Request{Context: ctx}
+		}
+
+		condition := &UserAttributeCondition{Attribute: "department", Matches: "^(sales|marketing)$"}
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john"})), ShouldBeTrue)
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "jane"})), ShouldBeFalse)
+		So(condition.Fulfills(nil, request(ladon.Context{})), ShouldBeFalse)
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john", "UserAttribute:department": "accounting"})), ShouldBeFalse)
+		So(condition.Fulfills(nil, request(ladon.Context{"UserAttribute:department": "marketing"})), ShouldBeTrue)
+
+		So(loaded, ShouldEqual, 2)
+
+		condition = &UserAttributeCondition{Attribute: "pydio:department", Matches: "^sales$"}
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john"})), ShouldBeTrue)
+
+		// Attributes that users can edit on themselves are never matched
+		condition = &UserAttributeCondition{Attribute: "team", Matches: "^sales$"}
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john"})), ShouldBeFalse)
+
+		condition = &UserAttributeCondition{Attribute: "groupPath", Matches: "^/sales"}
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john"})), ShouldBeTrue)
+
+		condition = &UserAttributeCondition{Attribute: "missing", Matches: ".*"}
+		So(condition.Fulfills(nil, request(ladon.Context{"UserLogin": "john"})), ShouldBeFalse)
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ory/ladon"
 	"go.uber.org/zap"
 
+	"github.com/pydio/cells/common/forms"
 	"github.com/pydio/cells/common/log"
 )
 
@@ -69,3 +70,17 @@ func (c *WithinPeriodCondition) Fulfills(value interface{}, _ *ladon.Request) bo
 func (c *WithinPeriodCondition) GetName() string {
 	return "WithinPeriodCondition"
 }
+
+// GetForm returns the form used to edit the condition options.
+func (c *WithinPeriodCondition) GetForm() *forms.Form {
+	return conditionForm(
+		&forms.FormField{
+			Name:        "matches",
+			Type:        forms.ParamString,
+			Label:       "Period",
+			Description: "Start and end dates separated by a slash, formatted as 2006-01-02T15:04-0700/2006-01-02T15:04-0700",
+			Mandatory:   true,
+			Editable:    true,
+		},
+	)
+}
@@ -118,6 +118,8 @@ var (
 					Description: "PolicyGroup.LoggedUsers.Rule2",
 					Subjects:    []string{"profile:standard", "profile:shared"},
 					Resources: []string{
+						"rest:/auth/token/personal",
+						"rest:/auth/token/personal/<.+>",
 						"rest:/user",
 						"rest:/user/<.+>",
 						"rest:/workspace",
@@ -703,3 +705,30 @@ func Upgrade210(ctx context.Context) error {
 	}
 	return nil
 }
+
+// Upgrade220 grants standard users the management of their personal access tokens
+func Upgrade220(ctx context.Context) error {
+	dao := servicecontext.GetDAO(ctx).(DAO)
+	if dao == nil {
+		return fmt.Errorf("cannot find DAO for policies initialization")
+	}
+	groups, e := dao.ListPolicyGroups(ctx)
+	if e != nil {
+		return e
+	}
+	for _, group := range groups {
+		if group.Uuid == "rest-apis-default-accesses" {
+			for _, p := range group.Policies {
+				if p.Id == "user-default-policy" {
+					p.Resources = append([]string{"rest:/auth/token/personal", "rest:/auth/token/personal/<.+>"}, p.Resources...)
+				}
+			}
+			if _, er := dao.StorePolicyGroup(ctx, group); er != nil {
+				log.Logger(ctx).Error("could not update policy group "+group.Uuid, zap.Error(er))
+			} else {
+				log.Logger(ctx).Info("Updated policy group " + group.Uuid)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/idm/policy/lang"
+)
+
+// ExposedConfigs lists the policy service options editable from the admin console.
+var ExposedConfigs = &forms.Form{
+	I18NBundle: lang.Bundle(),
+	Groups: []*forms.Group{{
+		Label: "Config.GeoIP.Title",
+		Fields: []forms.Field{
+			&forms.FormField{
+				Name:        "geoipDatabase",
+				Type:        forms.ParamString,
+				Label:       "Config.GeoIP.Database.Label",
+				Description: "Config.GeoIP.Database.Description",
+			},
+		},
+	}},
+}
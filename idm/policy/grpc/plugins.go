@@ -25,6 +25,7 @@ import (
 	"github.com/micro/go-micro"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/plugins"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/service"
@@ -33,6 +34,9 @@ import (
 
 func init() {
 	plugins.Register(func() {
+
+		config.RegisterExposedConfigs(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_POLICY, ExposedConfigs)
+
 		service.NewService(
 			service.Name(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_POLICY),
 			service.Tag(common.SERVICE_TAG_IDM),
@@ -71,6 +75,10 @@ func init() {
 					TargetVersion: service.ValidVersion("2.0.99"),
 					Up:            policy.Upgrade210,
 				},
+				{
+					TargetVersion: service.ValidVersion("2.1.99"),
+					Up:            policy.Upgrade220,
+				},
 			}),
 			service.WithMicro(func(m micro.Service) error {
 				handler := new(Handler)
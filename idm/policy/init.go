@@ -50,4 +50,24 @@ func init() {
 		return new(conditions.DateAfterCondition)
 	}
 
+	ladon.ConditionFactories[new(conditions.IPRangeCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.IPRangeCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.UserAttributeCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.UserAttributeCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.NodeMetaCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.NodeMetaCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.ClientApplicationCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.ClientApplicationCondition)
+	}
+
+	ladon.ConditionFactories[new(conditions.CountryCondition).GetName()] = func() ladon.Condition {
+		return new(conditions.CountryCondition)
+	}
+
 }
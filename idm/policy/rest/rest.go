@@ -28,8 +28,10 @@ import (
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/micro"
 	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
 	"github.com/pydio/cells/common/service"
 	"github.com/pydio/cells/common/utils/i18n"
+	"github.com/pydio/cells/idm/policy"
 	"github.com/pydio/cells/idm/policy/lang"
 )
 
@@ -72,3 +74,47 @@ func (h *PolicyHandler) ListPolicies(req *restful.Request, rsp *restful.Response
 
 	rsp.WriteEntity(response)
 }
+
+// SimulatePolicies evaluates a batch of requests against candidate policy groups without storing them
+func (h *PolicyHandler) SimulatePolicies(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	var input rest.PolicySimulationRequest
+	if err := req.ReadEntity(&input); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	log.Logger(ctx).Debug("Received Policy.Simulate API request")
+
+	groups := input.PolicyGroups
+	if input.IncludeStored {
+		stored, err := h.getClient().ListPolicyGroups(ctx, &idm.ListPolicyGroupsRequest{})
+		if err != nil {
+			service.RestError500(req, rsp, err)
+			return
+		}
+		groups = policy.MergePolicyGroups(groups, stored.PolicyGroups)
+	}
+
+	simulator := policy.NewSimulator(groups)
+	response := &rest.PolicySimulationResponse{}
+	for _, request := range input.Requests {
+		result := &rest.PolicySimulationResult{Request: request}
+		if r, err := simulator.Evaluate(request); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Allowed = r.Allowed
+			result.ExplicitDeny = r.ExplicitDeny
+			result.DefaultDeny = r.DefaultDeny
+			if r.Policy != nil {
+				result.PolicyId = r.Policy.Id
+				result.PolicyDescription = r.Policy.Description
+				result.PolicyGroupUuid = r.Group.Uuid
+				result.PolicyGroupName = r.Group.Name
+			}
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	rsp.WriteEntity(response)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package policy
+
+import (
+	"strings"
+
+	"github.com/ory/ladon"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+// SimulationResult is the decision taken for a request by a Simulator.
+type SimulationResult struct {
+	Allowed      bool
+	ExplicitDeny bool
+	DefaultDeny  bool
+	// Policy that decided: the denying policy on explicit deny, the first allowing policy otherwise
+	Policy *idm.Policy
+	Group  *idm.PolicyGroup
+}
+
+// Simulator evaluates requests against a set of policy groups kept in memory, without storing them.
+// It is not safe for concurrent use.
+type Simulator struct {
+	warden   *ladon.Ladon
+	policies []ladon.Policy
+	protos   map[ladon.Policy]*idm.Policy
+	groups   map[ladon.Policy]*idm.PolicyGroup
+	recorder *decisionRecorder
+}
+
+// MergePolicyGroups returns the candidate groups followed by the stored groups that they do not replace.
+func MergePolicyGroups(candidates, stored []*idm.PolicyGroup) []*idm.PolicyGroup {
+	merged := append([]*idm.PolicyGroup{}, candidates...)
+	for _, s := range stored {
+		var replaced bool
+		for _, c := range candidates {
+			if c.Uuid != "" && c.Uuid == s.Uuid {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// NewSimulator prepares a Simulator for the given policy groups.
+func NewSimulator(groups []*idm.PolicyGroup) *Simulator {
+	s := &Simulator{
+		protos:   make(map[ladon.Policy]*idm.Policy),
+		groups:   make(map[ladon.Policy]*idm.PolicyGroup),
+		recorder: &decisionRecorder{},
+	}
+	s.warden = &ladon.Ladon{AuditLogger: s.recorder}
+	for _, g := range groups {
+		for _, p := range g.Policies {
+			lp := ProtoToLadonPolicy(p)
+			s.policies = append(s.policies, lp)
+			s.protos[lp] = p
+			s.groups[lp] = g
+		}
+	}
+	return s
+}
+
+// Evaluate decides a request like the IsAllowed handler does: each subject is checked
+// and an explicit deny on any of them wins over allows.
+func (s *Simulator) Evaluate(request *idm.PolicyEngineRequest) (*SimulationResult, error) {
+
+	reqContext := make(map[string]interface{})
+	for k, v := range request.Context {
+		reqContext[k] = v
+	}
+	result := &SimulationResult{}
+
+	for _, subject := range request.Subjects {
+
+		ladonRequest := &ladon.Request{
+			Subject:  subject,
+			Resource: request.Resource,
+			Action:   request.Action,
+			Context:  reqContext,
+		}
+
+		s.recorder.deciders = nil
+		if err := s.warden.DoPoliciesAllow(ladonRequest, s.policies); err == nil {
+			// Explicit allow, keep the first allowing policy
+			if !result.Allowed && len(s.recorder.deciders) > 0 {
+				s.setDecider(result, s.recorder.deciders[0])
+			}
+			result.Allowed = true
+		} else if strings.Contains(err.Error(), "Request was denied by default") {
+			// No match for this subject, wait for the other ones
+		} else if strings.Contains(err.Error(), "Request was forcefully denied") {
+			result.Allowed = false
+			result.ExplicitDeny = true
+			if l := len(s.recorder.deciders); l > 0 {
+				s.setDecider(result, s.recorder.deciders[l-1])
+			}
+			return result, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	if !result.Allowed {
+		result.DefaultDeny = true
+	}
+
+	return result, nil
+}
+
+func (s *Simulator) setDecider(result *SimulationResult, p ladon.Policy) {
+	result.Policy = s.protos[p]
+	result.Group = s.groups[p]
+}
+
+// decisionRecorder is a ladon.AuditLogger keeping the deciding policies of the last request
+type decisionRecorder struct {
+	deciders ladon.Policies
+}
+
+func (d *decisionRecorder) LogRejectedAccessRequest(r *ladon.Request, p ladon.Policies, deciders ladon.Policies) {
+	d.deciders = deciders
+}
+
+func (d *decisionRecorder) LogGrantedAccessRequest(r *ladon.Request, p ladon.Policies, deciders ladon.Policies) {
+	d.deciders = deciders
+}
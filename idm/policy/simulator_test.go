@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package policy
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+func TestSimulator(t *testing.T) {
+
+	stored := []*idm.PolicyGroup{
+		{
+			Uuid: "rest-group",
+			Policies: []*idm.Policy{
+				{
+					Id:        "allow-users",
+					Subjects:  []string{"profile:standard"},
+					Resources: []string{"rest:/<.+>"},
+					Actions:   []string{"GET"},
+					Effect:    idm.PolicyEffect_allow,
+				},
+				{
+					Id:        "deny-external",
+					Subjects:  []string{"profile:standard"},
+					Resources: []string{"rest:/admin<.*>"},
+					Actions:   []string{"GET"},
+					Effect:    idm.PolicyEffect_deny,
+					Conditions: map[string]*idm.PolicyCondition{
+						"RemoteAddress": {
+							Type:        "StringNotMatchCondition",
+							JsonOptions: "{\"matches\":\"^192\\\\.168\\\\.\"}",
+						},
+					},
+				},
+			},
+		},
+		{
+			Uuid: "other-group",
+			Policies: []*idm.Policy{
+				{
+					Id:        "allow-admin",
+					Subjects:  []string{"profile:admin"},
+					Resources: []string{"rest:/<.+>"},
+					Actions:   []string{"GET", "PUT"},
+					Effect:    idm.PolicyEffect_allow,
+				},
+			},
+		},
+	}
+
+	Convey("Test stored policies", t, func() {
+		s := NewSimulator(stored)
+
+		r, e := s.Evaluate(&idm.PolicyEngineRequest{Subjects: []string{"user:john", "profile:standard"}, Resource: "rest:/workspace", Action: "GET"})
+		So(e, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		So(r.Policy.Id, ShouldEqual, "allow-users")
+		So(r.Group.Uuid, ShouldEqual, "rest-group")
+
+		r, e = s.Evaluate(&idm.PolicyEngineRequest{Subjects: []string{"profile:standard"}, Resource: "rest:/admin/users", Action: "GET", Context: map[string]string{"RemoteAddress": "10.0.0.1"}})
+		So(e, ShouldBeNil)
+		So(r.Allowed, ShouldBeFalse)
+		So(r.ExplicitDeny, ShouldBeTrue)
+		So(r.Policy.Id, ShouldEqual, "deny-external")
+
+		r, e = s.Evaluate(&idm.PolicyEngineRequest{Subjects: []string{"profile:standard"}, Resource: "rest:/admin/users", Action: "GET", Context: map[string]string{"RemoteAddress": "192.168.0.1"}})
+		So(e, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+
+		r, e = s.Evaluate(&idm.PolicyEngineRequest{Subjects: []string{"profile:standard"}, Resource: "rest:/workspace", Action: "PUT"})
+		So(e, ShouldBeNil)
+		So(r.Allowed, ShouldBeFalse)
+		So(r.DefaultDeny, ShouldBeTrue)
+		So(r.Policy, ShouldBeNil)
+	})
+
+	Convey("Test candidate replacing a stored group", t, func() {
+		candidate := &idm.PolicyGroup{
+			Uuid: "rest-group",
+			Policies: []*idm.Policy{
+				{
+					Subjects:  []string{"profile:standard"},
+					Resources: []string{"rest:/<.+>"},
+					Actions:   []string{"GET", "PUT"},
+					Effect:    idm.PolicyEffect_allow,
+				},
+			},
+		}
+		merged := MergePolicyGroups([]*idm.PolicyGroup{candidate}, stored)
+		So(merged, ShouldHaveLength, 2)
+		So(merged[0], ShouldEqual, candidate)
+		So(merged[1].Uuid, ShouldEqual, "other-group")
+
+		r, e := NewSimulator(merged).Evaluate(&idm.PolicyEngineRequest{Subjects: []string{"profile:standard"}, Resource: "rest:/workspace", Action: "PUT"})
+		So(e, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		So(r.Group, ShouldEqual, candidate)
+	})
+}
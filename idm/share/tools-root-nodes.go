@@ -201,6 +201,9 @@ func CheckLinkRootNodes(ctx context.Context, link *rest.ShareLink) error {
 		if resp.Node.GetStringMeta(common.META_FLAG_READONLY) != "" {
 			hasReadonly = true
 		}
+		if flag := resp.Node.GetStringMeta(common.META_FLAG_SENSITIVE); flag != "" && views.BlockSensitiveLinks() {
+			return errors.Forbidden(common.SERVICE_SHARE, "This resource was flagged as containing sensitive data (%s), it cannot be shared publicly.", flag)
+		}
 	}
 	if hasReadonly {
 		for _, p := range link.Permissions {
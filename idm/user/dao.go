@@ -24,6 +24,8 @@
 package user
 
 import (
+	"time"
+
 	"github.com/pydio/cells/common/dao"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/tree"
@@ -47,6 +49,13 @@ type DAO interface {
 	Count(sql.Enquirer, ...bool) (int, error)
 	Bind(userName string, password string) (*idm.User, error)
 	CleanRole(roleId string) error
+
+	// AddPasswordHistory records a password hash in the history of the user.
+	AddPasswordHistory(uuid string, hash string) error
+	// PasswordReused checks if a clear password was used by the user among its depth latest passwords.
+	PasswordReused(uuid string, password string, depth int) (bool, error)
+	// PasswordChangedAt returns the last time the password was changed, zero if unknown.
+	PasswordChangedAt(uuid string) (time.Time, error)
 }
 
 // NewDAO wraps passed DAO with specific Pydio implementation of User DAO and returns it.
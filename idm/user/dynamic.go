@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package user
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pydio/cells/common/proto/idm"
+)
+
+const (
+	// DynamicRuleLogin is a reserved rule key matching the user login
+	DynamicRuleLogin = "login"
+	// DynamicRuleGroupPath is a reserved rule key matching the user group path
+	DynamicRuleGroupPath = "groupPath"
+)
+
+// DynamicRule is the parsed membership rule of a dynamic group. Rules are made of
+// clauses like "key=value" or "key!=value", combined with AND, OR, NOT and parenthesis,
+// e.g. "department=legal AND (country=FR OR country=BE)". Apart from the reserved login
+// and groupPath keys, keys are read from private attributes (see DynamicRuleAttribute).
+// Values are compared case-insensitively and may be quoted if they contain spaces.
+type DynamicRule struct {
+	root ruleExpr
+}
+
+// DynamicRuleAttribute returns the user attribute matched by a rule key. Rules only match private
+// "pydio:" attributes, which are managed by administrators and cannot be edited by users on themselves:
+// "department" reads the "pydio:department" attribute.
+func DynamicRuleAttribute(key string) string {
+	if strings.HasPrefix(key, idm.UserAttrPrivatePrefix) {
+		return key
+	}
+	return idm.UserAttrPrivatePrefix + key
+}
+
+// ParseDynamicRule parses a rule string into a DynamicRule.
+func ParseDynamicRule(rule string) (*DynamicRule, error) {
+	tokens, err := tokenizeRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty dynamic rule")
+	}
+	p := &ruleParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %s in dynamic rule", p.tokens[p.pos].value)
+	}
+	return &DynamicRule{root: root}, nil
+}
+
+// Matches checks if a user satisfies this rule.
+func (r *DynamicRule) Matches(u *idm.User) bool {
+	return r.root.eval(u)
+}
+
+type ruleExpr interface {
+	eval(u *idm.User) bool
+}
+
+type andExpr []ruleExpr
+
+func (a andExpr) eval(u *idm.User) bool {
+	for _, e := range a {
+		if !e.eval(u) {
+			return false
+		}
+	}
+	return true
+}
+
+type orExpr []ruleExpr
+
+func (o orExpr) eval(u *idm.User) bool {
+	for _, e := range o {
+		if e.eval(u) {
+			return true
+		}
+	}
+	return false
+}
+
+type notExpr struct {
+	expr ruleExpr
+}
+
+func (n notExpr) eval(u *idm.User) bool {
+	return !n.expr.eval(u)
+}
+
+type clauseExpr struct {
+	key    string
+	value  string
+	negate bool
+}
+
+func (c clauseExpr) eval(u *idm.User) bool {
+	var value string
+	var has bool
+	switch c.key {
+	case DynamicRuleLogin:
+		value, has = u.Login, true
+	case DynamicRuleGroupPath:
+		value, has = u.GroupPath, true
+	default:
+		value, has = u.Attributes[DynamicRuleAttribute(c.key)]
+	}
+	match := has && strings.EqualFold(value, c.value)
+	if c.negate {
+		return !match
+	}
+	return match
+}
+
+type ruleTokenType int
+
+const (
+	tokenWord ruleTokenType = iota
+	tokenQuoted
+	tokenOpen
+	tokenClose
+	tokenEqual
+	tokenNotEqual
+)
+
+type ruleToken struct {
+	kind  ruleTokenType
+	value string
+}
+
+// keyword checks if token is the given unquoted keyword.
+func (t ruleToken) keyword(k string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.value, k)
+}
+
+func tokenizeRule(rule string) (tokens []ruleToken, err error) {
+	runes := []rune(rule)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, ruleToken{kind: tokenOpen, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, ruleToken{kind: tokenClose, value: ")"})
+			i++
+		case r == '=':
+			tokens = append(tokens, ruleToken{kind: tokenEqual, value: "="})
+			i++
+		case r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected character ! in dynamic rule")
+			}
+			tokens = append(tokens, ruleToken{kind: tokenNotEqual, value: "!="})
+			i += 2
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value in dynamic rule")
+			}
+			tokens = append(tokens, ruleToken{kind: tokenQuoted, value: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()=!\"'", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, ruleToken{kind: tokenWord, value: string(runes[i:end])})
+			i = end
+		}
+	}
+	return
+}
+
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() (ruleToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return ruleToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *ruleParser) next() (ruleToken, error) {
+	t, ok := p.peek()
+	if !ok {
+		return t, fmt.Errorf("unexpected end of dynamic rule")
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *ruleParser) parseOr() (ruleExpr, error) {
+	var or orExpr
+	for {
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, e)
+		if t, ok := p.peek(); !ok || !t.keyword("OR") {
+			break
+		}
+		p.pos++
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleExpr, error) {
+	var and andExpr
+	for {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+		if t, ok := p.peek(); !ok || !t.keyword("AND") {
+			break
+		}
+		p.pos++
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *ruleParser) parseUnary() (ruleExpr, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.keyword("NOT"):
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: e}, nil
+	case t.kind == tokenOpen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c, err := p.next(); err != nil || c.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis in dynamic rule")
+		}
+		return e, nil
+	case t.kind == tokenWord:
+		op, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if op.kind != tokenEqual && op.kind != tokenNotEqual {
+			return nil, fmt.Errorf("expected = or != after %s in dynamic rule", t.value)
+		}
+		v, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if v.kind != tokenWord && v.kind != tokenQuoted {
+			return nil, fmt.Errorf("expected a value after %s%s in dynamic rule", t.value, op.value)
+		}
+		return clauseExpr{key: t.value, value: v.value, negate: op.kind == tokenNotEqual}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %s in dynamic rule", t.value)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package user
+
+import (
+	"testing"
+
+	"github.com/pydio/cells/common/proto/idm"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDynamicRule(t *testing.T) {
+
+	u := &idm.User{
+		Login:     "jdoe",
+		GroupPath: "/paris",
+		Attributes: map[string]string{
+			"pydio:department": "Legal",
+			"pydio:country":    "FR",
+			"pydio:title":      "Head of legal",
+			// Public attributes can be edited by the user and are never matched
+			"team": "legal",
+		},
+	}
+
+	Convey("Test dynamic rules evaluation", t, func() {
+		for _, c := range []struct {
+			rule  string
+			match bool
+		}{
+			{rule: "department=legal", match: true},
+			{rule: "department=legal AND country=FR", match: true},
+			{rule: "department=legal and country=BE", match: false},
+			{rule: "country=BE OR country=FR", match: true},
+			{rule: "department=sales OR department=legal AND country=FR", match: true},
+			{rule: "(department=sales OR department=legal) AND country=BE", match: false},
+			{rule: "NOT country=FR", match: false},
+			{rule: "country!=BE", match: true},
+			{rule: "missing!=value", match: true},
+			{rule: "missing=value", match: false},
+			{rule: "title='head of legal'", match: true},
+			{rule: "title=\"Head of legal\" AND login=jdoe", match: true},
+			{rule: "groupPath=/paris", match: true},
+			{rule: "pydio:department=legal", match: true},
+			{rule: "team=legal", match: false},
+		} {
+			r, e := ParseDynamicRule(c.rule)
+			So(e, ShouldBeNil)
+			So(r.Matches(u), ShouldEqual, c.match)
+		}
+	})
+
+	Convey("Test invalid dynamic rules", t, func() {
+		for _, rule := range []string{
+			"",
+			"department",
+			"department=",
+			"department=legal AND",
+			"(department=legal",
+			"department=legal)",
+			"department=legal country=FR",
+			"title='head of legal",
+			"department!legal",
+			"=legal",
+		} {
+			_, e := ParseDynamicRule(rule)
+			So(e, ShouldNotBeNil)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/idm/user"
+)
+
+var (
+	dynamicGroupsCache = cache.New(10*time.Second, 20*time.Second)
+	// dynamicGroupsLock guards the generation, which is increased on each reset so that
+	// a load running concurrently does not store rules that are already outdated
+	dynamicGroupsLock       sync.Mutex
+	dynamicGroupsGeneration int
+)
+
+// dynamicGroup associates a group to its parsed membership rule.
+type dynamicGroup struct {
+	group *idm.User
+	rule  *user.DynamicRule
+}
+
+// DynamicGroupsRefresher listens to users and groups events to keep dynamic groups memberships up-to-date.
+type DynamicGroupsRefresher struct{}
+
+// Handle clears the cached rules when a group is modified, and the cached user when a user is modified,
+// so that memberships are computed again on next load.
+func (d *DynamicGroupsRefresher) Handle(ctx context.Context, msg *idm.ChangeEvent) error {
+	if msg.User == nil {
+		return nil
+	}
+	if msg.User.IsGroup {
+		clearDynamicGroupsCache()
+	} else if msg.Type == idm.ChangeEventType_UPDATE || msg.Type == idm.ChangeEventType_DELETE {
+		permissions.ForceClearUserCache(msg.User.Login)
+	}
+	return nil
+}
+
+func clearDynamicGroupsCache() {
+	dynamicGroupsLock.Lock()
+	defer dynamicGroupsLock.Unlock()
+	dynamicGroupsGeneration++
+	dynamicGroupsCache.Flush()
+}
+
+// applyDynamicGroups inserts the roles of the dynamic groups whose rule is matched by this user.
+// They are placed after the structural group roles and before all other roles, so that they
+// behave like static groups and never override roles directly attached to the user.
+func (h *Handler) applyDynamicGroups(usr *idm.User, groups []*dynamicGroup) {
+	if usr.IsGroup || usr.Login == common.PYDIO_S3ANON_USERNAME || len(groups) == 0 {
+		return
+	}
+	existing := make(map[string]bool, len(usr.Roles))
+	for _, r := range usr.Roles {
+		existing[r.Uuid] = true
+	}
+	var dynamic []*idm.Role
+	for _, g := range groups {
+		if existing[g.group.Uuid] || !g.rule.Matches(usr) {
+			continue
+		}
+		dynamic = append(dynamic, &idm.Role{Uuid: g.group.Uuid, GroupRole: true})
+	}
+	if len(dynamic) == 0 {
+		return
+	}
+	idx := 0
+	for i, r := range usr.Roles {
+		if r.GroupRole {
+			idx = i + 1
+		}
+	}
+	roles := make([]*idm.Role, 0, len(usr.Roles)+len(dynamic))
+	roles = append(roles, usr.Roles[:idx]...)
+	roles = append(roles, dynamic...)
+	roles = append(roles, usr.Roles[idx:]...)
+	usr.Roles = roles
+}
+
+// loadDynamicGroups finds all groups carrying a dynamicRule attribute and parses their rules.
+func (h *Handler) loadDynamicGroups(ctx context.Context, dao user.DAO) (groups []*dynamicGroup, err error) {
+
+	dynamicGroupsLock.Lock()
+	generation := dynamicGroupsGeneration
+	values, ok := dynamicGroupsCache.Get("dynamicGroups")
+	dynamicGroupsLock.Unlock()
+	if ok {
+		var conv bool
+		if groups, conv = values.([]*dynamicGroup); conv {
+			return
+		}
+	}
+
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{
+		NodeType:          idm.NodeType_GROUP,
+		AttributeName:     idm.UserAttrDynamicRule,
+		AttributeAnyValue: true,
+	})
+	results := new([]interface{})
+	if err = dao.Search(&service.Query{SubQueries: []*any.Any{q}}, results); err != nil {
+		return
+	}
+	for _, in := range *results {
+		g, ok := in.(*idm.User)
+		if !ok || !g.IsGroup {
+			continue
+		}
+		rule, e := user.ParseDynamicRule(g.Attributes[idm.UserAttrDynamicRule])
+		if e != nil {
+			log.Logger(ctx).Error("ignoring invalid dynamic rule on group "+g.GroupPath, g.ZapUuid(), zap.Error(e))
+			continue
+		}
+		groups = append(groups, &dynamicGroup{group: g, rule: rule})
+	}
+
+	dynamicGroupsLock.Lock()
+	if generation == dynamicGroupsGeneration {
+		dynamicGroupsCache.Set("dynamicGroups", groups, 0)
+	}
+	dynamicGroupsLock.Unlock()
+
+	return
+}
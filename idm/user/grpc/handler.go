@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/micro/go-micro/client"
@@ -38,6 +39,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth"
 	"github.com/pydio/cells/common/log"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/proto/jobs"
@@ -57,6 +59,8 @@ var (
 		{Subject: "profile:admin", Action: service.ResourcePolicyAction_WRITE, Effect: service.ResourcePolicy_allow},
 	}
 	autoAppliesCache *cache.Cache
+	// loadPasswordPolicy reads the password policy, it can be replaced in tests
+	loadPasswordPolicy = auth.LoadPasswordPolicy
 )
 
 // ByAge implements sort.Interface for []Person based on
@@ -81,6 +85,7 @@ func (h *Handler) BindUser(ctx context.Context, req *idm.BindUserRequest, resp *
 	if err != nil {
 		return err
 	}
+	h.checkPasswordAge(ctx, dao, user)
 	resp.User = user
 	resp.User.Password = ""
 
@@ -92,6 +97,70 @@ func (h *Handler) BindUser(ctx context.Context, req *idm.BindUserRequest, resp *
 	return nil
 }
 
+// checkPasswordAge starts tracking the password age of users that have no history yet, and adds
+// a pass_change lock to users whose password has expired, forcing a change at login.
+func (h *Handler) checkPasswordAge(ctx context.Context, dao user.DAO, u *idm.User) {
+	changed, err := dao.PasswordChangedAt(u.Uuid)
+	if err != nil {
+		log.Logger(ctx).Error("cannot read password history", u.ZapUuid(), zap.Error(err))
+		return
+	}
+	if changed.IsZero() {
+		if err := dao.AddPasswordHistory(u.Uuid, u.Password); err != nil {
+			log.Logger(ctx).Error("cannot store password history", u.ZapUuid(), zap.Error(err))
+		}
+		return
+	}
+	if !loadPasswordPolicy().Expired(changed, time.Now()) {
+		return
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string]string)
+	}
+	var locks []string
+	if l, ok := u.Attributes["locks"]; ok {
+		json.Unmarshal([]byte(l), &locks)
+	}
+	for _, lock := range locks {
+		if lock == "pass_change" {
+			return
+		}
+	}
+	locks = append(locks, "pass_change")
+	marsh, _ := json.Marshal(locks)
+	u.Attributes["locks"] = string(marsh)
+	update := proto.Clone(u).(*idm.User)
+	update.Password = ""
+	if _, _, e := dao.Add(update); e != nil {
+		log.Logger(ctx).Error("cannot force password change", u.ZapUuid(), zap.Error(e))
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Password of user [%s] has expired, a change is required", u.Login),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		u.ZapUuid(),
+	)
+}
+
+// existingUserUuid finds the uuid of the user being updated, whether the request identifies it
+// by uuid or by login only. It returns an empty string for a new user.
+func (h *Handler) existingUserUuid(dao user.DAO, u *idm.User) (string, error) {
+	if u.Uuid != "" || u.Login == "" {
+		return u.Uuid, nil
+	}
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: u.Login, NodeType: idm.NodeType_USER})
+	results := new([]interface{})
+	if e := dao.Search(&service.Query{SubQueries: []*any.Any{q}}, results); e != nil {
+		return "", e
+	}
+	for _, in := range *results {
+		if existing, ok := in.(*idm.User); ok && existing.Login == u.Login {
+			return existing.Uuid, nil
+		}
+	}
+	return "", nil
+}
+
 // CreateUser adds or creates a user or a group in the underlying database.
 func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, resp *idm.CreateUserResponse) error {
 
@@ -100,7 +169,31 @@ func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, re
 	}
 	dao := servicecontext.GetDAO(ctx).(user.DAO)
 
+	if rule, ok := req.User.Attributes[idm.UserAttrDynamicRule]; ok && req.User.IsGroup && rule != "" {
+		if _, e := user.ParseDynamicRule(rule); e != nil {
+			return errors.BadRequest(common.SERVICE_USER, "%s", e.Error())
+		}
+	}
+
 	passChange := req.User.Password
+	if passChange != "" && !req.User.IsGroup && req.User.Attributes[idm.UserAttrPassHashed] != "true" {
+		// Apply password policy, unless password is imported already hashed
+		policy := loadPasswordPolicy()
+		if e := policy.Validate(req.User.Login, passChange); e != nil {
+			return errors.BadRequest(common.SERVICE_USER, "%s", e.Error())
+		}
+		if policy.HistorySize > 0 {
+			if uuid, e := h.existingUserUuid(dao, req.User); e != nil {
+				log.Logger(ctx).Error("cannot check password history", req.User.ZapLogin(), zap.Error(e))
+			} else if uuid != "" {
+				if reused, e := dao.PasswordReused(uuid, passChange, policy.HistorySize); e != nil {
+					log.Logger(ctx).Error("cannot check password history", req.User.ZapLogin(), zap.Error(e))
+				} else if reused {
+					return errors.BadRequest(common.SERVICE_USER, "this password was used recently, please choose another one")
+				}
+			}
+		}
+	}
 	// Create or update user
 	newUser, createdNodes, err := dao.Add(req.User)
 	if err != nil {
@@ -125,6 +218,7 @@ func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, re
 			}
 			marsh, _ := json.Marshal(newLocks)
 			out.Attributes["locks"] = string(marsh)
+			out.Password = "" // Already stored, do not hash it again
 			if _, _, e := dao.Add(out); e == nil {
 				log.Logger(ctx).Info("user "+req.User.Login+" successfully updated his password", req.User.ZapUuid())
 			}
@@ -132,6 +226,9 @@ func (h *Handler) CreateUser(ctx context.Context, req *idm.CreateUserRequest, re
 	}
 	out.Password = ""
 	resp.User = out
+	if out.IsGroup {
+		clearDynamicGroupsCache()
+	}
 	if len(req.User.Policies) == 0 {
 		var userPolicies []*service.ResourcePolicy
 		userPolicies = append(userPolicies, defaultPolicies...)
@@ -322,6 +419,10 @@ func (h *Handler) SearchUser(ctx context.Context, request *idm.SearchUserRequest
 	if er != nil {
 		return er
 	}
+	dynamicGroups, er := h.loadDynamicGroups(ctx, dao)
+	if er != nil {
+		return er
+	}
 
 	usersGroups := new([]interface{})
 	if err := dao.Search(request.Query, usersGroups); err != nil {
@@ -336,6 +437,7 @@ func (h *Handler) SearchUser(ctx context.Context, request *idm.SearchUserRequest
 				log.Logger(ctx).Error("cannot load policies for user "+usr.Uuid, zap.Error(e))
 				continue
 			}
+			h.applyDynamicGroups(usr, dynamicGroups)
 			h.applyAutoApplies(usr, autoApplies)
 			response.Send(&idm.SearchUserResponse{User: usr})
 		} else {
@@ -376,6 +478,10 @@ func (h *Handler) StreamUser(ctx context.Context, streamer idm.UserService_Strea
 	if e != nil {
 		return e
 	}
+	dynamicGroups, e := h.loadDynamicGroups(ctx, dao)
+	if e != nil {
+		return e
+	}
 
 	for {
 		incoming, err := streamer.Recv()
@@ -391,6 +497,7 @@ func (h *Handler) StreamUser(ctx context.Context, streamer idm.UserService_Strea
 		for _, in := range *users {
 			if usr, ok := in.(*idm.User); ok {
 				usr.Password = ""
+				h.applyDynamicGroups(usr, dynamicGroups)
 				h.applyAutoApplies(usr, autoApplies)
 				streamer.Send(&idm.SearchUserResponse{User: usr})
 			}
@@ -31,6 +31,7 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	cache "github.com/patrickmn/go-cache"
 
+	"github.com/pydio/cells/common/auth"
 	"github.com/pydio/cells/common/config"
 	"github.com/pydio/cells/common/proto/idm"
 	"github.com/pydio/cells/common/service/context"
@@ -59,6 +60,8 @@ func TestMain(m *testing.M) {
 	autoAppliesCache.Set("autoApplies", map[string][]*idm.Role{
 		"autoApplyProfile": {{Uuid: "auto-apply", AutoApplies: []string{"autoApplyProfile"}}},
 	}, 0)
+	// Do not wait for the configuration to read the password policy
+	loadPasswordPolicy = auth.DefaultPasswordPolicy
 
 	sqlDao := sql.NewDAO("sqlite3", "file::memory:?mode=memory&cache=shared", "idm_user")
 	if sqlDao == nil {
@@ -237,6 +240,25 @@ func TestUser(t *testing.T) {
 		So(bindResp.User, ShouldNotBeNil)
 	})
 
+	Convey("Password policy is applied on create", t, func() {
+		loadPasswordPolicy = func() *auth.PasswordPolicy {
+			p := auth.DefaultPasswordPolicy()
+			p.MinLength = 8
+			return p
+		}
+		defer func() {
+			loadPasswordPolicy = auth.DefaultPasswordPolicy
+		}()
+
+		resp := new(idm.CreateUserResponse)
+		err := h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "shortpass", Password: "f00"}}, resp)
+		So(err, ShouldNotBeNil)
+
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "shortpass", Password: "f00b4rb4z"}}, resp)
+		So(err, ShouldBeNil)
+		So(resp.GetUser().GetLogin(), ShouldEqual, "shortpass")
+	})
+
 	Convey("Test password change lock", t, func() {
 
 		resp := new(idm.CreateUserResponse)
@@ -271,6 +293,83 @@ func TestUser(t *testing.T) {
 
 	})
 
+	Convey("Test dynamic groups", t, func() {
+
+		resp := new(idm.CreateUserResponse)
+		err := h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{
+			IsGroup:    true,
+			GroupPath:  "/dyn-invalid",
+			Attributes: map[string]string{idm.UserAttrDynamicRule: "department=legal AND"},
+		}}, resp)
+		So(err, ShouldNotBeNil)
+
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{
+			IsGroup:    true,
+			GroupPath:  "/dyn-legal",
+			Attributes: map[string]string{idm.UserAttrDynamicRule: "department=legal AND country=FR"},
+		}}, resp)
+		So(err, ShouldBeNil)
+		groupUuid := resp.User.Uuid
+
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "legal-fr", Attributes: map[string]string{"pydio:department": "legal", "pydio:country": "FR"}}}, resp)
+		So(err, ShouldBeNil)
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "legal-be", Attributes: map[string]string{"pydio:department": "legal", "pydio:country": "BE"}}}, resp)
+		So(err, ShouldBeNil)
+
+		rolesFor := func(login string) []*idm.Role {
+			mock := &userStreamMock{}
+			userQueryAny, _ := ptypes.MarshalAny(&idm.UserSingleQuery{Login: login})
+			err := h.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{userQueryAny}}}, mock)
+			So(err, ShouldBeNil)
+			So(mock.InternalBuffer, ShouldHaveLength, 1)
+			return mock.InternalBuffer[0].Roles
+		}
+		hasRole := func(roles []*idm.Role, uuid string) bool {
+			for _, r := range roles {
+				if r.Uuid == uuid {
+					return true
+				}
+			}
+			return false
+		}
+
+		roles := rolesFor("legal-fr")
+		So(roles, ShouldHaveLength, 3)
+		So(roles[0].Uuid, ShouldEqual, "ROOT_GROUP")
+		// Dynamic group role is inserted after static groups, before the user role
+		So(roles[1].Uuid, ShouldEqual, groupUuid)
+		So(roles[1].GroupRole, ShouldBeTrue)
+		So(roles[2].UserRole, ShouldBeTrue)
+		So(hasRole(rolesFor("legal-be"), groupUuid), ShouldBeFalse)
+
+		// Membership is refreshed when the user changes
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "legal-be", Attributes: map[string]string{"pydio:department": "legal", "pydio:country": "FR"}}}, resp)
+		So(err, ShouldBeNil)
+		So(hasRole(rolesFor("legal-be"), groupUuid), ShouldBeTrue)
+
+		// Public attributes edited by the user on himself do not grant membership
+		err = h.CreateUser(ctx, &idm.CreateUserRequest{User: &idm.User{Login: "self-edit", Attributes: map[string]string{"department": "legal", "country": "FR"}}}, resp)
+		So(err, ShouldBeNil)
+		So(hasRole(rolesFor("self-edit"), groupUuid), ShouldBeFalse)
+
+		// Rules can be reset while users are loaded
+		var cwg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			cwg.Add(2)
+			go func() {
+				defer cwg.Done()
+				h.loadDynamicGroups(ctx, servicecontext.GetDAO(ctx).(user.DAO))
+			}()
+			go func() {
+				defer cwg.Done()
+				clearDynamicGroupsCache()
+			}()
+		}
+		cwg.Wait()
+		So(hasRole(rolesFor("legal-fr"), groupUuid), ShouldBeTrue)
+
+	})
+
 }
 
 // =================================================
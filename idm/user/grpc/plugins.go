@@ -77,6 +77,10 @@ func init() {
 				if err := m.Options().Server.Subscribe(m.Options().Server.NewSubscriber(common.TOPIC_IDM_EVENT, cleaner)); err != nil {
 					return err
 				}
+				// Refresh dynamic groups memberships on users and groups changes
+				if err := m.Options().Server.Subscribe(m.Options().Server.NewSubscriber(common.TOPIC_IDM_EVENT, &DynamicGroupsRefresher{})); err != nil {
+					return err
+				}
 
 				return nil
 			}),
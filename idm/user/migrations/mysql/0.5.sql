@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS idm_user_pwd_history (
+    uuid       VARCHAR(128) CHARACTER SET ASCII NOT NULL,
+    hash       VARCHAR(255) NOT NULL,
+    stamp      INT(11) NOT NULL,
+
+    PRIMARY KEY (uuid, stamp, hash),
+    FOREIGN KEY (uuid) REFERENCES idm_user_idx_tree(uuid) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE idm_user_pwd_history;
@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS idm_user_pwd_history (
+    uuid       VARCHAR(128) NOT NULL,
+    hash       VARCHAR(255) NOT NULL,
+    stamp      INTEGER NOT NULL,
+
+    PRIMARY KEY (uuid, stamp, hash)
+);
+
+-- +migrate Down
+DROP TABLE idm_user_pwd_history;
@@ -396,10 +396,10 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 				existingAcls = permissions.GetACLsForRoles(ctx, []*idm.Role{r}, &idm.ACLAction{Name: "parameter:*"})
 			}
 		}
-		// Put back the pydio: attributes
+		// Put back the pydio: attributes that were not sent
 		if update.Attributes != nil {
 			for k, v := range update.Attributes {
-				if strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
+				if _, sent := inputUser.Attributes[k]; !sent && strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
 					if inputUser.Attributes == nil {
 						inputUser.Attributes = map[string]string{}
 					}
@@ -409,6 +409,19 @@ func (s *UserHandler) PutUser(req *restful.Request, rsp *restful.Response) {
 		}
 	}
 
+	// Private attributes are matched by dynamic groups rules and policies: only admins can modify them
+	if ctxClaims.Profile != common.PYDIO_PROFILE_ADMIN {
+		for k, v := range inputUser.Attributes {
+			if !strings.HasPrefix(k, idm.UserAttrPrivatePrefix) {
+				continue
+			}
+			if update == nil || update.Attributes == nil || update.Attributes[k] != v {
+				service.RestError403(req, rsp, fmt.Errorf("you are not allowed to modify attribute %s", k))
+				return
+			}
+		}
+	}
+
 	// Check specific frontend USER_CREATE_USERS permission
 	var isHidden bool
 	if h, o := inputUser.Attributes["hidden"]; o && h == "true" {
@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/auth/totp"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/rest"
+	"github.com/pydio/cells/common/service"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+// TotpEnroll generates a pending second factor for the current user. It must be activated with TotpVerify.
+func (s *UserHandler) TotpEnroll(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	user, err := s.totpCurrentUser(req)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	existing, err := totp.Load(user.Uuid)
+	if err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	if existing != nil && existing.Active {
+		service.RestError403(req, rsp, errors.Forbidden(common.SERVICE_USER, "A second factor is already active, reset it first"))
+		return
+	}
+	enrollment, codes, err := totp.NewEnrollment()
+	if err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	if err := totp.Save(user.Uuid, enrollment); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] started a second factor enrollment", user.Login),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		user.ZapUuid(),
+	)
+
+	rsp.WriteEntity(&rest.TotpEnrollResponse{
+		Secret:        enrollment.Secret,
+		KeyURI:        totp.KeyURI(totp.Issuer(), user.Login, enrollment.Secret),
+		RecoveryCodes: codes,
+	})
+}
+
+// TotpVerify activates the pending second factor of the current user if the code is valid.
+func (s *UserHandler) TotpVerify(req *restful.Request, rsp *restful.Response) {
+
+	var input rest.TotpVerifyRequest
+	if err := req.ReadEntity(&input); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	ctx := req.Request.Context()
+	user, err := s.totpCurrentUser(req)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	enrollment, err := totp.Load(user.Uuid)
+	if err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	if enrollment == nil {
+		service.RestError404(req, rsp, errors.NotFound(common.SERVICE_USER, "No second factor enrollment was started"))
+		return
+	}
+	if enrollment.Active {
+		service.RestError403(req, rsp, errors.Forbidden(common.SERVICE_USER, "Second factor is already active"))
+		return
+	}
+	if _, ok, err := totp.Verify(user.Uuid, input.Code, time.Now(), true); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	} else if !ok {
+		service.RestError403(req, rsp, errors.Forbidden(common.SERVICE_USER, "Invalid verification code"))
+		return
+	}
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("User [%s] activated a second factor", user.Login),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		user.ZapUuid(),
+	)
+
+	rsp.WriteEntity(&rest.TotpVerifyResponse{Success: true})
+}
+
+// TotpReset removes a second factor. Users must provide a valid code to remove their own,
+// admins can remove the second factor of any user, e.g. when a device is lost.
+func (s *UserHandler) TotpReset(req *restful.Request, rsp *restful.Response) {
+
+	var input rest.TotpResetRequest
+	if err := req.ReadEntity(&input); err != nil {
+		service.RestError500(req, rsp, err)
+		return
+	}
+	ctx := req.Request.Context()
+	ctxLogin, ctxClaims := permissions.FindUserNameInContext(ctx)
+
+	var user *idm.User
+	var err error
+	if input.Login == "" || input.Login == ctxLogin {
+		if user, err = s.totpCurrentUser(req); err != nil {
+			service.RestErrorDetect(req, rsp, err)
+			return
+		}
+		enrollment, err := totp.Load(user.Uuid)
+		if err != nil {
+			service.RestError500(req, rsp, err)
+			return
+		}
+		if enrollment != nil && enrollment.Active {
+			if _, ok, err := totp.Verify(user.Uuid, input.Code, time.Now(), false); err != nil {
+				service.RestError500(req, rsp, err)
+				return
+			} else if !ok {
+				service.RestError403(req, rsp, errors.Forbidden(common.SERVICE_USER, "Invalid verification code"))
+				return
+			}
+		}
+	} else {
+		if ctxClaims.Profile != common.PYDIO_PROFILE_ADMIN {
+			service.RestError403(req, rsp, errors.Forbidden(common.SERVICE_USER, "Only admins can reset the second factor of other users"))
+			return
+		}
+		if user, err = permissions.SearchUniqueUser(ctx, input.Login, ""); err != nil || user == nil {
+			service.RestError404(req, rsp, errors.NotFound(common.SERVICE_USER, "Cannot find user %s", input.Login))
+			return
+		}
+	}
+
+	totp.Delete(user.Uuid)
+	log.Auditer(ctx).Info(
+		fmt.Sprintf("Second factor of user [%s] was reset by [%s]", user.Login, ctxLogin),
+		log.GetAuditId(common.AUDIT_USER_UPDATE),
+		user.ZapUuid(),
+	)
+
+	rsp.WriteEntity(&rest.TotpResetResponse{Success: true})
+}
+
+// totpCurrentUser loads the user of the request, who must belong to the internal directory.
+func (s *UserHandler) totpCurrentUser(req *restful.Request) (*idm.User, error) {
+	ctx := req.Request.Context()
+	login, _ := permissions.FindUserNameInContext(ctx)
+	if login == "" {
+		return nil, errors.Unauthorized(common.SERVICE_USER, "Cannot find current user")
+	}
+	user, err := permissions.SearchUniqueUser(ctx, login, "")
+	if err != nil || user == nil {
+		return nil, errors.NotFound(common.SERVICE_USER, "Cannot find current user")
+	}
+	if !totp.IsLocalUser(user) {
+		return nil, errors.Forbidden(common.SERVICE_USER, "Second factor is only available for users of the internal directory")
+	}
+	return user, nil
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/golang/protobuf/ptypes"
@@ -62,7 +63,11 @@ var (
 		"GetRoles":         `select role from idm_user_roles where uuid = ?`,
 		"DeleteUserRoles":  `delete from idm_user_roles where uuid = ?`,
 		//"DeleteUserRolesClean": `delete from idm_user_roles where uuid not in (select uuid from idm_user_idx_tree)`,
-		"DeleteRoleById": `delete from idm_user_roles where role = ?`,
+		"DeleteRoleById":       `delete from idm_user_roles where role = ?`,
+		"AddPasswordHash":      `insert into idm_user_pwd_history (uuid, hash, stamp) values (?, ?, ?)`,
+		"GetPasswordHashes":    `select hash, stamp from idm_user_pwd_history where uuid = ? order by stamp desc`,
+		"DeletePasswordHash":   `delete from idm_user_pwd_history where uuid = ? and hash = ?`,
+		"DeletePasswordHashes": `delete from idm_user_pwd_history where uuid = ?`,
 		//"DeleteAttsClean":      `delete from idm_user_attributes where uuid not in (select uuid from idm_user_idx_tree)`,
 	}
 
@@ -253,6 +258,11 @@ func (s *sqlimpl) Add(in interface{}) (interface{}, []*tree.Node, error) {
 		foundOrCreatedNode, _ := s.IndexSQL.GetNode(mPath)
 		user.Uuid = foundOrCreatedNode.Uuid
 	}
+	if node.Etag != "" {
+		if err := s.AddPasswordHistory(user.Uuid, node.Etag); err != nil {
+			log.Logger(context.Background()).Error("cannot store password history", zap.Error(err))
+		}
+	}
 
 	// Remove existing attributes and roles, replace with new ones using a transaction
 	if user.GroupLabel != "" {
@@ -671,10 +681,97 @@ func (s *sqlimpl) deleteNodeData(uuid string) error {
 	if _, err := stRoles.Exec(uuid); err != nil {
 		return err
 	}
+	if stHist, er := s.GetStmt("DeletePasswordHashes"); er == nil {
+		if _, err := stHist.Exec(uuid); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+type passwordHash struct {
+	hash  string
+	stamp int64
+}
+
+func (s *sqlimpl) passwordHashes(uuid string) ([]passwordHash, error) {
+	stmt, er := s.GetStmt("GetPasswordHashes")
+	if er != nil {
+		return nil, er
+	}
+	res, er := stmt.Query(uuid)
+	if er != nil {
+		return nil, er
+	}
+	defer res.Close()
+	var hashes []passwordHash
+	for res.Next() {
+		var h passwordHash
+		if e := res.Scan(&h.hash, &h.stamp); e != nil {
+			return nil, e
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, nil
+}
+
+// AddPasswordHistory records a password hash for the user, and only keeps the auth.MaxPasswordHistory latest ones.
+func (s *sqlimpl) AddPasswordHistory(uuid string, hash string) error {
+	add, er := s.GetStmt("AddPasswordHash")
+	if er != nil {
+		return er
+	}
+	if _, er := add.Exec(uuid, hash, time.Now().Unix()); er != nil {
+		return er
+	}
+	hashes, er := s.passwordHashes(uuid)
+	if er != nil || len(hashes) <= auth.MaxPasswordHistory {
+		return er
+	}
+	del, er := s.GetStmt("DeletePasswordHash")
+	if er != nil {
+		return er
+	}
+	for _, h := range hashes[auth.MaxPasswordHistory:] {
+		if _, er := del.Exec(uuid, h.hash); er != nil {
+			return er
+		}
+	}
+	return nil
+}
+
+// PasswordReused checks if the clear password matches the current password of the user or one of the
+// depth previous ones.
+func (s *sqlimpl) PasswordReused(uuid string, password string, depth int) (bool, error) {
+	hashes, er := s.passwordHashes(uuid)
+	if er != nil {
+		return false, er
+	}
+	if len(hashes) > depth {
+		hashes = hashes[:depth]
+	}
+	if node, e := s.IndexSQL.GetNodeByUUID(uuid); e == nil && node != nil && node.Etag != "" {
+		hashes = append(hashes, passwordHash{hash: node.Etag})
+	}
+	for _, h := range hashes {
+		if valid, _ := hasher.CheckDBKDF2PydioPwd(password, h.hash); valid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PasswordChangedAt returns the last time the password of the user was changed, or a zero time
+// if it was never recorded.
+func (s *sqlimpl) PasswordChangedAt(uuid string) (time.Time, error) {
+	hashes, er := s.passwordHashes(uuid)
+	if er != nil || len(hashes) == 0 {
+		return time.Time{}, er
+	}
+	return time.Unix(hashes[0].stamp, 0), nil
+}
+
 func (s *sqlimpl) rebuildGroupPath(node *mtree.TreeNode) {
 	if len(node.Path) == 0 {
 		var path []string
@@ -66,6 +66,7 @@ import (
 	_ "github.com/pydio/cells/gateway/grpc"
 	_ "github.com/pydio/cells/gateway/micro"
 	_ "github.com/pydio/cells/gateway/proxy"
+	_ "github.com/pydio/cells/gateway/scim"
 	_ "github.com/pydio/cells/gateway/websocket/api"
 	_ "github.com/pydio/cells/gateway/wopi"
 
@@ -97,17 +98,22 @@ import (
 
 	// All Actions for scheduler
 	_ "github.com/pydio/cells/broker/activity/actions"
+	_ "github.com/pydio/cells/broker/mailer/actions"
 	_ "github.com/pydio/cells/scheduler/actions/archive"
 	_ "github.com/pydio/cells/scheduler/actions/changes"
 	_ "github.com/pydio/cells/scheduler/actions/cmd"
 	_ "github.com/pydio/cells/scheduler/actions/idm"
 	_ "github.com/pydio/cells/scheduler/actions/images"
+	_ "github.com/pydio/cells/scheduler/actions/reports"
+	_ "github.com/pydio/cells/scheduler/actions/scanner"
 	_ "github.com/pydio/cells/scheduler/actions/scheduler"
+	_ "github.com/pydio/cells/scheduler/actions/transfer"
 	_ "github.com/pydio/cells/scheduler/actions/tree"
 
 	// ETL Actions and stores
 	_ "github.com/pydio/cells/common/etl/actions"
 	_ "github.com/pydio/cells/common/etl/stores/cells/local"
+	_ "github.com/pydio/cells/common/etl/stores/ldap"
 	_ "github.com/pydio/cells/common/etl/stores/pydio8"
 
 	"github.com/pydio/cells/common"
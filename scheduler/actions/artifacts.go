@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	servicecontext "github.com/pydio/cells/common/service/context"
+	context2 "github.com/pydio/cells/common/utils/context"
+)
+
+const (
+	// TaskLogFlushSize is the buffer size after which a TaskLogWriter sends its content to the jobs service.
+	TaskLogFlushSize = 16 * 1024
+)
+
+// TaskIdentifiers finds the current job and task identifiers inside the context passed to actions.
+func TaskIdentifiers(ctx context.Context) (jobId string, taskId string, ok bool) {
+	jobId, ok1 := context2.CanonicalMeta(ctx, servicecontext.ContextMetaJobUuid)
+	taskId, ok2 := context2.CanonicalMeta(ctx, servicecontext.ContextMetaTaskUuid)
+	return jobId, taskId, ok1 && ok2 && jobId != "" && taskId != ""
+}
+
+// TaskLogWriter is an io.WriteCloser buffering data and appending it to the current task log stream.
+// It can be used to pipe a command output or a long-running process progress.
+type TaskLogWriter struct {
+	sync.Mutex
+	ctx    context.Context
+	client jobs.JobServiceClient
+	jobId  string
+	taskId string
+	buffer *bytes.Buffer
+}
+
+// NewTaskLogWriter creates a TaskLogWriter for the task found in context. If no task is found, data is discarded.
+func NewTaskLogWriter(ctx context.Context, cl client.Client) io.WriteCloser {
+	jobId, taskId, ok := TaskIdentifiers(ctx)
+	if !ok {
+		return nopWriteCloser{Writer: ioutil.Discard}
+	}
+	return &TaskLogWriter{
+		ctx:    ctx,
+		client: jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, cl),
+		jobId:  jobId,
+		taskId: taskId,
+		buffer: &bytes.Buffer{},
+	}
+}
+
+// Write buffers data and flushes it once TaskLogFlushSize is reached.
+func (w *TaskLogWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	n, _ := w.buffer.Write(p)
+	if w.buffer.Len() >= TaskLogFlushSize {
+		if e := w.flush(); e != nil {
+			return n, e
+		}
+	}
+	return n, nil
+}
+
+// Flush sends buffered data to the jobs service.
+func (w *TaskLogWriter) Flush() error {
+	w.Lock()
+	defer w.Unlock()
+	return w.flush()
+}
+
+// Close flushes remaining data.
+func (w *TaskLogWriter) Close() error {
+	return w.Flush()
+}
+
+func (w *TaskLogWriter) flush() error {
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	data := make([]byte, w.buffer.Len())
+	copy(data, w.buffer.Bytes())
+	w.buffer.Reset()
+	_, e := w.client.AppendTaskLog(w.ctx, &jobs.AppendTaskLogRequest{JobID: w.jobId, TaskID: w.taskId, Data: data})
+	return e
+}
+
+// PutTaskArtifact stores the content of reader as a named artifact of the task found in context.
+func PutTaskArtifact(ctx context.Context, cl client.Client, name string, reader io.Reader) (*jobs.TaskArtifact, error) {
+	jobId, taskId, ok := TaskIdentifiers(ctx)
+	if !ok {
+		return nil, fmt.Errorf("cannot find task identifiers in context")
+	}
+	data, e := ioutil.ReadAll(reader)
+	if e != nil {
+		return nil, e
+	}
+	resp, e := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, cl).PutTaskArtifact(ctx, &jobs.PutTaskArtifactRequest{
+		Artifact: &jobs.TaskArtifact{JobID: jobId, TaskID: taskId, Name: name},
+		Data:     data,
+	})
+	if e != nil {
+		return nil, e
+	}
+	return resp.Artifact, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -21,6 +21,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -109,6 +110,7 @@ func (c *ShellAction) GetName() string {
 
 // Pass parameters
 func (c *ShellAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.Client = cl
 	c.TemporaryFolder = os.TempDir()
 	c.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
 
@@ -246,13 +248,21 @@ func (c *ShellAction) Run(ctx context.Context, channels *actions.RunnableChannel
 		command.Stdin = stdIn
 	}
 
+	// Stream command output to the task log
+	logWriter := actions.NewTaskLogWriter(ctx, c.Client)
+	defer logWriter.Close()
+	command.Stderr = logWriter
+	outBuffer := &bytes.Buffer{}
 	if stdOut != nil {
 		command.Stdout = stdOut
+	} else {
+		command.Stdout = io.MultiWriter(outBuffer, logWriter)
 	}
 	var exitStatus int
 	var cmdError *exec.ExitError
 
-	out, e := command.Output()
+	e := command.Run()
+	out := outBuffer.Bytes()
 	if e != nil {
 
 		if c.ExitOnError {
@@ -113,6 +113,12 @@ type RecursiveNodeWalkerAction interface {
 	SetNodeFilterAsWalkFilter(*jobs.NodesSelector)
 }
 
+// Actions that implement this interface can be run in simulation mode, to compute their
+// output from a sample input without any side effects.
+type SimulatableAction interface {
+	Simulate(ctx context.Context, input jobs.ActionMessage) (jobs.ActionMessage, error)
+}
+
 // RunnableChannels defines the API to communicate with a Runnable via Channels
 type RunnableChannels struct {
 	// Input Channels
@@ -0,0 +1,573 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/mailer"
+	"github.com/pydio/cells/common/proto/tree"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/utils/permissions"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	usageReportActionName = "actions.reports.usage"
+
+	// UsageReportsDocStore stores the sizes of the last report of each job, to compute growth
+	UsageReportsDocStore = "usage_reports"
+
+	usageDefaultLargest = 10
+	usageMailMaxLines   = 20
+)
+
+// UsageReportAction computes storage usage per workspace and per user, and writes
+// the report as a CSV or JSON file.
+type UsageReportAction struct {
+	TreeClient   tree.NodeProviderClient
+	Router       views.Handler
+	MailerClient mailer.MailerServiceClient
+
+	targetFolder string
+	formats      []string
+	largest      int
+	perUser      bool
+	emailTo      []string
+	jobId        string
+	cl           client.Client
+}
+
+func (c *UsageReportAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                usageReportActionName,
+		Label:             "Usage Report",
+		Icon:              "chart-pie",
+		Category:          actions.ActionCategoryScheduler,
+		Description:       "Compute storage used, files count, largest files, growth since last report and shares count for each workspace and each user personal folder. The report is stored as a task artifact, and optionally written in a folder and sent by email.",
+		SummaryTemplate:   "",
+		HasForm:           true,
+		OutputDescription: "Summary of the report as Json output",
+	}
+}
+
+func (c *UsageReportAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "targetFolder",
+					Type:        "string",
+					Label:       "Target Folder",
+					Description: "Folder where the report file is written (full path starting with the datasource name). Leave empty to only store it with the task.",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "format",
+					Type:        "select",
+					Label:       "Format",
+					Description: "Format of the report file",
+					Default:     "csv",
+					Mandatory:   true,
+					Editable:    true,
+					ChoicePresetList: []map[string]string{
+						{"csv": "CSV"},
+						{"json": "JSON"},
+						{"both": "CSV and JSON"},
+					},
+				},
+				&forms.FormField{
+					Name:        "largestFiles",
+					Type:        "integer",
+					Label:       "Largest Files",
+					Description: "Number of largest files listed for each workspace and user",
+					Default:     usageDefaultLargest,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "perUser",
+					Type:        "boolean",
+					Label:       "Per User",
+					Description: "Compute usage of each user personal folder",
+					Default:     true,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "emailTo",
+					Type:        "string",
+					Label:       "Email To",
+					Description: "Comma-separated list of email addresses receiving a summary of the report",
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *UsageReportAction) GetName() string {
+	return usageReportActionName
+}
+
+func (c *UsageReportAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (c *UsageReportAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	p := action.Parameters
+	c.targetFolder = strings.TrimSuffix(p["targetFolder"], "/")
+	switch p["format"] {
+	case "", "csv":
+		c.formats = []string{"csv"}
+	case "json":
+		c.formats = []string{"json"}
+	case "both":
+		c.formats = []string{"csv", "json"}
+	default:
+		return errors.BadRequest(common.SERVICE_JOBS, "unsupported format %s for usage report", p["format"])
+	}
+	c.largest = usageDefaultLargest
+	if l, ok := p["largestFiles"]; ok && l != "" {
+		if n, e := strconv.Atoi(l); e == nil && n >= 0 {
+			c.largest = n
+		}
+	}
+	c.perUser = p["perUser"] != "false"
+	c.emailTo = nil
+	for _, a := range strings.Split(p["emailTo"], ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			c.emailTo = append(c.emailTo, a)
+		}
+	}
+	c.jobId = job.ID
+	c.cl = cl
+	if c.TreeClient == nil {
+		c.TreeClient = tree.NewNodeProviderClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_TREE, cl)
+	}
+	if c.Router == nil && c.targetFolder != "" {
+		c.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+	if c.MailerClient == nil && len(c.emailTo) > 0 {
+		c.MailerClient = mailer.NewMailerServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_MAILER, cl)
+	}
+	return nil
+}
+
+// Run the actual action code
+func (c *UsageReportAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	report, e := c.collect(ctx, channels)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	prev, e := c.loadSnapshot(ctx)
+	if e != nil {
+		log.TasksLogger(ctx).Error("Cannot load previous report, growth will not be computed", zap.Error(e))
+	}
+	report.ApplyPrevious(prev)
+	report.Sort()
+
+	baseName := "usage-report-" + time.Unix(report.GeneratedAt, 0).Format("2006-01-02-1504")
+	var written []string
+	for _, format := range c.formats {
+		var data []byte
+		if format == "json" {
+			data, e = report.JSON()
+		} else {
+			data, e = report.CSV()
+		}
+		if e != nil {
+			return input.WithError(e), e
+		}
+		name := baseName + "." + format
+		if _, er := actions.PutTaskArtifact(ctx, c.cl, name, bytes.NewReader(data)); er != nil {
+			log.TasksLogger(ctx).Error("Cannot store report as task artifact", zap.Error(er))
+		}
+		if c.targetFolder != "" {
+			target := &tree.Node{Path: path.Join(c.targetFolder, name), Type: tree.NodeType_LEAF}
+			if _, e := c.Router.PutObject(ctx, target, bytes.NewReader(data), &views.PutRequestData{Size: int64(len(data))}); e != nil {
+				return input.WithError(e), e
+			}
+			written = append(written, target.Path)
+			log.TasksLogger(ctx).Info("Usage report written to " + target.Path)
+		}
+	}
+
+	if e := c.storeSnapshot(ctx, report.Snapshot()); e != nil {
+		log.TasksLogger(ctx).Error("Cannot store report snapshot", zap.Error(e))
+	}
+
+	if len(c.emailTo) > 0 {
+		if e := c.sendMail(ctx, report, written); e != nil {
+			return input.WithError(e), e
+		}
+	}
+
+	summary := map[string]interface{}{
+		"Workspaces": len(report.Workspaces),
+		"Users":      len(report.Users),
+		"Files":      written,
+	}
+	body, _ := json.Marshal(summary)
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		JsonBody:   body,
+		StringBody: fmt.Sprintf("Usage report computed for %d workspace(s) and %d user(s)", len(report.Workspaces), len(report.Users)),
+	})
+	return input, nil
+}
+
+// collect lists workspaces, their roots and their shares, then walks the index under each root.
+func (c *UsageReportAction) collect(ctx context.Context, channels *actions.RunnableChannels) (*UsageReport, error) {
+
+	report := &UsageReport{GeneratedAt: time.Now().Unix()}
+
+	workspaces, e := c.listWorkspaces(ctx)
+	if e != nil {
+		return nil, e
+	}
+	roots, e := c.listWorkspacesRoots(ctx)
+	if e != nil {
+		return nil, e
+	}
+
+	// Resolve roots of admin workspaces. Virtual roots (e.g. personal folders) cannot be read and
+	// are covered by the per-user part of the report.
+	wsUsages := make(map[string]*Usage)
+	wsRootPaths := make(map[string][]string)
+	for _, ws := range workspaces {
+		if ws.Scope != idm.WorkspaceScope_ADMIN {
+			continue
+		}
+		usage := NewUsage(ws.UUID, ws.Label, c.largest)
+		for _, nodeId := range roots[ws.UUID] {
+			root, er := c.readByUuid(ctx, nodeId)
+			if er != nil {
+				log.Logger(ctx).Debug("Skipping unresolved workspace root", zap.String("workspace", ws.Label), zap.String("nodeId", nodeId))
+				continue
+			}
+			wsRootPaths[ws.UUID] = append(wsRootPaths[ws.UUID], root.Path)
+			if channels != nil {
+				channels.StatusMsg <- "Computing usage of workspace " + ws.Label
+			}
+			if e := c.walk(ctx, root, usage); e != nil {
+				return nil, e
+			}
+		}
+		wsUsages[ws.UUID] = usage
+		report.Workspaces = append(report.Workspaces, usage)
+	}
+
+	// Count cells and links by workspace (using their roots location) and by owner
+	ownedCells := make(map[string]int)
+	ownedLinks := make(map[string]int)
+	for _, ws := range workspaces {
+		if ws.Scope != idm.WorkspaceScope_ROOM && ws.Scope != idm.WorkspaceScope_LINK {
+			continue
+		}
+		isLink := ws.Scope == idm.WorkspaceScope_LINK
+		for _, owner := range workspaceOwners(ws) {
+			if isLink {
+				ownedLinks[owner]++
+			} else {
+				ownedCells[owner]++
+			}
+		}
+		counted := make(map[string]bool)
+		for _, nodeId := range roots[ws.UUID] {
+			root, er := c.readByUuid(ctx, nodeId)
+			if er != nil {
+				continue
+			}
+			for wsId, rootPaths := range wsRootPaths {
+				if counted[wsId] || !isUnderOneOf(root.Path, rootPaths) {
+					continue
+				}
+				counted[wsId] = true
+				if isLink {
+					wsUsages[wsId].Links++
+				} else {
+					wsUsages[wsId].Cells++
+				}
+			}
+		}
+	}
+
+	if c.perUser {
+		users, e := c.collectUsers(ctx, channels)
+		if e != nil {
+			return nil, e
+		}
+		for _, u := range users {
+			u.Cells = ownedCells[u.Uuid]
+			u.Links = ownedLinks[u.Uuid]
+		}
+		report.Users = users
+	}
+
+	return report, nil
+}
+
+// collectUsers computes usage of each user personal folder
+func (c *UsageReportAction) collectUsers(ctx context.Context, channels *actions.RunnableChannels) ([]*Usage, error) {
+
+	var personal []*tree.Node
+	manager := views.GetVirtualNodesManager()
+	for _, vNode := range manager.ListNodes() {
+		if vNode.MetaStore["onDelete"] == "rename-uuid" {
+			personal = append(personal, vNode)
+		}
+	}
+	if len(personal) == 0 {
+		return nil, nil
+	}
+	pool := views.NewClientsPool(false)
+
+	q, _ := ptypes.MarshalAny(&idm.UserSingleQuery{NodeType: idm.NodeType_USER})
+	userClient := idm.NewUserServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER, c.cl)
+	stream, e := userClient.SearchUser(ctx, &idm.SearchUserRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	var users []*idm.User
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF || (er == nil && resp == nil) {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		if !resp.User.IsGroup {
+			users = append(users, resp.User)
+		}
+	}
+
+	var usages []*Usage
+	for _, u := range users {
+		usage := NewUsage(u.Uuid, u.Login, c.largest)
+		if channels != nil {
+			channels.StatusMsg <- "Computing usage of user " + u.Login
+		}
+		for _, vNode := range personal {
+			resolved, er := manager.ResolvePathWithVars(ctx, vNode, map[string]string{"User.Name": u.Login}, pool)
+			if er != nil {
+				continue
+			}
+			resp, er := c.TreeClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: resolved})
+			if er != nil || resp.Node == nil {
+				// Personal folder not created yet
+				continue
+			}
+			if e := c.walk(ctx, resp.Node, usage); e != nil {
+				return nil, e
+			}
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// walk recursively lists the children of root and accumulates them in usage
+func (c *UsageReportAction) walk(ctx context.Context, root *tree.Node, usage *Usage) error {
+	stream, e := c.TreeClient.ListNodes(ctx, &tree.ListNodesRequest{Node: root, Recursive: true})
+	if e != nil {
+		return e
+	}
+	defer stream.Close()
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF || (er == nil && resp == nil) {
+			break
+		} else if er != nil {
+			return er
+		}
+		if resp.Node != nil {
+			usage.Add(resp.Node)
+		}
+	}
+	return nil
+}
+
+func (c *UsageReportAction) readByUuid(ctx context.Context, uuid string) (*tree.Node, error) {
+	resp, e := c.TreeClient.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Uuid: uuid}})
+	if e != nil {
+		return nil, e
+	}
+	if resp.Node == nil {
+		return nil, errors.NotFound(common.SERVICE_TREE, "cannot find node %s", uuid)
+	}
+	return resp.Node, nil
+}
+
+func (c *UsageReportAction) listWorkspaces(ctx context.Context) ([]*idm.Workspace, error) {
+	var queries []*any.Any
+	for _, scope := range []idm.WorkspaceScope{idm.WorkspaceScope_ADMIN, idm.WorkspaceScope_ROOM, idm.WorkspaceScope_LINK} {
+		q, _ := ptypes.MarshalAny(&idm.WorkspaceSingleQuery{Scope: scope})
+		queries = append(queries, q)
+	}
+	wsClient := idm.NewWorkspaceServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_WORKSPACE, c.cl)
+	stream, e := wsClient.SearchWorkspace(ctx, &idm.SearchWorkspaceRequest{Query: &service.Query{SubQueries: queries, Operation: service.OperationType_OR}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	var workspaces []*idm.Workspace
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF || (er == nil && resp == nil) {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		workspaces = append(workspaces, resp.Workspace)
+	}
+	return workspaces, nil
+}
+
+// listWorkspacesRoots reads the workspace-path ACLs, giving the root nodes of each workspace
+func (c *UsageReportAction) listWorkspacesRoots(ctx context.Context) (map[string][]string, error) {
+	q, _ := ptypes.MarshalAny(&idm.ACLSingleQuery{Actions: []*idm.ACLAction{{Name: permissions.AclWsrootActionName}}})
+	aclClient := idm.NewACLServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_ACL, c.cl)
+	stream, e := aclClient.SearchACL(ctx, &idm.SearchACLRequest{Query: &service.Query{SubQueries: []*any.Any{q}}})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	roots := make(map[string][]string)
+	for {
+		resp, er := stream.Recv()
+		if er == io.EOF || (er == nil && resp == nil) {
+			break
+		} else if er != nil {
+			return nil, er
+		}
+		acl := resp.ACL
+		if acl.WorkspaceID != "" && acl.NodeID != "" {
+			roots[acl.WorkspaceID] = append(roots[acl.WorkspaceID], acl.NodeID)
+		}
+	}
+	return roots, nil
+}
+
+func (c *UsageReportAction) loadSnapshot(ctx context.Context) (*UsageSnapshot, error) {
+	if c.jobId == "" {
+		return nil, nil
+	}
+	docClient := docstore.NewDocStoreClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_DOCSTORE, c.cl)
+	resp, e := docClient.GetDocument(ctx, &docstore.GetDocumentRequest{StoreID: UsageReportsDocStore, DocumentID: c.jobId})
+	if e != nil {
+		if errors.Parse(e.Error()).Code == 404 {
+			return nil, nil
+		}
+		return nil, e
+	}
+	if resp.Document == nil {
+		return nil, nil
+	}
+	var snapshot UsageSnapshot
+	if e := json.Unmarshal([]byte(resp.Document.Data), &snapshot); e != nil {
+		return nil, e
+	}
+	return &snapshot, nil
+}
+
+func (c *UsageReportAction) storeSnapshot(ctx context.Context, snapshot *UsageSnapshot) error {
+	if c.jobId == "" {
+		return nil
+	}
+	data, _ := json.Marshal(snapshot)
+	docClient := docstore.NewDocStoreClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_DOCSTORE, c.cl)
+	_, e := docClient.PutDocument(ctx, &docstore.PutDocumentRequest{
+		StoreID:    UsageReportsDocStore,
+		DocumentID: c.jobId,
+		Document:   &docstore.Document{ID: c.jobId, Data: string(data)},
+	})
+	return e
+}
+
+func (c *UsageReportAction) sendMail(ctx context.Context, report *UsageReport, written []string) error {
+	var to []*mailer.User
+	for _, a := range c.emailTo {
+		to = append(to, &mailer.User{Address: a})
+	}
+	content := report.Markdown(usageMailMaxLines)
+	if len(written) > 0 {
+		content += "Full report is available in " + strings.Join(written, ", ") + "\n"
+	}
+	_, e := c.MailerClient.SendMail(ctx, &mailer.SendMailRequest{
+		Mail: &mailer.Mail{
+			To:              to,
+			Subject:         "Storage usage report",
+			ContentMarkdown: content,
+		},
+		InQueue: true,
+	})
+	if e == nil {
+		log.TasksLogger(ctx).Info(fmt.Sprintf("Usage report sent to %s", strings.Join(c.emailTo, ", ")))
+	}
+	return e
+}
+
+// workspaceOwners finds the subjects of the OWNER policies of a workspace
+func workspaceOwners(ws *idm.Workspace) (owners []string) {
+	for _, p := range ws.Policies {
+		if p.Action == service.ResourcePolicyAction_OWNER && p.Effect == service.ResourcePolicy_allow {
+			owners = append(owners, p.Subject)
+		}
+	}
+	return
+}
+
+func isUnderOneOf(p string, roots []string) bool {
+	for _, r := range roots {
+		if p == r || strings.HasPrefix(p, strings.TrimSuffix(r, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+// LargeFile describes one of the largest files of a workspace or user
+type LargeFile struct {
+	Uuid string `json:"Uuid"`
+	Path string `json:"Path"`
+	Size int64  `json:"Size"`
+}
+
+// Usage gathers storage figures for a workspace or a user
+type Usage struct {
+	Uuid    string `json:"Uuid"`
+	Label   string `json:"Label"`
+	Size    int64  `json:"Size"`
+	Files   int64  `json:"Files"`
+	Folders int64  `json:"Folders"`
+	// Number of cells and public links whose roots are inside the workspace, or owned by the user
+	Cells int `json:"Cells"`
+	Links int `json:"Links"`
+	// Size at the time of the previous report, and difference with the current size
+	PreviousSize int64        `json:"PreviousSize"`
+	Growth       int64        `json:"Growth"`
+	LargestFiles []*LargeFile `json:"LargestFiles,omitempty"`
+
+	maxLargest int
+}
+
+// NewUsage creates an empty Usage keeping track of maxLargest files.
+func NewUsage(uuid, label string, maxLargest int) *Usage {
+	return &Usage{Uuid: uuid, Label: label, maxLargest: maxLargest}
+}
+
+// Add accumulates a node into the usage figures.
+func (u *Usage) Add(node *tree.Node) {
+	if node.IsLeaf() {
+		if path.Base(node.Path) == common.PYDIO_SYNC_HIDDEN_FILE_META {
+			return
+		}
+		u.Files++
+		u.Size += node.Size
+		u.addLargest(node)
+	} else {
+		u.Folders++
+	}
+}
+
+func (u *Usage) addLargest(node *tree.Node) {
+	if u.maxLargest <= 0 {
+		return
+	}
+	if len(u.LargestFiles) == u.maxLargest && u.LargestFiles[len(u.LargestFiles)-1].Size >= node.Size {
+		return
+	}
+	i := sort.Search(len(u.LargestFiles), func(i int) bool {
+		return u.LargestFiles[i].Size < node.Size
+	})
+	u.LargestFiles = append(u.LargestFiles, nil)
+	copy(u.LargestFiles[i+1:], u.LargestFiles[i:])
+	u.LargestFiles[i] = &LargeFile{Uuid: node.Uuid, Path: node.Path, Size: node.Size}
+	if len(u.LargestFiles) > u.maxLargest {
+		u.LargestFiles = u.LargestFiles[:u.maxLargest]
+	}
+}
+
+// UsageSnapshot keeps the sizes of a report, to compute growth in the next one.
+type UsageSnapshot struct {
+	GeneratedAt int64            `json:"GeneratedAt"`
+	Workspaces  map[string]int64 `json:"Workspaces"`
+	Users       map[string]int64 `json:"Users"`
+}
+
+// UsageReport is the output of the usage report action
+type UsageReport struct {
+	GeneratedAt int64    `json:"GeneratedAt"`
+	PreviousAt  int64    `json:"PreviousAt,omitempty"`
+	Workspaces  []*Usage `json:"Workspaces"`
+	Users       []*Usage `json:"Users,omitempty"`
+}
+
+// Sort orders workspaces and users by decreasing size.
+func (r *UsageReport) Sort() {
+	for _, list := range [][]*Usage{r.Workspaces, r.Users} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].Size > list[j].Size
+		})
+	}
+}
+
+// ApplyPrevious computes growth against a previous snapshot. Entries that
+// did not exist in the previous report grow from zero.
+func (r *UsageReport) ApplyPrevious(prev *UsageSnapshot) {
+	if prev == nil {
+		return
+	}
+	r.PreviousAt = prev.GeneratedAt
+	for _, w := range r.Workspaces {
+		w.PreviousSize = prev.Workspaces[w.Uuid]
+		w.Growth = w.Size - w.PreviousSize
+	}
+	for _, u := range r.Users {
+		u.PreviousSize = prev.Users[u.Uuid]
+		u.Growth = u.Size - u.PreviousSize
+	}
+}
+
+// Snapshot extracts the sizes to be stored for the next report.
+func (r *UsageReport) Snapshot() *UsageSnapshot {
+	s := &UsageSnapshot{GeneratedAt: r.GeneratedAt, Workspaces: map[string]int64{}, Users: map[string]int64{}}
+	for _, w := range r.Workspaces {
+		s.Workspaces[w.Uuid] = w.Size
+	}
+	for _, u := range r.Users {
+		s.Users[u.Uuid] = u.Size
+	}
+	return s
+}
+
+// JSON encodes the full report.
+func (r *UsageReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// CSV encodes the report as a flat table, one line per workspace or user.
+func (r *UsageReport) CSV() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := csv.NewWriter(buf)
+	header := []string{"Type", "Uuid", "Label", "Size", "Files", "Folders", "Cells", "Links", "PreviousSize", "Growth", "LargestFiles"}
+	if e := w.Write(header); e != nil {
+		return nil, e
+	}
+	write := func(kind string, list []*Usage) error {
+		for _, u := range list {
+			var largest []string
+			for _, l := range u.LargestFiles {
+				largest = append(largest, fmt.Sprintf("%s (%d)", l.Path, l.Size))
+			}
+			if e := w.Write([]string{
+				kind,
+				u.Uuid,
+				u.Label,
+				fmt.Sprintf("%d", u.Size),
+				fmt.Sprintf("%d", u.Files),
+				fmt.Sprintf("%d", u.Folders),
+				fmt.Sprintf("%d", u.Cells),
+				fmt.Sprintf("%d", u.Links),
+				fmt.Sprintf("%d", u.PreviousSize),
+				fmt.Sprintf("%d", u.Growth),
+				strings.Join(largest, "; "),
+			}); e != nil {
+				return e
+			}
+		}
+		return nil
+	}
+	if e := write("workspace", r.Workspaces); e != nil {
+		return nil, e
+	}
+	if e := write("user", r.Users); e != nil {
+		return nil, e
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// Markdown renders a short summary, used as email content.
+func (r *UsageReport) Markdown(maxLines int) string {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "## Storage usage report - %s\n\n", time.Unix(r.GeneratedAt, 0).Format("2006-01-02 15:04"))
+	if r.PreviousAt > 0 {
+		fmt.Fprintf(buf, "Growth is computed since previous report of %s.\n\n", time.Unix(r.PreviousAt, 0).Format("2006-01-02 15:04"))
+	}
+	table := func(title string, list []*Usage) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Fprintf(buf, "### %s\n\n", title)
+		fmt.Fprintf(buf, "| Name | Size | Files | Growth | Cells | Links |\n")
+		fmt.Fprintf(buf, "|---|---|---|---|---|---|\n")
+		for i, u := range list {
+			if maxLines > 0 && i >= maxLines {
+				fmt.Fprintf(buf, "\n... and %d more\n", len(list)-maxLines)
+				break
+			}
+			growth := humanize.Bytes(uint64(abs(u.Growth)))
+			if u.Growth < 0 {
+				growth = "-" + growth
+			} else {
+				growth = "+" + growth
+			}
+			fmt.Fprintf(buf, "| %s | %s | %d | %s | %d | %d |\n", u.Label, humanize.Bytes(uint64(u.Size)), u.Files, growth, u.Cells, u.Links)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	table("Workspaces", r.Workspaces)
+	table("Users", r.Users)
+	return buf.String()
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/common/views"
+)
+
+func TestUsage(t *testing.T) {
+
+	Convey("Accumulate nodes and keep largest files", t, func() {
+		u := NewUsage("ws", "Common Files", 2)
+		u.Add(&tree.Node{Path: "ds/a", Type: tree.NodeType_COLLECTION})
+		u.Add(&tree.Node{Path: "ds/a/small.txt", Size: 10, Type: tree.NodeType_LEAF})
+		u.Add(&tree.Node{Path: "ds/a/big.bin", Size: 1000, Type: tree.NodeType_LEAF})
+		u.Add(&tree.Node{Path: "ds/a/medium.bin", Size: 100, Type: tree.NodeType_LEAF})
+		u.Add(&tree.Node{Path: "ds/a/.pydio", Size: 36, Type: tree.NodeType_LEAF})
+		So(u.Files, ShouldEqual, 3)
+		So(u.Folders, ShouldEqual, 1)
+		So(u.Size, ShouldEqual, 1110)
+		So(u.LargestFiles, ShouldHaveLength, 2)
+		So(u.LargestFiles[0].Path, ShouldEqual, "ds/a/big.bin")
+		So(u.LargestFiles[1].Path, ShouldEqual, "ds/a/medium.bin")
+
+		none := NewUsage("ws", "Common Files", 0)
+		none.Add(&tree.Node{Path: "ds/a/big.bin", Size: 1000, Type: tree.NodeType_LEAF})
+		So(none.LargestFiles, ShouldBeEmpty)
+	})
+
+	Convey("Growth is computed from previous snapshot", t, func() {
+		report := &UsageReport{
+			GeneratedAt: 2000,
+			Workspaces:  []*Usage{{Uuid: "ws1", Size: 500}, {Uuid: "ws2", Size: 3000}},
+			Users:       []*Usage{{Uuid: "u1", Size: 100}},
+		}
+		report.ApplyPrevious(&UsageSnapshot{GeneratedAt: 1000, Workspaces: map[string]int64{"ws1": 800}, Users: map[string]int64{"u1": 40}})
+		report.Sort()
+		So(report.PreviousAt, ShouldEqual, 1000)
+		So(report.Workspaces[0].Uuid, ShouldEqual, "ws2")
+		So(report.Workspaces[0].Growth, ShouldEqual, 3000)
+		So(report.Workspaces[1].Growth, ShouldEqual, -300)
+		So(report.Users[0].Growth, ShouldEqual, 60)
+
+		snap := report.Snapshot()
+		So(snap.GeneratedAt, ShouldEqual, 2000)
+		So(snap.Workspaces["ws1"], ShouldEqual, 500)
+		So(snap.Users["u1"], ShouldEqual, 100)
+	})
+
+	Convey("Encode report", t, func() {
+		report := &UsageReport{
+			GeneratedAt: 2000,
+			Workspaces:  []*Usage{{Uuid: "ws1", Label: "Common, Files", Size: 2048, Files: 2, Cells: 1, Links: 3, LargestFiles: []*LargeFile{{Path: "ds/big.bin", Size: 2000}}}},
+			Users:       []*Usage{{Uuid: "u1", Label: "admin", Size: 100, Growth: -20}},
+		}
+		data, e := report.CSV()
+		So(e, ShouldBeNil)
+		records, e := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+		So(e, ShouldBeNil)
+		So(records, ShouldHaveLength, 3)
+		So(records[1][0], ShouldEqual, "workspace")
+		So(records[1][2], ShouldEqual, "Common, Files")
+		So(records[1][3], ShouldEqual, "2048")
+		So(records[1][10], ShouldEqual, "ds/big.bin (2000)")
+		So(records[2][0], ShouldEqual, "user")
+		So(records[2][9], ShouldEqual, "-20")
+
+		data, e = report.JSON()
+		So(e, ShouldBeNil)
+		var decoded UsageReport
+		So(json.Unmarshal(data, &decoded), ShouldBeNil)
+		So(decoded.Workspaces[0].Links, ShouldEqual, 3)
+
+		md := report.Markdown(1)
+		So(md, ShouldContainSubstring, "| Common, Files | 2.0 kB | 2 | +0 B | 1 | 3 |")
+		So(md, ShouldContainSubstring, "| admin | 100 B | 0 | -20 B | 0 | 0 |")
+	})
+
+}
+
+func TestUsageReportAction(t *testing.T) {
+
+	Convey("Init parameters", t, func() {
+		action := &UsageReportAction{TreeClient: views.NewHandlerMock()}
+		So(action.Init(&jobs.Job{ID: "job"}, nil, &jobs.Action{Parameters: map[string]string{"format": "xml"}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{ID: "job"}, nil, &jobs.Action{Parameters: map[string]string{
+			"format":       "both",
+			"largestFiles": "3",
+			"perUser":      "false",
+		}}), ShouldBeNil)
+		So(action.formats, ShouldResemble, []string{"csv", "json"})
+		So(action.largest, ShouldEqual, 3)
+		So(action.perUser, ShouldBeFalse)
+		So(action.emailTo, ShouldBeEmpty)
+	})
+
+	Convey("Walk a workspace root", t, func() {
+		mock := views.NewHandlerMock()
+		mock.Nodes["ds/root/folder"] = &tree.Node{Path: "ds/root/folder", Type: tree.NodeType_COLLECTION}
+		mock.Nodes["ds/root/folder/file1"] = &tree.Node{Path: "ds/root/folder/file1", Size: 12, Type: tree.NodeType_LEAF}
+		mock.Nodes["ds/root/file2"] = &tree.Node{Path: "ds/root/file2", Size: 30, Type: tree.NodeType_LEAF}
+		mock.Nodes["ds/other/file3"] = &tree.Node{Path: "ds/other/file3", Size: 1000, Type: tree.NodeType_LEAF}
+		action := &UsageReportAction{TreeClient: mock}
+		usage := NewUsage("ws", "Workspace", 5)
+		So(action.walk(context.Background(), &tree.Node{Path: "ds/root"}, usage), ShouldBeNil)
+		So(usage.Files, ShouldEqual, 2)
+		So(usage.Folders, ShouldEqual, 1)
+		So(usage.Size, ShouldEqual, 42)
+	})
+
+	Convey("Shares helpers", t, func() {
+		ws := &idm.Workspace{Policies: []*service.ResourcePolicy{
+			{Subject: "user-uuid", Action: service.ResourcePolicyAction_OWNER, Effect: service.ResourcePolicy_allow},
+			{Subject: "user:login", Action: service.ResourcePolicyAction_READ, Effect: service.ResourcePolicy_allow},
+		}}
+		So(workspaceOwners(ws), ShouldResemble, []string{"user-uuid"})
+		So(isUnderOneOf("ds/root/folder", []string{"ds/other", "ds/root/"}), ShouldBeTrue)
+		So(isUnderOneOf("ds/rootfolder", []string{"ds/root"}), ShouldBeFalse)
+	})
+
+}
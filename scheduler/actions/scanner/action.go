@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	sensitiveActionName = "actions.scanner.sensitive"
+)
+
+const (
+	defaultDetectorsNames = "creditcard,iban,ssn,nir,apikey"
+)
+
+// SensitiveData is stored as node metadata under the common.META_NAMESPACE_SENSITIVE_DATA namespace
+type SensitiveData struct {
+	Findings
+	// Whether the number of findings reached the threshold
+	Flagged bool `json:"Flagged"`
+	// Timestamp of the scan
+	ScanTime int64 `json:"ScanTime"`
+	// Etag of the scanned content
+	Etag string `json:"Etag,omitempty"`
+}
+
+// SensitiveDataAction scans files contents to find credit cards numbers, IBANs, national IDs or API keys
+type SensitiveDataAction struct {
+	Router views.Handler
+	Client tree.NodeReceiverClient
+
+	detectors []*Detector
+	threshold int
+}
+
+func (c *SensitiveDataAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                sensitiveActionName,
+		Label:             "Sensitive Data Scanner",
+		Icon:              "shield-search",
+		Category:          actions.ActionCategoryTree,
+		Description:       "Scan files contents to detect sensitive data (credit cards numbers, IBANs, national IDs, API keys) and record findings as metadata. Flagged files can be prevented from being shared publicly.",
+		InputDescription:  "Multiple selection of files",
+		OutputDescription: "Input files with updated metadata, and findings as Json output",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (c *SensitiveDataAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "detectors",
+					Type:        forms.ParamString,
+					Label:       "Detectors",
+					Description: "Comma-separated list of built-in detectors: creditcard, iban, ssn, nir, apikey",
+					Default:     defaultDetectorsNames,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "customDetectors",
+					Type:        forms.ParamTextarea,
+					Label:       "Custom Detectors",
+					Description: "Json-encoded list of additional detectors, e.g. [{\"Name\":\"employee\",\"Regexp\":\"EMP-\\\\d{6}\"}]. An optional Validator can be set to luhn, iban, ssn or nir.",
+					Default:     "",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "threshold",
+					Type:        forms.ParamInteger,
+					Label:       "Threshold",
+					Description: "Minimum number of findings for flagging the file",
+					Default:     1,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *SensitiveDataAction) GetName() string {
+	return sensitiveActionName
+}
+
+// Init passes parameters to the action
+func (c *SensitiveDataAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+
+	builtins := DefaultDetectors()
+	names := defaultDetectorsNames
+	if d, ok := action.Parameters["detectors"]; ok {
+		names = d
+	}
+	c.detectors = nil
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		d, ok := builtins[name]
+		if !ok {
+			return errors.BadRequest(common.SERVICE_JOBS, "unknown detector %s", name)
+		}
+		c.detectors = append(c.detectors, d)
+	}
+	custom, e := ParseDetectors(action.Parameters["customDetectors"])
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "invalid custom detectors: %s", e.Error())
+	}
+	c.detectors = append(c.detectors, custom...)
+	if len(c.detectors) == 0 {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide at least one detector")
+	}
+	c.threshold = 1
+	if t, ok := action.Parameters["threshold"]; ok {
+		if i, er := strconv.Atoi(t); er == nil && i > 0 {
+			c.threshold = i
+		}
+	}
+	if c.Router == nil {
+		c.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+	if c.Client == nil {
+		c.Client = tree.NewNodeReceiverClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_META, cl)
+	}
+
+	return nil
+}
+
+// Run the actual action code
+func (c *SensitiveDataAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil // Ignore
+	}
+
+	results := make(map[string]*SensitiveData)
+	for _, n := range input.Nodes {
+		if n.Type == tree.NodeType_COLLECTION || n.Etag == common.NODE_FLAG_ETAG_TEMPORARY {
+			continue
+		}
+		data, e := c.scanNode(ctx, n)
+		if e != nil {
+			return input.WithError(e), e
+		}
+		results[n.Path] = data
+
+		metaNode := &tree.Node{Uuid: n.Uuid, Path: n.Path, MetaStore: make(map[string]string)}
+		metaNode.SetMeta(common.META_NAMESPACE_SENSITIVE_DATA, data)
+		n.SetMeta(common.META_NAMESPACE_SENSITIVE_DATA, data)
+		if data.Flagged {
+			flag := strings.Join(data.Names(), ",")
+			metaNode.SetMeta(common.META_FLAG_SENSITIVE, flag)
+			n.SetMeta(common.META_FLAG_SENSITIVE, flag)
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Flagged %s as sensitive: %d finding(s) (%s)", path.Base(n.Path), data.Total(), flag))
+		} else {
+			// Empty value removes a previous flag
+			metaNode.MetaStore[common.META_FLAG_SENSITIVE] = ""
+			delete(n.MetaStore, common.META_FLAG_SENSITIVE)
+		}
+		if _, e := c.Client.UpdateNode(ctx, &tree.UpdateNodeRequest{From: metaNode, To: metaNode}); e != nil {
+			return input.WithError(e), e
+		}
+	}
+
+	body, _ := json.Marshal(results)
+	input.AppendOutput(&jobs.ActionOutput{Success: true, JsonBody: body})
+
+	return input, nil
+}
+
+func (c *SensitiveDataAction) scanNode(ctx context.Context, node *tree.Node) (*SensitiveData, error) {
+	reader, e := c.Router.GetObject(ctx, node, &views.GetRequestData{StartOffset: 0, Length: -1})
+	if e != nil {
+		return nil, e
+	}
+	defer reader.Close()
+	findings, e := Scan(reader, c.detectors)
+	if e != nil {
+		return nil, e
+	}
+	return &SensitiveData{
+		Findings: *findings,
+		Flagged:  findings.Total() >= c.threshold,
+		ScanTime: time.Now().Unix(),
+		Etag:     node.Etag,
+	}, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scanner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+func TestSensitiveDataAction_Init(t *testing.T) {
+
+	Convey("Init", t, func() {
+		mock := &views.HandlerMock{Nodes: map[string]*tree.Node{}}
+		action := &SensitiveDataAction{Router: mock, Client: mock}
+		So(action.GetName(), ShouldEqual, sensitiveActionName)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{}}), ShouldBeNil)
+		So(action.detectors, ShouldHaveLength, 5)
+		So(action.threshold, ShouldEqual, 1)
+
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"detectors": "unknown"}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"detectors": ""}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{
+			"detectors":       "iban",
+			"customDetectors": `[{"Name":"employee","Regexp":"EMP-\\d{6}"}]`,
+			"threshold":       "3",
+		}}), ShouldBeNil)
+		So(action.detectors, ShouldHaveLength, 2)
+		So(action.threshold, ShouldEqual, 3)
+	})
+
+}
+
+func TestSensitiveDataAction_Run(t *testing.T) {
+
+	root, _ := ioutil.TempDir("", "scanner-test")
+	defer os.RemoveAll(root)
+	ioutil.WriteFile(filepath.Join(root, "cards.txt"), []byte("Card number 4111 1111 1111 1111"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "clean.txt"), []byte("Nothing to report"), 0644)
+
+	Convey("Run", t, func() {
+		mock := &views.HandlerMock{RootDir: root, Nodes: map[string]*tree.Node{}}
+		action := &SensitiveDataAction{Router: mock, Client: mock}
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{}}), ShouldBeNil)
+		channels := &actions.RunnableChannels{StatusMsg: make(chan string), Progress: make(chan float32)}
+
+		ignored, _ := action.Run(context.Background(), channels, jobs.ActionMessage{})
+		So(ignored.GetLastOutput().Ignored, ShouldBeTrue)
+
+		cards := &tree.Node{Uuid: "cards", Path: "cards.txt", Type: tree.NodeType_LEAF, MetaStore: map[string]string{}}
+		output, e := action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{cards}})
+		So(e, ShouldBeNil)
+		So(output.Nodes[0].GetStringMeta(common.META_FLAG_SENSITIVE), ShouldEqual, "creditcard")
+		updated := mock.Nodes["to"]
+		So(updated.Uuid, ShouldEqual, "cards")
+		So(updated.GetStringMeta(common.META_FLAG_SENSITIVE), ShouldEqual, "creditcard")
+		var data SensitiveData
+		So(updated.GetMeta(common.META_NAMESPACE_SENSITIVE_DATA, &data), ShouldBeNil)
+		So(data.Flagged, ShouldBeTrue)
+		So(data.Detectors["creditcard"], ShouldEqual, 1)
+
+		clean := &tree.Node{Uuid: "clean", Path: "clean.txt", Type: tree.NodeType_LEAF, MetaStore: map[string]string{}}
+		clean.SetMeta(common.META_FLAG_SENSITIVE, "creditcard")
+		output, e = action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{clean}})
+		So(e, ShouldBeNil)
+		So(output.Nodes[0].HasMetaKey(common.META_FLAG_SENSITIVE), ShouldBeFalse)
+		updated = mock.Nodes["to"]
+		So(updated.MetaStore[common.META_FLAG_SENSITIVE], ShouldEqual, "")
+		So(updated.HasMetaKey(common.META_NAMESPACE_SENSITIVE_DATA), ShouldBeTrue)
+	})
+
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package scanner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Size of the overlap kept between two chunks: matches longer than this may be missed
+	scanOverlap   = 512
+	scanChunkSize = 64 * 1024
+	// Number of masked samples kept per detector
+	scanMaxSamples = 5
+)
+
+// Validator performs a checksum verification on a regexp match
+type Validator func(match string) bool
+
+var validators = map[string]Validator{
+	"luhn": ValidateLuhn,
+	"iban": ValidateIBAN,
+	"ssn":  ValidateSSN,
+	"nir":  ValidateNIR,
+}
+
+// Detector finds a given type of sensitive data
+type Detector struct {
+	// Name used to report findings
+	Name string `json:"Name"`
+	// Regular expression finding candidates
+	Regexp string `json:"Regexp"`
+	// Optional checksum validation, one of luhn, iban, ssn, nir
+	Validator string `json:"Validator,omitempty"`
+
+	regexp    *regexp.Regexp
+	validator Validator
+}
+
+// DefaultDetectors returns the built-in detectors, indexed by name
+func DefaultDetectors() map[string]*Detector {
+	defs := []*Detector{
+		{Name: "creditcard", Regexp: `\b(?:\d[ -]?){12,18}\d\b`, Validator: "luhn"},
+		{Name: "iban", Regexp: `\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`, Validator: "iban"},
+		{Name: "ssn", Regexp: `\b\d{3}-\d{2}-\d{4}\b`, Validator: "ssn"},
+		{Name: "nir", Regexp: `\b[12] ?\d{2} ?(?:0[1-9]|1[0-2]) ?(?:\d{2}|2A|2B) ?\d{3} ?\d{3} ?\d{2}\b`, Validator: "nir"},
+		{Name: "apikey", Regexp: `\bAKIA[0-9A-Z]{16}\b|\b(?:sk|rk)_live_[0-9a-zA-Z]{24,}\b|\bgh[pousr]_[A-Za-z0-9]{36}\b|\bxox[abprs]-[0-9A-Za-z-]{10,}\b|-----BEGIN (?:RSA |EC |DSA |OPENSSH )?PRIVATE KEY-----`},
+	}
+	detectors := make(map[string]*Detector, len(defs))
+	for _, d := range defs {
+		d.compile()
+		detectors[d.Name] = d
+	}
+	return detectors
+}
+
+// ParseDetectors decodes and compiles a JSON-encoded list of custom detectors
+func ParseDetectors(data string) ([]*Detector, error) {
+	var detectors []*Detector
+	if strings.TrimSpace(data) == "" {
+		return detectors, nil
+	}
+	if e := json.Unmarshal([]byte(data), &detectors); e != nil {
+		return nil, fmt.Errorf("cannot decode detectors: %s", e.Error())
+	}
+	for _, d := range detectors {
+		if d.Name == "" || d.Regexp == "" {
+			return nil, fmt.Errorf("detectors must have a Name and a Regexp")
+		}
+		if e := d.compile(); e != nil {
+			return nil, fmt.Errorf("%s: %s", d.Name, e.Error())
+		}
+	}
+	return detectors, nil
+}
+
+func (d *Detector) compile() (e error) {
+	if d.regexp, e = regexp.Compile(d.Regexp); e != nil {
+		return e
+	}
+	if d.Validator != "" {
+		v, ok := validators[d.Validator]
+		if !ok {
+			return fmt.Errorf("unknown validator %s", d.Validator)
+		}
+		d.validator = v
+	}
+	return nil
+}
+
+// Findings summarizes the sensitive data found in a content
+type Findings struct {
+	// Number of validated matches per detector
+	Detectors map[string]int `json:"Detectors"`
+	// Masked samples per detector
+	Samples map[string][]string `json:"Samples,omitempty"`
+}
+
+// Total returns the total number of findings
+func (f *Findings) Total() (total int) {
+	for _, c := range f.Detectors {
+		total += c
+	}
+	return
+}
+
+// Names returns the sorted names of the detectors that found something
+func (f *Findings) Names() (names []string) {
+	for n, c := range f.Detectors {
+		if c > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+// Scan reads the content by chunks and applies all detectors. Consecutive chunks overlap
+// to catch matches on boundaries, and matches already counted are not reported twice.
+func Scan(reader io.Reader, detectors []*Detector) (*Findings, error) {
+	findings := &Findings{Detectors: make(map[string]int), Samples: make(map[string][]string)}
+	lastEnds := make(map[string]int64, len(detectors))
+	br := bufio.NewReaderSize(reader, scanChunkSize)
+	var carry []byte
+	var offset int64 // absolute offset of the buffer start
+	chunk := make([]byte, scanChunkSize)
+	for {
+		n, er := io.ReadFull(br, chunk)
+		eof := er == io.EOF || er == io.ErrUnexpectedEOF
+		if er != nil && !eof {
+			return nil, er
+		}
+		buffer := append(carry, chunk[:n]...)
+		boundary := len(buffer)
+		if !eof && boundary > scanOverlap {
+			boundary = len(buffer) - scanOverlap
+		}
+		for _, d := range detectors {
+			for _, loc := range d.regexp.FindAllIndex(buffer, -1) {
+				start, end := offset+int64(loc[0]), offset+int64(loc[1])
+				if loc[0] >= boundary || start < lastEnds[d.Name] {
+					continue
+				}
+				match := string(buffer[loc[0]:loc[1]])
+				if d.validator != nil && !d.validator(match) {
+					continue
+				}
+				lastEnds[d.Name] = end
+				findings.Detectors[d.Name]++
+				if len(findings.Samples[d.Name]) < scanMaxSamples {
+					findings.Samples[d.Name] = append(findings.Samples[d.Name], Mask(match))
+				}
+			}
+		}
+		if eof {
+			break
+		}
+		carry = append([]byte{}, buffer[boundary:]...)
+		offset += int64(boundary)
+	}
+	return findings, nil
+}
+
+// Mask replaces all but the last four alphanumeric characters with stars
+func Mask(value string) string {
+	runes := []rune(value)
+	visible := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !isAlphaNum(runes[i]) {
+			continue
+		}
+		if visible < 4 {
+			visible++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+func isAlphaNum(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func stripSeparators(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
+
+// ValidateLuhn checks a credit card number using the Luhn algorithm
+func ValidateLuhn(match string) bool {
+	digits := stripSeparators(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ValidateIBAN checks an IBAN using the ISO 7064 mod-97 algorithm
+func ValidateIBAN(match string) bool {
+	iban := strings.ToUpper(stripSeparators(match))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	rearranged := iban[4:] + iban[:4]
+	var numeric strings.Builder
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			numeric.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// ValidateSSN checks the area, group and serial numbers of a US Social Security Number
+func ValidateSSN(match string) bool {
+	parts := strings.Split(match, "-")
+	if len(parts) != 3 {
+		return false
+	}
+	area, _ := strconv.Atoi(parts[0])
+	return area != 0 && area != 666 && area < 900 && parts[1] != "00" && parts[2] != "0000"
+}
+
+// ValidateNIR checks the control key of a French social security number (NIR)
+func ValidateNIR(match string) bool {
+	nir := strings.ToUpper(stripSeparators(match))
+	if len(nir) != 15 {
+		return false
+	}
+	body := nir[:13]
+	// Corsica departments
+	switch body[5:7] {
+	case "2A":
+		body = body[:5] + "19" + body[7:]
+	case "2B":
+		body = body[:5] + "18" + body[7:]
+	}
+	n, e := strconv.ParseInt(body, 10, 64)
+	if e != nil {
+		return false
+	}
+	key, e := strconv.ParseInt(nir[13:], 10, 64)
+	if e != nil {
+		return false
+	}
+	return 97-(n%97) == key
+}
@@ -173,3 +173,13 @@ loop:
 
 	return outputMessage, nil
 }
+
+// Simulate implements SimulatableAction: it checks the parameters and returns immediately
+func (f *FakeAction) Simulate(ctx context.Context, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if _, err := strconv.ParseInt(jobs.EvaluateFieldStr(ctx, input, f.timer), 10, 64); err != nil {
+		return input.WithError(err), err
+	}
+	input.AppendOutput(&jobs.ActionOutput{StringBody: "Hello World"})
+	return input, nil
+}
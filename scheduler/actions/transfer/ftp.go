@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package transfer
+
+import (
+	"crypto/tls"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/jlaffaye/ftp"
+)
+
+type ftpRemote struct {
+	conn *ftp.ServerConn
+}
+
+func dialFtp(c *RemoteConfig) (Remote, error) {
+	options := []ftp.DialOption{ftp.DialWithTimeout(c.Timeout)}
+	if c.Scheme == "ftps" {
+		host := c.Host
+		if i := strings.LastIndex(host, ":"); i > 0 {
+			host = host[:i]
+		}
+		options = append(options, ftp.DialWithExplicitTLS(&tls.Config{ServerName: host}))
+	}
+	conn, e := ftp.Dial(c.Host, options...)
+	if e != nil {
+		return nil, e
+	}
+	if e := conn.Login(c.User, c.Password); e != nil {
+		conn.Quit()
+		return nil, e
+	}
+	return &ftpRemote{conn: conn}, nil
+}
+
+// MkdirAll creates each missing segment. FTP has no recursive mkdir, and errors on
+// existing folders are not distinguishable, so they are ignored.
+func (f *ftpRemote) MkdirAll(dir string) error {
+	dir = path.Clean(dir)
+	var current string
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		current = current + "/" + part
+		f.conn.MakeDir(current)
+	}
+	return nil
+}
+
+func (f *ftpRemote) Put(remotePath string, reader io.Reader) (int64, error) {
+	counter := &countingReader{Reader: reader}
+	if e := f.conn.Stor(remotePath, counter); e != nil {
+		return counter.n, e
+	}
+	return counter.n, nil
+}
+
+func (f *ftpRemote) Get(remotePath string) (io.ReadCloser, error) {
+	return f.conn.Retr(remotePath)
+}
+
+func (f *ftpRemote) Close() error {
+	return f.conn.Quit()
+}
+
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, e := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, e
+}
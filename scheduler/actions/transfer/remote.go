@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Remote is a connection to an external server receiving files
+type Remote interface {
+	// MkdirAll creates a remote folder and all its parents
+	MkdirAll(dir string) error
+	// Put writes the content of reader to a remote file, replacing any existing file
+	Put(remotePath string, reader io.Reader) (int64, error)
+	// Get opens a remote file for reading
+	Get(remotePath string) (io.ReadCloser, error)
+	// Close terminates the connection
+	Close() error
+}
+
+// RemoteConfig gathers the parameters required to connect to a remote server
+type RemoteConfig struct {
+	// Scheme is one of sftp, ftp or ftps
+	Scheme   string
+	Host     string
+	User     string
+	BasePath string
+
+	Password   string
+	PrivateKey string
+	Passphrase string
+	// HostKey is the expected public key of the SFTP server, either in authorized_keys format or as a SHA256 fingerprint
+	HostKey string
+	// InsecureHostKey accepts any SFTP server key when no HostKey is set
+	InsecureHostKey bool
+	Timeout         time.Duration
+}
+
+// ParseRemoteURL reads scheme, user, host and base path from a URL like sftp://user@host:22/path.
+func ParseRemoteURL(remoteURL string) (*RemoteConfig, error) {
+	u, e := url.Parse(remoteURL)
+	if e != nil {
+		return nil, e
+	}
+	conf := &RemoteConfig{Scheme: strings.ToLower(u.Scheme), BasePath: u.Path}
+	var defaultPort string
+	switch conf.Scheme {
+	case "sftp":
+		defaultPort = "22"
+	case "ftp", "ftps":
+		defaultPort = "21"
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s, use sftp, ftp or ftps", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing host in %s", remoteURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	conf.Host = net.JoinHostPort(u.Hostname(), port)
+	if u.User != nil {
+		conf.User = u.User.Username()
+		if _, ok := u.User.Password(); ok {
+			return nil, fmt.Errorf("passwords must not be set in the URL, use a secret instead")
+		}
+	}
+	if conf.User == "" {
+		if conf.Scheme == "sftp" {
+			return nil, fmt.Errorf("missing user in %s", remoteURL)
+		}
+		conf.User = "anonymous"
+	}
+	if conf.BasePath == "" {
+		conf.BasePath = "/"
+	}
+	return conf, nil
+}
+
+// Dial opens a connection to the remote server
+func (c *RemoteConfig) Dial() (Remote, error) {
+	switch c.Scheme {
+	case "sftp":
+		return dialSftp(c)
+	case "ftp", "ftps":
+		return dialFtp(c)
+	}
+	return nil, fmt.Errorf("unsupported scheme %s", c.Scheme)
+}
+
+// Resolve joins a relative path to the base path of the remote
+func (c *RemoteConfig) Resolve(rel string) string {
+	return path.Join(c.BasePath, path.Clean("/"+rel))
+}
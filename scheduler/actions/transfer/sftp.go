@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+type sftpRemote struct {
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+func dialSftp(c *RemoteConfig) (Remote, error) {
+	var auths []ssh.AuthMethod
+	if c.PrivateKey != "" {
+		var signer ssh.Signer
+		var e error
+		if c.Passphrase != "" {
+			signer, e = ssh.ParsePrivateKeyWithPassphrase([]byte(c.PrivateKey), []byte(c.Passphrase))
+		} else {
+			signer, e = ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		}
+		if e != nil {
+			return nil, fmt.Errorf("cannot parse private key: %s", e.Error())
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		auths = append(auths, ssh.Password(c.Password))
+	}
+	if len(auths) == 0 {
+		return nil, fmt.Errorf("please provide a password or a private key for sftp")
+	}
+	hostKeyCallback, e := hostKeyCallback(c.HostKey, c.InsecureHostKey)
+	if e != nil {
+		return nil, e
+	}
+	conn, e := ssh.Dial("tcp", c.Host, &ssh.ClientConfig{
+		User:            c.User,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         c.Timeout,
+	})
+	if e != nil {
+		return nil, e
+	}
+	client, e := sftp.NewClient(conn)
+	if e != nil {
+		conn.Close()
+		return nil, e
+	}
+	return &sftpRemote{conn: conn, client: client}, nil
+}
+
+// hostKeyCallback checks the server key against an authorized_keys line or a SHA256 fingerprint.
+// A key is required, unless insecure is set explicitly.
+func hostKeyCallback(hostKey string, insecure bool) (ssh.HostKeyCallback, error) {
+	hostKey = strings.TrimSpace(hostKey)
+	if hostKey == "" {
+		if insecure {
+			return ssh.InsecureIgnoreHostKey(), nil
+		}
+		return nil, fmt.Errorf("missing host key for sftp server")
+	}
+	if strings.HasPrefix(hostKey, "SHA256:") {
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if fp := ssh.FingerprintSHA256(key); fp != hostKey {
+				return fmt.Errorf("host key mismatch for %s: got %s", hostname, fp)
+			}
+			return nil
+		}, nil
+	}
+	key, _, _, _, e := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if e != nil {
+		return nil, fmt.Errorf("cannot parse host key: %s", e.Error())
+	}
+	return ssh.FixedHostKey(key), nil
+}
+
+func (s *sftpRemote) MkdirAll(dir string) error {
+	return s.client.MkdirAll(dir)
+}
+
+func (s *sftpRemote) Put(remotePath string, reader io.Reader) (int64, error) {
+	f, e := s.client.Create(remotePath)
+	if e != nil {
+		return 0, e
+	}
+	written, e := f.ReadFrom(reader)
+	if e != nil {
+		f.Close()
+		return written, e
+	}
+	return written, f.Close()
+}
+
+func (s *sftpRemote) Get(remotePath string) (io.ReadCloser, error) {
+	return s.client.Open(remotePath)
+}
+
+func (s *sftpRemote) Close() error {
+	s.client.Close()
+	return s.conn.Close()
+}
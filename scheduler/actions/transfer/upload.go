@@ -0,0 +1,534 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/config"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	uploadActionName = "actions.transfer.upload"
+
+	uploadDefaultTemplate = "{{.NodeName}}"
+	uploadDefaultRetries  = 3
+	uploadDefaultDelay    = "5s"
+	uploadDefaultTimeout  = 30 * time.Second
+)
+
+// UploadResult reports a transferred file
+type UploadResult struct {
+	NodePath   string `json:"NodePath"`
+	RemotePath string `json:"RemotePath"`
+	Size       int64  `json:"Size"`
+	Checksum   string `json:"Checksum,omitempty"`
+	Attempts   int    `json:"Attempts"`
+}
+
+// UploadAction pushes the input nodes to an external SFTP or FTP server
+type UploadAction struct {
+	Router views.Handler
+	// Secrets resolves a secret key from the vault, it can be replaced for tests
+	Secrets func(key string) string
+
+	remoteURL        string
+	passwordSecret   string
+	privateKeySecret string
+	passphraseSecret string
+	hostKey          string
+	insecureHostKey  bool
+	pathTemplate     *template.Template
+	checksum         string
+	checksumFile     bool
+	retry            *jobs.RetryPolicy
+	timeout          time.Duration
+}
+
+func (u *UploadAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                uploadActionName,
+		Category:          actions.ActionCategoryPutGet,
+		Label:             "Upload to SFTP/FTP",
+		Icon:              "upload",
+		Description:       "Push files (or folders recursively) to an external SFTP, FTP or FTPS server. Credentials are read from the secrets vault, and each transfer is checked by reading back the remote file.",
+		InputDescription:  "Multiple selection of files or folders",
+		OutputDescription: "Same selection, with the list of transferred files as Json output",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (u *UploadAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "url",
+					Type:        forms.ParamString,
+					Label:       "Server URL",
+					Description: "Remote server and base folder, e.g. sftp://user@host:22/incoming or ftp://user@host/incoming",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "remotePath",
+					Type:        forms.ParamString,
+					Label:       "Remote Path",
+					Description: "Path of each file relative to the base folder. Template variables: {{.NodeName}}, {{.NodeBase}}, {{.NodeExt}}, {{.NodePath}}, {{.NodeUuid}}, {{.Date}}, {{.Time}}, {{.JobID}}",
+					Default:     uploadDefaultTemplate,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "passwordSecret",
+					Type:        forms.ParamString,
+					Label:       "Password Secret",
+					Description: "Key of the secret storing the password",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "privateKeySecret",
+					Type:        forms.ParamString,
+					Label:       "Private Key Secret",
+					Description: "Key of the secret storing a PEM-encoded private key (SFTP only)",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "passphraseSecret",
+					Type:        forms.ParamString,
+					Label:       "Passphrase Secret",
+					Description: "Key of the secret storing the private key passphrase, if any",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "hostKey",
+					Type:        forms.ParamString,
+					Label:       "Host Key",
+					Description: "Expected SFTP server key, in authorized_keys format or as a SHA256 fingerprint. Required for SFTP unless the check is disabled below.",
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "insecureHostKey",
+					Type:        forms.ParamBool,
+					Label:       "Skip Host Key Check",
+					Description: "Accept any SFTP server key. The identity of the server is not verified, use only for testing.",
+					Default:     false,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "checksum",
+					Type:        forms.ParamSelect,
+					Label:       "Checksum",
+					Description: "Hash compared after transfer by reading back the remote file",
+					Default:     "sha256",
+					Mandatory:   false,
+					Editable:    true,
+					ChoicePresetList: []map[string]string{
+						{"sha256": "SHA256"},
+						{"md5": "MD5"},
+						{"none": "Size only"},
+					},
+				},
+				&forms.FormField{
+					Name:        "checksumFile",
+					Type:        forms.ParamBool,
+					Label:       "Checksum File",
+					Description: "Write the checksum next to each file, in a file suffixed with the hash name",
+					Default:     false,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "retries",
+					Type:        forms.ParamInteger,
+					Label:       "Attempts",
+					Description: "Maximum number of attempts for each file",
+					Default:     uploadDefaultRetries,
+					Mandatory:   false,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "retryDelay",
+					Type:        forms.ParamString,
+					Label:       "Retry Delay",
+					Description: "Delay before the first retry, doubled after each attempt",
+					Default:     uploadDefaultDelay,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns the unique identifier of this action
+func (u *UploadAction) GetName() string {
+	return uploadActionName
+}
+
+func (u *UploadAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters
+func (u *UploadAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	p := action.Parameters
+	u.remoteURL = p["url"]
+	if u.remoteURL == "" {
+		return errors.BadRequest(common.SERVICE_TASKS, "missing parameter url in Action")
+	}
+	if _, e := ParseRemoteURL(u.remoteURL); e != nil {
+		return errors.BadRequest(common.SERVICE_TASKS, "invalid url: %s", e.Error())
+	}
+	u.passwordSecret = p["passwordSecret"]
+	u.privateKeySecret = p["privateKeySecret"]
+	u.passphraseSecret = p["passphraseSecret"]
+	u.hostKey = strings.TrimSpace(p["hostKey"])
+	u.insecureHostKey = p["insecureHostKey"] == "true"
+
+	tpl := p["remotePath"]
+	if tpl == "" {
+		tpl = uploadDefaultTemplate
+	}
+	var e error
+	if u.pathTemplate, e = template.New("remotePath").Option("missingkey=zero").Parse(tpl); e != nil {
+		return errors.BadRequest(common.SERVICE_TASKS, "invalid remotePath template: %s", e.Error())
+	}
+
+	u.checksum = p["checksum"]
+	switch u.checksum {
+	case "":
+		u.checksum = "sha256"
+	case "sha256", "md5", "none":
+	default:
+		return errors.BadRequest(common.SERVICE_TASKS, "unsupported checksum %s", u.checksum)
+	}
+	u.checksumFile = p["checksumFile"] == "true" && u.checksum != "none"
+
+	attempts := uploadDefaultRetries
+	if r, ok := p["retries"]; ok && r != "" {
+		if attempts, e = strconv.Atoi(r); e != nil {
+			return errors.BadRequest(common.SERVICE_TASKS, "invalid retries value %s", r)
+		}
+	}
+	delay := p["retryDelay"]
+	if delay == "" {
+		delay = uploadDefaultDelay
+	}
+	u.retry = &jobs.RetryPolicy{MaxAttempts: int32(attempts), InitialBackoff: delay}
+	if e := u.retry.Check(); e != nil {
+		return errors.BadRequest(common.SERVICE_TASKS, "invalid retry policy: %s", e.Error())
+	}
+	if remote, _ := ParseRemoteURL(u.remoteURL); remote.Scheme == "sftp" && u.hostKey == "" && !u.insecureHostKey {
+		return errors.BadRequest(common.SERVICE_TASKS, "missing parameter hostKey for sftp, or enable insecureHostKey")
+	}
+	u.timeout = uploadDefaultTimeout
+
+	if u.Secrets == nil {
+		u.Secrets = func(key string) string {
+			return config.GetSecret(key).String("")
+		}
+	}
+	if u.Router == nil {
+		u.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+	return nil
+}
+
+// Run the actual action code
+func (u *UploadAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil
+	}
+	conf, e := u.remoteConfig()
+	if e != nil {
+		return input.WithError(e), e
+	}
+	if conf.Scheme == "sftp" && conf.InsecureHostKey {
+		log.TasksLogger(ctx).Warn("Host key check is disabled, the identity of the SFTP server is not verified")
+	}
+
+	files, e := u.listFiles(ctx, input)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	jobID, _, _ := actions.TaskIdentifiers(ctx)
+	now := time.Now()
+
+	var remote Remote
+	defer func() {
+		if remote != nil {
+			remote.Close()
+		}
+	}()
+
+	var results []*UploadResult
+	var failures []string
+	for i, f := range files {
+		remotePath, er := u.remotePath(f, jobID, now)
+		if er != nil {
+			return input.WithError(er), er
+		}
+		remotePath = conf.Resolve(remotePath)
+		if channels != nil {
+			channels.StatusMsg <- "Uploading " + path.Base(f.node.Path)
+		}
+		result := &UploadResult{NodePath: f.node.Path, RemotePath: remotePath}
+		var lastErr error
+		for attempt := 1; attempt <= u.retry.Attempts(); attempt++ {
+			result.Attempts = attempt
+			if attempt > 1 {
+				delay := u.retry.Backoff(attempt - 1)
+				log.TasksLogger(ctx).Info(fmt.Sprintf("Retrying upload of %s in %s (attempt %d/%d)", f.node.Path, delay, attempt, u.retry.Attempts()))
+				select {
+				case <-ctx.Done():
+					return input.WithError(ctx.Err()), ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+			if remote == nil {
+				if remote, lastErr = conf.Dial(); lastErr != nil {
+					remote = nil
+					log.TasksLogger(ctx).Error("Cannot connect to "+conf.Host, zap.Error(lastErr))
+					continue
+				}
+			}
+			if lastErr = u.uploadFile(ctx, remote, f.node, remotePath, result); lastErr == nil {
+				break
+			}
+			log.TasksLogger(ctx).Error("Upload of "+f.node.Path+" failed", zap.Error(lastErr))
+			// Connection may be broken, reconnect on next attempt
+			remote.Close()
+			remote = nil
+		}
+		if lastErr != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", f.node.Path, lastErr.Error()))
+		} else {
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Uploaded %s to %s (%d bytes)", f.node.Path, remotePath, result.Size))
+			results = append(results, result)
+		}
+		if channels != nil {
+			channels.Progress <- float32(i+1) / float32(len(files))
+		}
+	}
+
+	body, _ := json.Marshal(results)
+	if len(failures) > 0 {
+		e := errors.InternalServerError(common.SERVICE_TASKS, "%d file(s) could not be uploaded: %s", len(failures), strings.Join(failures, "; "))
+		output := input.WithError(e)
+		output.AppendOutput(&jobs.ActionOutput{JsonBody: body})
+		return output, e
+	}
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		JsonBody:   body,
+		StringBody: fmt.Sprintf("%d file(s) uploaded to %s", len(results), conf.Host),
+	})
+	return input, nil
+}
+
+// uploadFile transfers a file, then checks its size and checksum by reading it back
+func (u *UploadAction) uploadFile(ctx context.Context, remote Remote, node *tree.Node, remotePath string, result *UploadResult) error {
+
+	reader, e := u.Router.GetObject(ctx, node, &views.GetRequestData{StartOffset: 0, Length: -1})
+	if e != nil {
+		return e
+	}
+	defer reader.Close()
+	if e := remote.MkdirAll(path.Dir(remotePath)); e != nil {
+		return e
+	}
+	localHash := u.newHash()
+	var src io.Reader = reader
+	if localHash != nil {
+		src = io.TeeReader(reader, localHash)
+	}
+	written, e := remote.Put(remotePath, src)
+	if e != nil {
+		return e
+	}
+	if node.Size > 0 && written != node.Size {
+		return fmt.Errorf("size mismatch: %d bytes sent, expected %d", written, node.Size)
+	}
+
+	// Read back remote file
+	remoteReader, e := remote.Get(remotePath)
+	if e != nil {
+		return fmt.Errorf("cannot read back remote file: %s", e.Error())
+	}
+	remoteHash := u.newHash()
+	var dst io.Writer = ioutil.Discard
+	if remoteHash != nil {
+		dst = remoteHash
+	}
+	read, e := io.Copy(dst, remoteReader)
+	remoteReader.Close()
+	if e != nil {
+		return fmt.Errorf("cannot read back remote file: %s", e.Error())
+	}
+	if read != written {
+		return fmt.Errorf("size mismatch: remote file has %d bytes, %d were sent", read, written)
+	}
+	result.Size = written
+	if localHash == nil {
+		return nil
+	}
+	local := hex.EncodeToString(localHash.Sum(nil))
+	if remoteSum := hex.EncodeToString(remoteHash.Sum(nil)); remoteSum != local {
+		return fmt.Errorf("%s checksum mismatch: local %s, remote %s", u.checksum, local, remoteSum)
+	}
+	result.Checksum = u.checksum + ":" + local
+	if u.checksumFile {
+		line := fmt.Sprintf("%s  %s\n", local, path.Base(remotePath))
+		if _, e := remote.Put(remotePath+"."+u.checksum, bytes.NewBufferString(line)); e != nil {
+			return fmt.Errorf("cannot write checksum file: %s", e.Error())
+		}
+	}
+	return nil
+}
+
+func (u *UploadAction) newHash() hash.Hash {
+	switch u.checksum {
+	case "md5":
+		return md5.New()
+	case "sha256":
+		return sha256.New()
+	}
+	return nil
+}
+
+func (u *UploadAction) remoteConfig() (*RemoteConfig, error) {
+	conf, e := ParseRemoteURL(u.remoteURL)
+	if e != nil {
+		return nil, e
+	}
+	if u.passwordSecret != "" {
+		conf.Password = u.Secrets(u.passwordSecret)
+	}
+	if u.privateKeySecret != "" {
+		conf.PrivateKey = u.Secrets(u.privateKeySecret)
+	}
+	if u.passphraseSecret != "" {
+		conf.Passphrase = u.Secrets(u.passphraseSecret)
+	}
+	conf.HostKey = u.hostKey
+	conf.InsecureHostKey = u.insecureHostKey
+	conf.Timeout = u.timeout
+	return conf, nil
+}
+
+type uploadFile struct {
+	node *tree.Node
+	// path relative to the selected folder, for files found in folders
+	rel string
+}
+
+// listFiles expands folders recursively
+func (u *UploadAction) listFiles(ctx context.Context, input jobs.ActionMessage) ([]*uploadFile, error) {
+	var files []*uploadFile
+	for _, n := range input.Nodes {
+		resp, e := u.Router.ReadNode(ctx, &tree.ReadNodeRequest{Node: n})
+		if e != nil {
+			return nil, e
+		}
+		node := resp.Node
+		if node.IsLeaf() {
+			files = append(files, &uploadFile{node: node})
+			continue
+		}
+		e = u.Router.ListNodesWithCallback(ctx, &tree.ListNodesRequest{Node: node, Recursive: true}, func(ctx context.Context, child *tree.Node, err error) error {
+			if err != nil {
+				return err
+			}
+			if child.IsLeaf() && path.Base(child.Path) != common.PYDIO_SYNC_HIDDEN_FILE_META {
+				rel := strings.TrimPrefix(child.Path, strings.TrimSuffix(node.Path, "/")+"/")
+				files = append(files, &uploadFile{node: child, rel: path.Join(path.Base(node.Path), rel)})
+			}
+			return nil
+		}, true)
+		if e != nil {
+			return nil, e
+		}
+	}
+	return files, nil
+}
+
+// remotePath renders the path template for a file. Files found in folders keep their
+// relative path, placed in the folder computed by the template for their parent.
+func (u *UploadAction) remotePath(f *uploadFile, jobID string, now time.Time) (string, error) {
+	name := path.Base(f.node.Path)
+	ext := path.Ext(name)
+	vars := map[string]string{
+		"NodeName": name,
+		"NodeBase": strings.TrimSuffix(name, ext),
+		"NodeExt":  strings.TrimPrefix(ext, "."),
+		"NodePath": f.node.Path,
+		"NodeUuid": f.node.Uuid,
+		"Date":     now.Format("2006-01-02"),
+		"Time":     now.Format("150405"),
+		"JobID":    jobID,
+	}
+	if f.rel != "" {
+		vars["NodeName"] = f.rel
+		vars["NodeBase"] = strings.TrimSuffix(f.rel, ext)
+	}
+	buf := bytes.NewBuffer(nil)
+	if e := u.pathTemplate.Execute(buf, vars); e != nil {
+		return "", e
+	}
+	out := strings.TrimSpace(buf.String())
+	if out == "" || strings.HasSuffix(out, "/") {
+		out = path.Join(out, vars["NodeName"])
+	}
+	return out, nil
+}
@@ -0,0 +1,551 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/docstore"
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	autoTagActionName = "actions.tree.autotag"
+)
+
+const (
+	// AutoTagRulesDocStore is the docstore used to share rules tables between jobs
+	AutoTagRulesDocStore = "autotag_rules"
+	// Docstore used by the user-meta REST service to store the known values of tags namespaces
+	autoTagKnownTagsDocStore = "user_meta_tags"
+	autoTagDefaultNamespace  = "usermeta-tags"
+	autoTagDefaultMaxContent = 1024 * 1024
+	// Metadata set by the actions.images.exif action
+	autoTagExifNamespace = "ImageExif"
+)
+
+// AutoTagRule maps a set of criteria to a list of tags. All non-empty criteria must match.
+type AutoTagRule struct {
+	// Label used to report the matching rules
+	Label string `json:"Label"`
+	// Extensions without leading dot, case insensitive
+	Extensions []string `json:"Extensions,omitempty"`
+	// Mime types, possibly ending with a wildcard like "image/*"
+	MimeTypes []string `json:"MimeTypes,omitempty"`
+	// Regular expression applied to the node path
+	PathRegexp string `json:"PathRegexp,omitempty"`
+	// Size range in bytes, zero values are ignored
+	MinSize int64 `json:"MinSize,omitempty"`
+	MaxSize int64 `json:"MaxSize,omitempty"`
+	// Regular expressions applied on EXIF fields values, as extracted by the actions.images.exif action
+	Exif map[string]string `json:"Exif,omitempty"`
+	// Regular expression applied to the first bytes of the file content
+	ContentRegexp string `json:"ContentRegexp,omitempty"`
+	// User-meta namespace receiving the tags, defaults to the action namespace
+	Namespace string `json:"Namespace,omitempty"`
+	// Tags applied when the rule matches
+	Tags []string `json:"Tags"`
+	// Stop evaluating next rules when this one matches
+	Final bool `json:"Final,omitempty"`
+
+	pathRegexp    *regexp.Regexp
+	contentRegexp *regexp.Regexp
+	exifRegexps   map[string]*regexp.Regexp
+}
+
+// AutoTagMatch reports a matching rule for a given node
+type AutoTagMatch struct {
+	NodeUuid  string
+	NodePath  string
+	Rule      string
+	Namespace string
+	Tags      []string
+}
+
+// ParseAutoTagRules decodes and compiles a JSON-encoded rules table
+func ParseAutoTagRules(data string) ([]*AutoTagRule, error) {
+	var rules []*AutoTagRule
+	if strings.TrimSpace(data) == "" {
+		return rules, nil
+	}
+	if e := json.Unmarshal([]byte(data), &rules); e != nil {
+		return nil, fmt.Errorf("cannot decode rules: %s", e.Error())
+	}
+	for i, r := range rules {
+		if r.Label == "" {
+			r.Label = fmt.Sprintf("Rule %d", i+1)
+		}
+		if e := r.compile(); e != nil {
+			return nil, fmt.Errorf("%s: %s", r.Label, e.Error())
+		}
+	}
+	return rules, nil
+}
+
+func (r *AutoTagRule) compile() (e error) {
+	if len(r.Tags) == 0 {
+		return fmt.Errorf("rule must define at least one tag")
+	}
+	if r.PathRegexp != "" {
+		if r.pathRegexp, e = regexp.Compile(r.PathRegexp); e != nil {
+			return e
+		}
+	}
+	if r.ContentRegexp != "" {
+		if r.contentRegexp, e = regexp.Compile(r.ContentRegexp); e != nil {
+			return e
+		}
+	}
+	r.exifRegexps = make(map[string]*regexp.Regexp, len(r.Exif))
+	for field, exp := range r.Exif {
+		if r.exifRegexps[field], e = regexp.Compile(exp); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Match checks all criteria against the node. The content func is only called if the rule requires it.
+func (r *AutoTagRule) Match(node *tree.Node, content func() []byte) bool {
+	if len(r.Extensions) > 0 {
+		ext := strings.ToLower(strings.TrimPrefix(path.Ext(node.Path), "."))
+		if !r.matchAny(r.Extensions, func(s string) bool { return strings.ToLower(strings.TrimPrefix(s, ".")) == ext }) {
+			return false
+		}
+	}
+	if r.MinSize > 0 && node.Size < r.MinSize {
+		return false
+	}
+	if r.MaxSize > 0 && node.Size > r.MaxSize {
+		return false
+	}
+	if r.pathRegexp != nil && !r.pathRegexp.MatchString(node.Path) {
+		return false
+	}
+	if len(r.MimeTypes) > 0 {
+		mimeType := autoTagMimeType(node, content)
+		if !r.matchAny(r.MimeTypes, func(s string) bool {
+			if strings.HasSuffix(s, "*") {
+				return strings.HasPrefix(mimeType, strings.TrimSuffix(s, "*"))
+			}
+			return mimeType == s
+		}) {
+			return false
+		}
+	}
+	if len(r.exifRegexps) > 0 {
+		var exif map[string]interface{}
+		if e := node.GetMeta(autoTagExifNamespace, &exif); e != nil || exif == nil {
+			return false
+		}
+		for field, exp := range r.exifRegexps {
+			value, ok := exif[field]
+			if !ok || !exp.MatchString(autoTagExifValue(value)) {
+				return false
+			}
+		}
+	}
+	if r.contentRegexp != nil {
+		data := content()
+		if data == nil || !r.contentRegexp.Match(data) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *AutoTagRule) matchAny(values []string, test func(string) bool) bool {
+	for _, v := range values {
+		if test(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// autoTagMimeType guesses the mime type from the extension, or by sniffing the content
+func autoTagMimeType(node *tree.Node, content func() []byte) string {
+	if m := mime.TypeByExtension(path.Ext(node.Path)); m != "" {
+		return strings.Split(m, ";")[0]
+	}
+	if data := content(); data != nil {
+		return strings.Split(http.DetectContentType(data), ";")[0]
+	}
+	return ""
+}
+
+func autoTagExifValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.Trim(v, "\" ")
+	case []interface{}:
+		var parts []string
+		for _, p := range v {
+			parts = append(parts, autoTagExifValue(p))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// AutoTagAction applies user-meta tags to nodes based on a rules table
+type AutoTagAction struct {
+	Client     tree.NodeReceiverClient
+	Router     views.Handler
+	Namespaces map[string]*idm.UserMetaNamespace
+
+	rules            []*AutoTagRule
+	rulesDocument    string
+	defaultNamespace string
+	maxContentSize   int64
+	cl               client.Client
+}
+
+func (c *AutoTagAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                autoTagActionName,
+		Label:             "Auto Tag",
+		Icon:              "tag-text-outline",
+		Category:          actions.ActionCategoryTree,
+		Description:       "Classify files by applying tags to user-defined metadata, based on a table of rules using extension, mime type, path, size, EXIF fields or content",
+		InputDescription:  "Multiple selection of files",
+		OutputDescription: "Updated selection of files, with the list of matching rules as Json output",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (c *AutoTagAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "rules",
+					Type:        forms.ParamTextarea,
+					Label:       "Rules",
+					Description: "Json-encoded list of rules, e.g. [{\"Label\":\"Invoices\",\"Extensions\":[\"pdf\"],\"PathRegexp\":\"(?i)invoice\",\"Tags\":[\"invoice\"]}]. Available criteria: Extensions, MimeTypes, PathRegexp, MinSize, MaxSize, Exif (map of field names to regexp), ContentRegexp. Each rule may also set Namespace and Final.",
+					Default:     "",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "rulesDocument",
+					Type:        forms.ParamString,
+					Label:       "Rules Document",
+					Description: "Identifier of a document of the " + AutoTagRulesDocStore + " docstore containing the rules, appended to the rules above",
+					Default:     "",
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "namespace",
+					Type:        forms.ParamString,
+					Label:       "Default Namespace",
+					Description: "User-defined metadata namespace receiving the tags when the rule does not specify one",
+					Default:     autoTagDefaultNamespace,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "maxContentSize",
+					Type:        forms.ParamInteger,
+					Label:       "Content Sample Size",
+					Description: "Number of bytes read at the beginning of the files to evaluate content rules",
+					Default:     autoTagDefaultMaxContent,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *AutoTagAction) GetName() string {
+	return autoTagActionName
+}
+
+// Init passes parameters to the action
+func (c *AutoTagAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+
+	rules, e := ParseAutoTagRules(action.Parameters["rules"])
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "invalid rules for action autotag: %s", e.Error())
+	}
+	c.rules = rules
+	c.rulesDocument = action.Parameters["rulesDocument"]
+	if len(c.rules) == 0 && c.rulesDocument == "" {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide rules or a rules document for action autotag")
+	}
+	c.defaultNamespace = autoTagDefaultNamespace
+	if ns, ok := action.Parameters["namespace"]; ok && ns != "" {
+		c.defaultNamespace = ns
+	}
+	c.maxContentSize = autoTagDefaultMaxContent
+	if m, ok := action.Parameters["maxContentSize"]; ok {
+		if size, er := strconv.ParseInt(m, 10, 64); er == nil && size > 0 {
+			c.maxContentSize = size
+		}
+	}
+	c.cl = cl
+	if c.Client == nil {
+		c.Client = tree.NewNodeReceiverClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_META, cl)
+	}
+	if c.Router == nil {
+		c.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+
+	return nil
+}
+
+// Run the actual action code
+func (c *AutoTagAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	return c.run(ctx, input, false)
+}
+
+// Simulate implements SimulatableAction: rules are evaluated and metadata is set on the input nodes without updating them
+func (c *AutoTagAction) Simulate(ctx context.Context, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	return c.run(ctx, input, true)
+}
+
+func (c *AutoTagAction) run(ctx context.Context, input jobs.ActionMessage, dryRun bool) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil // Ignore
+	}
+	rules, e := c.loadRules(ctx)
+	if e != nil {
+		return input.WithError(e), e
+	}
+	namespaces, e := c.loadNamespaces(ctx)
+	if e != nil {
+		return input.WithError(e), e
+	}
+
+	var matches []*AutoTagMatch
+	newTags := make(map[string][]string)
+	for _, n := range input.Nodes {
+		if n.Type == tree.NodeType_COLLECTION {
+			continue
+		}
+		updates := make(map[string][]string)
+		var sample []byte
+		var sampleRead bool
+		content := func() []byte {
+			if !sampleRead {
+				sampleRead = true
+				sample = c.readSample(ctx, n)
+			}
+			return sample
+		}
+		for _, rule := range rules {
+			if !rule.Match(n, content) {
+				continue
+			}
+			ns := rule.Namespace
+			if ns == "" {
+				ns = c.defaultNamespace
+			}
+			if _, ok := namespaces[ns]; !ok {
+				log.TasksLogger(ctx).Error(fmt.Sprintf("Rule %s matched %s but namespace %s is not a user-defined metadata", rule.Label, path.Base(n.Path), ns))
+				continue
+			}
+			matches = append(matches, &AutoTagMatch{NodeUuid: n.Uuid, NodePath: n.Path, Rule: rule.Label, Namespace: ns, Tags: rule.Tags})
+			updates[ns] = append(updates[ns], rule.Tags...)
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Rule %s matched %s: applying tags %s", rule.Label, path.Base(n.Path), strings.Join(rule.Tags, ", ")))
+			if rule.Final {
+				break
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		metaNode := &tree.Node{Uuid: n.Uuid, Path: n.Path, MetaStore: make(map[string]string)}
+		for ns, tags := range updates {
+			value := autoTagValue(namespaces[ns], n.GetStringMeta(ns), tags)
+			n.SetMeta(ns, value)
+			metaNode.SetMeta(ns, value)
+			if autoTagIsTagsNamespace(namespaces[ns]) {
+				newTags[ns] = append(newTags[ns], tags...)
+			}
+		}
+		if dryRun {
+			continue
+		}
+		if _, e := c.Client.UpdateNode(ctx, &tree.UpdateNodeRequest{From: metaNode, To: metaNode}); e != nil {
+			return input.WithError(e), e
+		}
+	}
+	if !dryRun {
+		for ns, tags := range newTags {
+			if e := c.storeKnownTags(ctx, ns, tags); e != nil {
+				log.TasksLogger(ctx).Error("Cannot store known tags for namespace "+ns, zap.Error(e))
+			}
+		}
+	}
+
+	body, _ := json.Marshal(matches)
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		JsonBody:   body,
+		StringBody: fmt.Sprintf("%d rule(s) matched", len(matches)),
+	})
+
+	return input, nil
+}
+
+// loadRules appends the rules stored in the docstore, if any, to the rules passed in parameters
+func (c *AutoTagAction) loadRules(ctx context.Context) ([]*AutoTagRule, error) {
+	if c.rulesDocument == "" {
+		return c.rules, nil
+	}
+	docClient := docstore.NewDocStoreClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_DOCSTORE, c.cl)
+	r, e := docClient.GetDocument(ctx, &docstore.GetDocumentRequest{StoreID: AutoTagRulesDocStore, DocumentID: c.rulesDocument})
+	if e != nil {
+		return nil, fmt.Errorf("cannot load rules document %s: %s", c.rulesDocument, e.Error())
+	}
+	if r.Document == nil {
+		return nil, fmt.Errorf("cannot find rules document %s", c.rulesDocument)
+	}
+	docRules, e := ParseAutoTagRules(r.Document.Data)
+	if e != nil {
+		return nil, e
+	}
+	return append(append([]*AutoTagRule{}, c.rules...), docRules...), nil
+}
+
+// loadNamespaces lists the user-defined metadata namespaces, tags can only be applied to existing ones
+func (c *AutoTagAction) loadNamespaces(ctx context.Context) (map[string]*idm.UserMetaNamespace, error) {
+	if c.Namespaces != nil {
+		return c.Namespaces, nil
+	}
+	metaClient := idm.NewUserMetaServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_USER_META, c.cl)
+	stream, e := metaClient.ListUserMetaNamespace(ctx, &idm.ListUserMetaNamespaceRequest{})
+	if e != nil {
+		return nil, e
+	}
+	defer stream.Close()
+	namespaces := make(map[string]*idm.UserMetaNamespace)
+	for {
+		resp, er := stream.Recv()
+		if er != nil {
+			break
+		}
+		if resp == nil {
+			continue
+		}
+		namespaces[resp.UserMetaNamespace.Namespace] = resp.UserMetaNamespace
+	}
+	return namespaces, nil
+}
+
+func (c *AutoTagAction) readSample(ctx context.Context, node *tree.Node) []byte {
+	if c.Router == nil || node.Size == 0 {
+		return nil
+	}
+	reader, e := c.Router.GetObject(ctx, node, &views.GetRequestData{StartOffset: 0, Length: c.maxContentSize})
+	if e != nil {
+		log.Logger(ctx).Debug("Cannot read content for autotag", zap.Error(e))
+		return nil
+	}
+	defer reader.Close()
+	data, e := ioutil.ReadAll(io.LimitReader(reader, c.maxContentSize))
+	if e != nil {
+		return nil
+	}
+	return data
+}
+
+// storeKnownTags registers new values in the list of tags used for auto-completion
+func (c *AutoTagAction) storeKnownTags(ctx context.Context, namespace string, tags []string) error {
+	docClient := docstore.NewDocStoreClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_DOCSTORE, c.cl)
+	var known []string
+	doc := &docstore.Document{ID: namespace}
+	if r, e := docClient.GetDocument(ctx, &docstore.GetDocumentRequest{StoreID: autoTagKnownTagsDocStore, DocumentID: namespace}); e == nil && r.Document != nil {
+		doc = r.Document
+		json.Unmarshal([]byte(doc.Data), &known)
+	}
+	merged := autoTagMerge(known, tags)
+	if len(merged) == len(known) {
+		return nil
+	}
+	data, _ := json.Marshal(merged)
+	doc.Data = string(data)
+	_, e := docClient.PutDocument(ctx, &docstore.PutDocumentRequest{StoreID: autoTagKnownTagsDocStore, DocumentID: namespace, Document: doc})
+	return e
+}
+
+func autoTagIsTagsNamespace(ns *idm.UserMetaNamespace) bool {
+	var def map[string]interface{}
+	if e := json.Unmarshal([]byte(ns.JsonDefinition), &def); e != nil {
+		return false
+	}
+	t, _ := def["type"].(string)
+	return t == "tags"
+}
+
+// autoTagValue computes the new meta value: tags namespaces receive a comma-separated list merged with
+// the existing value, other namespaces receive the last matching tag.
+func autoTagValue(ns *idm.UserMetaNamespace, current string, tags []string) string {
+	if !autoTagIsTagsNamespace(ns) {
+		return tags[len(tags)-1]
+	}
+	var existing []string
+	for _, t := range strings.Split(current, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			existing = append(existing, t)
+		}
+	}
+	return strings.Join(autoTagMerge(existing, tags), ",")
+}
+
+func autoTagMerge(existing []string, tags []string) []string {
+	seen := make(map[string]bool, len(existing))
+	merged := append([]string{}, existing...)
+	for _, t := range existing {
+		seen[t] = true
+	}
+	for _, t := range tags {
+		if !seen[t] {
+			seen[t] = true
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+func TestAutoTagRules(t *testing.T) {
+
+	Convey("Parse rules", t, func() {
+		rules, e := ParseAutoTagRules("")
+		So(e, ShouldBeNil)
+		So(rules, ShouldBeEmpty)
+
+		_, e = ParseAutoTagRules("not json")
+		So(e, ShouldNotBeNil)
+
+		_, e = ParseAutoTagRules(`[{"Label":"no tags"}]`)
+		So(e, ShouldNotBeNil)
+
+		_, e = ParseAutoTagRules(`[{"PathRegexp":"(invalid","Tags":["a"]}]`)
+		So(e, ShouldNotBeNil)
+
+		rules, e = ParseAutoTagRules(`[{"Tags":["a"]}]`)
+		So(e, ShouldBeNil)
+		So(rules[0].Label, ShouldEqual, "Rule 1")
+	})
+
+	Convey("Match criteria", t, func() {
+		noContent := func() []byte { return nil }
+		pdf := &tree.Node{Path: "docs/Invoice-2018.PDF", Size: 2048}
+
+		rules, _ := ParseAutoTagRules(`[
+			{"Extensions":["pdf"],"Tags":["ext"]},
+			{"MimeTypes":["application/*"],"Tags":["mime"]},
+			{"PathRegexp":"(?i)invoice","Tags":["path"]},
+			{"MinSize":1024,"MaxSize":4096,"Tags":["size"]},
+			{"MinSize":4096,"Tags":["big"]},
+			{"Extensions":["jpg"],"PathRegexp":"invoice","Tags":["both"]}
+		]`)
+		So(rules[0].Match(pdf, noContent), ShouldBeTrue)
+		So(rules[1].Match(pdf, noContent), ShouldBeTrue)
+		So(rules[2].Match(pdf, noContent), ShouldBeTrue)
+		So(rules[3].Match(pdf, noContent), ShouldBeTrue)
+		So(rules[4].Match(pdf, noContent), ShouldBeFalse)
+		So(rules[5].Match(pdf, noContent), ShouldBeFalse)
+	})
+
+	Convey("Match content and sniffed mime type", t, func() {
+		var reads int
+		content := func() []byte {
+			reads++
+			return []byte("%PDF-1.4 Total amount due")
+		}
+		node := &tree.Node{Path: "scan-without-extension", Size: 25}
+		rules, _ := ParseAutoTagRules(`[
+			{"MimeTypes":["application/pdf"],"Tags":["pdf"]},
+			{"ContentRegexp":"amount due","Tags":["invoice"]},
+			{"ContentRegexp":"salary","Tags":["hr"]}
+		]`)
+		So(rules[0].Match(node, content), ShouldBeTrue)
+		So(rules[1].Match(node, content), ShouldBeTrue)
+		So(rules[2].Match(node, content), ShouldBeFalse)
+		So(reads, ShouldEqual, 3)
+		So(rules[1].Match(node, func() []byte { return nil }), ShouldBeFalse)
+	})
+
+	Convey("Match EXIF fields", t, func() {
+		node := &tree.Node{Path: "photo.jpg", MetaStore: map[string]string{}}
+		rules, _ := ParseAutoTagRules(`[{"Exif":{"Model":"^Canon","ISOSpeedRatings":"^100$"},"Tags":["canon"]}]`)
+		So(rules[0].Match(node, nil), ShouldBeFalse)
+
+		node.SetMeta(autoTagExifNamespace, map[string]interface{}{"Model": "\"Canon EOS\"", "ISOSpeedRatings": []interface{}{100}})
+		So(rules[0].Match(node, nil), ShouldBeTrue)
+
+		node.SetMeta(autoTagExifNamespace, map[string]interface{}{"Model": "\"Nikon\"", "ISOSpeedRatings": []interface{}{100}})
+		So(rules[0].Match(node, nil), ShouldBeFalse)
+	})
+
+	Convey("Compute values", t, func() {
+		tags := &idm.UserMetaNamespace{Namespace: "usermeta-tags", JsonDefinition: `{"type":"tags"}`}
+		text := &idm.UserMetaNamespace{Namespace: "usermeta-class", JsonDefinition: `{"type":"string"}`}
+		So(autoTagValue(tags, "", []string{"a", "b"}), ShouldEqual, "a,b")
+		So(autoTagValue(tags, "b, c", []string{"a", "b"}), ShouldEqual, "b,c,a")
+		So(autoTagValue(text, "old", []string{"a", "b"}), ShouldEqual, "b")
+	})
+
+}
+
+func TestAutoTagAction_Init(t *testing.T) {
+
+	Convey("Init", t, func() {
+		action := &AutoTagAction{}
+		So(action.GetName(), ShouldEqual, autoTagActionName)
+		e := action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{}})
+		So(e, ShouldNotBeNil)
+		e = action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"rules": "[{]"}})
+		So(e, ShouldNotBeNil)
+		e = action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{
+			"rules":          `[{"Extensions":["pdf"],"Tags":["pdf"]}]`,
+			"maxContentSize": "512",
+		}})
+		So(e, ShouldBeNil)
+		So(action.rules, ShouldHaveLength, 1)
+		So(action.defaultNamespace, ShouldEqual, autoTagDefaultNamespace)
+		So(action.maxContentSize, ShouldEqual, 512)
+	})
+
+}
+
+func TestAutoTagAction_Run(t *testing.T) {
+
+	Convey("Run", t, func() {
+		mock := &views.HandlerMock{Nodes: map[string]*tree.Node{"reports/file.txt": {Path: "reports/file.txt"}}}
+		action := &AutoTagAction{Client: mock, Router: mock}
+		action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{
+			"rules": `[
+				{"Label":"Reports","PathRegexp":"^reports/","Tags":["report"]},
+				{"Label":"Hello","ContentRegexp":"hello world","Tags":["hello"],"Final":true},
+				{"Label":"Unreached","Extensions":["txt"],"Tags":["text"]},
+				{"Label":"Unknown","Extensions":["txt"],"Namespace":"unknown","Tags":["unknown"]}
+			]`,
+		}})
+		So(action.Router, ShouldEqual, mock)
+		So(action.Client, ShouldEqual, mock)
+		action.Namespaces = map[string]*idm.UserMetaNamespace{
+			autoTagDefaultNamespace: {Namespace: autoTagDefaultNamespace, JsonDefinition: `{"type":"tags"}`},
+		}
+		channels := &actions.RunnableChannels{StatusMsg: make(chan string), Progress: make(chan float32)}
+
+		ignored, _ := action.Run(context.Background(), channels, jobs.ActionMessage{})
+		So(ignored.GetLastOutput().Ignored, ShouldBeTrue)
+
+		node := &tree.Node{Uuid: "uuid", Path: "reports/file.txt", Size: 12, Type: tree.NodeType_LEAF, MetaStore: map[string]string{}}
+		node.SetMeta(autoTagDefaultNamespace, "existing")
+		output, e := action.Run(context.Background(), channels, jobs.ActionMessage{Nodes: []*tree.Node{node}})
+		So(e, ShouldBeNil)
+		So(output.Nodes[0].GetStringMeta(autoTagDefaultNamespace), ShouldEqual, "existing,report,hello")
+
+		updated := mock.Nodes["to"]
+		So(updated, ShouldNotBeNil)
+		So(updated.Uuid, ShouldEqual, "uuid")
+		So(updated.GetStringMeta(autoTagDefaultNamespace), ShouldEqual, "existing,report,hello")
+
+		var matches []*AutoTagMatch
+		So(json.Unmarshal(output.GetLastOutput().JsonBody, &matches), ShouldBeNil)
+		So(matches, ShouldHaveLength, 2)
+		So(matches[0].Rule, ShouldEqual, "Reports")
+		So(matches[1].Rule, ShouldEqual, "Hello")
+	})
+
+	Convey("Simulate", t, func() {
+		mock := &views.HandlerMock{Nodes: map[string]*tree.Node{}}
+		action := &AutoTagAction{Client: mock, Router: mock}
+		action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{
+			"rules": `[{"Label":"Text","Extensions":["txt"],"Tags":["text"]}]`,
+		}})
+		action.Namespaces = map[string]*idm.UserMetaNamespace{
+			autoTagDefaultNamespace: {Namespace: autoTagDefaultNamespace, JsonDefinition: `{"type":"tags"}`},
+		}
+		node := &tree.Node{Uuid: "uuid", Path: "file.txt", MetaStore: map[string]string{}}
+		output, e := action.Simulate(context.Background(), jobs.ActionMessage{Nodes: []*tree.Node{node}})
+		So(e, ShouldBeNil)
+		So(output.Nodes[0].GetStringMeta(autoTagDefaultNamespace), ShouldEqual, "text")
+		So(mock.Nodes["to"], ShouldBeNil)
+	})
+
+}
@@ -39,6 +39,18 @@ func init() {
 		return &MetaAction{}
 	})
 
+	manager.Register(autoTagActionName, func() actions.ConcreteAction {
+		return &AutoTagAction{}
+	})
+
+	manager.Register(tierMigrateActionName, func() actions.ConcreteAction {
+		return &TierMigrateAction{}
+	})
+
+	manager.Register(tierRecallActionName, func() actions.ConcreteAction {
+		return &TierRecallAction{}
+	})
+
 	manager.Register(snapshotActionName, func() actions.ConcreteAction {
 		return &SnapshotAction{}
 	})
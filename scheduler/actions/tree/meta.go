@@ -124,3 +124,21 @@ func (c *MetaAction) Run(ctx context.Context, channels *actions.RunnableChannels
 
 	return input, nil
 }
+
+// Simulate implements SimulatableAction: metadata is set on the input nodes without updating them
+func (c *MetaAction) Simulate(ctx context.Context, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil // Ignore
+	}
+
+	for _, n := range input.Nodes {
+		ns := jobs.EvaluateFieldStr(ctx, input, c.MetaNamespace)
+		val := jobs.EvaluateFieldStr(ctx, input, c.MetaValue)
+		n.SetMeta(ns, val)
+	}
+
+	input.AppendOutput(&jobs.ActionOutput{Success: true})
+
+	return input, nil
+}
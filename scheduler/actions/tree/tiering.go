@@ -0,0 +1,477 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/errors"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/forms"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/object"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	tierMigrateActionName = "actions.tree.tier.migrate"
+	tierRecallActionName  = "actions.tree.tier.recall"
+
+	tierDefaultFolder = "tiering"
+	// Copies are verified by reading the target node, which may take some time to be indexed
+	tierVerifyRetries = 5
+	tierVerifyDelay   = 1 * time.Second
+	// Tiered nodes are only looked up by the views once tiering is enabled, can be replaced in tests
+	enableTiering = views.EnableTiering
+)
+
+// TierResult reports the outcome of a tiering operation on a single node
+type TierResult struct {
+	NodeUuid string `json:"NodeUuid"`
+	NodePath string `json:"NodePath"`
+	Location string `json:"Location,omitempty"`
+	Status   string `json:"Status"`
+	Error    string `json:"Error,omitempty"`
+}
+
+const (
+	tierStatusDone    = "done"
+	tierStatusSkipped = "skipped"
+	tierStatusFailed  = "failed"
+)
+
+// tierClients gathers the clients shared by migrate and recall actions
+type tierClients struct {
+	Router     views.Handler
+	MetaClient tree.NodeReceiverClient
+	// Encrypted caches the encryption status of datasources, it can be preset for tests
+	Encrypted map[string]bool
+
+	cl client.Client
+}
+
+func (t *tierClients) initClients(cl client.Client) {
+	t.cl = cl
+	if t.Router == nil {
+		t.Router = views.NewStandardRouter(views.RouterOptions{AdminView: true})
+	}
+	if t.MetaClient == nil {
+		t.MetaClient = tree.NewNodeReceiverClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_META, cl)
+	}
+	if t.Encrypted == nil {
+		t.Encrypted = make(map[string]bool)
+	}
+}
+
+// isEncrypted checks whether a datasource uses encryption. Encrypted content cannot be copied
+// as is to another datasource, as the encryption materials are bound to the original node.
+func (t *tierClients) isEncrypted(ctx context.Context, dsName string) (bool, error) {
+	if enc, ok := t.Encrypted[dsName]; ok {
+		return enc, nil
+	}
+	dsClient := object.NewDataSourceEndpointClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_DATA_SYNC_+dsName, t.cl)
+	resp, e := dsClient.GetDataSourceConfig(ctx, &object.GetDataSourceConfigRequest{})
+	if e != nil {
+		return false, e
+	}
+	enc := resp.DataSource != nil && resp.DataSource.EncryptionMode != object.EncryptionMode_CLEAR
+	t.Encrypted[dsName] = enc
+	return enc, nil
+}
+
+// setLocation stores (or clears if loc is nil) the tiering metadata of a node
+func (t *tierClients) setLocation(ctx context.Context, node *tree.Node, loc *views.TieredLocation) error {
+	metaNode := &tree.Node{Uuid: node.Uuid, Path: node.Path, MetaStore: make(map[string]string)}
+	if loc == nil {
+		metaNode.MetaStore[common.META_NAMESPACE_TIERING] = ""
+	} else {
+		metaNode.SetMeta(common.META_NAMESPACE_TIERING, loc)
+	}
+	_, e := t.MetaClient.UpdateNode(ctx, &tree.UpdateNodeRequest{From: metaNode, To: metaNode})
+	return e
+}
+
+// readRaw reads a node as stored, without the tiering properties
+func (t *tierClients) readRaw(ctx context.Context, node *tree.Node) (*tree.Node, error) {
+	resp, e := t.Router.ReadNode(views.WithTieringBypass(ctx), &tree.ReadNodeRequest{Node: node})
+	if e != nil {
+		return nil, e
+	}
+	return resp.Node, nil
+}
+
+// verifyCopy waits for the copied node to be indexed and checks its size
+func (t *tierClients) verifyCopy(ctx context.Context, target *tree.Node, size int64) (*tree.Node, error) {
+	var lastErr error
+	for i := 0; i < tierVerifyRetries; i++ {
+		if i > 0 {
+			<-time.After(tierVerifyDelay)
+		}
+		resp, e := t.Router.ReadNode(ctx, &tree.ReadNodeRequest{Node: &tree.Node{Path: target.Path}})
+		if e != nil {
+			lastErr = e
+			continue
+		}
+		if resp.Node.Size != size {
+			return nil, fmt.Errorf("size mismatch after copy to %s (expected %d, got %d)", target.Path, size, resp.Node.Size)
+		}
+		return resp.Node, nil
+	}
+	return nil, fmt.Errorf("cannot verify copy to %s: %v", target.Path, lastErr)
+}
+
+func tierOutput(input jobs.ActionMessage, results []*TierResult, verb string) (jobs.ActionMessage, error) {
+	var done, failed int
+	for _, r := range results {
+		switch r.Status {
+		case tierStatusDone:
+			done++
+		case tierStatusFailed:
+			failed++
+		}
+	}
+	body, _ := json.Marshal(results)
+	summary := fmt.Sprintf("%d file(s) %s, %d failed, %d skipped", done, verb, failed, len(results)-done-failed)
+	if failed > 0 && done == 0 {
+		e := errors.InternalServerError(common.SERVICE_JOBS, "tiering failed: %s", summary)
+		output := input.WithError(e)
+		output.AppendOutput(&jobs.ActionOutput{JsonBody: body, StringBody: summary})
+		return output, e
+	}
+	input.AppendOutput(&jobs.ActionOutput{
+		Success:    true,
+		JsonBody:   body,
+		StringBody: summary,
+	})
+	return input, nil
+}
+
+// TierMigrateAction moves the content of files to another datasource, keeping a stub in place.
+// Files keep their UUID and metadata, reads are transparently served from the tier location.
+type TierMigrateAction struct {
+	tierClients
+	targetDataSource string
+	targetFolder     string
+}
+
+func (c *TierMigrateAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                tierMigrateActionName,
+		Label:             "Tier Migrate",
+		Icon:              "archive-arrow-down",
+		Category:          actions.ActionCategoryTree,
+		Description:       "Move the content of files to a cheaper datasource. Files stay in place with their metadata, and are transparently read from the target datasource. Use a nodes selector with a relative date range to pick files that were not modified recently.",
+		InputDescription:  "Multiple selection of files",
+		OutputDescription: "Same selection of files, with the per-file migration status as Json output",
+		SummaryTemplate:   "",
+		HasForm:           true,
+	}
+}
+
+func (c *TierMigrateAction) GetParametersForm() *forms.Form {
+	return &forms.Form{Groups: []*forms.Group{
+		{
+			Fields: []forms.Field{
+				&forms.FormField{
+					Name:        "targetDataSource",
+					Type:        "string",
+					Label:       "Target DataSource",
+					Description: "Name of the datasource receiving the content of the files. Encrypted datasources are not supported.",
+					Mandatory:   true,
+					Editable:    true,
+				},
+				&forms.FormField{
+					Name:        "targetFolder",
+					Type:        "string",
+					Label:       "Target Folder",
+					Description: "Folder of the target datasource where content is stored",
+					Default:     tierDefaultFolder,
+					Mandatory:   false,
+					Editable:    true,
+				},
+			},
+		},
+	}}
+}
+
+// GetName returns this action unique identifier
+func (c *TierMigrateAction) GetName() string {
+	return tierMigrateActionName
+}
+
+func (c *TierMigrateAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (c *TierMigrateAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.targetDataSource = action.Parameters["targetDataSource"]
+	if c.targetDataSource == "" {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide a target datasource for action tier migrate")
+	}
+	c.targetFolder = strings.Trim(action.Parameters["targetFolder"], "/")
+	if c.targetFolder == "" {
+		c.targetFolder = tierDefaultFolder
+	}
+	c.initClients(cl)
+	return nil
+}
+
+// Run the actual action code
+func (c *TierMigrateAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil // Ignore
+	}
+	if enc, e := c.isEncrypted(ctx, c.targetDataSource); e != nil {
+		return input.WithError(e), e
+	} else if enc {
+		e := errors.BadRequest(common.SERVICE_JOBS, "target datasource %s is encrypted", c.targetDataSource)
+		return input.WithError(e), e
+	}
+	if e := enableTiering(); e != nil {
+		return input.WithError(e), e
+	}
+
+	var results []*TierResult
+	for i, n := range input.Nodes {
+		result := &TierResult{NodeUuid: n.Uuid, NodePath: n.Path}
+		if channels != nil {
+			channels.StatusMsg <- "Migrating " + path.Base(n.Path)
+		}
+		loc, skip, e := c.migrate(ctx, n)
+		if e != nil {
+			result.Status = tierStatusFailed
+			result.Error = e.Error()
+			log.TasksLogger(ctx).Error("Cannot migrate "+n.Path, zap.Error(e))
+		} else if skip != "" {
+			result.Status = tierStatusSkipped
+			result.Error = skip
+			log.TasksLogger(ctx).Debug("Skipping " + n.Path + ": " + skip)
+		} else {
+			result.Status = tierStatusDone
+			result.Location = loc.Path
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Migrated %s to %s", n.Path, loc.Path))
+		}
+		results = append(results, result)
+		if channels != nil {
+			channels.Progress <- float32(i+1) / float32(len(input.Nodes))
+		}
+	}
+
+	return tierOutput(input, results, "migrated")
+}
+
+// migrate moves the content of a single node. It returns a reason if the node was skipped.
+func (c *TierMigrateAction) migrate(ctx context.Context, n *tree.Node) (*views.TieredLocation, string, error) {
+
+	node, e := c.readRaw(ctx, n)
+	if e != nil {
+		return nil, "", e
+	}
+	if !node.IsLeaf() {
+		return nil, "not a file", nil
+	}
+	if node.Size == 0 {
+		return nil, "empty file", nil
+	}
+	if path.Base(node.Path) == common.PYDIO_SYNC_HIDDEN_FILE_META {
+		return nil, "hidden file", nil
+	}
+	if node.Etag == common.NODE_FLAG_ETAG_TEMPORARY {
+		return nil, "file is being uploaded", nil
+	}
+	if _, ok := views.GetTieredLocation(node); ok {
+		return nil, "already migrated", nil
+	}
+	sourceDs := node.GetStringMeta(common.META_NAMESPACE_DATASOURCE_NAME)
+	if sourceDs == "" {
+		sourceDs = strings.Split(strings.Trim(node.Path, "/"), "/")[0]
+	}
+	if sourceDs == c.targetDataSource {
+		return nil, "already in target datasource", nil
+	}
+	if enc, e := c.isEncrypted(ctx, sourceDs); e != nil {
+		return nil, "", e
+	} else if enc {
+		return nil, "", fmt.Errorf("datasource %s is encrypted", sourceDs)
+	}
+
+	target := &tree.Node{
+		Path: path.Join(c.targetDataSource, c.targetFolder, node.Uuid+path.Ext(node.Path)),
+		Type: tree.NodeType_LEAF,
+		Size: node.Size,
+	}
+	if _, e := c.Router.CopyObject(ctx, node, target, &views.CopyRequestData{
+		Metadata: map[string]string{common.X_AMZ_META_DIRECTIVE: "REPLACE"},
+	}); e != nil {
+		return nil, "", e
+	}
+	copied, e := c.verifyCopy(ctx, target, node.Size)
+	if e != nil {
+		c.Router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: target})
+		return nil, "", e
+	}
+
+	loc := &views.TieredLocation{
+		DataSource: c.targetDataSource,
+		Path:       target.Path,
+		Uuid:       copied.Uuid,
+		Size:       node.Size,
+		Etag:       node.Etag,
+		MTime:      node.MTime,
+		TieredAt:   time.Now().Unix(),
+	}
+	// Metadata is set before replacing the content, so that the node is never seen empty
+	if e := c.setLocation(ctx, node, loc); e != nil {
+		c.Router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: target})
+		return nil, "", e
+	}
+	// The stub keeps the original size in the index
+	stubData := &views.PutRequestData{
+		Size:     0,
+		Metadata: map[string]string{common.X_AMZ_META_CLEAR_SIZE: fmt.Sprintf("%d", node.Size)},
+	}
+	if _, e := c.Router.PutObject(views.WithTieringBypass(ctx), node, bytes.NewReader([]byte{}), stubData); e != nil {
+		c.setLocation(ctx, node, nil)
+		c.Router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: target})
+		return nil, "", e
+	}
+	return loc, "", nil
+}
+
+// TierRecallAction brings the content of tiered files back to their original datasource.
+type TierRecallAction struct {
+	tierClients
+}
+
+func (c *TierRecallAction) GetDescription(lang ...string) actions.ActionDescription {
+	return actions.ActionDescription{
+		ID:                tierRecallActionName,
+		Label:             "Tier Recall",
+		Icon:              "archive-arrow-up",
+		Category:          actions.ActionCategoryTree,
+		Description:       "Restore the content of files previously moved by the Tier Migrate action to their original datasource",
+		InputDescription:  "Multiple selection of files",
+		OutputDescription: "Same selection of files, with the per-file recall status as Json output",
+		SummaryTemplate:   "",
+		HasForm:           false,
+	}
+}
+
+func (c *TierRecallAction) GetParametersForm() *forms.Form {
+	return nil
+}
+
+// GetName returns this action unique identifier
+func (c *TierRecallAction) GetName() string {
+	return tierRecallActionName
+}
+
+func (c *TierRecallAction) ProvidesProgress() bool {
+	return true
+}
+
+// Init passes parameters to the action
+func (c *TierRecallAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	c.initClients(cl)
+	return nil
+}
+
+// Run the actual action code
+func (c *TierRecallAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+
+	if len(input.Nodes) == 0 {
+		return input.WithIgnore(), nil // Ignore
+	}
+
+	var results []*TierResult
+	for i, n := range input.Nodes {
+		result := &TierResult{NodeUuid: n.Uuid, NodePath: n.Path}
+		if channels != nil {
+			channels.StatusMsg <- "Recalling " + path.Base(n.Path)
+		}
+		loc, e := c.recall(ctx, n)
+		if e != nil {
+			result.Status = tierStatusFailed
+			result.Error = e.Error()
+			log.TasksLogger(ctx).Error("Cannot recall "+n.Path, zap.Error(e))
+		} else if loc == nil {
+			result.Status = tierStatusSkipped
+			result.Error = "not migrated"
+		} else {
+			result.Status = tierStatusDone
+			result.Location = loc.Path
+			log.TasksLogger(ctx).Info(fmt.Sprintf("Recalled %s from %s", n.Path, loc.Path))
+		}
+		results = append(results, result)
+		if channels != nil {
+			channels.Progress <- float32(i+1) / float32(len(input.Nodes))
+		}
+	}
+
+	return tierOutput(input, results, "recalled")
+}
+
+// recall restores the content of a single node, it returns a nil location if the node is not tiered
+func (c *TierRecallAction) recall(ctx context.Context, n *tree.Node) (*views.TieredLocation, error) {
+
+	node, e := c.readRaw(ctx, n)
+	if e != nil {
+		return nil, e
+	}
+	loc, ok := views.GetTieredLocation(node)
+	if !ok {
+		return nil, nil
+	}
+	source := &tree.Node{Uuid: loc.Uuid, Path: loc.Path, Type: tree.NodeType_LEAF, Size: loc.Size}
+	reader, e := c.Router.GetObject(views.WithTieringBypass(ctx), source, &views.GetRequestData{StartOffset: 0, Length: -1})
+	if e != nil {
+		return nil, e
+	}
+	defer reader.Close()
+	written, e := c.Router.PutObject(views.WithTieringBypass(ctx), node, reader, &views.PutRequestData{Size: loc.Size})
+	if e != nil {
+		return nil, e
+	}
+	if written != loc.Size {
+		return nil, fmt.Errorf("size mismatch after recall from %s (expected %d, got %d)", loc.Path, loc.Size, written)
+	}
+	if e := c.setLocation(ctx, node, nil); e != nil {
+		return nil, e
+	}
+	if _, e := c.Router.DeleteNode(ctx, &tree.DeleteNodeRequest{Node: source}); e != nil {
+		log.TasksLogger(ctx).Error("Cannot delete tier copy "+loc.Path, zap.Error(e))
+	}
+	return loc, nil
+}
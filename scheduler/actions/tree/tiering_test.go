@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/common/views"
+)
+
+// tierRouterMock indexes copied nodes and records written content
+type tierRouterMock struct {
+	*views.HandlerMock
+	written map[string]string
+}
+
+func (m *tierRouterMock) CopyObject(ctx context.Context, from *tree.Node, to *tree.Node, requestData *views.CopyRequestData) (int64, error) {
+	m.Nodes[to.Path] = &tree.Node{Uuid: "copy-" + from.Uuid, Path: to.Path, Size: from.Size, Type: tree.NodeType_LEAF}
+	return from.Size, nil
+}
+
+func (m *tierRouterMock) PutObject(ctx context.Context, node *tree.Node, reader io.Reader, requestData *views.PutRequestData) (int64, error) {
+	data, _ := ioutil.ReadAll(reader)
+	m.written[node.Path] = string(data)
+	return int64(len(data)), nil
+}
+
+func newTierRouterMock() *tierRouterMock {
+	m := &tierRouterMock{HandlerMock: views.NewHandlerMock(), written: make(map[string]string)}
+	m.Nodes["pydiods1/old.txt"] = &tree.Node{Uuid: "old-uuid", Path: "pydiods1/old.txt", Size: 1024, Etag: "etag", MTime: 1500000000, Type: tree.NodeType_LEAF}
+	m.Nodes["pydiods1/empty.txt"] = &tree.Node{Uuid: "empty-uuid", Path: "pydiods1/empty.txt", Size: 0, Type: tree.NodeType_LEAF}
+	m.Nodes["pydiods1/folder"] = &tree.Node{Uuid: "folder-uuid", Path: "pydiods1/folder", Type: tree.NodeType_COLLECTION}
+	return m
+}
+
+func TestTierMigrateAction(t *testing.T) {
+
+	tierVerifyDelay = 0
+	enableTiering = func() error { return nil }
+
+	Convey("Init requires a target datasource", t, func() {
+		action := &TierMigrateAction{}
+		action.Router = newTierRouterMock()
+		action.MetaClient = views.NewHandlerMock()
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{}}), ShouldNotBeNil)
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"targetDataSource": "cold"}}), ShouldBeNil)
+		So(action.targetFolder, ShouldEqual, tierDefaultFolder)
+	})
+
+	Convey("Migrate files to the target datasource", t, func() {
+		router := newTierRouterMock()
+		meta := views.NewHandlerMock()
+		action := &TierMigrateAction{}
+		action.Router = router
+		action.MetaClient = meta
+		action.Encrypted = map[string]bool{"cold": false, "pydiods1": false}
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"targetDataSource": "cold", "targetFolder": "/archive/"}}), ShouldBeNil)
+
+		output, e := action.Run(context.Background(), nil, jobs.ActionMessage{Nodes: []*tree.Node{
+			{Path: "pydiods1/old.txt"},
+			{Path: "pydiods1/empty.txt"},
+			{Path: "pydiods1/folder"},
+		}})
+		So(e, ShouldBeNil)
+		var results []*TierResult
+		So(json.Unmarshal(output.GetLastOutput().JsonBody, &results), ShouldBeNil)
+		So(results, ShouldHaveLength, 3)
+		So(results[0].Status, ShouldEqual, tierStatusDone)
+		So(results[0].Location, ShouldEqual, "cold/archive/old-uuid.txt")
+		So(results[1].Status, ShouldEqual, tierStatusSkipped)
+		So(results[2].Status, ShouldEqual, tierStatusSkipped)
+
+		So(router.Nodes, ShouldContainKey, "cold/archive/old-uuid.txt")
+		So(router.written, ShouldContainKey, "pydiods1/old.txt")
+		So(router.written["pydiods1/old.txt"], ShouldBeEmpty)
+
+		loc, ok := views.GetTieredLocation(meta.Nodes["to"])
+		So(ok, ShouldBeTrue)
+		So(loc.DataSource, ShouldEqual, "cold")
+		So(loc.Uuid, ShouldEqual, "copy-old-uuid")
+		So(loc.Size, ShouldEqual, 1024)
+		So(loc.Etag, ShouldEqual, "etag")
+		So(loc.MTime, ShouldEqual, 1500000000)
+	})
+
+	Convey("Encrypted datasources are refused", t, func() {
+		router := newTierRouterMock()
+		action := &TierMigrateAction{}
+		action.Router = router
+		action.MetaClient = views.NewHandlerMock()
+		action.Encrypted = map[string]bool{"cold": false, "pydiods1": true}
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{Parameters: map[string]string{"targetDataSource": "cold"}}), ShouldBeNil)
+		_, e := action.Run(context.Background(), nil, jobs.ActionMessage{Nodes: []*tree.Node{{Path: "pydiods1/old.txt"}}})
+		So(e, ShouldNotBeNil)
+		So(router.written, ShouldBeEmpty)
+
+		action.Encrypted = map[string]bool{"cold": true}
+		_, e = action.Run(context.Background(), nil, jobs.ActionMessage{Nodes: []*tree.Node{{Path: "pydiods1/old.txt"}}})
+		So(e, ShouldNotBeNil)
+	})
+
+}
+
+func TestTierRecallAction(t *testing.T) {
+
+	Convey("Recall content from the tier location", t, func() {
+		router := newTierRouterMock()
+		meta := views.NewHandlerMock()
+		coldPath := "cold/tiering/old-uuid.txt"
+		content := coldPath + "hello world"
+		router.Nodes[coldPath] = &tree.Node{Uuid: "cold-uuid", Path: coldPath, Size: int64(len(content))}
+		router.Nodes["pydiods1/old.txt"].Size = 0
+		router.Nodes["pydiods1/old.txt"].SetMeta(common.META_NAMESPACE_TIERING, &views.TieredLocation{
+			DataSource: "cold",
+			Path:       coldPath,
+			Uuid:       "cold-uuid",
+			Size:       int64(len(content)),
+		})
+
+		action := &TierRecallAction{}
+		action.Router = router
+		action.MetaClient = meta
+		So(action.Init(&jobs.Job{}, nil, &jobs.Action{}), ShouldBeNil)
+
+		output, e := action.Run(context.Background(), nil, jobs.ActionMessage{Nodes: []*tree.Node{
+			{Path: "pydiods1/old.txt"},
+			{Path: "pydiods1/empty.txt"},
+		}})
+		So(e, ShouldBeNil)
+		var results []*TierResult
+		So(json.Unmarshal(output.GetLastOutput().JsonBody, &results), ShouldBeNil)
+		So(results[0].Status, ShouldEqual, tierStatusDone)
+		So(results[1].Status, ShouldEqual, tierStatusSkipped)
+
+		So(router.written["pydiods1/old.txt"], ShouldEqual, content)
+		So(meta.Nodes["to"].GetStringMeta(common.META_NAMESPACE_TIERING), ShouldEqual, "")
+		So(router.Nodes, ShouldNotContainKey, coldPath)
+	})
+
+}
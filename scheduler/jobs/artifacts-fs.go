@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+const (
+	artifactsLogFile   = "task.log"
+	artifactsFolder    = "artifacts"
+	artifactsFilesMode = 0600
+)
+
+// FSArtifactsStore implements ArtifactsDAO on the local filesystem, using one folder per task.
+type FSArtifactsStore struct {
+	root string
+	lock sync.Mutex
+}
+
+// NewFSArtifactsStore creates the root folder if required and returns a new FSArtifactsStore.
+func NewFSArtifactsStore(root string) (*FSArtifactsStore, error) {
+	if e := os.MkdirAll(root, 0755); e != nil {
+		return nil, e
+	}
+	return &FSArtifactsStore{root: root}, nil
+}
+
+// AppendTaskLog appends data to the task.log file of the task.
+func (s *FSArtifactsStore) AppendTaskLog(jobId, taskId string, data []byte) (int64, error) {
+	folder, e := s.taskFolder(jobId, taskId)
+	if e != nil {
+		return 0, e
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e := os.MkdirAll(folder, 0755); e != nil {
+		return 0, e
+	}
+	f, e := os.OpenFile(filepath.Join(folder, artifactsLogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, artifactsFilesMode)
+	if e != nil {
+		return 0, e
+	}
+	defer f.Close()
+	if _, e := f.Write(data); e != nil {
+		return 0, e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		return 0, e
+	}
+	return st.Size(), nil
+}
+
+// ReadTaskLog reads a chunk of the task.log file. An empty chunk is returned if the log does not exist yet.
+func (s *FSArtifactsStore) ReadTaskLog(jobId, taskId string, offset int64, limit int) ([]byte, int64, int64, error) {
+	folder, e := s.taskFolder(jobId, taskId)
+	if e != nil {
+		return nil, 0, 0, e
+	}
+	f, e := os.Open(filepath.Join(folder, artifactsLogFile))
+	if os.IsNotExist(e) {
+		return []byte{}, 0, 0, nil
+	} else if e != nil {
+		return nil, 0, 0, e
+	}
+	defer f.Close()
+	st, e := f.Stat()
+	if e != nil {
+		return nil, 0, 0, e
+	}
+	size := st.Size()
+	if offset < 0 {
+		offset = size + offset
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if offset >= size {
+		return []byte{}, size, size, nil
+	}
+	data, e := readChunk(f, offset, limit)
+	if e != nil {
+		return nil, 0, 0, e
+	}
+	return data, offset + int64(len(data)), size, nil
+}
+
+// PutArtifact writes the content of reader to the artifacts folder of the task.
+func (s *FSArtifactsStore) PutArtifact(jobId, taskId, name string, reader io.Reader, append bool) (*jobs.TaskArtifact, error) {
+	target, e := s.artifactPath(jobId, taskId, name)
+	if e != nil {
+		return nil, e
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e := os.MkdirAll(filepath.Dir(target), 0755); e != nil {
+		return nil, e
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, e := os.OpenFile(target, flags, artifactsFilesMode)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	if _, e := io.Copy(f, reader); e != nil {
+		return nil, e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		return nil, e
+	}
+	return artifactFromInfo(jobId, taskId, st), nil
+}
+
+// GetArtifact opens an artifact file.
+func (s *FSArtifactsStore) GetArtifact(jobId, taskId, name string) (io.ReadCloser, *jobs.TaskArtifact, error) {
+	target, e := s.artifactPath(jobId, taskId, name)
+	if e != nil {
+		return nil, nil, e
+	}
+	f, e := os.Open(target)
+	if e != nil {
+		return nil, nil, e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return nil, nil, e
+	}
+	return f, artifactFromInfo(jobId, taskId, st), nil
+}
+
+// ListArtifacts lists the files of the artifacts folder, sorted by name.
+func (s *FSArtifactsStore) ListArtifacts(jobId, taskId string) ([]*jobs.TaskArtifact, error) {
+	folder, e := s.taskFolder(jobId, taskId)
+	if e != nil {
+		return nil, e
+	}
+	infos, e := ioutil.ReadDir(filepath.Join(folder, artifactsFolder))
+	if os.IsNotExist(e) {
+		return []*jobs.TaskArtifact{}, nil
+	} else if e != nil {
+		return nil, e
+	}
+	var artifacts []*jobs.TaskArtifact
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		artifacts = append(artifacts, artifactFromInfo(jobId, taskId, info))
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+	return artifacts, nil
+}
+
+// DeleteArtifacts removes the tasks folders, or the whole job folder if no task is passed.
+func (s *FSArtifactsStore) DeleteArtifacts(jobId string, taskIds ...string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(taskIds) == 0 {
+		if e := checkArtifactName(jobId); e != nil {
+			return e
+		}
+		return os.RemoveAll(filepath.Join(s.root, jobId))
+	}
+	for _, taskId := range taskIds {
+		folder, e := s.taskFolder(jobId, taskId)
+		if e != nil {
+			return e
+		}
+		if e := os.RemoveAll(folder); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *FSArtifactsStore) taskFolder(jobId, taskId string) (string, error) {
+	for _, n := range []string{jobId, taskId} {
+		if e := checkArtifactName(n); e != nil {
+			return "", e
+		}
+	}
+	return filepath.Join(s.root, jobId, taskId), nil
+}
+
+func (s *FSArtifactsStore) artifactPath(jobId, taskId, name string) (string, error) {
+	folder, e := s.taskFolder(jobId, taskId)
+	if e != nil {
+		return "", e
+	}
+	if e := checkArtifactName(name); e != nil {
+		return "", e
+	}
+	return filepath.Join(folder, artifactsFolder, name), nil
+}
+
+// checkArtifactName makes sure that identifiers and names cannot be used to escape the store root
+func checkArtifactName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	return nil
+}
+
+func readChunk(f *os.File, offset int64, limit int) ([]byte, error) {
+	if _, e := f.Seek(offset, io.SeekStart); e != nil {
+		return nil, e
+	}
+	data := make([]byte, limit)
+	n, e := io.ReadFull(f, data)
+	if e != nil && e != io.EOF && e != io.ErrUnexpectedEOF {
+		return nil, e
+	}
+	return data[:n], nil
+}
+
+func artifactFromInfo(jobId, taskId string, info os.FileInfo) *jobs.TaskArtifact {
+	contentType := mime.TypeByExtension(filepath.Ext(info.Name()))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &jobs.TaskArtifact{
+		JobID:       jobId,
+		TaskID:      taskId,
+		Name:        info.Name(),
+		Size:        info.Size(),
+		MTime:       int32(info.ModTime().Unix()),
+		ContentType: contentType,
+	}
+}
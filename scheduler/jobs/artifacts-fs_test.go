@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFSArtifactsStore(t *testing.T) {
+
+	root, _ := ioutil.TempDir("", "artifacts-test")
+	defer os.RemoveAll(root)
+	store, err := NewFSArtifactsStore(root)
+
+	Convey("Test task log stream", t, func() {
+		So(err, ShouldBeNil)
+
+		data, next, size, e := store.ReadTaskLog("job", "task", 0, 10)
+		So(e, ShouldBeNil)
+		So(data, ShouldBeEmpty)
+		So(next, ShouldEqual, 0)
+		So(size, ShouldEqual, 0)
+
+		size, e = store.AppendTaskLog("job", "task", []byte("line one\n"))
+		So(e, ShouldBeNil)
+		So(size, ShouldEqual, 9)
+		size, e = store.AppendTaskLog("job", "task", []byte("line two\n"))
+		So(e, ShouldBeNil)
+		So(size, ShouldEqual, 18)
+
+		data, next, size, e = store.ReadTaskLog("job", "task", 0, 9)
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "line one\n")
+		So(next, ShouldEqual, 9)
+		So(size, ShouldEqual, 18)
+
+		data, next, _, e = store.ReadTaskLog("job", "task", next, 100)
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "line two\n")
+		So(next, ShouldEqual, 18)
+
+		data, next, _, e = store.ReadTaskLog("job", "task", -4, 100)
+		So(e, ShouldBeNil)
+		So(string(data), ShouldEqual, "two\n")
+		So(next, ShouldEqual, 18)
+
+		data, _, _, e = store.ReadTaskLog("job", "task", 18, 100)
+		So(e, ShouldBeNil)
+		So(data, ShouldBeEmpty)
+	})
+
+	Convey("Test artifacts", t, func() {
+		So(err, ShouldBeNil)
+
+		a, e := store.PutArtifact("job", "task", "report.html", bytes.NewBufferString("a,b\n"), false)
+		So(e, ShouldBeNil)
+		So(a.Name, ShouldEqual, "report.html")
+		So(a.Size, ShouldEqual, 4)
+		So(a.ContentType, ShouldStartWith, "text/html")
+
+		a, e = store.PutArtifact("job", "task", "report.html", bytes.NewBufferString("c,d\n"), true)
+		So(e, ShouldBeNil)
+		So(a.Size, ShouldEqual, 8)
+
+		_, e = store.PutArtifact("job", "task", "output", bytes.NewBufferString("raw"), false)
+		So(e, ShouldBeNil)
+
+		list, e := store.ListArtifacts("job", "task")
+		So(e, ShouldBeNil)
+		So(list, ShouldHaveLength, 2)
+		So(list[0].Name, ShouldEqual, "output")
+		So(list[0].ContentType, ShouldEqual, "application/octet-stream")
+		So(list[1].Name, ShouldEqual, "report.html")
+
+		reader, a, e := store.GetArtifact("job", "task", "report.html")
+		So(e, ShouldBeNil)
+		content, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		So(string(content), ShouldEqual, "a,b\nc,d\n")
+
+		_, _, e = store.GetArtifact("job", "task", "missing")
+		So(os.IsNotExist(e), ShouldBeTrue)
+
+		list, e = store.ListArtifacts("job", "other-task")
+		So(e, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+	})
+
+	Convey("Test invalid names", t, func() {
+		So(err, ShouldBeNil)
+
+		_, e := store.PutArtifact("job", "task", "../escape", bytes.NewBufferString("data"), false)
+		So(e, ShouldNotBeNil)
+		_, e = store.PutArtifact("job", "..", "file", bytes.NewBufferString("data"), false)
+		So(e, ShouldNotBeNil)
+		_, e = store.AppendTaskLog("", "task", []byte("data"))
+		So(e, ShouldNotBeNil)
+		So(store.DeleteArtifacts(".."), ShouldNotBeNil)
+	})
+
+	Convey("Test deletion", t, func() {
+		So(err, ShouldBeNil)
+
+		_, e := store.AppendTaskLog("job", "task2", []byte("data"))
+		So(e, ShouldBeNil)
+		So(store.DeleteArtifacts("job", "task"), ShouldBeNil)
+		_, e = os.Stat(filepath.Join(root, "job", "task"))
+		So(os.IsNotExist(e), ShouldBeTrue)
+		_, e = os.Stat(filepath.Join(root, "job", "task2"))
+		So(e, ShouldBeNil)
+
+		So(store.DeleteArtifacts("job"), ShouldBeNil)
+		_, e = os.Stat(filepath.Join(root, "job"))
+		So(os.IsNotExist(e), ShouldBeTrue)
+	})
+
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	bolt "github.com/etcd-io/bbolt"
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var (
+	// Tasks waiting to be claimed by a scheduler node
+	queueBucketKey = []byte("queue")
+	// Index of the entries waiting to be claimed, sorted by enqueue time
+	queuePendingBucketKey = []byte("queue-pending")
+	// Index of the leased entries, sorted by lease expiry
+	queueLeasesBucketKey = []byte("queue-leases")
+)
+
+// EnqueueTask adds an entry to the tasks queue
+func (s *BoltStore) EnqueueTask(task *jobs.QueuedTask) error {
+
+	if task.ID == "" {
+		task.ID = uuid.New()
+	}
+	if task.EnqueueTime == 0 {
+		task.EnqueueTime = int32(time.Now().Unix())
+	}
+	task.LeaseOwner = ""
+	task.LeaseExpiry = 0
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(queueBucketKey)
+		if err != nil {
+			return err
+		}
+		pending, err := tx.CreateBucketIfNotExists(queuePendingBucketKey)
+		if err != nil {
+			return err
+		}
+		if err := putQueuedTask(bucket, task); err != nil {
+			return err
+		}
+		return pending.Put(queueIndexKey(task.EnqueueTime, task.ID), []byte(task.ID))
+	})
+
+}
+
+// ClaimTask leases the oldest available entry to nodeId. Entries whose lease has expired are
+// considered available again. An entry is only claimed if the number of live leases for its job
+// is lower than the job MaxConcurrency. Entries pointing to deleted jobs are removed.
+// Entries are indexed by status, so that polling an idle queue does not read all entries.
+func (s *BoltStore) ClaimTask(nodeId string, lease time.Duration) (*jobs.QueuedTask, error) {
+
+	now := int32(time.Now().Unix())
+
+	// Most calls find nothing to do, look for some work without locking the database first
+	var found bool
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		if hasExpiredLease(tx, now) {
+			found = true
+			return nil
+		}
+		t, _, orphans, e := nextQueuedTask(tx, now)
+		found = t != nil || len(orphans) > 0
+		return e
+	}); err != nil || !found {
+		return nil, err
+	}
+
+	var claimed *jobs.QueuedTask
+	err := s.db.Update(func(tx *bolt.Tx) error {
+
+		if e := requeueExpiredLeases(tx, now); e != nil {
+			return e
+		}
+		t, key, orphans, e := nextQueuedTask(tx, now)
+		if e != nil {
+			return e
+		}
+		bucket := tx.Bucket(queueBucketKey)
+		pending := tx.Bucket(queuePendingBucketKey)
+		for _, k := range orphans {
+			// Job was removed in the meantime
+			if er := bucket.Delete(pending.Get(k)); er != nil {
+				return er
+			}
+			if er := pending.Delete(k); er != nil {
+				return er
+			}
+		}
+		if t == nil {
+			return nil
+		}
+		if t.LeaseOwner != "" {
+			log.Logger(context.Background()).Info("Lease has expired, task is requeued", zap.String("job", t.JobID), zap.String("entry", t.ID), zap.String("previousOwner", t.LeaseOwner))
+		}
+		leases, er := tx.CreateBucketIfNotExists(queueLeasesBucketKey)
+		if er != nil {
+			return er
+		}
+		t.LeaseOwner = nodeId
+		t.LeaseExpiry = leaseExpiry(lease)
+		t.Claims++
+		if er := pending.Delete(key); er != nil {
+			return er
+		}
+		if er := leases.Put(queueIndexKey(t.LeaseExpiry, t.ID), []byte(t.ID)); er != nil {
+			return er
+		}
+		if er := putQueuedTask(bucket, t); er != nil {
+			return er
+		}
+		claimed = t
+		return nil
+
+	})
+
+	return claimed, err
+}
+
+// RenewTaskLease extends the lease of an entry held by nodeId. It fails if the entry
+// does not exist anymore, if its lease has expired or if it was claimed by another node.
+func (s *BoltStore) RenewTaskLease(id string, nodeId string, lease time.Duration) error {
+
+	return s.updateQueuedTask(id, nodeId, func(tx *bolt.Tx, bucket *bolt.Bucket, t *jobs.QueuedTask) error {
+		if t.LeaseExpiry <= int32(time.Now().Unix()) {
+			return fmt.Errorf("lease on queued task %s has expired", id)
+		}
+		leases := tx.Bucket(queueLeasesBucketKey)
+		if leases == nil {
+			return fmt.Errorf("cannot find lease on queued task %s", id)
+		}
+		if err := leases.Delete(queueIndexKey(t.LeaseExpiry, t.ID)); err != nil {
+			return err
+		}
+		t.LeaseExpiry = leaseExpiry(lease)
+		if err := leases.Put(queueIndexKey(t.LeaseExpiry, t.ID), []byte(t.ID)); err != nil {
+			return err
+		}
+		return putQueuedTask(bucket, t)
+	})
+
+}
+
+// ReleaseTask removes an entry held by nodeId from the queue.
+func (s *BoltStore) ReleaseTask(id string, nodeId string) error {
+
+	return s.updateQueuedTask(id, nodeId, func(tx *bolt.Tx, bucket *bolt.Bucket, t *jobs.QueuedTask) error {
+		if leases := tx.Bucket(queueLeasesBucketKey); leases != nil {
+			if err := leases.Delete(queueIndexKey(t.LeaseExpiry, t.ID)); err != nil {
+				return err
+			}
+		}
+		if pending := tx.Bucket(queuePendingBucketKey); pending != nil {
+			// Lease may have expired and the entry requeued
+			if err := pending.Delete(queueIndexKey(t.EnqueueTime, t.ID)); err != nil {
+				return err
+			}
+		}
+		return bucket.Delete([]byte(t.ID))
+	})
+
+}
+
+func (s *BoltStore) updateQueuedTask(id string, nodeId string, callback func(tx *bolt.Tx, bucket *bolt.Bucket, t *jobs.QueuedTask) error) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(queueBucketKey)
+		if bucket == nil {
+			return fmt.Errorf("cannot find queued task %s", id)
+		}
+		t, err := getQueuedTask(bucket, []byte(id))
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("cannot find queued task %s", id)
+		}
+		if t.LeaseOwner != nodeId {
+			return fmt.Errorf("queued task %s is leased by another node", id)
+		}
+		return callback(tx, bucket, t)
+	})
+
+}
+
+// hasExpiredLease checks if the oldest lease has expired
+func hasExpiredLease(tx *bolt.Tx, now int32) bool {
+	leases := tx.Bucket(queueLeasesBucketKey)
+	if leases == nil {
+		return false
+	}
+	k, _ := leases.Cursor().First()
+	return k != nil && queueIndexTime(k) <= now
+}
+
+// requeueExpiredLeases moves the entries whose lease has expired back to the pending index
+func requeueExpiredLeases(tx *bolt.Tx, now int32) error {
+	leases := tx.Bucket(queueLeasesBucketKey)
+	if leases == nil {
+		return nil
+	}
+	pending, err := tx.CreateBucketIfNotExists(queuePendingBucketKey)
+	if err != nil {
+		return err
+	}
+	bucket := tx.Bucket(queueBucketKey)
+	c := leases.Cursor()
+	for k, v := c.First(); k != nil && queueIndexTime(k) <= now; k, v = c.First() {
+		id := append([]byte{}, v...)
+		if err := leases.Delete(k); err != nil {
+			return err
+		}
+		t, err := getQueuedTask(bucket, id)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			continue
+		}
+		if err := pending.Put(queueIndexKey(t.EnqueueTime, t.ID), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextQueuedTask finds the oldest pending entry that can be claimed, and the pending keys of the entries
+// pointing to deleted jobs. Expired leases must have been requeued before.
+func nextQueuedTask(tx *bolt.Tx, now int32) (next *jobs.QueuedTask, nextKey []byte, orphans [][]byte, err error) {
+	pending := tx.Bucket(queuePendingBucketKey)
+	bucket := tx.Bucket(queueBucketKey)
+	jobsBucket := tx.Bucket(jobsBucketKey)
+	if pending == nil || bucket == nil || jobsBucket == nil {
+		return
+	}
+	running := make(map[string]int32)
+	if leases := tx.Bucket(queueLeasesBucketKey); leases != nil {
+		if err = leases.ForEach(func(k, v []byte) error {
+			if queueIndexTime(k) <= now {
+				return nil
+			}
+			t, e := getQueuedTask(bucket, v)
+			if t != nil {
+				running[t.JobID]++
+			}
+			return e
+		}); err != nil {
+			return
+		}
+	}
+	limits := make(map[string]int32)
+	c := pending.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var t *jobs.QueuedTask
+		if t, err = getQueuedTask(bucket, v); err != nil {
+			return
+		}
+		if t == nil {
+			orphans = append(orphans, append([]byte{}, k...))
+			continue
+		}
+		limit, ok := limits[t.JobID]
+		if !ok {
+			data := jobsBucket.Get([]byte(t.JobID))
+			if data == nil {
+				orphans = append(orphans, append([]byte{}, k...))
+				continue
+			}
+			job := &jobs.Job{}
+			if err = json.Unmarshal(data, job); err != nil {
+				return
+			}
+			limit = job.MaxConcurrency
+			limits[t.JobID] = limit
+		}
+		if limit > 0 && running[t.JobID] >= limit {
+			continue
+		}
+		return t, append([]byte{}, k...), orphans, nil
+	}
+	return
+}
+
+func getQueuedTask(bucket *bolt.Bucket, id []byte) (*jobs.QueuedTask, error) {
+	data := bucket.Get(id)
+	if data == nil {
+		return nil, nil
+	}
+	t := &jobs.QueuedTask{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func putQueuedTask(bucket *bolt.Bucket, t *jobs.QueuedTask) error {
+	jsonData, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(t.ID), jsonData)
+}
+
+// queueIndexKey builds an index key sorted by timestamp, then by entry ID
+func queueIndexKey(ts int32, id string) []byte {
+	return []byte(fmt.Sprintf("%010d-%s", ts, id))
+}
+
+// queueIndexTime reads the timestamp of an index key
+func queueIndexTime(key []byte) int32 {
+	if len(key) < 10 {
+		return 0
+	}
+	ts, _ := strconv.ParseInt(string(key[:10]), 10, 32)
+	return int32(ts)
+}
+
+// leaseExpiry rounds the expiry up to the next second, so that a lease is never shorter than required.
+func leaseExpiry(lease time.Duration) int32 {
+	return int32(math.Ceil(float64(time.Now().Add(lease).UnixNano()) / float64(time.Second)))
+}
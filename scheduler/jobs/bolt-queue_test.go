@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package jobs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	bolt "github.com/etcd-io/bbolt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+func TestBoltStore_Queue(t *testing.T) {
+
+	Convey("Test Enqueue / Claim / Release", t, func() {
+
+		dbFile := os.TempDir() + "/bolt-test-queue.db"
+		defer os.Remove(dbFile)
+		db, err := NewBoltStore(dbFile)
+		So(err, ShouldBeNil)
+		defer db.Close()
+
+		So(db.PutJob(&jobs.Job{ID: "limited-job", MaxConcurrency: 1}), ShouldBeNil)
+		So(db.PutJob(&jobs.Job{ID: "other-job"}), ShouldBeNil)
+
+		// Nothing to claim
+		t, e := db.ClaimTask("node-a", time.Minute)
+		So(e, ShouldBeNil)
+		So(t, ShouldBeNil)
+
+		So(db.EnqueueTask(&jobs.QueuedTask{ID: "t1", JobID: "limited-job", EnqueueTime: 10}), ShouldBeNil)
+		So(db.EnqueueTask(&jobs.QueuedTask{ID: "t2", JobID: "limited-job", EnqueueTime: 20}), ShouldBeNil)
+		So(db.EnqueueTask(&jobs.QueuedTask{ID: "t3", JobID: "other-job", EnqueueTime: 30}), ShouldBeNil)
+		So(db.EnqueueTask(&jobs.QueuedTask{ID: "t4", JobID: "deleted-job", EnqueueTime: 5}), ShouldBeNil)
+		generated := &jobs.QueuedTask{JobID: "other-job"}
+		So(db.EnqueueTask(generated), ShouldBeNil)
+		So(generated.ID, ShouldNotBeEmpty)
+		So(generated.EnqueueTime, ShouldBeGreaterThan, 0)
+
+		// Oldest first, orphan entry is dropped
+		t, e = db.ClaimTask("node-a", time.Minute)
+		So(e, ShouldBeNil)
+		So(t, ShouldNotBeNil)
+		So(t.ID, ShouldEqual, "t1")
+		So(t.LeaseOwner, ShouldEqual, "node-a")
+		So(t.Claims, ShouldEqual, 1)
+
+		// MaxConcurrency is reached for limited-job, next one is from other-job
+		t, e = db.ClaimTask("node-b", time.Minute)
+		So(e, ShouldBeNil)
+		So(t.ID, ShouldEqual, "t3")
+
+		// Only owner can renew or release
+		So(db.RenewTaskLease("t1", "node-b", time.Minute), ShouldNotBeNil)
+		So(db.ReleaseTask("t1", "node-b"), ShouldNotBeNil)
+		So(db.RenewTaskLease("t1", "node-a", time.Minute), ShouldBeNil)
+		So(db.ReleaseTask("t1", "node-a"), ShouldBeNil)
+		So(db.ReleaseTask("t1", "node-a"), ShouldNotBeNil)
+
+		// Slot is free again
+		t, e = db.ClaimTask("node-b", time.Minute)
+		So(e, ShouldBeNil)
+		So(t.ID, ShouldEqual, "t2")
+
+		t, e = db.ClaimTask("node-b", time.Minute)
+		So(e, ShouldBeNil)
+		So(t.ID, ShouldEqual, generated.ID)
+
+		t, e = db.ClaimTask("node-b", time.Minute)
+		So(e, ShouldBeNil)
+		So(t, ShouldBeNil)
+
+		// Released entries are removed from the indexes
+		So(db.ReleaseTask("t2", "node-b"), ShouldBeNil)
+		So(db.ReleaseTask("t3", "node-b"), ShouldBeNil)
+		So(db.ReleaseTask(generated.ID, "node-b"), ShouldBeNil)
+		So(db.db.View(func(tx *bolt.Tx) error {
+			for _, b := range [][]byte{queueBucketKey, queuePendingBucketKey, queueLeasesBucketKey} {
+				So(tx.Bucket(b).Stats().KeyN, ShouldEqual, 0)
+			}
+			return nil
+		}), ShouldBeNil)
+
+	})
+
+	Convey("Test expired leases are requeued", t, func() {
+
+		dbFile := os.TempDir() + "/bolt-test-queue-lease.db"
+		defer os.Remove(dbFile)
+		db, err := NewBoltStore(dbFile)
+		So(err, ShouldBeNil)
+		defer db.Close()
+
+		So(db.PutJob(&jobs.Job{ID: "limited-job", MaxConcurrency: 1}), ShouldBeNil)
+		So(db.EnqueueTask(&jobs.QueuedTask{ID: "t1", JobID: "limited-job"}), ShouldBeNil)
+
+		t, e := db.ClaimTask("node-a", time.Second)
+		So(e, ShouldBeNil)
+		So(t.ID, ShouldEqual, "t1")
+
+		t, e = db.ClaimTask("node-b", time.Second)
+		So(e, ShouldBeNil)
+		So(t, ShouldBeNil)
+
+		<-time.After(2100 * time.Millisecond)
+		// Expired lease cannot be renewed
+		So(db.RenewTaskLease("t1", "node-a", time.Minute), ShouldNotBeNil)
+		t, e = db.ClaimTask("node-b", time.Minute)
+		So(e, ShouldBeNil)
+		So(t, ShouldNotBeNil)
+		So(t.ID, ShouldEqual, "t1")
+		So(t.LeaseOwner, ShouldEqual, "node-b")
+		So(t.Claims, ShouldEqual, 2)
+
+		// Previous owner lost its lease
+		So(db.RenewTaskLease("t1", "node-a", time.Minute), ShouldNotBeNil)
+		So(db.ReleaseTask("t1", "node-b"), ShouldBeNil)
+
+	})
+}
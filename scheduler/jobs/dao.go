@@ -20,7 +20,12 @@
 
 package jobs
 
-import "github.com/pydio/cells/common/proto/jobs"
+import (
+	"io"
+	"time"
+
+	"github.com/pydio/cells/common/proto/jobs"
+)
 
 // DAO provides method interface to access the store for scheduler job and task definitions.
 type DAO interface {
@@ -33,4 +38,39 @@ type DAO interface {
 	PutTasks(task map[string]map[string]*jobs.Task) error
 	ListTasks(jobId string, taskStatus jobs.TaskStatus, cursor ...int32) (chan *jobs.Task, chan bool, error)
 	DeleteTasks(jobId string, taskId []string) error
+
+	QueueDAO
+}
+
+// QueueDAO stores the tasks waiting to be run, shared by all the scheduler nodes.
+// Nodes claim entries with a lease that must be renewed until the task is finished,
+// otherwise the entry is made available again to other nodes.
+type QueueDAO interface {
+	// EnqueueTask adds an entry to the queue
+	EnqueueTask(task *jobs.QueuedTask) error
+	// ClaimTask leases the oldest available entry to nodeId, respecting the jobs MaxConcurrency.
+	// It returns nil if no entry can be claimed.
+	ClaimTask(nodeId string, lease time.Duration) (*jobs.QueuedTask, error)
+	// RenewTaskLease extends the lease of an entry held by nodeId
+	RenewTaskLease(id string, nodeId string, lease time.Duration) error
+	// ReleaseTask removes an entry held by nodeId from the queue
+	ReleaseTask(id string, nodeId string) error
+}
+
+// ArtifactsDAO stores the binary outputs of the tasks: an append-only log stream and
+// named artifacts (reports, exports, etc.) for each task.
+type ArtifactsDAO interface {
+	// AppendTaskLog appends data to the log stream of a task and returns its new size
+	AppendTaskLog(jobId, taskId string, data []byte) (int64, error)
+	// ReadTaskLog reads at most limit bytes of the log stream starting at offset.
+	// A negative offset is counted from the end of the stream.
+	ReadTaskLog(jobId, taskId string, offset int64, limit int) (data []byte, next int64, size int64, e error)
+	// PutArtifact creates, replaces or appends to a named artifact
+	PutArtifact(jobId, taskId, name string, reader io.Reader, append bool) (*jobs.TaskArtifact, error)
+	// GetArtifact opens an artifact for reading
+	GetArtifact(jobId, taskId, name string) (io.ReadCloser, *jobs.TaskArtifact, error)
+	// ListArtifacts lists the artifacts of a task
+	ListArtifacts(jobId, taskId string) ([]*jobs.TaskArtifact, error)
+	// DeleteArtifacts removes logs and artifacts of the given tasks, or of all the job tasks if none is passed
+	DeleteArtifacts(jobId string, taskIds ...string) error
 }
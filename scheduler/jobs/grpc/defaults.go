@@ -134,11 +134,29 @@ func getDefaultJobs() []*jobs.Job {
 		},
 	}
 
+	usageReportJob := &jobs.Job{
+		ID:             "usage-report",
+		Owner:          common.PYDIO_SYSTEM_USERNAME,
+		Label:          "Weekly storage usage report",
+		Inactive:       true,
+		MaxConcurrency: 1,
+		Schedule: &jobs.Schedule{
+			Iso8601Schedule: "R/2012-06-04T03:00:00.828696-07:03/P7D",
+		},
+		Actions: []*jobs.Action{
+			{
+				ID:         "actions.reports.usage",
+				Parameters: map[string]string{"format": "csv", "perUser": "true"},
+			},
+		},
+	}
+
 	defJobs := []*jobs.Job{
 		thumbnailsJob,
 		cleanThumbsJob,
 		stuckTasksJob,
 		cleanUserDataJob,
+		usageReportJob,
 	}
 
 	return defJobs
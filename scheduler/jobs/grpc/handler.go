@@ -21,7 +21,11 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -36,10 +40,12 @@ import (
 	logcore "github.com/pydio/cells/broker/log/grpc"
 	"github.com/pydio/cells/common"
 	"github.com/pydio/cells/common/log"
+	defaults "github.com/pydio/cells/common/micro"
 	proto "github.com/pydio/cells/common/proto/jobs"
 	log2 "github.com/pydio/cells/common/proto/log"
 	"github.com/pydio/cells/scheduler/jobs"
 	"github.com/pydio/cells/scheduler/lang"
+	"github.com/pydio/cells/scheduler/tasks"
 )
 
 var (
@@ -49,7 +55,8 @@ var (
 // JobsHandler implements the JobService API
 type JobsHandler struct {
 	logcore.Handler
-	store jobs.DAO
+	store     jobs.DAO
+	artifacts jobs.ArtifactsDAO
 
 	putTaskChan       chan *proto.Task
 	putTaskBuff       map[string]map[string]*proto.Task
@@ -60,9 +67,10 @@ type JobsHandler struct {
 }
 
 // NewJobsHandler creates a new JobsHandler
-func NewJobsHandler(store jobs.DAO, messageRepository log3.MessageRepository) *JobsHandler {
+func NewJobsHandler(store jobs.DAO, messageRepository log3.MessageRepository, artifacts jobs.ArtifactsDAO) *JobsHandler {
 	j := &JobsHandler{
 		store:        store,
+		artifacts:    artifacts,
 		putTaskChan:  make(chan *proto.Task),
 		jobsBuff:     make(map[string]*proto.Job),
 		jobsBuffLock: &sync.Mutex{},
@@ -81,6 +89,14 @@ func (j *JobsHandler) Close() {
 // JOBS STORE
 /////////////////
 func (j *JobsHandler) PutJob(ctx context.Context, request *proto.PutJobRequest, response *proto.PutJobResponse) error {
+	if e := request.Job.CheckDefinition(); e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "invalid job definition: %s", e.Error())
+	}
+	if request.Job.CalendarName != "" {
+		if _, e := proto.LoadCalendar(request.Job.CalendarName); e != nil {
+			return errors.BadRequest(common.SERVICE_JOBS, "invalid job definition: %s", e.Error())
+		}
+	}
 	err := j.store.PutJob(request.Job)
 	log.Logger(ctx).Debug("Scheduler PutJob", zap.Any("job", request.Job))
 	if err != nil {
@@ -123,6 +139,7 @@ func (j *JobsHandler) DeleteJob(ctx context.Context, request *proto.DeleteJobReq
 		}))
 		go func() {
 			j.DeleteLogsFor(bgContext, request.JobID)
+			j.deleteArtifactsFor(bgContext, request.JobID)
 		}()
 		response.Success = true
 
@@ -173,9 +190,10 @@ func (j *JobsHandler) DeleteJob(ctx context.Context, request *proto.DeleteJobReq
 				client.Publish(ctx, client.NewPublication(common.TOPIC_JOB_CONFIG_EVENT, &proto.JobChangeEvent{
 					JobRemoved: id,
 				}))
-				go func() {
+				go func(id string) {
 					j.DeleteLogsFor(bgContext, id)
-				}()
+					j.deleteArtifactsFor(bgContext, id)
+				}(id)
 
 			}
 		}
@@ -213,7 +231,7 @@ func (j *JobsHandler) PutTask(ctx context.Context, request *proto.PutTaskRequest
 
 	job, e := j.store.GetJob(request.Task.JobID, 0)
 	if e != nil {
-		return errors.NotFound(common.SERVICE_JOBS, "Cannot append task to a non existing job ("+request.Task.JobID+")")
+		return errors.NotFound(common.SERVICE_JOBS, "Cannot append task to a non existing job (%s)", request.Task.JobID)
 	}
 
 	err := j.store.PutTask(request.Task)
@@ -298,7 +316,7 @@ func (j *JobsHandler) PutTaskStream(ctx context.Context, streamer proto.JobServi
 		if !ok {
 			job, e := j.store.GetJob(t.JobID, 0)
 			if e != nil {
-				return errors.NotFound(common.SERVICE_JOBS, "Cannot append task to a non existing job ("+request.Task.JobID+")")
+				return errors.NotFound(common.SERVICE_JOBS, "Cannot append task to a non existing job (%s)", request.Task.JobID)
 			}
 			j.jobsBuffLock.Lock()
 			j.jobsBuff[t.JobID] = job
@@ -384,9 +402,10 @@ func (j *JobsHandler) DeleteTasks(ctx context.Context, request *proto.DeleteTask
 				return e
 			}
 			response.Deleted = append(response.Deleted, tasks...)
-			go func() {
+			go func(jId string, tasks []string) {
 				j.DeleteLogsFor(bgContext, jId, tasks...)
-			}()
+				j.deleteArtifactsFor(bgContext, jId, tasks...)
+			}(jId, tasks)
 		}
 		return nil
 
@@ -396,6 +415,7 @@ func (j *JobsHandler) DeleteTasks(ctx context.Context, request *proto.DeleteTask
 			response.Deleted = append(response.Deleted, request.TaskID...)
 			go func() {
 				j.DeleteLogsFor(bgContext, request.JobId, request.TaskID...)
+				j.deleteArtifactsFor(bgContext, request.JobId, request.TaskID...)
 			}()
 			return nil
 		} else {
@@ -485,3 +505,176 @@ func (j *JobsHandler) CleanStuckTasks(ctx context.Context, duration ...time.Dura
 	}
 
 }
+
+//////////////////
+// TASKS QUEUE
+/////////////////
+
+// EnqueueTask stores a task in the queue shared by all scheduler nodes
+func (j *JobsHandler) EnqueueTask(ctx context.Context, request *proto.EnqueueTaskRequest, response *proto.EnqueueTaskResponse) error {
+	if request.Task == nil || request.Task.JobID == "" {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide a task with a JobID")
+	}
+	if e := j.store.EnqueueTask(request.Task); e != nil {
+		return e
+	}
+	response.Task = request.Task
+	return nil
+}
+
+// ClaimTask leases the next available task to the calling scheduler node
+func (j *JobsHandler) ClaimTask(ctx context.Context, request *proto.ClaimTaskRequest, response *proto.ClaimTaskResponse) error {
+	if request.NodeID == "" || request.LeaseSeconds <= 0 {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide a NodeID and a lease duration")
+	}
+	t, e := j.store.ClaimTask(request.NodeID, time.Duration(request.LeaseSeconds)*time.Second)
+	if e != nil {
+		return e
+	}
+	response.Task = t
+	return nil
+}
+
+// RenewTaskLease extends the lease held by a scheduler node. Success is false if the lease was lost.
+func (j *JobsHandler) RenewTaskLease(ctx context.Context, request *proto.RenewTaskLeaseRequest, response *proto.RenewTaskLeaseResponse) error {
+	if e := j.store.RenewTaskLease(request.ID, request.NodeID, time.Duration(request.LeaseSeconds)*time.Second); e != nil {
+		log.Logger(ctx).Debug("Cannot renew task lease", zap.String("entry", request.ID), zap.Error(e))
+		return nil
+	}
+	response.Success = true
+	return nil
+}
+
+// ReleaseTask removes a finished task from the queue
+func (j *JobsHandler) ReleaseTask(ctx context.Context, request *proto.ReleaseTaskRequest, response *proto.ReleaseTaskResponse) error {
+	if e := j.store.ReleaseTask(request.ID, request.NodeID); e != nil {
+		log.Logger(ctx).Debug("Cannot release task", zap.String("entry", request.ID), zap.Error(e))
+		return nil
+	}
+	response.Success = true
+	return nil
+}
+
+/////////////////
+// SIMULATION
+/////////////////
+
+// SimulateJob walks a job definition against a sample event or input and returns the execution trace.
+// Actions are not run, unless they support the simulation mode.
+func (j *JobsHandler) SimulateJob(ctx context.Context, request *proto.SimulateJobRequest, response *proto.SimulateJobResponse) error {
+	if request.Job == nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide a job definition")
+	}
+	if e := request.Job.CheckDefinition(); e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "invalid job definition: %s", e.Error())
+	}
+	res, e := tasks.SimulateJob(ctx, defaults.NewClient(), request)
+	if e != nil {
+		return e
+	}
+	*response = *res
+	return nil
+}
+
+/////////////////
+// TASKS ARTIFACTS
+/////////////////
+
+const (
+	defaultTaskLogReadLimit      = 64 * 1024
+	defaultTaskArtifactReadLimit = 1024 * 1024
+	maximumArtifactsReadLimit    = 4 * 1024 * 1024
+)
+
+// AppendTaskLog appends data to the log stream of a task
+func (j *JobsHandler) AppendTaskLog(ctx context.Context, request *proto.AppendTaskLogRequest, response *proto.AppendTaskLogResponse) error {
+	size, e := j.artifacts.AppendTaskLog(request.JobID, request.TaskID, request.Data)
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "cannot append to task log: %s", e.Error())
+	}
+	response.Size = size
+	return nil
+}
+
+// ReadTaskLog reads a chunk of the log stream of a task, to be called repeatedly with NextOffset to tail the log
+func (j *JobsHandler) ReadTaskLog(ctx context.Context, request *proto.ReadTaskLogRequest, response *proto.ReadTaskLogResponse) error {
+	data, next, size, e := j.artifacts.ReadTaskLog(request.JobID, request.TaskID, request.Offset, artifactsReadLimit(request.Limit, defaultTaskLogReadLimit))
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "cannot read task log: %s", e.Error())
+	}
+	response.Data = data
+	response.NextOffset = next
+	response.Size = size
+	return nil
+}
+
+// PutTaskArtifact creates, replaces or appends data to a named artifact of a task
+func (j *JobsHandler) PutTaskArtifact(ctx context.Context, request *proto.PutTaskArtifactRequest, response *proto.PutTaskArtifactResponse) error {
+	a := request.Artifact
+	if a == nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "please provide an artifact")
+	}
+	artifact, e := j.artifacts.PutArtifact(a.JobID, a.TaskID, a.Name, bytes.NewReader(request.Data), request.Append)
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "cannot store artifact: %s", e.Error())
+	}
+	response.Artifact = artifact
+	return nil
+}
+
+// ReadTaskArtifact reads a chunk of an artifact
+func (j *JobsHandler) ReadTaskArtifact(ctx context.Context, request *proto.ReadTaskArtifactRequest, response *proto.ReadTaskArtifactResponse) error {
+	reader, artifact, e := j.artifacts.GetArtifact(request.JobID, request.TaskID, request.Name)
+	if os.IsNotExist(e) {
+		return errors.NotFound(common.SERVICE_JOBS, "cannot find artifact %s", request.Name)
+	} else if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "cannot read artifact: %s", e.Error())
+	}
+	defer reader.Close()
+	var data []byte
+	if seeker, ok := reader.(io.Seeker); ok {
+		if _, e := seeker.Seek(request.Offset, io.SeekStart); e != nil {
+			return e
+		}
+		data, e = ioutil.ReadAll(io.LimitReader(reader, int64(artifactsReadLimit(request.Limit, defaultTaskArtifactReadLimit))))
+	} else {
+		// Reading chunks would skip the offset again on each call, send the remaining data at once
+		if _, e := io.CopyN(ioutil.Discard, reader, request.Offset); e != nil && e != io.EOF {
+			return e
+		}
+		data, e = ioutil.ReadAll(reader)
+	}
+	if e != nil {
+		return e
+	}
+	response.Artifact = artifact
+	response.Data = data
+	return nil
+}
+
+// ListTaskArtifacts lists the artifacts of a task
+func (j *JobsHandler) ListTaskArtifacts(ctx context.Context, request *proto.ListTaskArtifactsRequest, response *proto.ListTaskArtifactsResponse) error {
+	artifacts, e := j.artifacts.ListArtifacts(request.JobID, request.TaskID)
+	if e != nil {
+		return errors.BadRequest(common.SERVICE_JOBS, "cannot list artifacts: %s", e.Error())
+	}
+	response.Artifacts = artifacts
+	return nil
+}
+
+// deleteArtifactsFor removes the logs streams and artifacts of a job or of some of its tasks
+func (j *JobsHandler) deleteArtifactsFor(ctx context.Context, job string, tasks ...string) {
+	if e := j.artifacts.DeleteArtifacts(job, tasks...); e != nil {
+		log.Logger(ctx).Error("Cannot delete tasks artifacts", zap.String("j", job), zap.Strings("t", tasks), zap.Error(e))
+	}
+}
+
+func artifactsReadLimit(limit int32, def int) int {
+	if limit <= 0 {
+		return def
+	}
+	if limit > maximumArtifactsReadLimit {
+		return maximumArtifactsReadLimit
+	}
+	return int(limit)
+}
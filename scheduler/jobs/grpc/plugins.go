@@ -85,7 +85,11 @@ func init() {
 				if err != nil {
 					return err
 				}
-				handler := NewJobsHandler(store, logStore)
+				artifacts, err := jobs.NewFSArtifactsStore(path.Join(serviceDir, "artifacts"))
+				if err != nil {
+					return err
+				}
+				handler := NewJobsHandler(store, logStore, artifacts)
 				proto.RegisterJobServiceHandler(m.Options().Server, handler)
 				log2.RegisterLogRecorderHandler(m.Options().Server, handler)
 				sync.RegisterSyncEndpointHandler(m.Options().Server, handler)
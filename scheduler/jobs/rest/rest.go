@@ -23,6 +23,7 @@ package rest
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/emicklei/go-restful"
 	"github.com/micro/go-micro/client"
@@ -316,3 +317,108 @@ func (s *JobsHandler) ListTasksLogs(req *restful.Request, rsp *restful.Response)
 	rsp.WriteEntity(logColl)
 
 }
+
+// SimulateJob forwards a job definition and a sample event or input to the JobService and returns the execution trace
+func (s *JobsHandler) SimulateJob(req *restful.Request, rsp *restful.Response) {
+
+	var request jobs.SimulateJobRequest
+	if e := req.ReadEntity(&request); e != nil {
+		service.RestError500(req, rsp, e)
+		return
+	}
+	ctx := req.Request.Context()
+	cli := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+	response, err := cli.SimulateJob(ctx, &request)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	rsp.WriteEntity(response)
+
+}
+
+// ReadTaskLog reads a chunk of the log stream of a task. Use a negative Offset to tail the log,
+// then pass the returned NextOffset to the next calls.
+func (s *JobsHandler) ReadTaskLog(req *restful.Request, rsp *restful.Response) {
+
+	request := &jobs.ReadTaskLogRequest{
+		JobID:  req.PathParameter("JobID"),
+		TaskID: req.PathParameter("TaskID"),
+	}
+	if o, e := strconv.ParseInt(req.QueryParameter("Offset"), 10, 64); e == nil {
+		request.Offset = o
+	}
+	if l, e := strconv.ParseInt(req.QueryParameter("Limit"), 10, 32); e == nil {
+		request.Limit = int32(l)
+	}
+	cli := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+	response, err := cli.ReadTaskLog(req.Request.Context(), request)
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	rsp.WriteEntity(response)
+
+}
+
+// ListTaskArtifacts lists the artifacts produced by a task
+func (s *JobsHandler) ListTaskArtifacts(req *restful.Request, rsp *restful.Response) {
+
+	cli := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+	response, err := cli.ListTaskArtifacts(req.Request.Context(), &jobs.ListTaskArtifactsRequest{
+		JobID:  req.PathParameter("JobID"),
+		TaskID: req.PathParameter("TaskID"),
+	})
+	if err != nil {
+		service.RestErrorDetect(req, rsp, err)
+		return
+	}
+	rsp.WriteEntity(response)
+
+}
+
+// DownloadTaskArtifact streams the content of an artifact as a file attachment.
+func (s *JobsHandler) DownloadTaskArtifact(req *restful.Request, rsp *restful.Response) {
+
+	ctx := req.Request.Context()
+	cli := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, defaults.NewClient())
+	request := &jobs.ReadTaskArtifactRequest{
+		JobID:  req.PathParameter("JobID"),
+		TaskID: req.PathParameter("TaskID"),
+		Name:   req.PathParameter("Name"),
+	}
+	if o, e := strconv.ParseInt(req.QueryParameter("Offset"), 10, 64); e == nil && o > 0 {
+		request.Offset = o
+	}
+	var headersSent bool
+	for {
+		response, err := cli.ReadTaskArtifact(ctx, request)
+		if err != nil {
+			if !headersSent {
+				service.RestErrorDetect(req, rsp, err)
+			} else {
+				log.Logger(ctx).Error("Error while streaming artifact", zap.Error(err))
+			}
+			return
+		}
+		if !headersSent {
+			a := response.Artifact
+			rsp.Header().Set("Content-Type", a.ContentType)
+			rsp.Header().Set("Content-Length", fmt.Sprintf("%d", a.Size-request.Offset))
+			rsp.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", a.Name))
+			rsp.WriteHeader(200)
+			headersSent = true
+		}
+		if len(response.Data) == 0 {
+			return
+		}
+		if _, e := rsp.ResponseWriter.Write(response.Data); e != nil {
+			return
+		}
+		request.Offset += int64(len(response.Data))
+		if request.Offset >= response.Artifact.Size {
+			return
+		}
+	}
+
+}
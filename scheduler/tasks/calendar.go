@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+var (
+	// CalendarMaxQueued is the maximum number of triggers kept for a job while its calendar is closed
+	CalendarMaxQueued = 1000
+	// CalendarRetryInterval is the delay before the triggers held for a calendar that cannot be loaded are evaluated again
+	CalendarRetryInterval = 1 * time.Minute
+)
+
+// calendarLauncher passes a trigger to the next step once the job calendar is open
+type calendarLauncher func(ctx context.Context, job *jobs.Job, event interface{})
+
+// calendarQueue holds the triggers of one job until its calendar opens
+type calendarQueue struct {
+	triggers []*pendingTrigger
+	dropped  int
+	timer    *time.Timer
+}
+
+// CalendarGate defers the triggers of jobs referencing a Calendar until it opens.
+// Event triggers are queued in order, scheduled triggers are coalesced to the latest one.
+// Queued triggers are only kept in memory: they are dropped by Forget when the job is updated
+// or removed, and lost if the scheduler restarts.
+type CalendarGate struct {
+	sync.Mutex
+	// Calendars resolves calendars by name, it defaults to jobs.LoadCalendar
+	Calendars func(name string) (*jobs.Calendar, error)
+
+	launch calendarLauncher
+	queues map[string]*calendarQueue
+}
+
+// NewCalendarGate creates a CalendarGate that uses launch when a trigger is allowed to run.
+func NewCalendarGate(launch calendarLauncher) *CalendarGate {
+	return &CalendarGate{
+		Calendars: jobs.LoadCalendar,
+		launch:    launch,
+		queues:    make(map[string]*calendarQueue),
+	}
+}
+
+// Trigger launches the trigger if the job calendar is open, or queues it until the calendar opens.
+// If the calendar cannot be loaded, triggers are held and evaluated again after CalendarRetryInterval.
+func (g *CalendarGate) Trigger(ctx context.Context, job *jobs.Job, event interface{}) {
+	if job.CalendarName == "" {
+		g.launch(ctx, job, event)
+		return
+	}
+	cal, e := g.Calendars(job.CalendarName)
+	if e != nil {
+		if g.hold(ctx, job, event, CalendarRetryInterval) {
+			log.Logger(ctx).Error("Cannot load calendar for job "+job.ID+", holding triggers until it can be loaded", zap.Error(e))
+		}
+		return
+	}
+	now := time.Now()
+	opening, ok := cal.NextOpening(now)
+	if !ok {
+		log.Logger(ctx).Error("Calendar " + cal.Name + " never opens, dropping trigger for job " + job.ID)
+		return
+	}
+	if !opening.After(now) {
+		g.launch(ctx, job, event)
+		return
+	}
+	if g.hold(ctx, job, event, opening.Sub(now)) {
+		log.Logger(ctx).Info("Calendar "+cal.Name+" is closed, deferring triggers for job "+job.ID, zap.Time("until", opening))
+	}
+}
+
+// hold queues a trigger, and returns true if the queue was created and will be flushed after wait.
+func (g *CalendarGate) hold(ctx context.Context, job *jobs.Job, event interface{}, wait time.Duration) (created bool) {
+	g.Lock()
+	defer g.Unlock()
+	q, exists := g.queues[job.ID]
+	if !exists {
+		q = &calendarQueue{}
+		g.queues[job.ID] = q
+		jobId := job.ID
+		q.timer = time.AfterFunc(wait, func() {
+			g.flush(jobId)
+		})
+		created = true
+	}
+	if _, scheduled := event.(*jobs.JobTriggerEvent); scheduled {
+		for _, p := range q.triggers {
+			if _, ok := p.event.(*jobs.JobTriggerEvent); ok {
+				p.ctx, p.job, p.event = ctx, job, event
+				return
+			}
+		}
+	}
+	if len(q.triggers) >= CalendarMaxQueued {
+		q.dropped++
+		return
+	}
+	q.triggers = append(q.triggers, &pendingTrigger{ctx: ctx, job: job, event: event})
+	return
+}
+
+// Pending returns the number of triggers waiting for the calendar of this job.
+func (g *CalendarGate) Pending(jobId string) int {
+	g.Lock()
+	defer g.Unlock()
+	if q, ok := g.queues[jobId]; ok {
+		return len(q.triggers)
+	}
+	return 0
+}
+
+// Forget drops pending triggers for a job, typically when it is removed or updated.
+func (g *CalendarGate) Forget(jobId string) {
+	g.Lock()
+	defer g.Unlock()
+	if q, ok := g.queues[jobId]; ok {
+		q.timer.Stop()
+		delete(g.queues, jobId)
+		if len(q.triggers) > 0 {
+			log.Logger(q.triggers[0].ctx).Warn("Job was updated or removed, dropping triggers deferred by its calendar", zap.String("job", jobId), zap.Int("dropped", len(q.triggers)+q.dropped))
+		}
+	}
+}
+
+// flush passes all queued triggers again through the gate, which launches them
+// unless the calendar has been closed again in the meantime.
+func (g *CalendarGate) flush(jobId string) {
+	g.Lock()
+	q, ok := g.queues[jobId]
+	if ok {
+		delete(g.queues, jobId)
+	}
+	g.Unlock()
+	if !ok || len(q.triggers) == 0 {
+		return
+	}
+	if q.dropped > 0 {
+		log.Logger(q.triggers[0].ctx).Warn("Calendar opened, some triggers were dropped for job "+jobId, zap.Int("dropped", q.dropped))
+	}
+	for _, p := range q.triggers {
+		g.Trigger(p.ctx, p.job, p.event)
+	}
+}
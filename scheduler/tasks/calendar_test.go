@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func testCalendars(closed bool) func(name string) (*jobs.Calendar, error) {
+	return func(name string) (*jobs.Calendar, error) {
+		switch name {
+		case "night":
+			if closed {
+				// Opens only in a month
+				next := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+				return &jobs.Calendar{Name: name, Allowed: []*jobs.CalendarWindow{{Dates: []string{next}}}}, nil
+			}
+			return &jobs.Calendar{Name: name}, nil
+		case "never":
+			return &jobs.Calendar{Name: name, Forbidden: []*jobs.CalendarWindow{{}}}, nil
+		}
+		return nil, fmt.Errorf("cannot find calendar %s", name)
+	}
+}
+
+func TestCalendarGate(t *testing.T) {
+
+	ctx := context.Background()
+
+	Convey("Jobs without calendar are not restricted, jobs with an unknown calendar are held", t, func() {
+		rec := &launchRecorder{}
+		g := NewCalendarGate(func(ctx context.Context, job *jobs.Job, event interface{}) {
+			rec.launch(ctx, job, event, 0)
+		})
+		g.Calendars = testCalendars(true)
+		g.Trigger(ctx, &jobs.Job{ID: "job"}, nodeUpdate("node", "etag"))
+		So(rec.count(), ShouldEqual, 1)
+		g.Trigger(ctx, &jobs.Job{ID: "other-job", CalendarName: "unknown"}, nodeUpdate("node", "etag"))
+		So(rec.count(), ShouldEqual, 1)
+		So(g.Pending("other-job"), ShouldEqual, 1)
+
+		// Still held while the calendar cannot be loaded
+		g.flush("other-job")
+		So(rec.count(), ShouldEqual, 1)
+		So(g.Pending("other-job"), ShouldEqual, 1)
+		g.Forget("other-job")
+	})
+
+	Convey("Triggers are queued until the calendar opens", t, func() {
+		rec := &launchRecorder{}
+		g := NewCalendarGate(func(ctx context.Context, job *jobs.Job, event interface{}) {
+			rec.launch(ctx, job, event, 0)
+		})
+		g.Calendars = testCalendars(true)
+		job := &jobs.Job{ID: "job", CalendarName: "night"}
+		g.Trigger(ctx, job, nodeUpdate("node1", "a"))
+		g.Trigger(ctx, job, nodeUpdate("node2", "b"))
+		// Scheduled triggers are coalesced
+		g.Trigger(ctx, job, &jobs.JobTriggerEvent{JobID: "job", Schedule: &jobs.Schedule{Cron: "0 * * * *"}})
+		g.Trigger(ctx, job, &jobs.JobTriggerEvent{JobID: "job", Schedule: &jobs.Schedule{Cron: "0 * * * *"}})
+		So(rec.count(), ShouldEqual, 0)
+		So(g.Pending("job"), ShouldEqual, 3)
+
+		g.Calendars = testCalendars(false)
+		g.flush("job")
+		So(rec.count(), ShouldEqual, 3)
+		So(g.Pending("job"), ShouldEqual, 0)
+		So(rec.events[0].(*tree.NodeChangeEvent).Target.Uuid, ShouldEqual, "node1")
+	})
+
+	Convey("Queue is limited and can be forgotten", t, func() {
+		rec := &launchRecorder{}
+		g := NewCalendarGate(func(ctx context.Context, job *jobs.Job, event interface{}) {
+			rec.launch(ctx, job, event, 0)
+		})
+		g.Calendars = testCalendars(true)
+		max := CalendarMaxQueued
+		CalendarMaxQueued = 2
+		defer func() {
+			CalendarMaxQueued = max
+		}()
+		job := &jobs.Job{ID: "job", CalendarName: "night"}
+		for i := 0; i < 5; i++ {
+			g.Trigger(ctx, job, nodeUpdate("node", "etag"))
+		}
+		So(g.Pending("job"), ShouldEqual, 2)
+		g.Forget("job")
+		So(g.Pending("job"), ShouldEqual, 0)
+		So(rec.count(), ShouldEqual, 0)
+	})
+
+	Convey("Triggers are dropped if the calendar never opens", t, func() {
+		rec := &launchRecorder{}
+		g := NewCalendarGate(func(ctx context.Context, job *jobs.Job, event interface{}) {
+			rec.launch(ctx, job, event, 0)
+		})
+		g.Calendars = testCalendars(true)
+		g.Trigger(ctx, &jobs.Job{ID: "job", CalendarName: "never"}, nodeUpdate("node", "etag"))
+		So(rec.count(), ShouldEqual, 0)
+		So(g.Pending("job"), ShouldEqual, 0)
+	})
+}
@@ -23,6 +23,7 @@ package grpc
 
 import (
 	"github.com/micro/go-micro"
+	"github.com/pborman/uuid"
 	"github.com/pydio/cells/common/plugins"
 	"github.com/pydio/cells/common/proto/jobs"
 
@@ -41,6 +42,8 @@ func init() {
 			service.WithMicro(func(m micro.Service) error {
 				jobs.RegisterTaskServiceHandler(m.Options().Server, new(Handler))
 				multiplexer := tasks.NewSubscriber(m.Options().Context, m.Options().Client, m.Options().Server)
+				// Tasks are queued in the jobs service and claimed by any running scheduler node
+				multiplexer.UseQueue(tasks.NewTaskQueueClient(multiplexer.RootContext, m.Options().Client), uuid.New())
 				multiplexer.Init()
 				return nil
 			}),
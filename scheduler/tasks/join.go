@@ -0,0 +1,348 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+)
+
+const (
+	joinActionName = "actions.scheduler.join"
+)
+
+// joinState keeps track of the upstream branches that have reached a given Join
+// during the run of a task. As an upstream action may run once per message it receives,
+// a branch is only considered arrived once it has reported and no action can run anymore at
+// its path, see Task.pathPending.
+type joinState struct {
+	join     *jobs.Join
+	expected map[string]bool
+	reported map[string]bool
+	messages []jobs.ActionMessage
+	failures []string
+	done     bool
+}
+
+// joinResult is returned by Task.JoinArrival to tell the caller what to do next.
+type joinResult struct {
+	// Fire is true when the join is complete and its chain must be dispatched with Merged
+	Fire   bool
+	Merged jobs.ActionMessage
+	// Err is set when the join fails
+	Err error
+	// Status is a human-readable description of the join state
+	Status string
+}
+
+// joinPath computes the ActionPath used as parent for the actions chained to a Join.
+func joinPath(joinID string) string {
+	return path.Join("ROOT", "JOIN$"+joinID)
+}
+
+// joinUpstreamPaths walks the job actions using the same path scheme as Runnable.Dispatch, and
+// returns the ActionPath of all actions that send their output to the given join.
+func joinUpstreamPaths(job *jobs.Job, joinID string) map[string]bool {
+	paths := make(map[string]bool)
+	var walk func(parentPath string, actions []*jobs.Action)
+	walk = func(parentPath string, actions []*jobs.Action) {
+		for i, a := range actions {
+			aPath := path.Join(parentPath, fmt.Sprintf(a.ID+"$%d", i))
+			if a.JoinID == joinID {
+				paths[aPath] = true
+			}
+			walk(aPath, a.ChainedActions)
+			walk(path.Join(parentPath, fmt.Sprintf(a.ID+"$%d$FAIL", i)), a.FailedFilterActions)
+		}
+	}
+	walk("ROOT", job.Actions)
+	for _, j := range job.Joins {
+		walk(joinPath(j.ID), j.ChainedActions)
+	}
+	return paths
+}
+
+// JoinArrival registers the end of an upstream branch for a given Join. Message is nil if
+// the branch was skipped (filtered out), branchErr is set if the branch failed.
+func (t *Task) JoinArrival(joinID string, actionPath string, message *jobs.ActionMessage, branchErr error) joinResult {
+	t.joinsLock.Lock()
+	defer t.joinsLock.Unlock()
+
+	state, err := t.joinState(joinID)
+	if err != nil {
+		return joinResult{Err: err}
+	}
+	if state.done {
+		return joinResult{Status: fmt.Sprintf("Join %s already processed, ignoring output of %s", joinID, actionPath)}
+	}
+	state.reported[actionPath] = true
+	if branchErr != nil {
+		if message == nil {
+			message = &jobs.ActionMessage{}
+		}
+		state.failures = append(state.failures, actionPath)
+		if state.join.FailOnBranchError {
+			state.done = true
+			return joinResult{Err: fmt.Errorf("join %s failed: branch %s returned an error (%s)", joinID, actionPath, branchErr.Error())}
+		}
+		m := message.WithError(branchErr)
+		message = &m
+	}
+	if message != nil {
+		state.messages = append(state.messages, *message)
+	}
+	return state.evaluate(false, t.pathPending)
+}
+
+// trackPath registers the start (1) or the end (-1) of a Dispatch loop or of a Runnable at a
+// given ActionPath. It is only required when the job has joins.
+func (t *Task) trackPath(actionPath string, delta int) {
+	if len(t.Job.Joins) == 0 {
+		return
+	}
+	t.joinsLock.Lock()
+	defer t.joinsLock.Unlock()
+	if t.activePaths == nil {
+		t.activePaths = make(map[string]int)
+	}
+	t.activePaths[actionPath] += delta
+	if t.activePaths[actionPath] <= 0 {
+		delete(t.activePaths, actionPath)
+	}
+}
+
+// LeavePath registers the end of a Dispatch loop or of a Runnable at a given ActionPath and,
+// if nothing runs at this path anymore, returns the joins that can now be resolved.
+func (t *Task) LeavePath(actionPath string) (results map[string]joinResult) {
+	if len(t.Job.Joins) == 0 {
+		return
+	}
+	t.trackPath(actionPath, -1)
+	t.joinsLock.Lock()
+	defer t.joinsLock.Unlock()
+	if t.activePaths[actionPath] > 0 {
+		return
+	}
+	results = make(map[string]joinResult)
+	for id, state := range t.joins {
+		if state.done {
+			continue
+		}
+		if res := state.evaluate(false, t.pathPending); res.Fire || res.Err != nil {
+			results[id] = res
+		}
+	}
+	return
+}
+
+// pathPending tells whether actions may still run at a given ActionPath, because a Dispatch loop
+// or a Runnable is active at this path or upstream of it. Must be called with joinsLock held.
+func (t *Task) pathPending(actionPath string) bool {
+	for p := range t.activePaths {
+		if p == actionPath || strings.HasPrefix(actionPath, p+"/") || strings.HasPrefix(actionPath, p+"$FAIL/") {
+			return true
+		}
+	}
+	return false
+}
+
+// BranchFailed registers an action that returned an error: the joins expecting a branch chained
+// below this action will never receive it.
+func (t *Task) BranchFailed(actionPath string, branchErr error) {
+	t.joinsLock.Lock()
+	defer t.joinsLock.Unlock()
+	if t.failedPaths == nil {
+		t.failedPaths = make(map[string]string)
+	}
+	t.failedPaths[actionPath] = branchErr.Error()
+}
+
+// FlushJoins resolves all joins that are still waiting for branches although the task has no more
+// running actions nor pending dispatches. This happens when an upstream branch was never reached
+// (e.g. a parent filter or a parent action failed).
+func (t *Task) FlushJoins() (results map[string]joinResult) {
+	if !t.Idle() {
+		return
+	}
+	t.joinsLock.Lock()
+	defer t.joinsLock.Unlock()
+	results = make(map[string]joinResult)
+	for id, state := range t.joins {
+		if state.done {
+			continue
+		}
+		if res, failed := state.failedUpstream(t.failedPaths); failed {
+			results[id] = res
+			continue
+		}
+		results[id] = state.evaluate(true, t.pathPending)
+	}
+	return
+}
+
+// joinState lazily creates the state for a given join, must be called with joinsLock held.
+func (t *Task) joinState(joinID string) (*joinState, error) {
+	if t.joins == nil {
+		t.joins = make(map[string]*joinState)
+	}
+	if state, ok := t.joins[joinID]; ok {
+		return state, nil
+	}
+	join, ok := t.Job.JoinByID(joinID)
+	if !ok {
+		return nil, fmt.Errorf("cannot find join %s in job definition", joinID)
+	}
+	state := &joinState{
+		join:     join,
+		expected: joinUpstreamPaths(t.Job, joinID),
+		reported: make(map[string]bool),
+	}
+	t.joins[joinID] = state
+	return state, nil
+}
+
+// failedUpstream registers the missing branches whose parent action failed as failures.
+// It returns a failed result if the join must fail on branch errors.
+func (s *joinState) failedUpstream(failedPaths map[string]string) (joinResult, bool) {
+	var missing []string
+	for p := range s.expected {
+		if !s.reported[p] {
+			missing = append(missing, p)
+		}
+	}
+	sort.Strings(missing)
+	for _, p := range missing {
+		for f, msg := range failedPaths {
+			if !strings.HasPrefix(p, f+"/") {
+				continue
+			}
+			s.reported[p] = true
+			s.failures = append(s.failures, p)
+			if s.join.FailOnBranchError {
+				s.done = true
+				return joinResult{Err: fmt.Errorf("join %s failed: branch %s was not reached, %s returned an error (%s)", s.join.ID, p, f, msg)}, true
+			}
+			break
+		}
+	}
+	return joinResult{}, false
+}
+
+// evaluate checks if the join can be triggered. If force is true, missing branches are considered as skipped.
+func (s *joinState) evaluate(force bool, pending func(actionPath string) bool) joinResult {
+	var missing []string
+	for p := range s.expected {
+		if !s.reported[p] || pending(p) {
+			missing = append(missing, p)
+		}
+	}
+	sort.Strings(missing)
+	successes := len(s.messages) - len(s.failures)
+	if s.join.FailOnBranchError {
+		successes = len(s.messages)
+	}
+
+	if s.join.Mode == jobs.JoinMode_WaitAny && successes > 0 {
+		// Use the first successful message
+		s.done = true
+		for _, m := range s.messages {
+			if last := m.GetLastOutput(); last == nil || last.ErrorString == "" {
+				return joinResult{Fire: true, Merged: m, Status: fmt.Sprintf("Join %s: first branch finished, continuing", s.join.ID)}
+			}
+		}
+	}
+	if len(missing) > 0 && !force {
+		return joinResult{Status: fmt.Sprintf("Join %s: waiting for %d/%d branch(es)", s.join.ID, len(missing), len(s.expected))}
+	}
+	s.done = true
+	if len(s.messages) == 0 || (s.join.Mode == jobs.JoinMode_WaitAny && successes == 0) {
+		if len(s.failures) > 0 {
+			return joinResult{Err: fmt.Errorf("join %s failed: all branches returned an error (%s)", s.join.ID, strings.Join(s.failures, ", "))}
+		}
+		return joinResult{Status: fmt.Sprintf("Join %s: no branch produced any output, ignoring", s.join.ID)}
+	}
+	status := fmt.Sprintf("Join %s: merged output of %d branch(es)", s.join.ID, len(s.messages))
+	if len(missing) > 0 {
+		status += fmt.Sprintf(", %d branch(es) never reached (%s)", len(missing), strings.Join(missing, ", "))
+	}
+	return joinResult{Fire: true, Merged: jobs.MergeActionMessages(s.messages...), Status: status}
+}
+
+// joinAction builds a pseudo-action used to log the join in the task ActionsLogs.
+func joinAction(join *jobs.Join) jobs.Action {
+	return jobs.Action{
+		ID:         joinActionName,
+		Label:      join.Label,
+		Parameters: map[string]string{"JoinID": join.ID, "Mode": join.Mode.String()},
+	}
+}
+
+// reportToJoin sends the end of a branch to the given join and handles the result.
+// It returns true if the join has failed.
+func (r *Runnable) reportToJoin(actionPath string, joinID string, message *jobs.ActionMessage, branchErr error, Queue chan Runnable) bool {
+	return r.handleJoinResult(joinID, r.Task.JoinArrival(joinID, actionPath, message, branchErr), Queue)
+}
+
+// leavePath registers the end of a Dispatch loop or of a Runnable and handles the joins it resolves.
+func (r *Runnable) leavePath(actionPath string, Queue chan Runnable) {
+	for joinID, res := range r.Task.LeavePath(actionPath) {
+		r.handleJoinResult(joinID, res, Queue)
+	}
+}
+
+// flushJoins triggers or fails pending joins once the task has no more running actions.
+func (r *Runnable) flushJoins(Queue chan Runnable) {
+	for joinID, res := range r.Task.FlushJoins() {
+		r.handleJoinResult(joinID, res, Queue)
+	}
+}
+
+// handleJoinResult updates the task status and logs, and dispatches the join chained actions if required.
+func (r *Runnable) handleJoinResult(joinID string, res joinResult, Queue chan Runnable) bool {
+	if res.Err != nil {
+		log.TasksLogger(r.Context).Error(res.Err.Error())
+		r.Task.SetStatus(jobs.TaskStatus_Error, "Error: "+res.Err.Error())
+		r.Task.SetEndTime(time.Now())
+		r.Task.Save()
+		return true
+	}
+	if res.Status != "" {
+		log.TasksLogger(r.Context).Info(res.Status)
+	}
+	if !res.Fire {
+		if res.Status != "" {
+			r.Task.SetStatus(jobs.TaskStatus_Running, res.Status)
+			r.Task.Save()
+		}
+		return false
+	}
+	join, _ := r.Task.Job.JoinByID(joinID)
+	r.Task.AppendLog(joinAction(join), res.Merged, res.Merged)
+	r.Task.Save()
+	r.Dispatch(joinPath(joinID), res.Merged, join.ChainedActions, Queue)
+	return false
+}
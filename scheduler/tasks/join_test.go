@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+func joinTestJob(mode jobs.JoinMode, failOnError bool) *jobs.Job {
+	return &jobs.Job{
+		ID: "join-job",
+		Actions: []*jobs.Action{
+			{ID: "branch.a", JoinID: "j1"},
+			{ID: "branch.b", ChainedActions: []*jobs.Action{
+				{ID: "branch.b.child", JoinID: "j1"},
+			}},
+		},
+		Joins: []*jobs.Join{
+			{ID: "j1", Mode: mode, FailOnBranchError: failOnError, ChainedActions: []*jobs.Action{
+				{ID: "after.join"},
+			}},
+		},
+	}
+}
+
+func TestJoinUpstreamPaths(t *testing.T) {
+
+	Convey("Test upstream paths computation", t, func() {
+		paths := joinUpstreamPaths(joinTestJob(jobs.JoinMode_WaitAll, false), "j1")
+		So(paths, ShouldHaveLength, 2)
+		So(paths, ShouldContainKey, "ROOT/branch.a$0")
+		So(paths, ShouldContainKey, "ROOT/branch.b$1/branch.b.child$0")
+	})
+
+	Convey("Test joins validation", t, func() {
+		So(joinTestJob(jobs.JoinMode_WaitAll, false).ValidateJoins(), ShouldBeNil)
+
+		job := joinTestJob(jobs.JoinMode_WaitAll, false)
+		job.Actions[0].JoinID = "unknown"
+		So(job.ValidateJoins(), ShouldNotBeNil)
+
+		job = joinTestJob(jobs.JoinMode_WaitAll, false)
+		job.Joins = append(job.Joins, &jobs.Join{ID: "orphan"})
+		So(job.ValidateJoins(), ShouldNotBeNil)
+	})
+}
+
+func TestTask_JoinArrival(t *testing.T) {
+
+	event := &jobs.JobTriggerEvent{JobID: "join-job"}
+	msgA := &jobs.ActionMessage{Nodes: []*tree.Node{{Uuid: "a", Path: "a"}}, OutputChain: []*jobs.ActionOutput{{Success: true, StringBody: "A"}}}
+	msgB := &jobs.ActionMessage{Nodes: []*tree.Node{{Uuid: "a", Path: "a"}, {Uuid: "b", Path: "b"}}, OutputChain: []*jobs.ActionOutput{{Success: true, StringBody: "B"}}}
+
+	Convey("Test WaitAll join", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		res := task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		So(res.Fire, ShouldBeFalse)
+		So(res.Err, ShouldBeNil)
+		res = task.JoinArrival("j1", "ROOT/branch.b$1/branch.b.child$0", msgB, nil)
+		So(res.Fire, ShouldBeTrue)
+		So(res.Merged.Nodes, ShouldHaveLength, 2)
+		So(res.Merged.OutputChain, ShouldHaveLength, 2)
+		So(res.Merged.OutputChain[1].StringBody, ShouldEqual, "B")
+		// Late arrivals are ignored
+		res = task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		So(res.Fire, ShouldBeFalse)
+	})
+
+	Convey("Test WaitAny join", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAny, false), event)
+		res := task.JoinArrival("j1", "ROOT/branch.a$0", nil, fmt.Errorf("branch error"))
+		So(res.Fire, ShouldBeFalse)
+		So(res.Err, ShouldBeNil)
+		res = task.JoinArrival("j1", "ROOT/branch.b$1/branch.b.child$0", msgB, nil)
+		So(res.Fire, ShouldBeTrue)
+		So(res.Merged.Nodes, ShouldHaveLength, 2)
+	})
+
+	Convey("Test join failing on branch error", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, true), event)
+		res := task.JoinArrival("j1", "ROOT/branch.a$0", msgA, fmt.Errorf("branch error"))
+		So(res.Fire, ShouldBeFalse)
+		So(res.Err, ShouldNotBeNil)
+	})
+
+	Convey("Test skipped branch and flush", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		res := task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		So(res.Fire, ShouldBeFalse)
+		// Task is still running something
+		task.Add(1)
+		So(task.FlushJoins(), ShouldBeEmpty)
+		task.Done(1)
+		results := task.FlushJoins()
+		So(results, ShouldHaveLength, 1)
+		So(results["j1"].Fire, ShouldBeTrue)
+		So(results["j1"].Merged.Nodes, ShouldHaveLength, 1)
+	})
+
+	Convey("Test flush waits for pending dispatches", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		task.trackDispatch(1)
+		So(task.FlushJoins(), ShouldBeEmpty)
+		task.trackDispatch(-1)
+		So(task.FlushJoins(), ShouldHaveLength, 1)
+	})
+
+	Convey("Test failed parent action and flush", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, true), event)
+		task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		task.BranchFailed("ROOT/branch.b$1", fmt.Errorf("parent error"))
+		results := task.FlushJoins()
+		So(results, ShouldHaveLength, 1)
+		So(results["j1"].Fire, ShouldBeFalse)
+		So(results["j1"].Err, ShouldNotBeNil)
+
+		// Without FailOnBranchError, the join continues with the other branches
+		task = NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		task.BranchFailed("ROOT/branch.b$1", fmt.Errorf("parent error"))
+		results = task.FlushJoins()
+		So(results["j1"].Fire, ShouldBeTrue)
+		So(results["j1"].Merged.Nodes, ShouldHaveLength, 1)
+
+		// If no branch succeeded, the join fails
+		task = NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		task.JoinArrival("j1", "ROOT/branch.a$0", nil, nil)
+		task.BranchFailed("ROOT/branch.b$1", fmt.Errorf("parent error"))
+		results = task.FlushJoins()
+		So(results["j1"].Err, ShouldNotBeNil)
+	})
+
+	Convey("Test several outputs on the same upstream path", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		// branch.a received two messages, hence runs twice
+		task.trackPath("ROOT/branch.a$0", 2)
+		task.trackPath("ROOT/branch.b$1/branch.b.child$0", 1)
+
+		So(task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil).Fire, ShouldBeFalse)
+		So(task.LeavePath("ROOT/branch.a$0"), ShouldBeEmpty)
+		So(task.JoinArrival("j1", "ROOT/branch.b$1/branch.b.child$0", msgB, nil).Fire, ShouldBeFalse)
+		So(task.LeavePath("ROOT/branch.b$1/branch.b.child$0"), ShouldBeEmpty)
+
+		msgC := &jobs.ActionMessage{Nodes: []*tree.Node{{Uuid: "c", Path: "c"}}, OutputChain: []*jobs.ActionOutput{{Success: true, StringBody: "C"}}}
+		So(task.JoinArrival("j1", "ROOT/branch.a$0", msgC, nil).Fire, ShouldBeFalse)
+		results := task.LeavePath("ROOT/branch.a$0")
+		So(results, ShouldHaveLength, 1)
+		So(results["j1"].Fire, ShouldBeTrue)
+		So(results["j1"].Merged.Nodes, ShouldHaveLength, 3)
+		So(results["j1"].Merged.OutputChain, ShouldHaveLength, 3)
+	})
+
+	Convey("Test upstream activity delays the join", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		// Parent of branch.b.child is still running and may dispatch other messages
+		task.trackPath("ROOT/branch.b$1", 1)
+		task.trackPath("ROOT/branch.b$1/branch.b.child$0", 1)
+
+		task.JoinArrival("j1", "ROOT/branch.a$0", msgA, nil)
+		task.JoinArrival("j1", "ROOT/branch.b$1/branch.b.child$0", msgB, nil)
+		So(task.LeavePath("ROOT/branch.b$1/branch.b.child$0"), ShouldBeEmpty)
+		results := task.LeavePath("ROOT/branch.b$1")
+		So(results, ShouldHaveLength, 1)
+		So(results["j1"].Fire, ShouldBeTrue)
+	})
+
+	Convey("Test unknown join", t, func() {
+		task := NewTaskFromEvent(context.Background(), joinTestJob(jobs.JoinMode_WaitAll, false), event)
+		res := task.JoinArrival("unknown", "ROOT/branch.a$0", msgA, nil)
+		So(res.Err, ShouldNotBeNil)
+	})
+}
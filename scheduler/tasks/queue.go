@@ -0,0 +1,311 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/micro/go-micro/client"
+	"github.com/micro/go-micro/metadata"
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common"
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/utils/permissions"
+)
+
+var (
+	// ErrLeaseLost is returned when the lease on a queued task was lost, e.g. claimed by another node
+	ErrLeaseLost = fmt.Errorf("lease on queued task was lost")
+	// QueueLeaseDuration is the duration of the lease taken on a queued task. It is renewed
+	// every third of this duration while the task is running.
+	QueueLeaseDuration = 30 * time.Second
+	// QueuePollInterval is the delay between two checks of the queue when it is empty.
+	QueuePollInterval = 1 * time.Second
+	// QueueIdleCheckInterval is the delay between two checks of a running task completion.
+	QueueIdleCheckInterval = 500 * time.Millisecond
+	// MaximumQueuedTasks is the maximum number of queued tasks run in parallel by one scheduler node.
+	MaximumQueuedTasks int32 = 50
+)
+
+// TaskQueue is a queue of tasks shared by all scheduler nodes. Tasks are claimed with a lease
+// that must be renewed while they are running. Expired leases are made available to other nodes.
+type TaskQueue interface {
+	EnqueueTask(task *jobs.QueuedTask) error
+	ClaimTask(nodeId string, lease time.Duration) (*jobs.QueuedTask, error)
+	RenewTaskLease(id string, nodeId string, lease time.Duration) error
+	ReleaseTask(id string, nodeId string) error
+}
+
+// NewTaskQueueClient provides a TaskQueue using the JobService API.
+func NewTaskQueueClient(ctx context.Context, cl client.Client) TaskQueue {
+	return &taskQueueClient{
+		ctx: ctx,
+		cli: jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, cl),
+	}
+}
+
+type taskQueueClient struct {
+	ctx context.Context
+	cli jobs.JobServiceClient
+}
+
+func (q *taskQueueClient) EnqueueTask(task *jobs.QueuedTask) error {
+	_, e := q.cli.EnqueueTask(q.ctx, &jobs.EnqueueTaskRequest{Task: task})
+	return e
+}
+
+func (q *taskQueueClient) ClaimTask(nodeId string, lease time.Duration) (*jobs.QueuedTask, error) {
+	resp, e := q.cli.ClaimTask(q.ctx, &jobs.ClaimTaskRequest{NodeID: nodeId, LeaseSeconds: int32(lease.Seconds())})
+	if e != nil {
+		return nil, e
+	}
+	return resp.Task, nil
+}
+
+func (q *taskQueueClient) RenewTaskLease(id string, nodeId string, lease time.Duration) error {
+	resp, e := q.cli.RenewTaskLease(q.ctx, &jobs.RenewTaskLeaseRequest{ID: id, NodeID: nodeId, LeaseSeconds: int32(lease.Seconds())})
+	if e != nil {
+		return e
+	}
+	if !resp.Success {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (q *taskQueueClient) ReleaseTask(id string, nodeId string) error {
+	resp, e := q.cli.ReleaseTask(q.ctx, &jobs.ReleaseTaskRequest{ID: id, NodeID: nodeId})
+	if e != nil {
+		return e
+	}
+	if !resp.Success {
+		return fmt.Errorf("cannot release %s", id)
+	}
+	return nil
+}
+
+// UseQueue makes the subscriber push triggered tasks to the shared queue instead of running
+// them directly, and starts claiming tasks from this queue under the given node identifier.
+func (s *Subscriber) UseQueue(queue TaskQueue, nodeId string) {
+	s.Queue = queue
+	s.NodeID = nodeId
+	s.queueWake = make(chan struct{}, 1)
+	s.queueStop = make(chan struct{})
+	go s.claimLoop()
+}
+
+// startTask creates a task for this event. It is either run directly, or pushed to the queue.
+// Skipped is the number of triggers dropped by the job throttle before this one.
+func (s *Subscriber) startTask(ctx context.Context, job *jobs.Job, event interface{}, skipped int32) {
+	if s.Queue == nil {
+		task := NewTaskFromEvent(ctx, job, event)
+		task.lockedTask.SkippedTriggers = skipped
+		go task.EnqueueRunnables(s.Client, s.MainQueue)
+		return
+	}
+	if e := s.enqueueTask(ctx, job, event, skipped); e != nil {
+		log.Logger(ctx).Error("Cannot push task to the queue, running it locally", zap.String("job", job.ID), zap.Error(e))
+		task := NewTaskFromEvent(ctx, job, event)
+		task.lockedTask.SkippedTriggers = skipped
+		go task.EnqueueRunnables(s.Client, s.MainQueue)
+	}
+}
+
+// enqueueTask serializes the event and the context metadata and pushes them to the queue
+func (s *Subscriber) enqueueTask(ctx context.Context, job *jobs.Job, event interface{}, skipped int32) error {
+	entry := &jobs.QueuedTask{
+		JobID:           job.ID,
+		ContextMetadata: make(map[string]string),
+		SkippedTriggers: skipped,
+	}
+	if msg, ok := event.(proto.Message); ok {
+		a, e := ptypes.MarshalAny(msg)
+		if e != nil {
+			return e
+		}
+		entry.Event = a
+	}
+	if meta, ok := metadata.FromContext(ctx); ok {
+		for k, v := range meta {
+			entry.ContextMetadata[k] = v
+		}
+	}
+	if u, _ := permissions.FindUserNameInContext(ctx); u != "" {
+		entry.ContextMetadata[common.PYDIO_CONTEXT_USER_KEY] = u
+	}
+	if e := s.Queue.EnqueueTask(entry); e != nil {
+		return e
+	}
+	select {
+	case s.queueWake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// claimLoop claims tasks from the queue as long as this node has some capacity
+func (s *Subscriber) claimLoop() {
+	for {
+		select {
+		case <-s.queueWake:
+		case <-time.After(QueuePollInterval):
+		case <-s.queueStop:
+			return
+		}
+		for atomic.LoadInt32(&s.queueRunning) < atomic.LoadInt32(&MaximumQueuedTasks) {
+			entry, e := s.Queue.ClaimTask(s.NodeID, QueueLeaseDuration)
+			if e != nil {
+				log.Logger(s.RootContext).Debug("Cannot claim task from queue", zap.Error(e))
+				break
+			}
+			if entry == nil {
+				break
+			}
+			s.runQueuedTask(entry)
+		}
+	}
+}
+
+// runQueuedTask rebuilds the task from a claimed entry, runs it and holds the lease until it is finished.
+func (s *Subscriber) runQueuedTask(entry *jobs.QueuedTask) {
+
+	atomic.AddInt32(&s.queueRunning, 1)
+	job, ok := s.jobDefinition(entry.JobID)
+	if !ok || job.Inactive {
+		s.releaseQueuedTask(entry)
+		return
+	}
+
+	ctx := metadata.NewContext(s.RootContext, metadata.Metadata(entry.ContextMetadata))
+	if u, ok := entry.ContextMetadata[common.PYDIO_CONTEXT_USER_KEY]; ok {
+		ctx = context.WithValue(ctx, common.PYDIO_CONTEXT_USER_KEY, u)
+	}
+	ctx = s.prepareTaskContext(ctx, job, false)
+	// Task is cancelled if the lease is lost, as another node may run it again
+	ctx, cancel := context.WithCancel(ctx)
+
+	var event interface{}
+	if entry.Event != nil {
+		var dyn ptypes.DynamicAny
+		if e := ptypes.UnmarshalAny(entry.Event, &dyn); e == nil {
+			event = dyn.Message
+		} else {
+			log.Logger(ctx).Error("Cannot unmarshal queued task event", zap.String("job", job.ID), zap.Error(e))
+		}
+	}
+	if entry.Claims > 1 {
+		log.Logger(ctx).Info("Running job "+job.ID+" from a requeued task", zap.String("entry", entry.ID), zap.Int32("claims", entry.Claims))
+	}
+
+	task := NewTaskFromEvent(ctx, job, event)
+	task.lockedTask.SkippedTriggers = entry.SkippedTriggers
+	task.trackDispatch(1)
+	go func() {
+		defer task.trackDispatch(-1)
+		task.EnqueueRunnables(s.Client, s.MainQueue)
+	}()
+	go s.holdLease(entry, task, cancel)
+}
+
+// holdLease renews the lease of a running task until it is idle, then releases it.
+// The task is cancelled if the lease is lost, or if it cannot be renewed before it expires.
+func (s *Subscriber) holdLease(entry *jobs.QueuedTask, task *Task, cancel context.CancelFunc) {
+
+	renew := time.NewTicker(QueueLeaseDuration / 3)
+	check := time.NewTicker(QueueIdleCheckInterval)
+	defer func() {
+		renew.Stop()
+		check.Stop()
+	}()
+	var idle int
+	lastRenew := time.Now()
+	for {
+		select {
+		case <-check.C:
+			// Require two consecutive checks, as counters may transiently drop to zero between two actions
+			if task.Idle() {
+				idle++
+			} else {
+				idle = 0
+			}
+			if idle >= 2 {
+				cancel()
+				s.releaseQueuedTask(entry)
+				return
+			}
+		case <-renew.C:
+			e := s.Queue.RenewTaskLease(entry.ID, s.NodeID, QueueLeaseDuration)
+			if e == nil {
+				lastRenew = time.Now()
+				continue
+			}
+			if e != ErrLeaseLost && time.Now().Sub(lastRenew) < QueueLeaseDuration {
+				log.Logger(s.RootContext).Warn("Cannot renew lease on running task, will retry", zap.String("job", entry.JobID), zap.Error(e))
+				continue
+			}
+			log.Logger(s.RootContext).Error("Lease on running task was lost, interrupting it as it may be claimed by another node", zap.String("job", entry.JobID), zap.Error(e))
+			cancel()
+			task.SetStatus(jobs.TaskStatus_Interrupted, "Interrupted: lease on queued task was lost")
+			task.SetEndTime(time.Now())
+			task.Save()
+			atomic.AddInt32(&s.queueRunning, -1)
+			return
+		case <-s.queueStop:
+			// Lease will expire and the task will be claimed by another node
+			atomic.AddInt32(&s.queueRunning, -1)
+			return
+		}
+	}
+}
+
+func (s *Subscriber) releaseQueuedTask(entry *jobs.QueuedTask) {
+	if e := s.Queue.ReleaseTask(entry.ID, s.NodeID); e != nil {
+		log.Logger(s.RootContext).Warn("Cannot release queued task", zap.String("job", entry.JobID), zap.Error(e))
+	}
+	atomic.AddInt32(&s.queueRunning, -1)
+	select {
+	case s.queueWake <- struct{}{}:
+	default:
+	}
+}
+
+// jobDefinition finds a job in the local definitions, or loads it from the JobService.
+func (s *Subscriber) jobDefinition(jobId string) (*jobs.Job, bool) {
+	s.jobsLock.RLock()
+	j, ok := s.JobsDefinitions[jobId]
+	s.jobsLock.RUnlock()
+	if ok {
+		return j, true
+	}
+	cli := jobs.NewJobServiceClient(common.SERVICE_GRPC_NAMESPACE_+common.SERVICE_JOBS, s.Client)
+	resp, e := cli.GetJob(s.RootContext, &jobs.GetJobRequest{JobID: jobId})
+	if e != nil || resp.Job == nil {
+		return nil, false
+	}
+	return resp.Job, true
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/client"
+	"github.com/pborman/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+	schedulerjobs "github.com/pydio/cells/scheduler/jobs"
+)
+
+const leaseTestActionName = "actions.test.lease"
+
+var leaseTestStats struct {
+	running int32
+	max     int32
+	total   int32
+}
+
+// leaseTestAction records the number of concurrent runs
+type leaseTestAction struct{}
+
+func (a *leaseTestAction) GetName() string { return leaseTestActionName }
+
+func (a *leaseTestAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	return nil
+}
+
+func (a *leaseTestAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	r := atomic.AddInt32(&leaseTestStats.running, 1)
+	for {
+		m := atomic.LoadInt32(&leaseTestStats.max)
+		if r <= m || atomic.CompareAndSwapInt32(&leaseTestStats.max, m, r) {
+			break
+		}
+	}
+	<-time.After(200 * time.Millisecond)
+	atomic.AddInt32(&leaseTestStats.running, -1)
+	atomic.AddInt32(&leaseTestStats.total, 1)
+	return input, nil
+}
+
+// countingQueue counts the entries claimed by one node
+type countingQueue struct {
+	TaskQueue
+	claims int32
+}
+
+func (c *countingQueue) ClaimTask(nodeId string, lease time.Duration) (*jobs.QueuedTask, error) {
+	t, e := c.TaskQueue.ClaimTask(nodeId, lease)
+	if t != nil {
+		atomic.AddInt32(&c.claims, 1)
+	}
+	return t, e
+}
+
+// lostLeaseQueue refuses to renew any lease
+type lostLeaseQueue struct {
+	TaskQueue
+}
+
+func (l *lostLeaseQueue) RenewTaskLease(id string, nodeId string, lease time.Duration) error {
+	return ErrLeaseLost
+}
+
+func newQueueTestSubscriber(queue TaskQueue, nodeId string, job *jobs.Job) *Subscriber {
+	s := &Subscriber{
+		RootContext:     context.Background(),
+		JobsDefinitions: map[string]*jobs.Job{job.ID: job},
+		MainQueue:       make(chan Runnable),
+		Dispatchers:     make(map[string]*Dispatcher),
+		jobsLock:        &sync.RWMutex{},
+	}
+	s.ListenToMainQueue()
+	s.UseQueue(queue, nodeId)
+	return s
+}
+
+func waitForTotal(total int32, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&leaseTestStats.total) >= total {
+			return true
+		}
+		<-time.After(20 * time.Millisecond)
+	}
+	return false
+}
+
+func TestSubscriber_Queue(t *testing.T) {
+
+	actions.GetActionsManager().Register(leaseTestActionName, func() actions.ConcreteAction {
+		return &leaseTestAction{}
+	})
+	QueuePollInterval = 50 * time.Millisecond
+	QueueIdleCheckInterval = 20 * time.Millisecond
+	QueueLeaseDuration = 1 * time.Second
+
+	// Each scenario uses a fresh store, as stopped nodes may leave leased entries behind
+	newStore := func() (*schedulerjobs.BoltStore, func()) {
+		dbFile := filepath.Join(os.TempDir(), "tasks-queue-test-"+uuid.New()+".db")
+		store, err := schedulerjobs.NewBoltStore(dbFile)
+		So(err, ShouldBeNil)
+		atomic.StoreInt32(&leaseTestStats.max, 0)
+		atomic.StoreInt32(&leaseTestStats.total, 0)
+		return store, func() {
+			store.Close()
+			os.Remove(dbFile)
+		}
+	}
+
+	Convey("MaxConcurrency is respected across nodes", t, func() {
+		store, closeStore := newStore()
+		defer closeStore()
+		job := &jobs.Job{ID: "limited-job", MaxConcurrency: 1, Actions: []*jobs.Action{{ID: leaseTestActionName}}}
+		So(store.PutJob(job), ShouldBeNil)
+
+		q1, q2 := &countingQueue{TaskQueue: store}, &countingQueue{TaskQueue: store}
+		s1 := newQueueTestSubscriber(q1, "node-1", job)
+		s2 := newQueueTestSubscriber(q2, "node-2", job)
+		defer s1.Stop()
+		defer s2.Stop()
+
+		for i := 0; i < 2; i++ {
+			s1.startTask(context.Background(), job, &jobs.JobTriggerEvent{JobID: job.ID, RunNow: true}, 0)
+			s2.startTask(context.Background(), job, &jobs.JobTriggerEvent{JobID: job.ID, RunNow: true}, 0)
+		}
+		So(waitForTotal(4, 10*time.Second), ShouldBeTrue)
+		So(atomic.LoadInt32(&leaseTestStats.max), ShouldEqual, 1)
+		So(atomic.LoadInt32(&q1.claims)+atomic.LoadInt32(&q2.claims), ShouldEqual, 4)
+	})
+
+	Convey("Tasks are spread across nodes", t, func() {
+		store, closeStore := newStore()
+		defer closeStore()
+		atomic.StoreInt32(&MaximumQueuedTasks, 1)
+		defer func() {
+			atomic.StoreInt32(&MaximumQueuedTasks, 50)
+		}()
+		job := &jobs.Job{ID: "parallel-job", Actions: []*jobs.Action{{ID: leaseTestActionName}}}
+		So(store.PutJob(job), ShouldBeNil)
+
+		q1, q2 := &countingQueue{TaskQueue: store}, &countingQueue{TaskQueue: store}
+		s1 := newQueueTestSubscriber(q1, "node-1", job)
+		s2 := newQueueTestSubscriber(q2, "node-2", job)
+		defer s1.Stop()
+		defer s2.Stop()
+
+		for i := 0; i < 6; i++ {
+			s1.startTask(context.Background(), job, &jobs.JobTriggerEvent{JobID: job.ID, RunNow: true}, 0)
+		}
+		So(waitForTotal(6, 10*time.Second), ShouldBeTrue)
+		So(atomic.LoadInt32(&q1.claims), ShouldBeGreaterThan, 0)
+		So(atomic.LoadInt32(&q2.claims), ShouldBeGreaterThan, 0)
+		So(atomic.LoadInt32(&leaseTestStats.max), ShouldBeLessThanOrEqualTo, 2)
+	})
+
+	Convey("Expired leases are claimed by another node", t, func() {
+		store, closeStore := newStore()
+		defer closeStore()
+		job := &jobs.Job{ID: "crashed-job", MaxConcurrency: 1, Actions: []*jobs.Action{{ID: leaseTestActionName}}}
+		So(store.PutJob(job), ShouldBeNil)
+		So(store.EnqueueTask(&jobs.QueuedTask{JobID: job.ID}), ShouldBeNil)
+
+		// A node claims the task and dies without renewing its lease
+		claimed, e := store.ClaimTask("dead-node", time.Second)
+		So(e, ShouldBeNil)
+		So(claimed, ShouldNotBeNil)
+
+		q := &countingQueue{TaskQueue: store}
+		s := newQueueTestSubscriber(q, "node-1", job)
+		defer s.Stop()
+
+		So(waitForTotal(1, 5*time.Second), ShouldBeTrue)
+		So(atomic.LoadInt32(&q.claims), ShouldEqual, 1)
+		// Entry is released once finished
+		So(waitForQueueEmpty(store, 2*time.Second), ShouldBeTrue)
+	})
+
+	Convey("Task is interrupted when its lease is lost", t, func() {
+		store, closeStore := newStore()
+		defer closeStore()
+		chain := &jobs.Action{ID: leaseTestActionName}
+		for i := 0; i < 5; i++ {
+			chain = &jobs.Action{ID: leaseTestActionName, ChainedActions: []*jobs.Action{chain}}
+		}
+		job := &jobs.Job{ID: "lost-lease-job", Actions: []*jobs.Action{chain}}
+		So(store.PutJob(job), ShouldBeNil)
+
+		s := newQueueTestSubscriber(&lostLeaseQueue{TaskQueue: store}, "node-1", job)
+		s.startTask(context.Background(), job, &jobs.JobTriggerEvent{JobID: job.ID, RunNow: true}, 0)
+		So(waitForTotal(1, 5*time.Second), ShouldBeTrue)
+		<-time.After(1500 * time.Millisecond)
+		So(atomic.LoadInt32(&leaseTestStats.total), ShouldBeLessThan, 6)
+		So(atomic.LoadInt32(&s.queueRunning), ShouldEqual, 0)
+
+		// Stopping twice does not panic
+		s.Stop()
+		s.Stop()
+	})
+}
+
+func waitForQueueEmpty(store TaskQueue, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if t, _ := store.ClaimTask("probe", time.Second); t == nil {
+			return true
+		} else {
+			store.ReleaseTask(t.ID, "probe")
+		}
+		<-time.After(50 * time.Millisecond)
+	}
+	return false
+}
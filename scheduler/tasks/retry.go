@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// runWithRetry runs the concrete implementation, applying the action RetryPolicy if any.
+// Each attempt is recorded and returned along with the last output and error. Waiting
+// between attempts can be interrupted by a Stop command or by the context cancellation.
+func (r *Runnable) runWithRetry(ctx context.Context, channels *actions.RunnableChannels) (jobs.ActionMessage, []*jobs.ActionAttempt, error) {
+
+	policy := r.Action.RetryPolicy
+	if policy == nil {
+		output, err := r.Implementation.Run(ctx, channels, r.Message)
+		return output, nil, err
+	}
+
+	var attempts []*jobs.ActionAttempt
+	var output jobs.ActionMessage
+	var err error
+	max := policy.Attempts()
+
+	for i := 1; i <= max; i++ {
+		attempt := &jobs.ActionAttempt{
+			Attempt:   int32(i),
+			StartTime: int32(time.Now().Unix()),
+		}
+		output, err = r.runAttempt(ctx, channels, policy.Timeout())
+		attempt.EndTime = int32(time.Now().Unix())
+		attempts = append(attempts, attempt)
+		if err == nil {
+			attempt.Success = true
+			return output, attempts, nil
+		}
+		attempt.ErrorString = err.Error()
+		if i == max || !policy.IsRetryable(err) {
+			break
+		}
+		backoff := policy.Backoff(i)
+		msg := fmt.Sprintf("Attempt %d/%d failed (%s), retrying in %s", i, max, err.Error(), backoff)
+		log.TasksLogger(r.Context).Info(msg, zap.String("action", r.ID))
+		r.Task.SetStatus(jobs.TaskStatus_Running, msg)
+		r.Task.Save()
+		select {
+		case <-time.After(backoff):
+		case <-channels.Stop:
+			return output, attempts, fmt.Errorf("stopped while waiting for retry (last error was %s)", err.Error())
+		case <-ctx.Done():
+			return output, attempts, ctx.Err()
+		}
+	}
+
+	return output, attempts, err
+}
+
+// runAttempt performs one run of the implementation, bounded by timeout if it is not 0.
+// The implementation is expected to honor the context cancellation.
+func (r *Runnable) runAttempt(ctx context.Context, channels *actions.RunnableChannels, timeout time.Duration) (jobs.ActionMessage, error) {
+	if timeout == 0 {
+		return r.Implementation.Run(ctx, channels, r.Message)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	output, err := r.Implementation.Run(attemptCtx, channels, r.Message)
+	if err != nil && attemptCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("attempt timed out after %s: %s", timeout, err.Error())
+	}
+	return output, err
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/micro/go-micro/client"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// flakyAction fails a given number of times before succeeding
+type flakyAction struct {
+	failures int
+	calls    int
+	err      string
+}
+
+func (f *flakyAction) GetName() string { return "actions.test.flaky" }
+
+func (f *flakyAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error { return nil }
+
+func (f *flakyAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return input.WithError(errors.New(f.err)), errors.New(f.err)
+	}
+	return input, nil
+}
+
+func retryTestRunnable(impl actions.ConcreteAction, policy *jobs.RetryPolicy) *Runnable {
+	job := &jobs.Job{ID: "retry-job"}
+	task := NewTaskFromEvent(context.Background(), job, &jobs.JobTriggerEvent{JobID: "retry-job"})
+	return &Runnable{
+		Action:         jobs.Action{ID: "actions.test.flaky", RetryPolicy: policy},
+		Task:           task,
+		Context:        context.Background(),
+		Implementation: impl,
+	}
+}
+
+func TestRunnable_RunWithRetry(t *testing.T) {
+
+	Convey("No policy runs once", t, func() {
+		impl := &flakyAction{failures: 1, err: "temporary failure"}
+		r := retryTestRunnable(impl, nil)
+		_, attempts, e := r.runWithRetry(context.Background(), &actions.RunnableChannels{})
+		So(e, ShouldNotBeNil)
+		So(attempts, ShouldBeEmpty)
+		So(impl.calls, ShouldEqual, 1)
+	})
+
+	Convey("Succeeds after retries", t, func() {
+		impl := &flakyAction{failures: 2, err: "temporary failure"}
+		r := retryTestRunnable(impl, &jobs.RetryPolicy{MaxAttempts: 3, InitialBackoff: "1ms"})
+		_, attempts, e := r.runWithRetry(context.Background(), &actions.RunnableChannels{})
+		So(e, ShouldBeNil)
+		So(impl.calls, ShouldEqual, 3)
+		So(attempts, ShouldHaveLength, 3)
+		So(attempts[0].Success, ShouldBeFalse)
+		So(attempts[0].ErrorString, ShouldEqual, "temporary failure")
+		So(attempts[2].Attempt, ShouldEqual, 3)
+		So(attempts[2].Success, ShouldBeTrue)
+	})
+
+	Convey("Gives up after MaxAttempts", t, func() {
+		impl := &flakyAction{failures: 5, err: "temporary failure"}
+		r := retryTestRunnable(impl, &jobs.RetryPolicy{MaxAttempts: 2, InitialBackoff: "1ms"})
+		_, attempts, e := r.runWithRetry(context.Background(), &actions.RunnableChannels{})
+		So(e, ShouldNotBeNil)
+		So(impl.calls, ShouldEqual, 2)
+		So(attempts, ShouldHaveLength, 2)
+	})
+
+	Convey("Does not retry non-retryable errors", t, func() {
+		impl := &flakyAction{failures: 5, err: "permission denied"}
+		r := retryTestRunnable(impl, &jobs.RetryPolicy{MaxAttempts: 4, InitialBackoff: "1ms", RetryableErrors: []string{"timeout"}})
+		_, attempts, e := r.runWithRetry(context.Background(), &actions.RunnableChannels{})
+		So(e, ShouldNotBeNil)
+		So(impl.calls, ShouldEqual, 1)
+		So(attempts, ShouldHaveLength, 1)
+	})
+
+	Convey("Stop interrupts backoff", t, func() {
+		impl := &flakyAction{failures: 5, err: "temporary failure"}
+		r := retryTestRunnable(impl, &jobs.RetryPolicy{MaxAttempts: 4, InitialBackoff: "1h"})
+		stop := make(chan interface{})
+		close(stop)
+		_, attempts, e := r.runWithRetry(context.Background(), &actions.RunnableChannels{Stop: stop})
+		So(e, ShouldNotBeNil)
+		So(impl.calls, ShouldEqual, 1)
+		So(attempts, ShouldHaveLength, 1)
+	})
+
+	Convey("Attempts are stored in task logs", t, func() {
+		r := retryTestRunnable(&flakyAction{}, nil)
+		r.Task.AppendLog(r.Action, jobs.ActionMessage{}, jobs.ActionMessage{}, &jobs.ActionAttempt{Attempt: 1, Success: true})
+		logs := r.Task.GetJobTaskClone().ActionsLogs
+		So(logs, ShouldHaveLength, 1)
+		So(logs[0].Attempts, ShouldHaveLength, 1)
+	})
+}
@@ -92,6 +92,7 @@ func NewRunnable(ctx context.Context, parentPath string, chainIndex int, cl clie
 func (r *Runnable) CreateChild(parentPath string, chainIndex int, action *jobs.Action, message jobs.ActionMessage) Runnable {
 
 	r.Task.Add(1)
+	r.Task.trackPath(path.Join(parentPath, fmt.Sprintf(action.ID+"$%d", chainIndex)), 1)
 	return NewRunnable(r.Context, parentPath, chainIndex, r.Client, r.Task, action, message)
 }
 
@@ -99,9 +100,15 @@ func (r *Runnable) CreateChild(parentPath string, chainIndex int, action *jobs.A
 // Todo - Check that done channel is working correctly with chained actions
 func (r *Runnable) Dispatch(parentPath string, input jobs.ActionMessage, actions []*jobs.Action, Queue chan Runnable) {
 
+	// Register all loops first, so that joins do not consider sibling branches as finished
+	for i, action := range actions {
+		r.Task.trackDispatch(1)
+		r.Task.trackPath(path.Join(parentPath, fmt.Sprintf(action.ID+"$%d", i)), 1)
+	}
 	for i, action := range actions {
 		act := action
 		chainIndex := i
+		aPath := path.Join(parentPath, fmt.Sprintf(act.ID+"$%d", chainIndex))
 		messagesOutput := make(chan jobs.ActionMessage)
 		failedFilter := make(chan jobs.ActionMessage)
 		done := make(chan bool, 1)
@@ -110,11 +117,17 @@ func (r *Runnable) Dispatch(parentPath string, input jobs.ActionMessage, actions
 				close(messagesOutput)
 				close(done)
 				close(failedFilter)
+				r.Task.trackDispatch(-1)
+				r.leavePath(aPath, Queue)
+				// Last pending dispatch may leave joins waiting for branches that were never reached
+				r.flushJoins(Queue)
 			}()
+			var passed bool
 			for {
 				select {
 				case message := <-messagesOutput:
 					// Build runnable and enqueue
+					passed = true
 					m := proto.Clone(&message).(*jobs.ActionMessage)
 					Queue <- r.CreateChild(parentPath, chainIndex, act, *m)
 				case failed := <-failedFilter:
@@ -123,6 +136,10 @@ func (r *Runnable) Dispatch(parentPath string, input jobs.ActionMessage, actions
 						r.Dispatch(path.Join(parentPath, fmt.Sprintf(act.ID+"$%d$FAIL", chainIndex)), failed, act.FailedFilterActions, Queue)
 					}
 				case <-done:
+					if act.JoinID != "" && !passed {
+						// Branch was filtered out, notify join that it will not receive anything from it
+						r.reportToJoin(aPath, act.JoinID, nil, nil, Queue)
+					}
 					return
 				}
 			}
@@ -135,7 +152,14 @@ func (r *Runnable) Dispatch(parentPath string, input jobs.ActionMessage, actions
 func (r *Runnable) RunAction(Queue chan Runnable) error {
 
 	if r.Implementation == nil {
-		return errors.NotFound(common.SERVICE_JOBS, fmt.Sprintf("cannot run action: no concrete implementation found for ID %s, are you sure this action has been correctly registered?", r.Action.ID))
+		r.leavePath(r.ActionPath, Queue)
+		return errors.NotFound(common.SERVICE_JOBS, "cannot run action: no concrete implementation found for ID %s, are you sure this action has been correctly registered?", r.Action.ID)
+	}
+	if r.Context.Err() != nil {
+		// Task was cancelled, e.g. its queue lease was lost
+		r.Task.Done(1)
+		r.leavePath(r.ActionPath, Queue)
+		return r.Context.Err()
 	}
 
 	taskUpdateDelegated := false
@@ -167,7 +191,7 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 	r.Task.Save()
 
 	runnableChannels, done := r.Task.GetRunnableChannels()
-	outputMessage, err := r.Implementation.Run(r.Context, runnableChannels, r.Message)
+	outputMessage, attempts, err := r.runWithRetry(r.Context, runnableChannels)
 	close(done)
 	r.Task.Done(1)
 
@@ -175,18 +199,34 @@ func (r *Runnable) RunAction(Queue chan Runnable) error {
 		log.TasksLogger(r.Context).Error("Error while running action "+r.ID, zap.Error(err))
 		r.Task.SetStatus(jobs.TaskStatus_Error, "Error: "+err.Error())
 		r.Task.SetEndTime(time.Now())
+		if len(attempts) > 0 {
+			r.Task.AppendLog(r.Action, r.Message, outputMessage.WithError(err), attempts...)
+		}
 		r.Task.Save()
+		r.Task.BranchFailed(r.ActionPath, err)
+		if r.JoinID != "" {
+			r.reportToJoin(r.ActionPath, r.JoinID, &r.Message, err, Queue)
+		}
+		r.leavePath(r.ActionPath, Queue)
+		r.flushJoins(Queue)
 		return err
 	}
-	r.Task.AppendLog(r.Action, r.Message, outputMessage)
+	r.Task.AppendLog(r.Action, r.Message, outputMessage, attempts...)
 
 	r.Dispatch(r.ActionPath, outputMessage, r.ChainedActions, Queue)
 
+	if r.JoinID != "" && r.reportToJoin(r.ActionPath, r.JoinID, &outputMessage, nil, Queue) {
+		r.leavePath(r.ActionPath, Queue)
+		return nil
+	}
+
 	if !taskUpdateDelegated {
 		r.Task.SetStatus(jobs.TaskStatus_Finished, "Complete")
 		r.Task.SetEndTime(time.Now())
 		r.Task.Save()
 	}
+	r.leavePath(r.ActionPath, Queue)
+	r.flushJoins(Queue)
 
 	return nil
 }
@@ -0,0 +1,333 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/client"
+
+	"github.com/pydio/cells/common/proto/idm"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+var (
+	// SimulationMaxSteps limits the size of a simulation trace
+	SimulationMaxSteps = 1000
+)
+
+// simulation walks a job definition the same way tasks do, without running the actions
+// unless they support the simulation mode.
+type simulation struct {
+	ctx   context.Context
+	cl    client.Client
+	job   *jobs.Job
+	trace []*jobs.SimulationStep
+	joins map[string][]jobs.ActionMessage
+}
+
+// SimulateJob evaluates the job-level filters against the request event, then walks the chain of
+// actions with the request input (or a message built from the event), and returns the execution trace.
+func SimulateJob(ctx context.Context, cl client.Client, request *jobs.SimulateJobRequest) (*jobs.SimulateJobResponse, error) {
+
+	job := request.Job
+	if job == nil {
+		return nil, fmt.Errorf("please provide a job definition")
+	}
+	if e := job.CheckDefinition(); e != nil {
+		return nil, e
+	}
+	var event interface{}
+	if request.NodeEvent != nil {
+		event = request.NodeEvent
+	} else if request.IdmEvent != nil {
+		event = request.IdmEvent
+	}
+
+	ctx = withJobParameters(ctx, job)
+	response := &jobs.SimulateJobResponse{
+		JobFilters: simulateJobFilters(ctx, job, event),
+		Triggered:  true,
+	}
+	for _, f := range response.JobFilters {
+		if !f.Passed {
+			response.Triggered = false
+			return response, nil
+		}
+	}
+
+	var input jobs.ActionMessage
+	if request.Input != nil {
+		input = *request.Input
+	} else {
+		input = createMessageFromEvent(event)
+	}
+	sim := &simulation{
+		ctx:   ctx,
+		cl:    cl,
+		job:   job,
+		joins: make(map[string][]jobs.ActionMessage),
+	}
+	sim.dispatch("ROOT", input, job.Actions)
+	sim.flushJoins()
+	response.Trace = sim.trace
+
+	return response, nil
+}
+
+// simulateJobFilters evaluates the filters applied by the Subscriber before starting a task.
+func simulateJobFilters(ctx context.Context, job *jobs.Job, event interface{}) (results []*jobs.SimulationFilterResult) {
+	if job.ContextMetaFilter != nil {
+		_, pass := job.ContextMetaFilter.Filter(ctx, jobs.ActionMessage{})
+		results = append(results, &jobs.SimulationFilterResult{Type: "ContextMetaFilter", Passed: pass})
+	}
+	switch e := event.(type) {
+	case *tree.NodeChangeEvent:
+		if len(job.EventNames) > 0 {
+			var match bool
+			for _, eName := range job.EventNames {
+				if eType, ok := jobs.ParseNodeChangeEventName(eName); ok && eType == e.Type {
+					match = true
+				}
+			}
+			results = append(results, &jobs.SimulationFilterResult{Type: "EventNames", Passed: match})
+		}
+		if job.NodeEventFilter != nil {
+			results = append(results, &jobs.SimulationFilterResult{Type: "NodeEventFilter", Passed: jobLevelNodeFilterPass(ctx, e, job.NodeEventFilter)})
+		}
+	case *idm.ChangeEvent:
+		if len(job.EventNames) > 0 {
+			var match bool
+			for _, eName := range job.EventNames {
+				if jobs.MatchesIdmChangeEvent(eName, e) {
+					match = true
+				}
+			}
+			results = append(results, &jobs.SimulationFilterResult{Type: "EventNames", Passed: match})
+		}
+	}
+	if job.IdmFilter != nil && event != nil {
+		_, _, pass := job.IdmFilter.Filter(ctx, createMessageFromEvent(event))
+		results = append(results, &jobs.SimulationFilterResult{Type: "IdmFilter", Passed: pass})
+	}
+	return
+}
+
+// dispatch mimics Runnable.Dispatch: it resolves the messages of each action and simulates them.
+func (s *simulation) dispatch(parentPath string, input jobs.ActionMessage, chain []*jobs.Action) {
+	for i, action := range chain {
+		aPath := path.Join(parentPath, fmt.Sprintf(action.ID+"$%d", i))
+		messages, failed := s.resolve(action, input)
+		if len(messages) == 0 {
+			step := &jobs.SimulationStep{
+				ActionPath: aPath,
+				ActionID:   action.ID,
+				Status:     jobs.SimulationStepStatus_FilteredOut,
+				Filters:    simulateActionFilters(s.ctx, action, input),
+				Input:      s.clone(input),
+			}
+			if len(failed) == 0 {
+				step.Message = "Selectors did not return any input"
+			}
+			s.addStep(step)
+			if action.JoinID != "" {
+				s.joinArrival(action.JoinID, nil)
+			}
+		}
+		for _, m := range messages {
+			s.run(aPath, action, input, m)
+		}
+		if len(action.FailedFilterActions) > 0 {
+			for _, f := range failed {
+				s.dispatch(path.Join(parentPath, fmt.Sprintf(action.ID+"$%d$FAIL", i)), f, action.FailedFilterActions)
+			}
+		}
+	}
+}
+
+// resolve applies filters and selectors exactly as a running task does.
+func (s *simulation) resolve(action *jobs.Action, input jobs.ActionMessage) (messages []jobs.ActionMessage, failed []jobs.ActionMessage) {
+	output := make(chan jobs.ActionMessage)
+	failedFilter := make(chan jobs.ActionMessage)
+	done := make(chan bool, 1)
+	go action.ToMessages(input, s.cl, s.ctx, output, failedFilter, done)
+	for {
+		select {
+		case m := <-output:
+			messages = append(messages, *(proto.Clone(&m).(*jobs.ActionMessage)))
+		case f := <-failedFilter:
+			failed = append(failed, f)
+		case <-done:
+			return
+		}
+	}
+}
+
+// run simulates the action on one message, then dispatches its output to the chained actions.
+func (s *simulation) run(aPath string, action *jobs.Action, original, input jobs.ActionMessage) {
+	step := &jobs.SimulationStep{
+		ActionPath: aPath,
+		ActionID:   action.ID,
+		Filters:    simulateActionFilters(s.ctx, action, original),
+		Input:      s.clone(input),
+	}
+	if !s.addStep(step) {
+		return
+	}
+	output, err := s.simulateAction(action, input, step)
+	if err != nil {
+		step.Status = jobs.SimulationStepStatus_Failed
+		step.Message = err.Error()
+		if action.JoinID != "" {
+			s.joinArrival(action.JoinID, nil)
+		}
+		return
+	}
+	step.Output = s.clone(output)
+	s.dispatch(aPath, output, action.ChainedActions)
+	if action.JoinID != "" {
+		s.joinArrival(action.JoinID, &output)
+		step.Message = "Output sent to join " + action.JoinID
+	}
+}
+
+// simulateAction calls the action Simulate method if it implements actions.SimulatableAction,
+// otherwise the input is returned as is.
+func (s *simulation) simulateAction(action *jobs.Action, input jobs.ActionMessage, step *jobs.SimulationStep) (jobs.ActionMessage, error) {
+	impl, ok := actions.GetActionsManager().ActionById(action.ID)
+	if !ok {
+		return input, fmt.Errorf("cannot find any implementation for action %s", action.ID)
+	}
+	if e := impl.Init(s.job, s.cl, action); e != nil {
+		return input, e
+	}
+	simulatable, ok := impl.(actions.SimulatableAction)
+	if !ok {
+		step.Status = jobs.SimulationStepStatus_NotSimulated
+		return input, nil
+	}
+	step.Status = jobs.SimulationStepStatus_Simulated
+	return simulatable.Simulate(s.ctx, *(proto.Clone(&input).(*jobs.ActionMessage)))
+}
+
+func (s *simulation) joinArrival(joinID string, message *jobs.ActionMessage) {
+	if _, ok := s.joins[joinID]; !ok {
+		s.joins[joinID] = []jobs.ActionMessage{}
+	}
+	if message != nil {
+		s.joins[joinID] = append(s.joins[joinID], *message)
+	}
+}
+
+// flushJoins runs the joins that received at least one message, in the order of the job definition.
+// As a simulation is sequential, all branches have been walked when a join is flushed.
+func (s *simulation) flushJoins() {
+	flushed := make(map[string]bool)
+	for {
+		var pending *jobs.Join
+		for _, j := range s.job.Joins {
+			if _, ok := s.joins[j.ID]; ok && !flushed[j.ID] {
+				pending = j
+				break
+			}
+		}
+		if pending == nil {
+			return
+		}
+		flushed[pending.ID] = true
+		messages := s.joins[pending.ID]
+		jPath := joinPath(pending.ID)
+		if len(messages) == 0 {
+			s.addStep(&jobs.SimulationStep{
+				ActionPath: jPath,
+				ActionID:   joinActionName,
+				Status:     jobs.SimulationStepStatus_FilteredOut,
+				Message:    fmt.Sprintf("Join %s: no branch produced any output", pending.ID),
+			})
+			continue
+		}
+		merged := messages[0]
+		if pending.Mode == jobs.JoinMode_WaitAll {
+			merged = jobs.MergeActionMessages(messages...)
+		}
+		if !s.addStep(&jobs.SimulationStep{
+			ActionPath: jPath,
+			ActionID:   joinActionName,
+			Output:     s.clone(merged),
+			Message:    fmt.Sprintf("Join %s: merged output of %d branch(es)", pending.ID, len(messages)),
+		}) {
+			return
+		}
+		s.dispatch(jPath, merged, pending.ChainedActions)
+	}
+}
+
+// addStep appends a step to the trace, and returns false once SimulationMaxSteps is reached.
+func (s *simulation) addStep(step *jobs.SimulationStep) bool {
+	if len(s.trace) > SimulationMaxSteps {
+		return false
+	}
+	if len(s.trace) == SimulationMaxSteps {
+		s.trace = append(s.trace, &jobs.SimulationStep{
+			ActionPath: step.ActionPath,
+			ActionID:   step.ActionID,
+			Status:     jobs.SimulationStepStatus_Failed,
+			Message:    fmt.Sprintf("Simulation stopped after %d steps", SimulationMaxSteps),
+		})
+		return false
+	}
+	s.trace = append(s.trace, step)
+	return true
+}
+
+func (s *simulation) clone(m jobs.ActionMessage) *jobs.ActionMessage {
+	return proto.Clone(&m).(*jobs.ActionMessage)
+}
+
+// simulateActionFilters evaluates each filter of the action separately on the input.
+func simulateActionFilters(ctx context.Context, action *jobs.Action, input jobs.ActionMessage) (results []*jobs.SimulationFilterResult) {
+	if action.NodesFilter != nil {
+		_, _, pass := action.NodesFilter.Filter(ctx, input)
+		results = append(results, &jobs.SimulationFilterResult{Type: "NodesFilter", Passed: pass})
+	}
+	if action.IdmFilter != nil {
+		_, _, pass := action.IdmFilter.Filter(ctx, input)
+		results = append(results, &jobs.SimulationFilterResult{Type: "IdmFilter", Passed: pass})
+	}
+	if action.UsersFilter != nil {
+		_, pass := action.UsersFilter.Filter(ctx, input)
+		results = append(results, &jobs.SimulationFilterResult{Type: "UsersFilter", Passed: pass})
+	}
+	if action.ActionOutputFilter != nil {
+		_, pass := action.ActionOutputFilter.Filter(ctx, input)
+		results = append(results, &jobs.SimulationFilterResult{Type: "ActionOutputFilter", Passed: pass})
+	}
+	if action.ContextMetaFilter != nil {
+		_, pass := action.ContextMetaFilter.Filter(ctx, input)
+		results = append(results, &jobs.SimulationFilterResult{Type: "ContextMetaFilter", Passed: pass})
+	}
+	return
+}
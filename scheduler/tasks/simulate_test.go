@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/micro/go-micro/client"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+	service "github.com/pydio/cells/common/service/proto"
+	"github.com/pydio/cells/scheduler/actions"
+)
+
+// noSimulateAction does not implement actions.SimulatableAction and must never run
+type noSimulateAction struct{}
+
+func (a *noSimulateAction) GetName() string { return "actions.test.nosimulate" }
+
+func (a *noSimulateAction) Init(job *jobs.Job, cl client.Client, action *jobs.Action) error {
+	return nil
+}
+
+func (a *noSimulateAction) Run(ctx context.Context, channels *actions.RunnableChannels, input jobs.ActionMessage) (jobs.ActionMessage, error) {
+	panic("action must not run during a simulation")
+}
+
+func jpgSelector() *jobs.NodesSelector {
+	q, _ := ptypes.MarshalAny(&tree.Query{Extension: "jpg"})
+	return &jobs.NodesSelector{Query: &service.Query{SubQueries: []*any.Any{q}}}
+}
+
+func TestSimulateJob(t *testing.T) {
+
+	actions.GetActionsManager().Register("actions.test.nosimulate", func() actions.ConcreteAction {
+		return &noSimulateAction{}
+	})
+	ctx := context.Background()
+	jpgEvent := &tree.NodeChangeEvent{Type: tree.NodeChangeEvent_CREATE, Target: &tree.Node{Path: "/folder/image.jpg", Type: tree.NodeType_LEAF}}
+	pdfEvent := &tree.NodeChangeEvent{Type: tree.NodeChangeEvent_CREATE, Target: &tree.Node{Path: "/folder/doc.pdf", Type: tree.NodeType_LEAF}}
+
+	Convey("Job-level filters are evaluated", t, func() {
+		job := &jobs.Job{
+			ID:              "sim-job",
+			EventNames:      []string{"NODE_CHANGE:0"},
+			NodeEventFilter: jpgSelector(),
+			Actions:         []*jobs.Action{{ID: "actions.test.fake", Parameters: map[string]string{"timer": "1"}}},
+		}
+		res, e := SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, NodeEvent: jpgEvent})
+		So(e, ShouldBeNil)
+		So(res.Triggered, ShouldBeTrue)
+		So(res.JobFilters, ShouldHaveLength, 2)
+		So(res.Trace, ShouldHaveLength, 1)
+
+		res, e = SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, NodeEvent: pdfEvent})
+		So(e, ShouldBeNil)
+		So(res.Triggered, ShouldBeFalse)
+		So(res.Trace, ShouldBeEmpty)
+
+		res, e = SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, NodeEvent: &tree.NodeChangeEvent{Type: tree.NodeChangeEvent_DELETE, Source: jpgEvent.Target}})
+		So(e, ShouldBeNil)
+		So(res.Triggered, ShouldBeFalse)
+	})
+
+	Convey("Chain is walked with filters and failed branches", t, func() {
+		job := &jobs.Job{
+			ID: "sim-job",
+			Actions: []*jobs.Action{
+				{
+					ID:          "actions.test.fake",
+					Parameters:  map[string]string{"timer": "1"},
+					NodesFilter: jpgSelector(),
+					ChainedActions: []*jobs.Action{
+						{ID: "actions.test.nosimulate"},
+					},
+					FailedFilterActions: []*jobs.Action{
+						{ID: "actions.test.unknown"},
+					},
+				},
+			},
+		}
+		res, e := SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, NodeEvent: jpgEvent})
+		So(e, ShouldBeNil)
+		So(res.Trace, ShouldHaveLength, 2)
+		So(res.Trace[0].ActionPath, ShouldEqual, "ROOT/actions.test.fake$0")
+		So(res.Trace[0].Status, ShouldEqual, jobs.SimulationStepStatus_Simulated)
+		So(res.Trace[0].Filters, ShouldHaveLength, 1)
+		So(res.Trace[0].Filters[0].Passed, ShouldBeTrue)
+		So(res.Trace[0].Output.GetLastOutput().StringBody, ShouldEqual, "Hello World")
+		So(res.Trace[1].ActionPath, ShouldEqual, "ROOT/actions.test.fake$0/actions.test.nosimulate$0")
+		So(res.Trace[1].Status, ShouldEqual, jobs.SimulationStepStatus_NotSimulated)
+
+		res, e = SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, NodeEvent: pdfEvent})
+		So(e, ShouldBeNil)
+		So(res.Trace, ShouldHaveLength, 2)
+		So(res.Trace[0].Status, ShouldEqual, jobs.SimulationStepStatus_FilteredOut)
+		So(res.Trace[0].Filters[0].Passed, ShouldBeFalse)
+		So(res.Trace[1].ActionPath, ShouldEqual, "ROOT/actions.test.fake$0$FAIL/actions.test.unknown$0")
+		So(res.Trace[1].Status, ShouldEqual, jobs.SimulationStepStatus_Failed)
+	})
+
+	Convey("Errors stop the branch and joins are flushed", t, func() {
+		job := &jobs.Job{
+			ID: "sim-job",
+			Actions: []*jobs.Action{
+				{ID: "actions.test.fake", Parameters: map[string]string{"timer": "invalid"}, JoinID: "j1", ChainedActions: []*jobs.Action{
+					{ID: "actions.test.nosimulate"},
+				}},
+				{ID: "actions.test.nosimulate", JoinID: "j1"},
+			},
+			Joins: []*jobs.Join{
+				{ID: "j1", ChainedActions: []*jobs.Action{{ID: "actions.test.nosimulate"}}},
+			},
+		}
+		res, e := SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: job, Input: &jobs.ActionMessage{}})
+		So(e, ShouldBeNil)
+		So(res.Trace, ShouldHaveLength, 4)
+		So(res.Trace[0].Status, ShouldEqual, jobs.SimulationStepStatus_Failed)
+		So(res.Trace[1].Message, ShouldEqual, "Output sent to join j1")
+		So(res.Trace[2].ActionPath, ShouldEqual, "ROOT/JOIN$j1")
+		So(res.Trace[3].ActionPath, ShouldEqual, "ROOT/JOIN$j1/actions.test.nosimulate$0")
+	})
+
+	Convey("Invalid definitions are rejected", t, func() {
+		_, e := SimulateJob(ctx, nil, &jobs.SimulateJobRequest{})
+		So(e, ShouldNotBeNil)
+		_, e = SimulateJob(ctx, nil, &jobs.SimulateJobRequest{Job: &jobs.Job{ID: "sim-job", Schedule: &jobs.Schedule{Cron: "invalid"}}})
+		So(e, ShouldNotBeNil)
+	})
+}
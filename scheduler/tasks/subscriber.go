@@ -68,6 +68,16 @@ type Subscriber struct {
 	jobsLock    *sync.RWMutex
 	RootContext context.Context
 	batcher     *cache.EventsBatcher
+	throttler   *Throttler
+	calendars   *CalendarGate
+
+	// Queue shared by all scheduler nodes, tasks are run directly if nil
+	Queue        TaskQueue
+	NodeID       string
+	queueWake    chan struct{}
+	queueStop    chan struct{}
+	queueRunning int32
+	stopOnce     sync.Once
 }
 
 // NewSubscriber creates a multiplexer for tasks managements and messages
@@ -88,6 +98,15 @@ func NewSubscriber(parentContext context.Context, client client.Client, srv serv
 	s.RootContext = context.WithValue(parentContext, common.PYDIO_CONTEXT_USER_KEY, common.PYDIO_SYSTEM_USERNAME)
 
 	s.batcher = cache.NewEventsBatcher(s.RootContext, 2*time.Second, 20*time.Second, 2000, s.processNodeEvent)
+	s.throttler = NewThrottler(s.startTask)
+	s.calendars = NewCalendarGate(func(ctx context.Context, job *jobs.Job, event interface{}) {
+		if _, scheduled := event.(*jobs.JobTriggerEvent); scheduled {
+			// Timer triggers are never throttled
+			s.startTask(ctx, job, event, 0)
+		} else {
+			s.throttler.Trigger(ctx, job, event)
+		}
+	})
 
 	// Use a "Queue" mechanism to make sure events are distributed accross tasks instances
 	opts := func(o *server.SubscriberOptions) {
@@ -144,9 +163,16 @@ func (s *Subscriber) Init() error {
 	return nil
 }
 
-// Stop closes internal EventsBatcher
+// Stop closes internal EventsBatcher and stops claiming tasks from the queue
 func (s *Subscriber) Stop() {
-	s.batcher.Done <- true
+	s.stopOnce.Do(func() {
+		if s.batcher != nil {
+			s.batcher.Done <- true
+		}
+		if s.queueStop != nil {
+			close(s.queueStop)
+		}
+	})
 }
 
 // ListenToMainQueue starts a go routine that listens to the Event Bus
@@ -201,6 +227,8 @@ func (s *Subscriber) jobsChangeEvent(ctx context.Context, msg *jobs.JobChangeEve
 		if _, ok := s.JobsDefinitions[msg.JobRemoved]; ok {
 			delete(s.JobsDefinitions, msg.JobRemoved)
 		}
+		s.throttler.Forget(msg.JobRemoved)
+		s.calendars.Forget(msg.JobRemoved)
 		if dispatcher, ok := s.Dispatchers[msg.JobRemoved]; ok {
 			dispatcher.Stop()
 			delete(s.Dispatchers, msg.JobRemoved)
@@ -208,6 +236,9 @@ func (s *Subscriber) jobsChangeEvent(ctx context.Context, msg *jobs.JobChangeEve
 	}
 	if msg.JobUpdated != nil {
 		s.JobsDefinitions[msg.JobUpdated.ID] = msg.JobUpdated
+		// Pending triggers refer to the previous definition
+		s.throttler.Forget(msg.JobUpdated.ID)
+		s.calendars.Forget(msg.JobUpdated.ID)
 		if dispatcher, ok := s.Dispatchers[msg.JobUpdated.ID]; ok {
 			dispatcher.Stop()
 			delete(s.Dispatchers, msg.JobUpdated.ID)
@@ -234,15 +265,18 @@ func (s *Subscriber) prepareTaskContext(ctx context.Context, job *jobs.Job, addS
 	ctx = servicecontext.WithServiceName(ctx, servicecontext.GetServiceName(s.RootContext))
 	ctx = servicecontext.WithServiceColor(ctx, servicecontext.GetServiceColor(s.RootContext))
 
-	// Inject evaluated job parameters
-	if len(job.Parameters) > 0{
+	return withJobParameters(ctx, job)
+}
+
+// withJobParameters injects the evaluated job parameters in the context
+func withJobParameters(ctx context.Context, job *jobs.Job) context.Context {
+	if len(job.Parameters) > 0 {
 		params := make(map[string]string, len(job.Parameters))
 		for _, p := range job.Parameters {
 			params[p.Name] = jobs.EvaluateFieldStr(ctx, jobs.ActionMessage{}, p.Value)
 		}
 		ctx = context.WithValue(ctx, ContextJobParametersKey, params)
 	}
-
 	return ctx
 }
 
@@ -267,9 +301,14 @@ func (s *Subscriber) timerEvent(ctx context.Context, event *jobs.JobTriggerEvent
 	}
 	ctx = s.prepareTaskContext(ctx, j, true)
 
+	if event.Schedule == nil {
+		// Manual runs ignore the job calendar
+		log.Logger(ctx).Info("Run Job " + jobId + " on manual trigger")
+		s.startTask(ctx, j, event, 0)
+		return nil
+	}
 	log.Logger(ctx).Info("Run Job " + jobId + " on timer event " + event.Schedule.String())
-	task := NewTaskFromEvent(ctx, j, event)
-	go task.EnqueueRunnables(s.Client, s.MainQueue)
+	s.calendars.Trigger(ctx, j, event)
 
 	return nil
 }
@@ -318,8 +357,7 @@ func (s *Subscriber) processNodeEvent(ctx context.Context, event *tree.NodeChang
 			if eType, ok := jobs.ParseNodeChangeEventName(eName); ok {
 				if event.Type == eType {
 					log.Logger(ctx).Debug("Run Job " + jobId + " on event " + eName)
-					task := NewTaskFromEvent(ctx, jobData, event)
-					go task.EnqueueRunnables(s.Client, s.MainQueue)
+					s.calendars.Trigger(ctx, jobData, event)
 				}
 			}
 		}
@@ -347,8 +385,7 @@ func (s *Subscriber) idmEvent(ctx context.Context, event *idm.ChangeEvent) error
 		for _, eName := range jobData.EventNames {
 			if jobs.MatchesIdmChangeEvent(eName, event) {
 				log.Logger(ctx).Debug("Run Job " + jobId + " on event " + eName)
-				task := NewTaskFromEvent(ctx, jobData, event)
-				go task.EnqueueRunnables(s.Client, s.MainQueue)
+				s.calendars.Trigger(ctx, jobData, event)
 			}
 		}
 	}
@@ -357,6 +394,10 @@ func (s *Subscriber) idmEvent(ctx context.Context, event *idm.ChangeEvent) error
 
 // Check if a node must go through jobs at all (if there is a NodesSelector at the job level)
 func (s *Subscriber) jobLevelFilterPass(ctx context.Context, event *tree.NodeChangeEvent, filter *jobs.NodesSelector) bool {
+	return jobLevelNodeFilterPass(ctx, event, filter)
+}
+
+func jobLevelNodeFilterPass(ctx context.Context, event *tree.NodeChangeEvent, filter *jobs.NodesSelector) bool {
 	var refNode *tree.Node
 	if event.Target != nil {
 		refNode = event.Target
@@ -43,6 +43,15 @@ type Task struct {
 	lock           *sync.RWMutex
 	RC             int
 	RunUUID        string
+	// number of Dispatch loops still evaluating their filters
+	dispatching int
+
+	joins     map[string]*joinState
+	joinsLock sync.Mutex
+	// actions that returned an error, by ActionPath
+	failedPaths map[string]string
+	// number of Dispatch loops and Runnables active at each ActionPath, only tracked for jobs with joins
+	activePaths map[string]int
 }
 
 func NewTaskFromEvent(ctx context.Context, job *jobs.Job, event interface{}) *Task {
@@ -81,6 +90,20 @@ func (t *Task) Done(delta int) {
 	t.RC = t.RC - delta
 }
 
+// trackDispatch registers the start (1) or the end (-1) of a Dispatch loop
+func (t *Task) trackDispatch(delta int) {
+	t.lockTask()
+	defer t.unlockTask()
+	t.dispatching += delta
+}
+
+// Idle returns true if the task has no running action and no pending dispatch.
+func (t *Task) Idle() bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.RC <= 0 && t.dispatching <= 0
+}
+
 func (t *Task) Save() {
 	PubSub.Pub(t.lockedTask, PubSubTopicTaskStatuses)
 }
@@ -136,7 +159,9 @@ func (t *Task) SetHasProgress() {
 	t.lockedTask.HasProgress = true
 }
 
-func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMessage) {
+// AppendLog stores a cleaned copy of the action, its input and output in the task logs,
+// along with the history of attempts if the action was retried.
+func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMessage, attempts ...*jobs.ActionAttempt) {
 	t.lockTask()
 	defer t.unlockTask()
 	// Remove unnecessary fields
@@ -160,6 +185,7 @@ func (t *Task) AppendLog(a jobs.Action, in jobs.ActionMessage, out jobs.ActionMe
 		Action:        &cleanedAction,
 		InputMessage:  &cleanedInput,
 		OutputMessage: &cleanedOutput,
+		Attempts:      attempts,
 	})
 }
 
@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pydio/cells/common/log"
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+var (
+	// ThrottleRateWindow is the window used to count triggers for TriggerThrottle.MaxPerMinute
+	ThrottleRateWindow = 1 * time.Minute
+)
+
+// throttleLauncher starts a task, along with the number of triggers skipped before it
+type throttleLauncher func(ctx context.Context, job *jobs.Job, event interface{}, skipped int32)
+
+// pendingTrigger is a trigger waiting for its debounce window or for a rate limit slot
+type pendingTrigger struct {
+	ctx   context.Context
+	job   *jobs.Job
+	event interface{}
+	timer *time.Timer
+}
+
+// jobThrottleState keeps track of the triggers of one job
+type jobThrottleState struct {
+	starts    []time.Time
+	skipped   int32
+	debounced map[string]*pendingTrigger
+	coalesced *pendingTrigger
+}
+
+// Throttler applies jobs TriggerThrottle to event triggers: debouncing on the same node,
+// maximum number of tasks per minute and coalescing to the latest trigger.
+type Throttler struct {
+	sync.Mutex
+	launch throttleLauncher
+	states map[string]*jobThrottleState
+}
+
+// NewThrottler creates a Throttler that uses launch to start tasks.
+func NewThrottler(launch throttleLauncher) *Throttler {
+	return &Throttler{
+		launch: launch,
+		states: make(map[string]*jobThrottleState),
+	}
+}
+
+// Trigger starts a task for this event, or delays or drops it depending on the job throttle.
+func (t *Throttler) Trigger(ctx context.Context, job *jobs.Job, event interface{}) {
+	if !job.Throttle.IsActive() {
+		t.launch(ctx, job, event, t.consumeSkipped(job.ID))
+		return
+	}
+	t.Lock()
+	state := t.state(job.ID)
+	if d := job.Throttle.DebounceDuration(); d > 0 {
+		if key := throttleNodeKey(event); key != "" {
+			if p, ok := state.debounced[key]; ok {
+				p.ctx, p.job, p.event = ctx, job, event
+				p.timer.Reset(d)
+				state.skipped++
+				t.Unlock()
+				log.Logger(ctx).Debug("Debouncing trigger for job "+job.ID, zap.String("node", key))
+				return
+			}
+			p := &pendingTrigger{ctx: ctx, job: job, event: event}
+			p.timer = time.AfterFunc(d, func() {
+				t.flushDebounced(job.ID, key)
+			})
+			state.debounced[key] = p
+			t.Unlock()
+			return
+		}
+	}
+	start := t.rateLimit(state, &pendingTrigger{ctx: ctx, job: job, event: event})
+	t.Unlock()
+	if start != nil {
+		t.launch(start.ctx, start.job, start.event, start.skipped)
+	}
+}
+
+// Skipped returns the number of triggers skipped for this job since the last started task.
+func (t *Throttler) Skipped(jobId string) int32 {
+	t.Lock()
+	defer t.Unlock()
+	if s, ok := t.states[jobId]; ok {
+		return s.skipped
+	}
+	return 0
+}
+
+// Forget drops pending triggers for a job, typically when it is removed or updated.
+func (t *Throttler) Forget(jobId string) {
+	t.Lock()
+	defer t.Unlock()
+	s, ok := t.states[jobId]
+	if !ok {
+		return
+	}
+	for _, p := range s.debounced {
+		p.timer.Stop()
+	}
+	if s.coalesced != nil {
+		s.coalesced.timer.Stop()
+	}
+	delete(t.states, jobId)
+}
+
+type throttledStart struct {
+	*pendingTrigger
+	skipped int32
+}
+
+// rateLimit decides if the trigger can start now. If not, it is either dropped or kept
+// as the coalesced trigger for this job. It must be called with the lock held.
+func (t *Throttler) rateLimit(state *jobThrottleState, p *pendingTrigger) *throttledStart {
+	max := int(p.job.Throttle.GetMaxPerMinute())
+	now := time.Now()
+	if max > 0 {
+		var starts []time.Time
+		for _, s := range state.starts {
+			if now.Sub(s) < ThrottleRateWindow {
+				starts = append(starts, s)
+			}
+		}
+		state.starts = starts
+		if len(starts) >= max {
+			if !p.job.Throttle.Coalesce {
+				state.skipped++
+				log.Logger(p.ctx).Debug("Rate limit reached, dropping trigger for job " + p.job.ID)
+				return nil
+			}
+			if state.coalesced != nil {
+				state.coalesced.ctx, state.coalesced.job, state.coalesced.event = p.ctx, p.job, p.event
+				state.skipped++
+				return nil
+			}
+			jobId := p.job.ID
+			p.timer = time.AfterFunc(starts[0].Add(ThrottleRateWindow).Sub(now), func() {
+				t.flushCoalesced(jobId)
+			})
+			state.coalesced = p
+			return nil
+		}
+		state.starts = append(state.starts, now)
+	}
+	start := &throttledStart{pendingTrigger: p, skipped: state.skipped}
+	state.skipped = 0
+	return start
+}
+
+func (t *Throttler) flushDebounced(jobId string, key string) {
+	t.Lock()
+	state, ok := t.states[jobId]
+	if !ok {
+		t.Unlock()
+		return
+	}
+	p, ok := state.debounced[key]
+	if !ok {
+		t.Unlock()
+		return
+	}
+	delete(state.debounced, key)
+	start := t.rateLimit(state, p)
+	t.Unlock()
+	if start != nil {
+		t.launch(start.ctx, start.job, start.event, start.skipped)
+	}
+}
+
+func (t *Throttler) flushCoalesced(jobId string) {
+	t.Lock()
+	state, ok := t.states[jobId]
+	if !ok || state.coalesced == nil {
+		t.Unlock()
+		return
+	}
+	p := state.coalesced
+	state.coalesced = nil
+	start := t.rateLimit(state, p)
+	t.Unlock()
+	if start != nil {
+		t.launch(start.ctx, start.job, start.event, start.skipped)
+	}
+}
+
+func (t *Throttler) consumeSkipped(jobId string) int32 {
+	t.Lock()
+	defer t.Unlock()
+	s, ok := t.states[jobId]
+	if !ok {
+		return 0
+	}
+	skipped := s.skipped
+	s.skipped = 0
+	return skipped
+}
+
+func (t *Throttler) state(jobId string) *jobThrottleState {
+	s, ok := t.states[jobId]
+	if !ok {
+		s = &jobThrottleState{debounced: make(map[string]*pendingTrigger)}
+		t.states[jobId] = s
+	}
+	return s
+}
+
+// throttleNodeKey finds the node UUID used for debouncing, empty for non-node events.
+func throttleNodeKey(event interface{}) string {
+	if e, ok := event.(*tree.NodeChangeEvent); ok {
+		if e.Target != nil && e.Target.Uuid != "" {
+			return e.Target.Uuid
+		} else if e.Source != nil {
+			return e.Source.Uuid
+		}
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2018. Abstrium SAS <team (at) pydio.com>
+ * This file is part of Pydio Cells.
+ *
+ * Pydio Cells is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * Pydio Cells is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Pydio Cells.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ * The latest code can be found at <https://pydio.com>.
+ */
+
+package tasks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/pydio/cells/common/proto/jobs"
+	"github.com/pydio/cells/common/proto/tree"
+)
+
+type launchRecorder struct {
+	sync.Mutex
+	events  []interface{}
+	skipped []int32
+}
+
+func (l *launchRecorder) launch(ctx context.Context, job *jobs.Job, event interface{}, skipped int32) {
+	l.Lock()
+	defer l.Unlock()
+	l.events = append(l.events, event)
+	l.skipped = append(l.skipped, skipped)
+}
+
+func (l *launchRecorder) count() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.events)
+}
+
+func nodeUpdate(uuid, etag string) *tree.NodeChangeEvent {
+	return &tree.NodeChangeEvent{Type: tree.NodeChangeEvent_UPDATE_CONTENT, Target: &tree.Node{Uuid: uuid, Etag: etag}}
+}
+
+func TestThrottler(t *testing.T) {
+
+	ctx := context.Background()
+
+	Convey("Jobs without throttle start immediately", t, func() {
+		rec := &launchRecorder{}
+		th := NewThrottler(rec.launch)
+		job := &jobs.Job{ID: "job"}
+		for i := 0; i < 3; i++ {
+			th.Trigger(ctx, job, nodeUpdate("node", "etag"))
+		}
+		So(rec.count(), ShouldEqual, 3)
+	})
+
+	Convey("Debounce keeps the latest event per node", t, func() {
+		rec := &launchRecorder{}
+		th := NewThrottler(rec.launch)
+		job := &jobs.Job{ID: "job", Throttle: &jobs.TriggerThrottle{Debounce: "100ms"}}
+		for i := 0; i < 5; i++ {
+			th.Trigger(ctx, job, nodeUpdate("node1", string('a'+rune(i))))
+		}
+		th.Trigger(ctx, job, nodeUpdate("node2", "z"))
+		So(rec.count(), ShouldEqual, 0)
+		So(th.Skipped(job.ID), ShouldEqual, 4)
+
+		<-time.After(250 * time.Millisecond)
+		So(rec.count(), ShouldEqual, 2)
+		etags := []string{rec.events[0].(*tree.NodeChangeEvent).Target.Etag, rec.events[1].(*tree.NodeChangeEvent).Target.Etag}
+		So(etags, ShouldContain, "e")
+		So(etags, ShouldContain, "z")
+		So(rec.skipped[0]+rec.skipped[1], ShouldEqual, 4)
+	})
+
+	Convey("Rate limit drops triggers above the maximum", t, func() {
+		ThrottleRateWindow = 200 * time.Millisecond
+		defer func() {
+			ThrottleRateWindow = time.Minute
+		}()
+		rec := &launchRecorder{}
+		th := NewThrottler(rec.launch)
+		job := &jobs.Job{ID: "job", Throttle: &jobs.TriggerThrottle{MaxPerMinute: 2}}
+		for i := 0; i < 5; i++ {
+			th.Trigger(ctx, job, nodeUpdate("node", "etag"))
+		}
+		So(rec.count(), ShouldEqual, 2)
+		So(th.Skipped(job.ID), ShouldEqual, 3)
+
+		<-time.After(300 * time.Millisecond)
+		So(rec.count(), ShouldEqual, 2)
+		th.Trigger(ctx, job, nodeUpdate("node", "etag"))
+		So(rec.count(), ShouldEqual, 3)
+		So(rec.skipped[2], ShouldEqual, 3)
+		So(th.Skipped(job.ID), ShouldEqual, 0)
+	})
+
+	Convey("Coalesce runs the latest trigger once a slot is free", t, func() {
+		ThrottleRateWindow = 200 * time.Millisecond
+		defer func() {
+			ThrottleRateWindow = time.Minute
+		}()
+		rec := &launchRecorder{}
+		th := NewThrottler(rec.launch)
+		job := &jobs.Job{ID: "job", Throttle: &jobs.TriggerThrottle{MaxPerMinute: 1, Coalesce: true}}
+		th.Trigger(ctx, job, nodeUpdate("node", "first"))
+		th.Trigger(ctx, job, nodeUpdate("node", "second"))
+		th.Trigger(ctx, job, nodeUpdate("node", "third"))
+		So(rec.count(), ShouldEqual, 1)
+		So(th.Skipped(job.ID), ShouldEqual, 1)
+
+		<-time.After(300 * time.Millisecond)
+		So(rec.count(), ShouldEqual, 2)
+		So(rec.events[1].(*tree.NodeChangeEvent).Target.Etag, ShouldEqual, "third")
+		So(rec.skipped[1], ShouldEqual, 1)
+	})
+
+	Convey("Forget drops pending triggers", t, func() {
+		rec := &launchRecorder{}
+		th := NewThrottler(rec.launch)
+		job := &jobs.Job{ID: "job", Throttle: &jobs.TriggerThrottle{Debounce: "50ms"}}
+		th.Trigger(ctx, job, nodeUpdate("node", "etag"))
+		th.Forget(job.ID)
+		<-time.After(150 * time.Millisecond)
+		So(rec.count(), ShouldEqual, 0)
+	})
+}
@@ -22,6 +22,8 @@ package timer
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/micro/go-micro/client"
 	"go.uber.org/zap"
@@ -42,14 +44,23 @@ type EventProducer struct {
 	EventChan chan *jobs.JobTriggerEvent
 	StopChan  chan bool
 	TestChan  chan *jobs.JobTriggerEvent
+
+	// Calendars resolves calendars by name, it defaults to jobs.LoadCalendar
+	Calendars func(name string) (*jobs.Calendar, error)
+
+	deferred     map[string]*time.Timer
+	deferredLock *sync.Mutex
 }
 
 // NewEventProducer creates a pool of ScheduleWaiters that will send events based on pre-defined scheduling.
 func NewEventProducer(rootCtx context.Context) *EventProducer {
 	e := &EventProducer{
-		Waiters:   make(map[string]*schedule.Ticker),
-		StopChan:  make(chan bool, 1),
-		EventChan: make(chan *jobs.JobTriggerEvent),
+		Waiters:      make(map[string]*schedule.Ticker),
+		StopChan:     make(chan bool, 1),
+		EventChan:    make(chan *jobs.JobTriggerEvent),
+		Calendars:    jobs.LoadCalendar,
+		deferred:     make(map[string]*time.Timer),
+		deferredLock: &sync.Mutex{},
 	}
 
 	e.Context = context.WithValue(rootCtx, common.PYDIO_CONTEXT_USER_KEY, common.PYDIO_SYSTEM_USERNAME)
@@ -107,16 +118,38 @@ func (e *EventProducer) StopAll() {
 		w.Stop()
 		delete(e.Waiters, jId)
 	}
+	e.deferredLock.Lock()
+	for jId, t := range e.deferred {
+		t.Stop()
+		delete(e.deferred, jId)
+	}
+	e.deferredLock.Unlock()
 	e.StopChan <- true
 }
 
 // StopWaiter stops a waiter given its ID and remove it from the Waiter pool.
+// A trigger deferred by the job calendar is dropped as well: deferred triggers are only kept
+// in memory, so they are also lost if the scheduler restarts.
 // If no waiter with this ID is registered, it returns silently.
 func (e *EventProducer) StopWaiter(jobId string) {
 	if w, ok := e.Waiters[jobId]; ok {
 		w.Stop()
 		delete(e.Waiters, jobId)
 	}
+	e.deferredLock.Lock()
+	if t, ok := e.deferred[jobId]; ok {
+		t.Stop()
+		delete(e.deferred, jobId)
+	}
+	e.deferredLock.Unlock()
+}
+
+// Deferred tells whether a trigger is waiting for the calendar of this job to open.
+func (e *EventProducer) Deferred(jobId string) bool {
+	e.deferredLock.Lock()
+	defer e.deferredLock.Unlock()
+	_, ok := e.deferred[jobId]
+	return ok
 }
 
 // StartOrUpdateJob creates a ScheduleWaiter and registers it in the EventProducer pool.
@@ -127,18 +160,59 @@ func (e *EventProducer) StartOrUpdateJob(job *jobs.Job) {
 	jobId := job.ID
 	e.StopWaiter(jobId)
 
-	//schedule := job.Schedule
-	if s, err := schedule.NewTickerScheduleFromISO(job.Schedule.Iso8601Schedule); err == nil {
-		w := schedule.NewTicker(s, func() error {
-			e.EventChan <- &jobs.JobTriggerEvent{
-				JobID:    jobId,
-				Schedule: job.Schedule,
-			}
-			return nil
-		})
+	w, err := job.Schedule.NewTicker(func() error {
+		e.fire(job)
+		return nil
+	})
+	if err == nil {
 		w.Start()
 		e.Waiters[jobId] = w
 	} else {
 		log.Logger(context.Background()).Error("Cannot register job", zap.Error(err))
 	}
 }
+
+// fire sends a trigger for this job, or defers it until the job calendar opens.
+// While a trigger is deferred, the following occurrences of the schedule are coalesced into it.
+func (e *EventProducer) fire(job *jobs.Job) {
+	event := &jobs.JobTriggerEvent{
+		JobID:    job.ID,
+		Schedule: job.Schedule,
+	}
+	if job.CalendarName == "" {
+		e.EventChan <- event
+		return
+	}
+	cal, err := e.Calendars(job.CalendarName)
+	if err != nil {
+		// Next occurrence of the schedule will try again
+		log.Logger(e.Context).Error("Cannot load calendar for job "+job.ID+", skipping trigger", zap.Error(err))
+		return
+	}
+	now := time.Now()
+	opening, ok := cal.NextOpening(now)
+	if !ok {
+		log.Logger(e.Context).Error("Calendar " + cal.Name + " never opens, skipping trigger for job " + job.ID)
+		return
+	}
+	if !opening.After(now) {
+		e.EventChan <- event
+		return
+	}
+	e.deferredLock.Lock()
+	defer e.deferredLock.Unlock()
+	if _, ok := e.deferred[job.ID]; ok {
+		return
+	}
+	log.Logger(e.Context).Info("Calendar "+cal.Name+" is closed, deferring trigger for job "+job.ID, zap.Time("until", opening))
+	jobId := job.ID
+	e.deferred[jobId] = time.AfterFunc(opening.Sub(now), func() {
+		e.deferredLock.Lock()
+		_, ok := e.deferred[jobId]
+		delete(e.deferred, jobId)
+		e.deferredLock.Unlock()
+		if ok {
+			e.EventChan <- event
+		}
+	})
+}
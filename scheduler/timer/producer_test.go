@@ -22,6 +22,7 @@ package timer
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"testing"
 	"time"
@@ -130,3 +131,71 @@ func TestProducer(t *testing.T) {
 
 	})
 }
+
+func TestProducerCron(t *testing.T) {
+
+	Convey("Test Producer with cron schedules", t, func() {
+
+		p := NewEventProducer(context.Background())
+		p.TestChan = make(chan *jobs.JobTriggerEvent)
+
+		p.StartOrUpdateJob(&jobs.Job{
+			ID: "cron-job",
+			Schedule: &jobs.Schedule{
+				Cron:     "0 2 * * 1-5",
+				TimeZone: "Europe/Paris",
+			},
+		})
+		So(p.Waiters, ShouldContainKey, "cron-job")
+
+		p.StartOrUpdateJob(&jobs.Job{
+			ID: "invalid-cron-job",
+			Schedule: &jobs.Schedule{
+				Cron: "0 2 * *",
+			},
+		})
+		So(p.Waiters, ShouldNotContainKey, "invalid-cron-job")
+
+		p.StopAll()
+		So(p.Waiters, ShouldBeEmpty)
+
+	})
+}
+
+func TestProducerCalendar(t *testing.T) {
+
+	Convey("Test Producer defers triggers outside of the job calendar", t, func() {
+
+		p := NewEventProducer(context.Background())
+		p.TestChan = make(chan *jobs.JobTriggerEvent, 10)
+		p.Calendars = func(name string) (*jobs.Calendar, error) {
+			if name == "closed" {
+				next := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+				return &jobs.Calendar{Name: name, Allowed: []*jobs.CalendarWindow{{Dates: []string{next}}}}, nil
+			}
+			if name == "unknown" {
+				return nil, fmt.Errorf("cannot find calendar %s", name)
+			}
+			return &jobs.Calendar{Name: name}, nil
+		}
+
+		p.fire(&jobs.Job{ID: "open-job", CalendarName: "open"})
+		So(<-p.TestChan, ShouldNotBeNil)
+
+		p.fire(&jobs.Job{ID: "unknown-job", CalendarName: "unknown"})
+		So(p.Deferred("unknown-job"), ShouldBeFalse)
+		So(p.TestChan, ShouldBeEmpty)
+
+		closed := &jobs.Job{ID: "closed-job", CalendarName: "closed"}
+		p.fire(closed)
+		p.fire(closed)
+		So(p.Deferred("closed-job"), ShouldBeTrue)
+		So(p.TestChan, ShouldBeEmpty)
+
+		p.StopWaiter("closed-job")
+		So(p.Deferred("closed-job"), ShouldBeFalse)
+
+		p.StopAll()
+
+	})
+}